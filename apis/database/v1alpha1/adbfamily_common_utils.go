@@ -41,6 +41,7 @@ package v1alpha1
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,6 +74,11 @@ func CreateMetaCondition(obj client.Object, err error, lifecycleState string, st
 type OCIConfigSpec struct {
 	ConfigMapName *string `json:"configMapName,omitempty"`
 	SecretName    *string `json:"secretName,omitempty"`
+	// The https endpoint of the database service, e.g. the endpoint of a Dedicated Region or an isolated realm.
+	// The default endpoint of the region is used if not set. Only the database requests in the region of the OCI
+	// config are sent to the endpoint; the requests to the other regions, i.e. the cross-region backup copies and the
+	// disaster recovery peers, and to the other services, e.g. the subnet lookup, use their default endpoints.
+	EndpointOverride *string `json:"endpointOverride,omitempty"`
 }
 
 /************************
@@ -139,6 +145,10 @@ func traverse(lastSpec interface{}, curSpec interface{}) bool {
 			}
 		} else {
 			fieldChanged := hasChanged(lastField, curField)
+			// The fields tagged with compare:"set" are lists whose order is not kept by OCI
+			if field.Tag.Get("compare") == "set" {
+				fieldChanged = hasSetChanged(lastField, curField)
+			}
 
 			// if fieldChanged {
 			// 	if curField.Kind() == reflect.Ptr {
@@ -173,6 +183,52 @@ func traverse(lastSpec interface{}, curSpec interface{}) bool {
 	return changed
 }
 
+// fieldByJSONPath returns the field of the struct value at the path of the json names, e.g. networkAccess.accessType.
+// The returned value is invalid if the path doesn't exist.
+func fieldByJSONPath(value reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+
+		var found reflect.Value
+		for _, field := range reflect.VisibleFields(value.Type()) {
+			if strings.Split(field.Tag.Get("json"), ",")[0] == name {
+				found = value.FieldByIndex(field.Index)
+				break
+			}
+		}
+		if !found.IsValid() {
+			return reflect.Value{}
+		}
+		value = found
+	}
+
+	return value
+}
+
+// nonZeroFieldPaths returns the json paths of the fields which are not with a zero value, e.g. spec.details.displayName.
+// The nested structs are traversed, so the paths point to the leaf fields.
+func nonZeroFieldPaths(value reflect.Value, path string) []string {
+	var paths []string
+
+	for _, field := range reflect.VisibleFields(value.Type()) {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		fieldValue := value.FieldByIndex(field.Index)
+		if field.Type.Kind() == reflect.Struct {
+			paths = append(paths, nonZeroFieldPaths(fieldValue, path+"."+name)...)
+		} else if !fieldValue.IsZero() {
+			paths = append(paths, path+"."+name)
+		}
+	}
+
+	return paths
+}
+
 // 1. If the current field is with a zero value, then the field is unchanged.
 // 2. If the current field is NOT with a zero value, then we want to comapre it with the last field.
 //    In this case if the last field is with a zero value, then the field is changed
@@ -201,6 +257,56 @@ func hasChanged(lastField reflect.Value, curField reflect.Value) bool {
 	return true
 }
 
+// hasSetChanged is the same as hasChanged, except that the string slices are compared regardless of the order, and
+// an empty slice which is not nil is changed if the last slice is not empty, so that the list can be cleared
+func hasSetChanged(lastField reflect.Value, curField reflect.Value) bool {
+	if curField.IsNil() {
+		return false
+	}
+	if lastField.Len() != curField.Len() {
+		return true
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < lastField.Len(); i++ {
+		counts[lastField.Index(i).String()]++
+	}
+	for i := 0; i < curField.Len(); i++ {
+		val := curField.Index(i).String()
+		if counts[val] == 0 {
+			return true
+		}
+		counts[val]--
+	}
+
+	return false
+}
+
+/************************
+*	Pointer helpers
+************************/
+
+func derefString(val *string) string {
+	if val == nil {
+		return ""
+	}
+	return *val
+}
+
+func derefInt(val *int) int {
+	if val == nil {
+		return 0
+	}
+	return *val
+}
+
+func derefBool(val *bool) bool {
+	if val == nil {
+		return false
+	}
+	return *val
+}
+
 /************************
 *	SDKTime format
 ************************/
@@ -248,6 +354,31 @@ func IsADBIntermediateState(state database.AutonomousDatabaseLifecycleStateEnum)
 	return false
 }
 
+// IsADBTransientState returns true if OCI rejects the update requests in the state, but the database is expected to
+// leave the state without any user action. The STOPPED and the TERMINATED states are not transient.
+func IsADBTransientState(state database.AutonomousDatabaseLifecycleStateEnum) bool {
+	switch state {
+	case database.AutonomousDatabaseLifecycleStateProvisioning,
+		database.AutonomousDatabaseLifecycleStateStarting,
+		database.AutonomousDatabaseLifecycleStateStopping,
+		database.AutonomousDatabaseLifecycleStateTerminating,
+		database.AutonomousDatabaseLifecycleStateUpdating,
+		database.AutonomousDatabaseLifecycleStateScaleInProgress,
+		database.AutonomousDatabaseLifecycleStateBackupInProgress,
+		database.AutonomousDatabaseLifecycleStateRestoreInProgress,
+		database.AutonomousDatabaseLifecycleStateMaintenanceInProgress,
+		database.AutonomousDatabaseLifecycleStateRestarting,
+		database.AutonomousDatabaseLifecycleStateRecreating,
+		database.AutonomousDatabaseLifecycleStateRoleChangeInProgress,
+		database.AutonomousDatabaseLifecycleStateUpgrading,
+		database.AutonomousDatabaseLifecycleStateUnavailable,
+		database.AutonomousDatabaseLifecycleStateAvailableNeedsAttention,
+		database.AutonomousDatabaseLifecycleStateInaccessible:
+		return true
+	}
+	return false
+}
+
 func ValidADBTerminateState(state database.AutonomousDatabaseLifecycleStateEnum) bool {
 	if state == database.AutonomousDatabaseLifecycleStateProvisioning ||
 		state == database.AutonomousDatabaseLifecycleStateAvailable ||
@@ -73,6 +73,10 @@ type AutonomousContainerDatabaseSpec struct {
 	// +kubebuilder:validation:Enum:="SYNC";"RESTART";"TERMINATE"
 	Action       AcdActionEnum     `json:"action,omitempty"`
 	FreeformTags map[string]string `json:"freeformTags,omitempty"`
+	// Enable the automatic failover (fast-start failover) of the Autonomous Data Guard. It's applied to the Data
+	// Guard association once the Autonomous Container Database is AVAILABLE, so it applies to all the databases in
+	// the container. The automatic failover is enabled only when the standby is AVAILABLE.
+	IsAutomaticFailoverEnabled *bool `json:"isAutomaticFailoverEnabled,omitempty"`
 
 	OCIConfig OCIConfigSpec `json:"ociConfig,omitempty"`
 	// +kubebuilder:default:=false
@@ -85,6 +89,9 @@ type AutonomousContainerDatabaseStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	LifecycleState database.AutonomousContainerDatabaseLifecycleStateEnum `json:"lifecycleState"`
 	TimeCreated    string                                                 `json:"timeCreated,omitempty"`
+	// Whether the automatic failover of the Autonomous Data Guard is armed. It's empty if the Autonomous Container
+	// Database has no Data Guard association.
+	IsAutomaticFailoverEnabled *bool `json:"isAutomaticFailoverEnabled,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -191,6 +198,14 @@ func (acd *AutonomousContainerDatabase) UpdateFromOCIACD(ociObj database.Autonom
 	return !reflect.DeepEqual(oldACD.Spec, acd.Spec)
 }
 
+// IsAutomaticFailoverPending returns true if the automatic failover is yet to be applied, e.g. while the standby is
+// not healthy
+func (acd *AutonomousContainerDatabase) IsAutomaticFailoverPending() bool {
+	return acd.Spec.IsAutomaticFailoverEnabled != nil &&
+		acd.Status.IsAutomaticFailoverEnabled != nil &&
+		*acd.Spec.IsAutomaticFailoverEnabled != *acd.Status.IsAutomaticFailoverEnabled
+}
+
 // RemoveUnchangedSpec removes the unchanged fields in spec, and returns if the spec has been changed.
 func (acd *AutonomousContainerDatabase) RemoveUnchangedSpec(prevSpec AutonomousContainerDatabaseSpec) (bool, error) {
 	changed, err := removeUnchangedFields(prevSpec, &acd.Spec)
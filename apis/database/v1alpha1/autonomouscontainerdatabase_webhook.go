@@ -65,8 +65,16 @@ var _ webhook.Validator = &AutonomousContainerDatabase{}
 func (r *AutonomousContainerDatabase) ValidateCreate() error {
 	autonomouscontainerdatabaselog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil
+	var allErrs field.ErrorList
+
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "database.oracle.com", Kind: "AutonomousContainerDatabase"},
+		r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -40,8 +40,12 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"reflect"
+	"strings"
 
+	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -52,13 +56,128 @@ import (
 // name of our custom finalizer
 const ADBFinalizer = "database.oracle.com/adb-finalizer"
 
+// ForceRefreshAnnotation is an annotation key. If the annotation exists, the next reconcile syncs the resource
+// with OCI regardless of the observedGeneration, and then removes the annotation.
+const ForceRefreshAnnotation = "database.oracle.com/force-refresh"
+
+// ExportManifestAnnotation is an annotation key. If the annotation exists, the next reconcile exports the live OCI
+// config of the database as a manifest to the ConfigMap <name>-manifest, and then removes the annotation.
+const ExportManifestAnnotation = "database.oracle.com/export-manifest"
+
+// AdoptCompartmentAnnotation is an annotation key. If the annotation exists, the next reconcile creates a resource
+// bound to each database in the compartment of the database which doesn't have a resource yet, and then removes
+// the annotation.
+const AdoptCompartmentAnnotation = "database.oracle.com/adopt-compartment"
+
+// ConfirmTerminateAnnotation is an annotation key. A resource whose hardLink is true can be deleted only if the
+// value of the annotation is the dbName of the database.
+const ConfirmTerminateAnnotation = "database.oracle.com/confirm-terminate"
+
+// ConfirmDetachAnnotation is an annotation key. The detachClone action is applied only if the value of the
+// annotation is the dbName of the database.
+const ConfirmDetachAnnotation = "database.oracle.com/confirm-detach"
+
+// ConsoleURLAnnotation is the annotation key of the URL of the database in the OCI console, which is set once the
+// OCID is known
+const ConsoleURLAnnotation = "database.oracle.com/console-url"
+
+// WalletProfileLabel is the label key of the Secret of a single connection profile. The value is the profile name.
+const WalletProfileLabel = "database.oracle.com/wallet-profile"
+
+// WalletChecksumAnnotation is the annotation key of the SHA-256 checksum of the files in the wallet Secret, which is
+// set if spec.details.wallet.checksum is true
+const WalletChecksumAnnotation = "database.oracle.com/wallet-sha256"
+
+// WalletOwnerAnnotation is the annotation key of the <namespace>/<name> of the resource which writes the wallet Secret
+// in another namespace, where the owner reference cannot be set
+const WalletOwnerAnnotation = "database.oracle.com/wallet-owner"
+
+// MaxLifecycleHistory is the number of the lifecycleStates kept in status.lifecycleHistory
+const MaxLifecycleHistory = 10
+
+// NameSuffixLength is the length of the random suffix appended to the names if spec.details.generateNameSuffix is true
+const NameSuffixLength = 5
+
+// DefaultAdminUsername is the name of the admin user if spec.details.adminUsername is not provided
+const DefaultAdminUsername = "ADMIN"
+
+// dataStorageGBsPerTB is the number of GBs in a TB of the data storage, as OCI counts them
+const dataStorageGBsPerTB = 1024
+
+// maxAutoScalingFactor is the max ratio of the CPU core count or the storage raised by the auto scaling to the
+// baseline
+const maxAutoScalingFactor = 3
+
+// DefaultIgnoredTagNamespaces are the tag namespaces which are always left out of the comparison of the definedTags.
+// The Tag Defaults of the Oracle-Tags namespace, e.g. CreatedBy and CreatedOn, are applied by OCI automatically.
+var DefaultIgnoredTagNamespaces = []string{"Oracle-Tags"}
+
+// ReconcileAnnotation is an annotation key. The reconciliation of the resource is paused if the value is "false".
+const ReconcileAnnotation = "database.oracle.com/reconcile"
+
 // AutonomousDatabaseSpec defines the desired state of AutonomousDatabase
 // Important: Run "make" to regenerate code after modifying this file
 type AutonomousDatabaseSpec struct {
 	Details   AutonomousDatabaseDetails `json:"details"`
 	OCIConfig OCIConfigSpec             `json:"ociConfig,omitempty"`
 	// +kubebuilder:default:=false
-	HardLink *bool `json:"hardLink,omitempty"`
+	HardLink    *bool           `json:"hardLink,omitempty"`
+	HealthCheck HealthCheckSpec `json:"healthCheck,omitempty"`
+	Bootstrap   BootstrapSpec   `json:"bootstrap,omitempty"`
+	// A one-shot action on the database. The action is run once and then recorded in status.lastAction; the spec is
+	// left as is. To run the same action again, remove it from the spec and set it again. rotateWallet rotates the
+	// wallet in OCI, which invalidates all the downloaded wallets, and then stores a new wallet. rotateEncryptionKey
+	// re-encrypts the database with the latest version of the customer-managed KMS key. refreshConnectionURLs
+	// fetches the ORDS and APEX URLs of an APEX or AJD database again, e.g. after the network access is changed.
+	// shrink reclaims the allocated storage which is not used by the database. detachClone detaches a refreshable
+	// clone from its source database, which makes it a standalone read-write database; it cannot be undone.
+	// +kubebuilder:validation:Enum:="";"rotateWallet";"rotateEncryptionKey";"refreshConnectionURLs";"shrink";"detachClone"
+	Action ADBActionEnum `json:"action,omitempty"`
+}
+
+type ADBActionEnum string
+
+const (
+	ADBActionRotateWallet          ADBActionEnum = "rotateWallet"
+	ADBActionRotateEncryptionKey   ADBActionEnum = "rotateEncryptionKey"
+	ADBActionRefreshConnectionURLs ADBActionEnum = "refreshConnectionURLs"
+	ADBActionShrink                ADBActionEnum = "shrink"
+	ADBActionDetachClone           ADBActionEnum = "detachClone"
+)
+
+/************************
+*	Health check specs
+************************/
+
+// HealthCheckSpec configures the periodic connectivity check against the database listener.
+// The result is reported in the Connected condition and doesn't affect the lifecycleState.
+type HealthCheckSpec struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	// The interval between two checks. Defaults to 60 seconds.
+	// +kubebuilder:validation:Minimum:=10
+	IntervalSeconds *int `json:"intervalSeconds,omitempty"`
+	// The timeout of a single check. Defaults to 10 seconds.
+	// +kubebuilder:validation:Minimum:=1
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+/************************
+*	Bootstrap specs
+************************/
+
+// BootstrapSpec configures the SQL scripts which are run once after the database is AVAILABLE and the wallet is
+// ready. The scripts are run as the admin user by a Job with SQL*Plus, in the order of the keys of the ConfigMap.
+type BootstrapSpec struct {
+	// The ConfigMap with the SQL scripts, in the namespace of the resource
+	ConfigMapName *string `json:"configMapName,omitempty"`
+	// The image of the Job, which has to provide sqlplus.
+	// Defaults to ghcr.io/oracle/oraclelinux8-instantclient:21.
+	Image *string `json:"image,omitempty"`
+	// The TNS alias in the wallet which the scripts connect to. Defaults to <dbName>_low.
+	TNSAlias *string `json:"tnsAlias,omitempty"`
+	// The number of retries of the Job before the BootstrapFailed condition is set. Defaults to 3.
+	// +kubebuilder:validation:Minimum:=0
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
 }
 
 /************************
@@ -93,11 +212,72 @@ type OCISecretSpec struct {
 type PasswordSpec struct {
 	K8sSecret K8sSecretSpec `json:"k8sSecret,omitempty"`
 	OCISecret OCISecretSpec `json:"ociSecret,omitempty"`
+	// The path of a file which contains the password, e.g. a file mounted from a CSI or projected volume.
+	// The volume has to be mounted to the operator pod.
+	VolumePath *string `json:"volumePath,omitempty"`
 }
 
 type WalletSpec struct {
-	Name     *string      `json:"name,omitempty"`
-	Password PasswordSpec `json:"password,omitempty"`
+	Name *string `json:"name,omitempty"`
+	// The namespace where the wallet Secret is created. Defaults to the namespace of the resource.
+	// The Secret in another namespace doesn't have the owner reference, so it isn't removed along with the resource.
+	Namespace *string      `json:"namespace,omitempty"`
+	Password  PasswordSpec `json:"password,omitempty"`
+	// The minimum TLS version that the client negotiates. The weak cipher suites are removed from the sqlnet.ora if it's set.
+	// +kubebuilder:validation:Enum:="1.2";"1.3"
+	MinTLSVersion *string `json:"minTlsVersion,omitempty"`
+	// Create a Secret per connection profile in addition to the wallet Secret, e.g. <name>-high and <name>-low.
+	// Each Secret has the wallet files, and the tnsnames.ora only contains the TNS alias of the profile.
+	SplitProfiles *bool `json:"splitProfiles,omitempty"`
+	// Upload the wallet zip to an OCI Object Storage bucket instead of storing it in a Secret
+	ObjectStorage WalletObjectStorageSpec `json:"objectStorage,omitempty"`
+	// When the wallet is generated. ifMissing generates the wallet only if the Secret or the object doesn't exist,
+	// always generates a new wallet in every reconcile, and never leaves the wallet to the user. Defaults to ifMissing.
+	// +kubebuilder:validation:Enum:="";"always";"ifMissing";"never"
+	Regenerate WalletRegenerateEnum `json:"regenerate,omitempty"`
+	// The format of the connection strings in status.allConnectionStrings. long is the connect descriptor, which
+	// the legacy clients require, and short is the Easy Connect string. Defaults to long.
+	// +kubebuilder:validation:Enum:="";"long";"short"
+	ConnectionFormat ConnectionFormatEnum `json:"connectionFormat,omitempty"`
+	// Annotate the wallet Secret with the SHA-256 checksum of the wallet files, so that the applications can verify
+	// the wallet they read is the one the operator wrote.
+	Checksum *bool `json:"checksum,omitempty"`
+	// The number of reconciles in which the wallet generation is retried if OCI cannot generate the wallet yet, e.g.
+	// the wallet service lags behind the database. The WalletFailed condition is set once the retries are exhausted,
+	// and the generation is not retried until the spec changes. The generation is retried until it succeeds if it's
+	// not set.
+	// +kubebuilder:validation:Minimum:=0
+	DownloadRetries *int `json:"downloadRetries,omitempty"`
+	// The interval between the retries of the wallet generation, e.g. 30s. Defaults to a backoff which starts from 2s
+	// and doubles after each retry, up to 15s.
+	DownloadInterval *metaV1.Duration `json:"downloadInterval,omitempty"`
+	// The base domain which replaces the domain of the hosts in the tnsnames.ora, e.g. a custom DNS zone or a
+	// private endpoint alias. The first label of each host is kept, so adb.us-ashburn-1.oraclecloud.com becomes
+	// adb.example.com if it's set to example.com.
+	HostRewrite *string `json:"hostRewrite,omitempty"`
+}
+
+type WalletRegenerateEnum string
+
+const (
+	WalletRegenerateAlways    WalletRegenerateEnum = "always"
+	WalletRegenerateIfMissing WalletRegenerateEnum = "ifMissing"
+	WalletRegenerateNever     WalletRegenerateEnum = "never"
+)
+
+type ConnectionFormatEnum string
+
+const (
+	ConnectionFormatLong  ConnectionFormatEnum = "long"
+	ConnectionFormatShort ConnectionFormatEnum = "short"
+)
+
+// WalletObjectStorageSpec is the location of the wallet zip in OCI Object Storage.
+// The object is named <prefix><wallet name>.zip.
+type WalletObjectStorageSpec struct {
+	Bucket    *string `json:"bucket,omitempty"`
+	Namespace *string `json:"namespace,omitempty"`
+	Prefix    *string `json:"prefix,omitempty"`
 }
 
 /************************
@@ -114,17 +294,44 @@ const (
 
 type NetworkAccessSpec struct {
 	// +kubebuilder:validation:Enum:="";"PUBLIC";"RESTRICTED";"PRIVATE"
-	AccessType               NetworkAccessTypeEnum `json:"accessType,omitempty"`
-	IsAccessControlEnabled   *bool                 `json:"isAccessControlEnabled,omitempty"`
-	AccessControlList        []string              `json:"accessControlList,omitempty"`
-	PrivateEndpoint          PrivateEndpointSpec   `json:"privateEndpoint,omitempty"`
-	IsMTLSConnectionRequired *bool                 `json:"isMTLSConnectionRequired,omitempty"`
+	AccessType NetworkAccessTypeEnum `json:"accessType,omitempty"`
+	// Enables the database-level access control. The accessControlList is only enforced while it's enabled. Once
+	// it's disabled, the list is kept in OCI but inactive, and the access is defined by the network security rules.
+	IsAccessControlEnabled   *bool               `json:"isAccessControlEnabled,omitempty"`
+	AccessControlList        []string            `json:"accessControlList,omitempty"`
+	PrivateEndpoint          PrivateEndpointSpec `json:"privateEndpoint,omitempty"`
+	IsMTLSConnectionRequired *bool               `json:"isMTLSConnectionRequired,omitempty"`
 }
 
 type PrivateEndpointSpec struct {
-	SubnetOCID     *string  `json:"subnetOCID,omitempty"`
-	NsgOCIDs       []string `json:"nsgOCIDs,omitempty"`
+	SubnetOCID *string  `json:"subnetOCID,omitempty"`
+	NsgOCIDs   []string `json:"nsgOCIDs,omitempty" compare:"set"`
+	// The display names of the network security groups in the VCN of the subnet. The names are resolved to OCIDs
+	// before the database is provisioned or updated, and are only used if the nsgOCIDs are not specified.
+	NsgNames       []string `json:"nsgNames,omitempty"`
 	HostnamePrefix *string  `json:"hostnamePrefix,omitempty"`
+	// The private IP address of the private endpoint, which has to be in the CIDR of the subnet.
+	// The IP is only applied when the database is provisioned. OCI assigns an IP if it's not specified.
+	PrivateEndpointIP *string `json:"privateEndpointIP,omitempty"`
+}
+
+/************************
+*	Disaster Recovery specs
+************************/
+
+type DisasterRecoveryTypeEnum string
+
+const (
+	DisasterRecoveryTypeADG         DisasterRecoveryTypeEnum = "ADG"
+	DisasterRecoveryTypeBackupBased DisasterRecoveryTypeEnum = "BACKUP_BASED"
+)
+
+// DisasterRecoveryPeerSpec defines a cross-region disaster recovery peer of a serverless database
+type DisasterRecoveryPeerSpec struct {
+	// The region where the peer is created, e.g. us-phoenix-1
+	Region *string `json:"region,omitempty"`
+	// +kubebuilder:validation:Enum:="ADG";"BACKUP_BASED"
+	DisasterRecoveryType DisasterRecoveryTypeEnum `json:"disasterRecoveryType,omitempty"`
 }
 
 // AutonomousDatabaseDetails defines the detail information of AutonomousDatabase, corresponding to oci-go-sdk/database/AutonomousDatabase
@@ -134,23 +341,202 @@ type AutonomousDatabaseDetails struct {
 	AutonomousContainerDatabase ACDSpec `json:"autonomousContainerDatabase,omitempty"`
 	DisplayName                 *string `json:"displayName,omitempty"`
 	DbName                      *string `json:"dbName,omitempty"`
+	// The name of the compartment, or the path from the root compartment like parent/child.
+	// It's resolved to the OCID when the database is provisioned, and only used if the compartmentOCID is not set.
+	CompartmentName *string `json:"compartmentName,omitempty"`
 	// +kubebuilder:validation:Enum:="OLTP";"DW";"AJD";"APEX"
 	DbWorkload database.AutonomousDatabaseDbWorkloadEnum `json:"dbWorkload,omitempty"`
 	// +kubebuilder:validation:Enum:="LICENSE_INCLUDED";"BRING_YOUR_OWN_LICENSE"
-	LicenseModel         database.AutonomousDatabaseLicenseModelEnum   `json:"licenseModel,omitempty"`
-	DbVersion            *string                                       `json:"dbVersion,omitempty"`
-	DataStorageSizeInTBs *int                                          `json:"dataStorageSizeInTBs,omitempty"`
-	CPUCoreCount         *int                                          `json:"cpuCoreCount,omitempty"`
-	AdminPassword        PasswordSpec                                  `json:"adminPassword,omitempty"`
-	IsAutoScalingEnabled *bool                                         `json:"isAutoScalingEnabled,omitempty"`
-	IsDedicated          *bool                                         `json:"isDedicated,omitempty"`
-	LifecycleState       database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState,omitempty"`
+	LicenseModel                   database.AutonomousDatabaseLicenseModelEnum   `json:"licenseModel,omitempty"`
+	DbVersion                      *string                                       `json:"dbVersion,omitempty"`
+	DataStorageSizeInTBs           *int                                          `json:"dataStorageSizeInTBs,omitempty"`
+	// The size of the data storage in GB, which cannot be set with the dataStorageSizeInTBs. The sizes are compared
+	// with 1 TB = 1024 GB, so changing the representation of the same size doesn't resize the database.
+	DataStorageSizeInGBs           *int                                          `json:"dataStorageSizeInGBs,omitempty"`
+	CPUCoreCount                   *int                                          `json:"cpuCoreCount,omitempty"`
+	// The compute model of the database, OCPU by default. The cpuCoreCount is counted in the CPUs of the compute
+	// model, and the storage size is limited by the CPU count of the compute model.
+	// +kubebuilder:validation:Enum:="OCPU";"ECPU"
+	ComputeModel                   string                                        `json:"computeModel,omitempty"`
+	AdminPassword                  PasswordSpec                                  `json:"adminPassword,omitempty"`
+	IsAutoScalingEnabled           *bool                                         `json:"isAutoScalingEnabled,omitempty"`
+	IsAutoScalingForStorageEnabled *bool                                         `json:"isAutoScalingForStorageEnabled,omitempty"`
+	IsDedicated                    *bool                                         `json:"isDedicated,omitempty"`
+	LifecycleState                 database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState,omitempty"`
+	// Stop the database before scaling the CPU or the storage, and start it again afterwards.
+	// Some shapes of the dedicated databases can only be scaled while the database is stopped.
+	StopBeforeScaling *bool `json:"stopBeforeScaling,omitempty"`
+	// The retention period of the automatic backups, between 1 and 60 days.
+	BackupRetentionPeriodInDays *int `json:"backupRetentionPeriodInDays,omitempty"`
+	// The name of the admin user, ADMIN by default. A different name can only be set when a dedicated database is provisioned.
+	AdminUsername *string `json:"adminUsername,omitempty"`
+	// Provision an Always Free database, which has fixed CPU and storage and is stopped by OCI after inactivity.
+	// It's only applied when the database is provisioned.
+	IsFreeTier *bool `json:"isFreeTier,omitempty"`
+	// The availability domain where a dedicated database is expected to be provisioned, e.g. Uocm:PHX-AD-1.
+	// A dedicated database is placed in the availability domain of its Autonomous Container Database, so the
+	// provisioning fails if the container database is in another availability domain of the region.
+	AvailabilityDomain *string `json:"availabilityDomain,omitempty"`
+	// The Autonomous VM Cluster where a dedicated database is expected to be provisioned. If the
+	// autonomousContainerDatabase is not set, the only AVAILABLE Autonomous Container Database on the VM cluster in
+	// the compartment is used. It's only applied when the database is provisioned.
+	AutonomousVMClusterOCID *string `json:"autonomousVmClusterOCID,omitempty"`
+	// The Cloud Exadata Infrastructure where a dedicated database is expected to be provisioned. The provisioning
+	// fails if the Autonomous Container Database is on another infrastructure. It's only applied when the database
+	// is provisioned.
+	CloudExadataInfrastructureOCID *string `json:"cloudExadataInfrastructureOCID,omitempty"`
+	// Allow the dbVersion to be a preview version, and accept the terms of service of the preview version.
+	// A preview version is rejected when the database is provisioned unless this is true. Only applies to a
+	// serverless database.
+	AllowPreviewVersions *bool `json:"allowPreviewVersions,omitempty"`
+	// Bind to the existing database with the displayName in the compartment, and only provision a new database if
+	// there is none. The terminated databases are ignored, and more than one database with the displayName is an error.
+	CreateIfMissing *bool `json:"createIfMissing,omitempty"`
+	// Bind to the database with the same displayName in the compartment if the database of the stored OCID is not
+	// found in OCI, e.g. it's terminated out-of-band, instead of retrying on the not found error. The terminated
+	// databases are ignored, and more than one database with the displayName is an error.
+	RebindOnMissing *bool `json:"rebindOnMissing,omitempty"`
+	// Append a random suffix to the displayName and the dbName when the database is provisioned, e.g. to avoid the
+	// collisions between the ephemeral databases of parallel test runs. The suffix is kept in status.nameSuffix, so
+	// that the generated names are used in the later reconciles.
+	GenerateNameSuffix *bool `json:"generateNameSuffix,omitempty"`
 
 	NetworkAccess NetworkAccessSpec `json:"networkAccess,omitempty"`
 
 	FreeformTags map[string]string `json:"freeformTags,omitempty"`
+	// The keys of the freeform tags to be removed from the database. If specified, the freeformTags are merged into
+	// the tags in OCI instead of replacing them, so that the tags which are not in the spec are kept.
+	RemoveTags []string `json:"removeTags,omitempty"`
+	// The defined tags, keyed by the tag namespace and then the tag key
+	DefinedTags map[string]map[string]string `json:"definedTags,omitempty"`
+	// The tag namespaces which are left out of the comparison of the definedTags, e.g. the namespaces of the Tag
+	// Defaults which OCI applies to the database automatically. The tags in these namespaces are kept as is in OCI.
+	// The Oracle-Tags namespace is always ignored.
+	IgnoredTagNamespaces []string `json:"ignoredTagNamespaces,omitempty"`
+	// The fields in spec.details which are managed out of band, e.g. freeformTags or networkAccess.accessControlList.
+	// The fields are applied when the database is provisioned, but are left out of the update and the drift detection.
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+	// The type of the local disaster recovery of a serverless database
+	// +kubebuilder:validation:Enum:="ADG";"BACKUP_BASED"
+	DisasterRecoveryType DisasterRecoveryTypeEnum `json:"disasterRecoveryType,omitempty"`
+	// The cross-region disaster recovery peer. Deprecated: use disasterRecoveryPeers. The peer is reconciled as an
+	// entry of disasterRecoveryPeers.
+	DisasterRecoveryPeer DisasterRecoveryPeerSpec `json:"disasterRecoveryPeer,omitempty"`
+	// The cross-region standby peers. A peer is created in each region added to the list, and the peer in a region
+	// removed from the list is terminated. The disasterRecoveryType of a peer is ADG by default.
+	DisasterRecoveryPeers []DisasterRecoveryPeerSpec `json:"disasterRecoveryPeers,omitempty"`
+	// The email addresses which receive the operational notifications of the database, e.g. the maintenance.
+	// The order is not significant. Set an empty list to remove all the contacts.
+	CustomerContacts []string `json:"customerContacts,omitempty" compare:"set"`
+	// Enable Database Management to monitor the database. It's enabled or disabled once the database is AVAILABLE.
+	IsDatabaseManagementEnabled *bool `json:"isDatabaseManagementEnabled,omitempty"`
+	// Enable Operations Insights to analyze the resource usage of the database. It's enabled or disabled once the
+	// database is AVAILABLE.
+	IsOperationsInsightsEnabled *bool `json:"isOperationsInsightsEnabled,omitempty"`
 
 	Wallet WalletSpec `json:"wallet,omitempty"`
+	// Whether the operator generates the wallet. Set it to false if the database is only observed, e.g. no application
+	// in the cluster connects to it, so that the wallet is never generated nor stored even if the wallet is specified.
+	// Defaults to true.
+	ManageWallet *bool `json:"manageWallet,omitempty"`
+	// Whether the Ready condition waits until the wallet is stored in the Secret or uploaded to the bucket. Set it to
+	// false if the applications connect with TLS without the wallet. Defaults to true.
+	ReadyRequiresWallet *bool `json:"readyRequiresWallet,omitempty"`
+}
+
+// DisasterRecoveryStatus defines the observed disaster recovery configuration of AutonomousDatabase
+type DisasterRecoveryStatus struct {
+	Type DisasterRecoveryTypeEnum            `json:"type,omitempty"`
+	Role database.AutonomousDatabaseRoleEnum `json:"role,omitempty"`
+	// The lag of the local standby database
+	LagTimeInSeconds            int      `json:"lagTimeInSeconds,omitempty"`
+	PeerAutonomousDatabaseOCIDs []string `json:"peerAutonomousDatabaseOCIDs,omitempty"`
+	// Deprecated: the peer is migrated into the peers by the operator
+	Peer DisasterRecoveryPeerStatus `json:"peer,omitempty"`
+	// The cross-region standby peers of spec.details.disasterRecoveryPeers and spec.details.disasterRecoveryPeer
+	Peers []DisasterRecoveryPeerStatus `json:"peers,omitempty"`
+}
+
+// BootstrapStatus defines the bootstrap SQL which has been run on the database. The scripts are not run again
+// unless the ConfigMap is changed.
+type BootstrapStatus struct {
+	// The ConfigMap whose scripts have been run
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// The time the scripts were completed
+	CompletionTime *metaV1.Time `json:"completionTime,omitempty"`
+}
+
+// oracleManagedKey is the kmsKeyId of a database encrypted with an Oracle-managed key
+const oracleManagedKey = "ORACLE_MANAGED_KEY"
+
+// EncryptionKeyStatus defines the observed master encryption key of AutonomousDatabase
+type EncryptionKeyStatus struct {
+	KmsKeyOCID        string `json:"kmsKeyOCID,omitempty"`
+	KmsKeyVersionOCID string `json:"kmsKeyVersionOCID,omitempty"`
+	VaultOCID         string `json:"vaultOCID,omitempty"`
+	// The time the current key version was activated
+	LastRotationTime string `json:"lastRotationTime,omitempty"`
+}
+
+// IsCustomerManaged returns true if the database is encrypted with a customer-managed KMS key
+func (s EncryptionKeyStatus) IsCustomerManaged() bool {
+	return s.KmsKeyOCID != "" && s.KmsKeyOCID != oracleManagedKey
+}
+
+// ConnectionURLsStatus defines the URLs to access the tools of AutonomousDatabase with a browser
+type ConnectionURLsStatus struct {
+	// The URL of SQL Developer Web, which is served by ORDS
+	SQLDevWebURL string `json:"sqlDevWebUrl,omitempty"`
+	// The URL of Oracle Application Express (APEX)
+	ApexURL string `json:"apexUrl,omitempty"`
+	// The URL of Graph Studio
+	GraphStudioURL string `json:"graphStudioUrl,omitempty"`
+}
+
+// LifecycleTransition is a lifecycleState of the database and the time the operator observed it
+type LifecycleTransition struct {
+	LifecycleState database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState"`
+	Time           metaV1.Time                                   `json:"time"`
+}
+
+// ShrinkStatus is the result of the last shrink action
+type ShrinkStatus struct {
+	// The allocated storage when the shrink was requested
+	AllocatedStorageBeforeInGBs int `json:"allocatedStorageBeforeInGBs,omitempty"`
+	// The storage reclaimed by the shrink
+	ReclaimedStorageSizeInGBs int `json:"reclaimedStorageSizeInGBs,omitempty"`
+	// The time the shrink completed
+	LastShrinkTime string `json:"lastShrinkTime,omitempty"`
+}
+
+// MaintenanceStatus defines the observed patch level and maintenance windows of AutonomousDatabase
+type MaintenanceStatus struct {
+	// The patch level of the database, EARLY or REGULAR. It's empty for a dedicated database.
+	ScheduleType database.AutonomousDatabaseAutonomousMaintenanceScheduleTypeEnum `json:"scheduleType,omitempty"`
+	// The start and the end of the next maintenance window
+	TimeMaintenanceBegin string `json:"timeMaintenanceBegin,omitempty"`
+	TimeMaintenanceEnd   string `json:"timeMaintenanceEnd,omitempty"`
+	// The lifecycleState of the local standby database, which is MAINTENANCE_IN_PROGRESS while the standby is patched
+	StandbyLifecycleState database.AutonomousDatabaseStandbySummaryLifecycleStateEnum `json:"standbyLifecycleState,omitempty"`
+
+	// The patching of a dedicated database, which is the one of its Autonomous Container Database.
+	// The patch model, e.g. RELEASE_UPDATES or RELEASE_UPDATE_REVISIONS
+	PatchModel             database.AutonomousContainerDatabasePatchModelEnum `json:"patchModel,omitempty"`
+	NextMaintenanceRunOCID string                                             `json:"nextMaintenanceRunOCID,omitempty"`
+	LastMaintenanceRunOCID string                                             `json:"lastMaintenanceRunOCID,omitempty"`
+	// The last patch applied
+	LastPatchOCID string `json:"lastPatchOCID,omitempty"`
+}
+
+// DisasterRecoveryPeerStatus is the cross-region peer created by the operator
+type DisasterRecoveryPeerStatus struct {
+	Region                 string                   `json:"region,omitempty"`
+	DisasterRecoveryType   DisasterRecoveryTypeEnum `json:"disasterRecoveryType,omitempty"`
+	AutonomousDatabaseOCID string                   `json:"autonomousDatabaseOCID,omitempty"`
+	// The role of the peer, e.g. STANDBY, or PRIMARY after a switchover
+	Role database.AutonomousDatabaseRoleEnum `json:"role,omitempty"`
+	// The lifecycleState of the peer in its region
+	LifecycleState database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState,omitempty"`
 }
 
 // AutonomousDatabaseStatus defines the observed state of AutonomousDatabase
@@ -160,6 +546,176 @@ type AutonomousDatabaseStatus struct {
 	LifecycleState       database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState,omitempty"`
 	TimeCreated          string                                        `json:"timeCreated,omitempty"`
 	AllConnectionStrings []ConnectionStringProfile                     `json:"allConnectionStrings,omitempty"`
+	// The URLs to access the tools of the database with a browser
+	ConnectionURLs ConnectionURLsStatus `json:"connectionUrls,omitempty"`
+	// The last lifecycleStates observed by the operator and the time they were observed, oldest first. Only the last
+	// 10 states are kept.
+	LifecycleHistory []LifecycleTransition `json:"lifecycleHistory,omitempty"`
+
+	// The attributes observed from OCI. The controller never writes them back to the spec.
+	AutonomousDatabaseOCID string `json:"autonomousDatabaseOCID,omitempty"`
+	CompartmentOCID        string `json:"compartmentOCID,omitempty"`
+	// The Autonomous Container Database where a dedicated database is provisioned
+	AutonomousContainerDatabaseOCID string                                      `json:"autonomousContainerDatabaseOCID,omitempty"`
+	DisplayName                     string                                      `json:"displayName,omitempty"`
+	DbName                          string                                      `json:"dbName,omitempty"`
+	DbVersion                       string                                      `json:"dbVersion,omitempty"`
+	DbWorkload                      database.AutonomousDatabaseDbWorkloadEnum   `json:"dbWorkload,omitempty"`
+	LicenseModel                    database.AutonomousDatabaseLicenseModelEnum `json:"licenseModel,omitempty"`
+	IsDedicated                     bool                                        `json:"isDedicated,omitempty"`
+	IsFreeTier                      bool                                        `json:"isFreeTier,omitempty"`
+	CPUCoreCount                    int                                         `json:"cpuCoreCount,omitempty"`
+	DataStorageSizeInTBs            int                                         `json:"dataStorageSizeInTBs,omitempty"`
+	IsAutoScalingEnabled            bool                                        `json:"isAutoScalingEnabled,omitempty"`
+	IsAutoScalingForStorageEnabled  bool                                        `json:"isAutoScalingForStorageEnabled,omitempty"`
+	FreeformTags                    map[string]string                           `json:"freeformTags,omitempty"`
+	DefinedTags                     map[string]map[string]string                `json:"definedTags,omitempty"`
+	CustomerContacts                []string                                    `json:"customerContacts,omitempty"`
+	// Whether the database is a refreshable clone, which is read-only and refreshed from its source database. It's
+	// false once the clone is detached by the detachClone action.
+	IsRefreshableClone bool `json:"isRefreshableClone,omitempty"`
+	// The status of Database Management, e.g. ENABLED or NOT_ENABLED
+	DatabaseManagementStatus database.AutonomousDatabaseDatabaseManagementStatusEnum `json:"databaseManagementStatus,omitempty"`
+	// The status of Operations Insights, e.g. ENABLED or NOT_ENABLED
+	OperationsInsightsStatus database.AutonomousDatabaseOperationsInsightsStatusEnum `json:"operationsInsightsStatus,omitempty"`
+	NetworkAccess                   NetworkAccessSpec                           `json:"networkAccess,omitempty"`
+	// The disaster recovery configuration. The types are the values applied by the operator, since they are
+	// missing from the OCI object.
+	DisasterRecovery DisasterRecoveryStatus `json:"disasterRecovery,omitempty"`
+	// The master encryption key of the database
+	EncryptionKey EncryptionKeyStatus `json:"encryptionKey,omitempty"`
+	// The CPU core count without the auto scaling, which is compared with spec.details.cpuCoreCount.
+	// The cpuCoreCount is the actual value, which can be higher than the baseline while the auto scaling is enabled.
+	BaselineCPUCoreCount int `json:"baselineCPUCoreCount,omitempty"`
+	// The maximum storage the database can grow to. The storage auto scaling can grow the storage up to three times
+	// of the dataStorageSizeInTBs, otherwise it's the dataStorageSizeInTBs.
+	MaxStorageSizeInTBs int `json:"maxStorageSizeInTBs,omitempty"`
+	// The storage allocated for the database and billed for, which is compared with the used storage to determine if
+	// the shrink is appropriate
+	AllocatedStorageSizeInGBs int `json:"allocatedStorageSizeInGBs,omitempty"`
+	// The result of the last shrink action
+	Shrink ShrinkStatus `json:"shrink,omitempty"`
+	// The last action completed, which is not run again until spec.action is changed. It's cleared once
+	// spec.action is removed.
+	LastAction ADBActionEnum `json:"lastAction,omitempty"`
+	// The generation of the resource when the lastAction completed
+	LastActionGeneration int64 `json:"lastActionGeneration,omitempty"`
+	// The patch level and the maintenance windows of the database
+	Maintenance MaintenanceStatus `json:"maintenance,omitempty"`
+	// The random suffix appended to the displayName and the dbName when the database is provisioned, if
+	// spec.details.generateNameSuffix is true
+	NameSuffix string `json:"nameSuffix,omitempty"`
+	// The retention period of the automatic backups configured by the operator.
+	// OCI doesn't return the value, so it's updated only after the operator applies the spec.
+	BackupRetentionPeriodInDays int `json:"backupRetentionPeriodInDays,omitempty"`
+	// The availability domain of a dedicated database, which is the one of its Autonomous Container Database.
+	AvailabilityDomain string `json:"availabilityDomain,omitempty"`
+	// The Autonomous VM Cluster of a dedicated database, which is the one of its Autonomous Container Database.
+	AutonomousVMClusterOCID string `json:"autonomousVmClusterOCID,omitempty"`
+	// The Exadata infrastructure of a dedicated database, which is the one of its Autonomous VM Cluster.
+	CloudExadataInfrastructureOCID string `json:"cloudExadataInfrastructureOCID,omitempty"`
+
+	// The URL of the wallet zip uploaded to OCI Object Storage
+	WalletObjectURL string `json:"walletObjectURL,omitempty"`
+	// The number of reconciles in which the wallet generation has been retried. It's reset once the wallet is generated.
+	WalletDownloadRetries int `json:"walletDownloadRetries,omitempty"`
+	// The earliest expiry of the certificates in the stored wallet. It's empty if the wallet has no certificate in
+	// the ewallet.pem.
+	WalletExpiryTime *metaV1.Time `json:"walletExpiryTime,omitempty"`
+	// The isMtlsConnectionRequired of the database when the stored wallet was generated. The wallet is generated again
+	// once the setting of the database changes.
+	WalletMTLSConnectionRequired *bool `json:"walletMtlsConnectionRequired,omitempty"`
+	// The bootstrap SQL which has been run on the database
+	Bootstrap BootstrapStatus `json:"bootstrap,omitempty"`
+
+	// The generation of the spec which has been applied to the database
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The last time the spec was synced with the database in OCI
+	LastSyncTime *metaV1.Time `json:"lastSyncTime,omitempty"`
+
+	// The details of the last failed OCI request are cleared once the spec is synced.
+	// The OCI error code of the last failed request
+	LastErrorCode string `json:"lastErrorCode,omitempty"`
+	// The error message of the last failed request
+	LastError string `json:"lastError,omitempty"`
+	// The opc-request-id of the last failed request, which is required to file a support ticket
+	LastRequestId string `json:"lastRequestId,omitempty"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metaV1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// ADBConditionConnected indicates whether the database accepts connections
+	ADBConditionConnected = "Connected"
+	// ADBConditionDbWorkloadUpdating indicates whether a dbWorkload transition is in progress
+	ADBConditionDbWorkloadUpdating = "DbWorkloadUpdating"
+	// ADBConditionAdminPasswordReady indicates whether the password file in the adminPassword.volumePath is readable
+	ADBConditionAdminPasswordReady = "AdminPasswordReady"
+	// ADBConditionPaused indicates whether the reconciliation is paused by the ReconcileAnnotation
+	ADBConditionPaused = "Paused"
+	// ADBConditionWalletUploaded indicates whether the wallet is uploaded to the Object Storage bucket
+	ADBConditionWalletUploaded = "WalletUploaded"
+	// ADBConditionStoppedForScaling indicates whether the database is stopped by the operator to be scaled
+	ADBConditionStoppedForScaling = "StoppedForScaling"
+	// ADBConditionWalletPending indicates whether the wallet cannot be generated yet and the generation is to be retried
+	ADBConditionWalletPending = "WalletPending"
+	// ADBConditionWalletFailed indicates whether the wallet generation is given up after the retries in
+	// spec.details.wallet.downloadRetries
+	ADBConditionWalletFailed = "WalletFailed"
+	// ADBConditionCloneDetaching indicates whether the refreshable clone is being detached by the detachClone action
+	ADBConditionCloneDetaching = "CloneDetaching"
+	// ADBConditionShrinking indicates whether the storage is being shrunk by the shrink action
+	ADBConditionShrinking = "Shrinking"
+	// ADBConditionWalletRotating indicates whether the wallet is being rotated by the rotateWallet action
+	ADBConditionWalletRotating = "WalletRotating"
+	// ADBConditionStalled indicates whether the database is PROVISIONING for longer than expected
+	ADBConditionStalled = "Stalled"
+	// ADBConditionOperationDenied indicates whether a required OCI operation is not allowed by the operator
+	ADBConditionOperationDenied = "OperationDenied"
+	// ADBConditionEncryptionKeyRotating indicates whether the encryption key is being rotated by the rotateEncryptionKey action
+	ADBConditionEncryptionKeyRotating = "EncryptionKeyRotating"
+	// ADBConditionAutoStopped indicates whether the Always Free database is stopped by OCI after inactivity
+	ADBConditionAutoStopped = "AutoStopped"
+	// ADBConditionTerminated indicates whether the database is TERMINATED in OCI, in which case it's no longer polled
+	ADBConditionTerminated = "Terminated"
+	// ADBConditionBootstrapFailed indicates whether the Job of the bootstrap SQL has failed after all the retries
+	ADBConditionBootstrapFailed = "BootstrapFailed"
+	// ADBConditionQuotaExceeded indicates whether the compartment doesn't have the quota to provision the database
+	ADBConditionQuotaExceeded = "QuotaExceeded"
+	// ADBConditionAdminPasswordRotating indicates whether the new admin password is not yet applied or confirmed by the health check
+	ADBConditionAdminPasswordRotating = "AdminPasswordRotating"
+	// ADBConditionOCIRequestFailed indicates whether the last reconcile failed. The reason classifies the error, e.g.
+	// Throttled or NotAuthorized.
+	ADBConditionOCIRequestFailed = "OCIRequestFailed"
+	// ADBConditionReady indicates whether the database is AVAILABLE, and the wallet is stored unless
+	// spec.details.readyRequiresWallet is false
+	ADBConditionReady = "Ready"
+)
+
+// The dbWorkload transitions that OCI allows on an existing database
+var allowedDbWorkloadTransitions = map[database.AutonomousDatabaseDbWorkloadEnum][]database.AutonomousDatabaseDbWorkloadEnum{
+	database.AutonomousDatabaseDbWorkloadOltp: {database.AutonomousDatabaseDbWorkloadDw},
+	database.AutonomousDatabaseDbWorkloadDw:   {database.AutonomousDatabaseDbWorkloadOltp},
+	database.AutonomousDatabaseDbWorkloadAjd:  {database.AutonomousDatabaseDbWorkloadOltp},
+	database.AutonomousDatabaseDbWorkloadApex: {database.AutonomousDatabaseDbWorkloadOltp, database.AutonomousDatabaseDbWorkloadAjd},
+}
+
+// IsDbWorkloadTransitionAllowed returns true if the dbWorkload of an existing database can be changed from one type to the other
+func IsDbWorkloadTransitionAllowed(from database.AutonomousDatabaseDbWorkloadEnum, to database.AutonomousDatabaseDbWorkloadEnum) bool {
+	if from == "" || to == "" || from == to {
+		return true
+	}
+
+	for _, workload := range allowedDbWorkloadTransitions[from] {
+		if workload == to {
+			return true
+		}
+	}
+	return false
 }
 
 type TLSAuthenticationEnum string
@@ -183,14 +739,15 @@ type ConnectionStringSpec struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName="adb";"adbs"
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:JSONPath=".spec.details.displayName",name="Display Name",type=string
-// +kubebuilder:printcolumn:JSONPath=".spec.details.dbName",name="Db Name",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.displayName",name="Display Name",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.dbName",name="Db Name",type=string
 // +kubebuilder:printcolumn:JSONPath=".status.lifecycleState",name="State",type=string
-// +kubebuilder:printcolumn:JSONPath=".spec.details.isDedicated",name="Dedicated",type=string
-// +kubebuilder:printcolumn:JSONPath=".spec.details.cpuCoreCount",name="OCPUs",type=integer
-// +kubebuilder:printcolumn:JSONPath=".spec.details.dataStorageSizeInTBs",name="Storage (TB)",type=integer
-// +kubebuilder:printcolumn:JSONPath=".spec.details.dbWorkload",name="Workload Type",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.isDedicated",name="Dedicated",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.cpuCoreCount",name="OCPUs",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.dataStorageSizeInTBs",name="Storage (TB)",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.dbWorkload",name="Workload Type",type=string
 // +kubebuilder:printcolumn:JSONPath=".status.timeCreated",name="Created",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.maintenance.timeMaintenanceBegin",name="Next Maintenance",type=string
 type AutonomousDatabase struct {
 	metaV1.TypeMeta   `json:",inline"`
 	metaV1.ObjectMeta `json:"metadata,omitempty"`
@@ -212,6 +769,165 @@ func init() {
 	SchemeBuilder.Register(&AutonomousDatabase{}, &AutonomousDatabaseList{})
 }
 
+// GetDisasterRecoveryPeers returns the desired cross-region standby peers, i.e. the peers of
+// spec.details.disasterRecoveryPeers followed by the peer of the deprecated spec.details.disasterRecoveryPeer
+func (adb *AutonomousDatabase) GetDisasterRecoveryPeers() []DisasterRecoveryPeerSpec {
+	peer := adb.Spec.Details.DisasterRecoveryPeer
+	if peer.Region == nil && peer.DisasterRecoveryType == "" {
+		return adb.Spec.Details.DisasterRecoveryPeers
+	}
+
+	peers := make([]DisasterRecoveryPeerSpec, 0, len(adb.Spec.Details.DisasterRecoveryPeers)+1)
+	peers = append(peers, adb.Spec.Details.DisasterRecoveryPeers...)
+	return append(peers, peer)
+}
+
+// HasDisasterRecoveryPeerIn returns true if a cross-region standby peer has been created in the region
+func (adb *AutonomousDatabase) HasDisasterRecoveryPeerIn(region string) bool {
+	for _, peer := range adb.Status.DisasterRecovery.Peers {
+		if strings.EqualFold(peer.Region, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDisasterRecoveryPeerTransient returns true if any of the cross-region standby peers is being created or terminated
+func (adb *AutonomousDatabase) IsDisasterRecoveryPeerTransient() bool {
+	for _, peer := range adb.Status.DisasterRecovery.Peers {
+		if IsADBIntermediateState(peer.LifecycleState) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmationDbName returns the dbName which a confirmation annotation must be set to, i.e. the dbName observed in
+// OCI, or the dbName in the spec with the generated suffix if the database is not yet observed
+func (adb *AutonomousDatabase) ConfirmationDbName() string {
+	if adb.Status.DbName != "" {
+		return adb.Status.DbName
+	}
+	if adb.Spec.Details.DbName != nil {
+		return *adb.Spec.Details.DbName + adb.Status.NameSuffix
+	}
+	return ""
+}
+
+// IsConfirmed returns true if the value of the confirmation annotation is the dbName of the database
+func (adb *AutonomousDatabase) IsConfirmed(annotation string) bool {
+	confirmation, ok := adb.GetAnnotations()[annotation]
+	return ok && strings.EqualFold(confirmation, adb.ConfirmationDbName())
+}
+
+// IsReconcilePaused returns true if the ReconcileAnnotation is set to "false"
+func (adb *AutonomousDatabase) IsReconcilePaused() bool {
+	return adb.GetAnnotations()[ReconcileAnnotation] == "false"
+}
+
+// PendingAction returns the spec.action if it has not been completed yet, i.e. it's not the status.lastAction,
+// otherwise an empty string
+func (adb *AutonomousDatabase) PendingAction() ADBActionEnum {
+	if adb.Spec.Action == adb.Status.LastAction {
+		return ""
+	}
+	return adb.Spec.Action
+}
+
+// IsShrinkAllowed returns true if the storage of the database can be shrunk. Only the databases on shared
+// infrastructure support the shrink, and the storage of an Always Free database cannot be changed.
+func (adb *AutonomousDatabase) IsShrinkAllowed() bool {
+	return !adb.Status.IsDedicated && !adb.Status.IsFreeTier
+}
+
+// IsConnectionURLsRefreshAllowed returns true if the dbWorkload serves the ORDS and APEX URLs, i.e. APEX or AJD
+func IsConnectionURLsRefreshAllowed(dbWorkload database.AutonomousDatabaseDbWorkloadEnum) bool {
+	return dbWorkload == database.AutonomousDatabaseDbWorkloadApex || dbWorkload == database.AutonomousDatabaseDbWorkloadAjd
+}
+
+// IsForceRefreshRequested returns true if the ForceRefreshAnnotation exists
+func (adb *AutonomousDatabase) IsForceRefreshRequested() bool {
+	_, ok := adb.GetAnnotations()[ForceRefreshAnnotation]
+	return ok
+}
+
+// IsExportManifestRequested returns true if the ExportManifestAnnotation exists
+func (adb *AutonomousDatabase) IsExportManifestRequested() bool {
+	_, ok := adb.GetAnnotations()[ExportManifestAnnotation]
+	return ok
+}
+
+// IsAdoptCompartmentRequested returns true if the AdoptCompartmentAnnotation exists
+func (adb *AutonomousDatabase) IsAdoptCompartmentRequested() bool {
+	_, ok := adb.GetAnnotations()[AdoptCompartmentAnnotation]
+	return ok
+}
+
+// GetAutonomousDatabaseOCID returns the OCID in the spec for a binding operation, or the OCID observed after
+// the provisioning. Returns nil if the database is not yet provisioned.
+func (adb *AutonomousDatabase) GetAutonomousDatabaseOCID() *string {
+	if adb.Spec.Details.AutonomousDatabaseOCID != nil {
+		return adb.Spec.Details.AutonomousDatabaseOCID
+	}
+	if adb.Status.AutonomousDatabaseOCID != "" {
+		ocid := adb.Status.AutonomousDatabaseOCID
+		return &ocid
+	}
+	return nil
+}
+
+// RecordLifecycleState appends the lifecycleState to the lifecycleHistory if it differs from the last recorded one.
+// The oldest states are dropped so that at most MaxLifecycleHistory states are kept.
+func (adb *AutonomousDatabase) RecordLifecycleState(now metaV1.Time) {
+	state := adb.Status.LifecycleState
+	if state == "" {
+		return
+	}
+
+	history := adb.Status.LifecycleHistory
+	if len(history) > 0 && history[len(history)-1].LifecycleState == state {
+		return
+	}
+
+	history = append(history, LifecycleTransition{LifecycleState: state, Time: now})
+	if len(history) > MaxLifecycleHistory {
+		history = history[len(history)-MaxLifecycleHistory:]
+	}
+	adb.Status.LifecycleHistory = history
+}
+
+// ConsoleURL returns the URL of the database in the OCI console, e.g.
+// https://cloud.oracle.com/db/adbs/ocid1.autonomousdatabase.oc1.phx.xxx?region=us-phoenix-1. The region is parsed
+// from the OCID. Returns an empty string if the OCID is unknown, or if the region is not in the commercial realm,
+// whose console is the only one with a well-known host.
+func (adb *AutonomousDatabase) ConsoleURL() string {
+	ocid := adb.GetAutonomousDatabaseOCID()
+	region := regionFromOCID(ocid)
+	if region == "" {
+		return ""
+	}
+	if realm, _ := common.Region(region).RealmID(); realm != "oc1" {
+		return ""
+	}
+	return fmt.Sprintf("https://cloud.oracle.com/db/adbs/%s?region=%s", *ocid, region)
+}
+
+// ApplyNameSuffix appends the generated suffix in the status to the displayName and the dbName of the details, which
+// are then the names of the database in OCI. The displayName is separated from the suffix by a hyphen, while the
+// dbName can only contain letters and digits. The details are left as is if there is no generated suffix.
+func (adb *AutonomousDatabase) ApplyNameSuffix(details *AutonomousDatabaseDetails) {
+	if adb.Status.NameSuffix == "" {
+		return
+	}
+
+	if details.DisplayName != nil {
+		details.DisplayName = common.String(*details.DisplayName + "-" + adb.Status.NameSuffix)
+	}
+	if details.DbName != nil {
+		details.DbName = common.String(*details.DbName + adb.Status.NameSuffix)
+	}
+}
+
 // GetLastSuccessfulSpec returns spec from the lass successful reconciliation.
 // Returns nil, nil if there is no lastSuccessfulSpec.
 func (adb *AutonomousDatabase) GetLastSuccessfulSpec() (*AutonomousDatabaseSpec, error) {
@@ -257,6 +973,64 @@ func (adb *AutonomousDatabase) UpdateStatusFromOCIADB(ociObj database.Autonomous
 	adb.Status.LifecycleState = ociObj.LifecycleState
 	adb.Status.TimeCreated = FormatSDKTime(ociObj.TimeCreated)
 
+	adb.Status.AutonomousDatabaseOCID = derefString(ociObj.Id)
+	adb.Status.CompartmentOCID = derefString(ociObj.CompartmentId)
+	adb.Status.AutonomousContainerDatabaseOCID = derefString(ociObj.AutonomousContainerDatabaseId)
+	adb.Status.DisplayName = derefString(ociObj.DisplayName)
+	adb.Status.DbName = derefString(ociObj.DbName)
+	adb.Status.DbVersion = derefString(ociObj.DbVersion)
+	adb.Status.DbWorkload = ociObj.DbWorkload
+	adb.Status.LicenseModel = ociObj.LicenseModel
+	adb.Status.IsDedicated = derefBool(ociObj.IsDedicated)
+	adb.Status.IsFreeTier = derefBool(ociObj.IsFreeTier)
+	adb.Status.CPUCoreCount = derefInt(ociObj.CpuCoreCount)
+	adb.Status.DataStorageSizeInTBs = derefInt(ociObj.DataStorageSizeInTBs)
+	adb.Status.IsAutoScalingEnabled = derefBool(ociObj.IsAutoScalingEnabled)
+	adb.Status.IsAutoScalingForStorageEnabled = derefBool(ociObj.IsAutoScalingForStorageEnabled)
+	adb.Status.IsRefreshableClone = derefBool(ociObj.IsRefreshableClone)
+	adb.Status.BaselineCPUCoreCount = adb.baselineCPUCoreCount()
+	adb.Status.MaxStorageSizeInTBs = adb.maxAutoScaledStorageSizeInTBs()
+	adb.Status.AllocatedStorageSizeInGBs = 0
+	if ociObj.AllocatedStorageSizeInTBs != nil {
+		adb.Status.AllocatedStorageSizeInGBs = int(math.Round(*ociObj.AllocatedStorageSizeInTBs * 1024))
+	}
+	if len(ociObj.FreeformTags) != 0 {
+		adb.Status.FreeformTags = ociObj.FreeformTags
+	} else {
+		adb.Status.FreeformTags = nil
+	}
+	adb.Status.DefinedTags = definedTagsFromOCIADB(ociObj)
+	adb.Status.CustomerContacts = customerContactsFromOCIADB(ociObj)
+	adb.Status.DatabaseManagementStatus = ociObj.DatabaseManagementStatus
+	adb.Status.OperationsInsightsStatus = ociObj.OperationsInsightsStatus
+	adb.Status.DisasterRecovery.Role = ociObj.Role
+	adb.Status.DisasterRecovery.LagTimeInSeconds = 0
+	if ociObj.StandbyDb != nil {
+		adb.Status.DisasterRecovery.LagTimeInSeconds = derefInt(ociObj.StandbyDb.LagTimeInSeconds)
+	}
+	adb.Status.Maintenance.ScheduleType = ociObj.AutonomousMaintenanceScheduleType
+	adb.Status.Maintenance.TimeMaintenanceBegin = FormatSDKTime(ociObj.TimeMaintenanceBegin)
+	adb.Status.Maintenance.TimeMaintenanceEnd = FormatSDKTime(ociObj.TimeMaintenanceEnd)
+	adb.Status.Maintenance.StandbyLifecycleState = ""
+	if ociObj.StandbyDb != nil {
+		adb.Status.Maintenance.StandbyLifecycleState = ociObj.StandbyDb.LifecycleState
+	}
+	if len(ociObj.PeerDbIds) != 0 {
+		adb.Status.DisasterRecovery.PeerAutonomousDatabaseOCIDs = ociObj.PeerDbIds
+	} else {
+		adb.Status.DisasterRecovery.PeerAutonomousDatabaseOCIDs = nil
+	}
+	adb.Status.NetworkAccess = networkAccessFromOCIADB(ociObj)
+	adb.Status.EncryptionKey = encryptionKeyFromOCIADB(ociObj)
+	adb.Status.ConnectionURLs = ConnectionURLsStatus{}
+	if ociObj.ConnectionUrls != nil {
+		adb.Status.ConnectionURLs = ConnectionURLsStatus{
+			SQLDevWebURL:   derefString(ociObj.ConnectionUrls.SqlDevWebUrl),
+			ApexURL:        derefString(ociObj.ConnectionUrls.ApexUrl),
+			GraphStudioURL: derefString(ociObj.ConnectionUrls.GraphStudioUrl),
+		}
+	}
+
 	if *ociObj.IsDedicated {
 		conns := make([]ConnectionStringSpec, len(ociObj.ConnectionStrings.AllConnectionStrings))
 		for key, val := range ociObj.ConnectionStrings.AllConnectionStrings {
@@ -272,7 +1046,11 @@ func (adb *AutonomousDatabase) UpdateStatusFromOCIADB(ociObj database.Autonomous
 
 		var conns []ConnectionStringProfile
 
+		syntaxFormat := adb.connectionSyntaxFormat()
 		for _, profile := range ociObj.ConnectionStrings.Profiles {
+			if profile.SyntaxFormat != "" && profile.SyntaxFormat != syntaxFormat {
+				continue
+			}
 			if profile.TlsAuthentication == database.DatabaseConnectionStringProfileTlsAuthenticationMutual {
 				mTLSConns = append(mTLSConns, ConnectionStringSpec{TNSName: *profile.DisplayName, ConnectionString: *profile.Value})
 			} else {
@@ -298,6 +1076,14 @@ func (adb *AutonomousDatabase) UpdateStatusFromOCIADB(ociObj database.Autonomous
 	}
 }
 
+// connectionSyntaxFormat returns the syntax format of the connection string profiles which are shown in the status
+func (adb *AutonomousDatabase) connectionSyntaxFormat() database.DatabaseConnectionStringProfileSyntaxFormatEnum {
+	if adb.Spec.Details.Wallet.ConnectionFormat == ConnectionFormatShort {
+		return database.DatabaseConnectionStringProfileSyntaxFormatEzconnect
+	}
+	return database.DatabaseConnectionStringProfileSyntaxFormatLong
+}
+
 // UpdateFromOCIADB updates the attributes using database.AutonomousDatabase object
 func (adb *AutonomousDatabase) UpdateFromOCIADB(ociObj database.AutonomousDatabase) (specChanged bool) {
 	oldADB := adb.DeepCopy()
@@ -314,9 +1100,12 @@ func (adb *AutonomousDatabase) UpdateFromOCIADB(ociObj database.AutonomousDataba
 	adb.Spec.Details.LicenseModel = ociObj.LicenseModel
 	adb.Spec.Details.DbVersion = ociObj.DbVersion
 	adb.Spec.Details.DataStorageSizeInTBs = ociObj.DataStorageSizeInTBs
+	adb.Spec.Details.DataStorageSizeInGBs = ociObj.DataStorageSizeInGBs
 	adb.Spec.Details.CPUCoreCount = ociObj.CpuCoreCount
 	adb.Spec.Details.IsAutoScalingEnabled = ociObj.IsAutoScalingEnabled
+	adb.Spec.Details.IsAutoScalingForStorageEnabled = ociObj.IsAutoScalingForStorageEnabled
 	adb.Spec.Details.IsDedicated = ociObj.IsDedicated
+	adb.Spec.Details.IsFreeTier = ociObj.IsFreeTier
 	adb.Spec.Details.LifecycleState = NextADBStableState(ociObj.LifecycleState)
 	// Special case: an emtpy map will be nil after unmarshalling while the OCI always returns an emty map.
 	if len(ociObj.FreeformTags) != 0 {
@@ -324,54 +1113,286 @@ func (adb *AutonomousDatabase) UpdateFromOCIADB(ociObj database.AutonomousDataba
 	} else {
 		adb.Spec.Details.FreeformTags = nil
 	}
+	adb.Spec.Details.DefinedTags = adb.removeIgnoredTagNamespaces(definedTagsFromOCIADB(ociObj))
+	adb.Spec.Details.CustomerContacts = customerContactsFromOCIADB(ociObj)
+	adb.Spec.Details.IsDatabaseManagementEnabled = enabledFromStatus(string(ociObj.DatabaseManagementStatus))
+	adb.Spec.Details.IsOperationsInsightsEnabled = enabledFromStatus(string(ociObj.OperationsInsightsStatus))
+
+	adb.Spec.Details.NetworkAccess = networkAccessFromOCIADB(ociObj)
+
+	// The backupRetentionPeriodInDays is missing from the OCI object, so the value configured by the operator is used
+	if adb.Status.BackupRetentionPeriodInDays != 0 {
+		retention := adb.Status.BackupRetentionPeriodInDays
+		adb.Spec.Details.BackupRetentionPeriodInDays = &retention
+	} else {
+		adb.Spec.Details.BackupRetentionPeriodInDays = nil
+	}
+
+	// The disaster recovery types are missing from the OCI object as well, so the values applied by the operator are used
+	adb.Spec.Details.DisasterRecoveryType = adb.Status.DisasterRecovery.Type
+
+	// The cross-region peers are reconciled by comparing the spec with the peers in the status rather than with the
+	// OCI object, so the peer fields are left as is
+
+	// The admin password is write-only in OCI, so it's compared with the lastSucSpec. It's not going to be updated
+	// in a bind operation, so leave the field as is if the lastSucSpec is nil.
+	// Leave the wallet field as is because the download wallet operation is independent from the update operation.
+	lastSucSpec, _ := adb.GetLastSuccessfulSpec()
+	if lastSucSpec != nil {
+		adb.Spec.Details.AdminPassword = lastSucSpec.Details.AdminPassword
+	}
+	// The adminUsername is missing from the OCI object and cannot be changed after the creation, so the field is left as is.
+
+	/***********************************
+	* update the status subresource
+	***********************************/
+	adb.UpdateStatusFromOCIADB(ociObj)
+
+	// Compare the spec with the baseline instead of the actual CPU core count, otherwise the operator scales down
+	// the CPU raised by the auto scaling
+	if ociObj.CpuCoreCount != nil {
+		baseline := adb.Status.BaselineCPUCoreCount
+		adb.Spec.Details.CPUCoreCount = &baseline
+	}
+
+	return !reflect.DeepEqual(oldADB.Spec, adb.Spec)
+}
+
+// baselineCPUCoreCount returns the CPU core count without the auto scaling. The auto scaling can raise the actual
+// CPU core count up to three times of the baseline, so the known baseline is kept while the actual value is in that
+// range. The baseline is kept as well during an intermediate state, since the actual value might not be updated yet.
+func (adb *AutonomousDatabase) baselineCPUCoreCount() int {
+	baseline := adb.Status.BaselineCPUCoreCount
+	actual := adb.Status.CPUCoreCount
+
+	if baseline == 0 || !adb.Status.IsAutoScalingEnabled {
+		return actual
+	}
+	if IsADBIntermediateState(adb.Status.LifecycleState) {
+		return baseline
+	}
+	if actual < baseline || actual > baseline*maxAutoScalingFactor {
+		return actual
+	}
+	return baseline
+}
+
+// maxAutoScaledStorageSizeInTBs returns the maximum storage the database can grow to with the storage auto scaling
+func (adb *AutonomousDatabase) maxAutoScaledStorageSizeInTBs() int {
+	if !adb.Status.IsAutoScalingForStorageEnabled {
+		return adb.Status.DataStorageSizeInTBs
+	}
+	return adb.Status.DataStorageSizeInTBs * maxAutoScalingFactor
+}
+
+// customerContactsFromOCIADB returns the email addresses of the customer contacts, or nil if there is none
+func customerContactsFromOCIADB(ociObj database.AutonomousDatabase) []string {
+	var emails []string
+	for _, contact := range ociObj.CustomerContacts {
+		if contact.Email != nil {
+			emails = append(emails, *contact.Email)
+		}
+	}
+	return emails
+}
+
+// The status of a feature which is enabled and disabled by the dedicated OCI operations, e.g. Database Management
+// and Operations Insights
+const (
+	EnablementStatusEnabling        = "ENABLING"
+	EnablementStatusEnabled         = "ENABLED"
+	EnablementStatusDisabling       = "DISABLING"
+	EnablementStatusNotEnabled      = "NOT_ENABLED"
+	EnablementStatusFailedEnabling  = "FAILED_ENABLING"
+	EnablementStatusFailedDisabling = "FAILED_DISABLING"
+)
+
+// enabledFromStatus returns true if the feature is enabled or being enabled. A failed transition is reported as
+// the previous value, so that it's retried. Nil is returned if the status is not reported.
+func enabledFromStatus(status string) *bool {
+	switch status {
+	case EnablementStatusEnabled, EnablementStatusEnabling, EnablementStatusFailedDisabling:
+		return common.Bool(true)
+	case EnablementStatusNotEnabled, EnablementStatusDisabling, EnablementStatusFailedEnabling:
+		return common.Bool(false)
+	default:
+		return nil
+	}
+}
+
+// IsEnablementTransient returns true if the feature is being enabled or disabled
+func IsEnablementTransient(status string) bool {
+	return status == EnablementStatusEnabling || status == EnablementStatusDisabling
+}
+
+// definedTagsFromOCIADB converts the defined tags of the OCI object, whose values can be of any type, to strings
+func definedTagsFromOCIADB(ociObj database.AutonomousDatabase) map[string]map[string]string {
+	if len(ociObj.DefinedTags) == 0 {
+		return nil
+	}
+
+	definedTags := map[string]map[string]string{}
+	for namespace, tags := range ociObj.DefinedTags {
+		definedTags[namespace] = map[string]string{}
+		for key, val := range tags {
+			definedTags[namespace][key] = fmt.Sprint(val)
+		}
+	}
+	return definedTags
+}
+
+// removeIgnoredFields sets the fields in the ignoreFields to zero values, so that they are seen as unchanged
+func (details *AutonomousDatabaseDetails) removeIgnoredFields() {
+	for _, path := range append([]string(nil), details.IgnoreFields...) {
+		if field := fieldByJSONPath(reflect.ValueOf(details).Elem(), path); field.IsValid() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
+
+// storageSizeInGBs returns the size of the data storage in GB, which is converted from the dataStorageSizeInTBs
+// if the dataStorageSizeInGBs is not set. Returns nil if neither is set.
+func (details *AutonomousDatabaseDetails) storageSizeInGBs() *int {
+	if details.DataStorageSizeInGBs != nil {
+		return details.DataStorageSizeInGBs
+	}
+	if details.DataStorageSizeInTBs != nil {
+		size := *details.DataStorageSizeInTBs * dataStorageGBsPerTB
+		return &size
+	}
+	return nil
+}
+
+// removeUnchangedStorageSize sets both the dataStorageSizeInTBs and the dataStorageSizeInGBs to nil if the size is
+// the same as the prevDetails, even if the size is set in the other unit, e.g. 1 TB and 1024 GB
+func (details *AutonomousDatabaseDetails) removeUnchangedStorageSize(prevDetails AutonomousDatabaseDetails) {
+	size := details.storageSizeInGBs()
+	prevSize := prevDetails.storageSizeInGBs()
+	if size != nil && prevSize != nil && *size == *prevSize {
+		details.DataStorageSizeInTBs = nil
+		details.DataStorageSizeInGBs = nil
+	}
+}
+
+// MergedFreeformTags returns the freeform tags to be applied to the database which has the ociTags. The freeformTags
+// replace the ociTags unless the removeTags are specified, in which case the freeformTags are merged into the ociTags
+// and the keys in the removeTags are removed. Nil is returned if the merged tags are the same as the ociTags.
+func (details *AutonomousDatabaseDetails) MergedFreeformTags(ociTags map[string]string) map[string]string {
+	if details.RemoveTags == nil {
+		return details.FreeformTags
+	}
+
+	merged := make(map[string]string, len(ociTags)+len(details.FreeformTags))
+	for key, val := range ociTags {
+		merged[key] = val
+	}
+	for key, val := range details.FreeformTags {
+		merged[key] = val
+	}
+	for _, key := range details.RemoveTags {
+		delete(merged, key)
+	}
+
+	if len(merged) == len(ociTags) {
+		unchanged := true
+		for key, val := range merged {
+			if ociVal, ok := ociTags[key]; !ok || ociVal != val {
+				unchanged = false
+				break
+			}
+		}
+		if unchanged {
+			return nil
+		}
+	}
+
+	return merged
+}
+
+// IsIgnoredTagNamespace returns true if the tag namespace is left out of the comparison of the definedTags
+func (adb *AutonomousDatabase) IsIgnoredTagNamespace(namespace string) bool {
+	for _, ignored := range DefaultIgnoredTagNamespaces {
+		if namespace == ignored {
+			return true
+		}
+	}
+	for _, ignored := range adb.Spec.Details.IgnoredTagNamespaces {
+		if namespace == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+func (adb *AutonomousDatabase) removeIgnoredTagNamespaces(definedTags map[string]map[string]string) map[string]map[string]string {
+	for namespace := range definedTags {
+		if adb.IsIgnoredTagNamespace(namespace) {
+			delete(definedTags, namespace)
+		}
+	}
+	if len(definedTags) == 0 {
+		return nil
+	}
+	return definedTags
+}
+
+// networkAccessFromOCIADB converts the network settings of the OCI object to a NetworkAccessSpec
+func networkAccessFromOCIADB(ociObj database.AutonomousDatabase) NetworkAccessSpec {
+	var networkAccess NetworkAccessSpec
 
 	// Determine network.accessType
 	if *ociObj.IsDedicated {
-		adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePrivate
+		networkAccess.AccessType = NetworkAccessTypePrivate
 	} else {
 		if ociObj.NsgIds != nil {
-			adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePrivate
+			networkAccess.AccessType = NetworkAccessTypePrivate
 		} else if ociObj.WhitelistedIps != nil {
-			adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypeRestricted
+			networkAccess.AccessType = NetworkAccessTypeRestricted
 		} else {
-			adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePublic
+			networkAccess.AccessType = NetworkAccessTypePublic
 		}
 	}
 
-	adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = ociObj.IsAccessControlEnabled
+	networkAccess.IsAccessControlEnabled = ociObj.IsAccessControlEnabled
 	if len(ociObj.WhitelistedIps) != 0 {
-		adb.Spec.Details.NetworkAccess.AccessControlList = ociObj.WhitelistedIps
-	} else {
-		adb.Spec.Details.NetworkAccess.AccessControlList = nil
+		networkAccess.AccessControlList = ociObj.WhitelistedIps
 	}
-	adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = ociObj.IsMtlsConnectionRequired
-	adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = ociObj.SubnetId
+	networkAccess.IsMTLSConnectionRequired = ociObj.IsMtlsConnectionRequired
+	networkAccess.PrivateEndpoint.SubnetOCID = ociObj.SubnetId
 	if len(ociObj.NsgIds) != 0 {
-		adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = ociObj.NsgIds
-	} else {
-		adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = nil
+		networkAccess.PrivateEndpoint.NsgOCIDs = ociObj.NsgIds
 	}
-	adb.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix = ociObj.PrivateEndpointLabel
+	networkAccess.PrivateEndpoint.HostnamePrefix = ociObj.PrivateEndpointLabel
+	networkAccess.PrivateEndpoint.PrivateEndpointIP = ociObj.PrivateEndpointIp
 
-	// The admin password is not going to be updated in a bind operation. Erase the field if the lastSucSpec is nil.
-	// Leave the wallet field as is because the download wallet operation is independent from the update operation.
-	lastSucSpec, _ := adb.GetLastSuccessfulSpec()
-	if lastSucSpec == nil {
-		adb.Spec.Details.AdminPassword = PasswordSpec{}
-	} else {
-		adb.Spec.Details.AdminPassword = lastSucSpec.Details.AdminPassword
+	return networkAccess
+}
+
+// encryptionKeyFromOCIADB converts the master encryption key of the OCI object to an EncryptionKeyStatus. The last
+// rotation time is the latest activation time in the key history.
+func encryptionKeyFromOCIADB(ociObj database.AutonomousDatabase) EncryptionKeyStatus {
+	encryptionKey := EncryptionKeyStatus{
+		KmsKeyOCID:        derefString(ociObj.KmsKeyId),
+		KmsKeyVersionOCID: derefString(ociObj.KmsKeyVersionId),
+		VaultOCID:         derefString(ociObj.VaultId),
 	}
 
-	/***********************************
-	* update the status subresource
-	***********************************/
-	adb.UpdateStatusFromOCIADB(ociObj)
+	var lastActivated *common.SDKTime
+	for _, entry := range ociObj.KeyHistoryEntry {
+		if entry.TimeActivated != nil && (lastActivated == nil || entry.TimeActivated.After(lastActivated.Time)) {
+			lastActivated = entry.TimeActivated
+		}
+	}
+	encryptionKey.LastRotationTime = FormatSDKTime(lastActivated)
 
-	return !reflect.DeepEqual(oldADB.Spec, adb.Spec)
+	return encryptionKey
 }
 
 // RemoveUnchangedDetails removes the unchanged fields in spec.details, and returns if the details has been changed.
 func (adb *AutonomousDatabase) RemoveUnchangedDetails(prevSpec AutonomousDatabaseSpec) (bool, error) {
+	// The ignored fields are managed out of band, so they are never changed
+	adb.Spec.Details.removeIgnoredFields()
+	adb.Spec.Details.removeUnchangedStorageSize(prevSpec.Details)
 
 	changed, err := removeUnchangedFields(prevSpec.Details, &adb.Spec.Details)
 	if err != nil {
@@ -381,6 +1402,47 @@ func (adb *AutonomousDatabase) RemoveUnchangedDetails(prevSpec AutonomousDatabas
 	return changed, nil
 }
 
+// GetDriftedDetails returns the paths of the fields in spec.details which are different from the OCI database while
+// they are unchanged since the lastSucSpec, i.e. the fields which are changed out of band. The fields changed in the
+// spec are left out since they are about to be applied. Nothing is returned if the spec has never been applied.
+func (adb *AutonomousDatabase) GetDriftedDetails(ociSpec AutonomousDatabaseSpec) ([]string, error) {
+	lastSucSpec, err := adb.GetLastSuccessfulSpec()
+	if err != nil || lastSucSpec == nil {
+		return nil, err
+	}
+
+	// The fields which are different from the OCI database
+	difDetails := adb.Spec.Details.DeepCopy()
+	difDetails.FreeformTags = difDetails.MergedFreeformTags(ociSpec.Details.FreeformTags)
+	adb.ApplyNameSuffix(difDetails)
+	difDetails.removeIgnoredFields()
+	difDetails.removeUnchangedStorageSize(ociSpec.Details)
+	if _, err := removeUnchangedFields(ociSpec.Details, difDetails); err != nil {
+		return nil, err
+	}
+
+	// The fields which are changed in the spec
+	changedDetails := adb.Spec.Details.DeepCopy()
+	changedDetails.removeUnchangedStorageSize(lastSucSpec.Details)
+	if _, err := removeUnchangedFields(lastSucSpec.Details, changedDetails); err != nil {
+		return nil, err
+	}
+
+	changedPaths := make(map[string]bool)
+	for _, path := range nonZeroFieldPaths(reflect.ValueOf(*changedDetails), "spec.details") {
+		changedPaths[path] = true
+	}
+
+	var drifted []string
+	for _, path := range nonZeroFieldPaths(reflect.ValueOf(*difDetails), "spec.details") {
+		if !changedPaths[path] {
+			drifted = append(drifted, path)
+		}
+	}
+
+	return drifted, nil
+}
+
 // A helper function which is useful for debugging. The function prints out a structural JSON format.
 func (adb *AutonomousDatabase) String() (string, error) {
 	out, err := json.MarshalIndent(adb, "", "    ")
@@ -40,12 +40,21 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -69,6 +78,16 @@ var _ webhook.Defaulter = &AutonomousDatabase{}
 func (r *AutonomousDatabase) Default() {
 	autonomousdatabaselog.Info("default", "name", r.Name)
 
+	// The cross-region standby peers use Autonomous Data Guard by default
+	for i := range r.Spec.Details.DisasterRecoveryPeers {
+		if r.Spec.Details.DisasterRecoveryPeers[i].DisasterRecoveryType == "" {
+			r.Spec.Details.DisasterRecoveryPeers[i].DisasterRecoveryType = DisasterRecoveryTypeADG
+		}
+	}
+	if r.Spec.Details.DisasterRecoveryPeer.Region != nil && r.Spec.Details.DisasterRecoveryPeer.DisasterRecoveryType == "" {
+		r.Spec.Details.DisasterRecoveryPeer.DisasterRecoveryType = DisasterRecoveryTypeADG
+	}
+
 	if !isDedicated(r) { // Shared database
 		// AccessType is PUBLIC by default
 		if r.Spec.Details.NetworkAccess.AccessType == NetworkAccessTypePublic {
@@ -76,11 +95,15 @@ func (r *AutonomousDatabase) Default() {
 			r.Spec.Details.NetworkAccess.AccessControlList = nil
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix = nil
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = nil
+			r.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = nil
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = nil
+			r.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP = nil
 		} else if r.Spec.Details.NetworkAccess.AccessType == NetworkAccessTypeRestricted {
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix = nil
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = nil
+			r.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = nil
 			r.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = nil
+			r.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP = nil
 		} else if r.Spec.Details.NetworkAccess.AccessType == NetworkAccessTypePrivate {
 			r.Spec.Details.NetworkAccess.AccessControlList = nil
 		}
@@ -91,7 +114,7 @@ func (r *AutonomousDatabase) Default() {
 
 }
 
-//+kubebuilder:webhook:verbs=create;update,path=/validate-database-oracle-com-v1alpha1-autonomousdatabase,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=autonomousdatabases,versions=v1alpha1,name=vautonomousdatabase.kb.io,admissionReviewVersions={v1}
+//+kubebuilder:webhook:verbs=create;update;delete,path=/validate-database-oracle-com-v1alpha1-autonomousdatabase,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=autonomousdatabases,versions=v1alpha1,name=vautonomousdatabase.kb.io,admissionReviewVersions={v1}
 
 var _ webhook.Validator = &AutonomousDatabase{}
 
@@ -103,15 +126,35 @@ func (r *AutonomousDatabase) ValidateCreate() error {
 
 	autonomousdatabaselog.Info("validate create", "name", r.Name)
 
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+	allErrs = validatePasswordReferences(r, allErrs)
+
 	if r.Spec.Details.AutonomousDatabaseOCID == nil { // provisioning operation
 		allErrs = validateCommon(r, allErrs)
+		allErrs = validateDeploymentType(r, allErrs)
 		allErrs = validateNetworkAccess(r, allErrs)
+		allErrs = validateStorageLimits(r, allErrs)
+		allErrs = validateDisasterRecovery(r, allErrs)
+		allErrs = validateFreeTier(r, allErrs)
 
 		if r.Spec.Details.LifecycleState != "" {
 			allErrs = append(allErrs,
 				field.Forbidden(field.NewPath("spec").Child("details").Child("lifecycleState"),
 					"cannot apply lifecycleState to a provision operation"))
 		}
+
+		// The existing database is looked up by the displayName
+		if r.Spec.Details.CreateIfMissing != nil && *r.Spec.Details.CreateIfMissing && r.Spec.Details.DisplayName == nil {
+			allErrs = append(allErrs,
+				field.Required(field.NewPath("spec").Child("details").Child("displayName"),
+					"displayName is required when createIfMissing is true"))
+		}
+
+		allErrs = validateNameSuffix(r, allErrs)
+	} else if r.Spec.Details.CreateIfMissing != nil && *r.Spec.Details.CreateIfMissing { // bind operation
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("createIfMissing"),
+				"cannot apply createIfMissing to a bind operation"))
 	}
 
 	if len(allErrs) == 0 {
@@ -161,6 +204,79 @@ func (r *AutonomousDatabase) ValidateUpdate(old runtime.Object) error {
 				"autonomousDatabaseOCID cannot be modified"))
 	}
 
+	// the provisioned database is stored in the status; cannot bind to another database
+	if r.Spec.Details.AutonomousDatabaseOCID != nil &&
+		oldADB.Status.AutonomousDatabaseOCID != "" &&
+		*r.Spec.Details.AutonomousDatabaseOCID != oldADB.Status.AutonomousDatabaseOCID {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("autonomousDatabaseOCID"),
+				"autonomousDatabaseOCID cannot be different from status.autonomousDatabaseOCID"))
+	}
+
+	// the encryption key can only be rotated if the database uses a customer-managed key
+	if r.Spec.Action == ADBActionRotateEncryptionKey &&
+		oldADB.Spec.Action != ADBActionRotateEncryptionKey &&
+		!oldADB.Status.EncryptionKey.IsCustomerManaged() {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("action"),
+				"rotateEncryptionKey requires the database to use a customer-managed key"))
+	}
+
+	// the ORDS and APEX URLs are only served by the APEX and AJD databases
+	if r.Spec.Action == ADBActionRefreshConnectionURLs &&
+		oldADB.Spec.Action != ADBActionRefreshConnectionURLs &&
+		!IsConnectionURLsRefreshAllowed(oldADB.Status.DbWorkload) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("action"),
+				"refreshConnectionURLs requires the dbWorkload to be APEX or AJD"))
+	}
+
+	// the storage can only be shrunk on an AVAILABLE database on shared infrastructure
+	if r.Spec.Action == ADBActionShrink && oldADB.Spec.Action != ADBActionShrink {
+		if !oldADB.IsShrinkAllowed() {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("action"),
+					"shrink is not supported on a dedicated or an Always Free database"))
+		} else if oldADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("action"),
+					"shrink requires the database to be AVAILABLE"))
+		}
+	}
+
+	// a refreshable clone can only be detached if it's AVAILABLE, and the detach is confirmed by the annotation
+	if r.Spec.Action == ADBActionDetachClone && oldADB.Spec.Action != ADBActionDetachClone {
+		if !oldADB.Status.IsRefreshableClone {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("action"),
+					"detachClone requires the database to be a refreshable clone"))
+		} else if oldADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("action"),
+					"detachClone requires the database to be AVAILABLE"))
+		} else if msg := checkConfirmation(r, ConfirmDetachAnnotation, "detachClone cannot be undone",
+			"the detach of the clone"); msg != "" {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("metadata").Child("annotations").Key(ConfirmDetachAnnotation), msg))
+		}
+	}
+
+	// only the transitions that OCI allows can be applied to the dbWorkload
+	if !IsDbWorkloadTransitionAllowed(oldADB.Spec.Details.DbWorkload, r.Spec.Details.DbWorkload) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("dbWorkload"),
+				fmt.Sprintf("cannot change dbWorkload from %s to %s", oldADB.Spec.Details.DbWorkload, r.Spec.Details.DbWorkload)))
+	}
+
+	// Operations Insights can only be enabled while the database is AVAILABLE
+	if r.Spec.Details.IsOperationsInsightsEnabled != nil && *r.Spec.Details.IsOperationsInsightsEnabled &&
+		(oldADB.Spec.Details.IsOperationsInsightsEnabled == nil || !*oldADB.Spec.Details.IsOperationsInsightsEnabled) &&
+		oldADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("isOperationsInsightsEnabled"),
+				"Operations Insights can only be enabled when the database is AVAILABLE"))
+	}
+
 	// cannot change lifecycleState with other fields together (except the oci config)
 	var lifecycleChanged, otherFieldsChanged bool
 
@@ -181,8 +297,61 @@ func (r *AutonomousDatabase) ValidateUpdate(old runtime.Object) error {
 				"cannot change lifecycleState with other spec attributes at the same time"))
 	}
 
+	// the private endpoint IP is only applied when the database is provisioned
+	if oldADB.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP != nil &&
+		!reflect.DeepEqual(r.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP, oldADB.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("privateEndpoint").Child("privateEndpointIP"),
+				"privateEndpointIP cannot be modified"))
+	}
+
+	// the admin user is only named when the database is provisioned
+	if !strings.EqualFold(adminUsername(r), adminUsername(oldADB)) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("adminUsername"),
+				"adminUsername cannot be modified"))
+	}
+
+	// the Always Free tier is only applied when the database is provisioned
+	if oldADB.Spec.Details.IsFreeTier != nil &&
+		!reflect.DeepEqual(r.Spec.Details.IsFreeTier, oldADB.Spec.Details.IsFreeTier) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("isFreeTier"),
+				"isFreeTier cannot be modified"))
+	}
+
+	// the type of a cross-region standby peer cannot be changed once the peer is created
+	createdPeers := oldADB.Status.DisasterRecovery.Peers
+	if oldADB.Status.DisasterRecovery.Peer.Region != "" {
+		createdPeers = append([]DisasterRecoveryPeerStatus{oldADB.Status.DisasterRecovery.Peer}, createdPeers...)
+	}
+	for _, created := range createdPeers {
+		for i, peer := range r.Spec.Details.DisasterRecoveryPeers {
+			allErrs = validateDisasterRecoveryPeerType(peer, created,
+				field.NewPath("spec").Child("details").Child("disasterRecoveryPeers").Index(i), allErrs)
+		}
+		allErrs = validateDisasterRecoveryPeerType(r.Spec.Details.DisasterRecoveryPeer, created,
+			field.NewPath("spec").Child("details").Child("disasterRecoveryPeer"), allErrs)
+	}
+
+	// the access control can only be disabled without the accessControlList if the whitelist exists, i.e. the access
+	// type stays RESTRICTED
+	if r.Spec.Details.NetworkAccess.AccessType == NetworkAccessTypeRestricted &&
+		oldADB.Spec.Details.NetworkAccess.AccessType != NetworkAccessTypeRestricted &&
+		r.Spec.Details.NetworkAccess.AccessControlList == nil && isAccessControlDisabled(r) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("accessControlList"),
+				fmt.Sprintf("accessControlList is required to change the network access type to %s", NetworkAccessTypeRestricted)))
+	}
+
 	allErrs = validateCommon(r, allErrs)
+	allErrs = validateDeploymentType(r, allErrs)
 	allErrs = validateNetworkAccess(r, allErrs)
+	allErrs = validateStorageLimits(r, allErrs)
+	allErrs = validateDisasterRecovery(r, allErrs)
+	allErrs = validateFreeTier(r, allErrs)
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+	allErrs = validatePasswordReferences(r, allErrs)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -200,20 +369,541 @@ func validateCommon(adb *AutonomousDatabase, allErrs field.ErrorList) field.Erro
 				"cannot apply k8sSecret.name and ociSecret.ocid at the same time"))
 	}
 
+	if adb.Spec.Details.AdminPassword.VolumePath != nil &&
+		(adb.Spec.Details.AdminPassword.K8sSecret.Name != nil || adb.Spec.Details.AdminPassword.OCISecret.OCID != nil) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("adminPassword"),
+				"cannot apply volumePath with k8sSecret.name or ociSecret.ocid at the same time"))
+	}
+
 	if adb.Spec.Details.Wallet.Password.K8sSecret.Name != nil && adb.Spec.Details.Wallet.Password.OCISecret.OCID != nil {
 		allErrs = append(allErrs,
 			field.Forbidden(field.NewPath("spec").Child("details").Child("wallet").Child("password"),
 				"cannot apply k8sSecret.name and ociSecret.ocid at the same time"))
 	}
 
+	if adb.Spec.Details.Wallet.Password.VolumePath != nil &&
+		(adb.Spec.Details.Wallet.Password.K8sSecret.Name != nil || adb.Spec.Details.Wallet.Password.OCISecret.OCID != nil) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("wallet").Child("password"),
+				"cannot apply volumePath with k8sSecret.name or ociSecret.ocid at the same time"))
+	}
+
+	// the ignored fields have to exist in spec.details
+	for i, path := range adb.Spec.Details.IgnoreFields {
+		if !fieldByJSONPath(reflect.ValueOf(adb.Spec.Details), path).IsValid() {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("details").Child("ignoreFields").Index(i), path,
+					"ignoreFields must be the paths of the fields in spec.details, e.g. freeformTags or networkAccess.accessControlList"))
+		}
+	}
+
+	// a tag cannot be set and removed at the same time
+	for _, key := range adb.Spec.Details.RemoveTags {
+		if _, ok := adb.Spec.Details.FreeformTags[key]; ok {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("details").Child("removeTags"),
+					fmt.Sprintf("tag %q cannot be in both freeformTags and removeTags", key)))
+		}
+	}
+
+	allErrs = validateAdminUsername(adb, allErrs)
+	allErrs = validateNames(adb, allErrs)
+	allErrs = validateBootstrap(adb, allErrs)
+
+	// wallet in Object Storage
+	objectStorage := adb.Spec.Details.Wallet.ObjectStorage
+	if objectStorage.Bucket != nil {
+		if objectStorage.Namespace == nil {
+			allErrs = append(allErrs,
+				field.Required(field.NewPath("spec").Child("details").Child("wallet").Child("objectStorage").Child("namespace"),
+					"the Object Storage namespace of the bucket is required"))
+		}
+
+		// The wallet zip is uploaded as generated, and no Secret is created
+		if adb.Spec.Details.Wallet.MinTLSVersion != nil || adb.Spec.Details.Wallet.SplitProfiles != nil ||
+			adb.Spec.Details.Wallet.HostRewrite != nil {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("details").Child("wallet").Child("objectStorage"),
+					"cannot apply minTlsVersion, splitProfiles or hostRewrite when the wallet is uploaded to Object Storage"))
+		}
+	} else if objectStorage.Namespace != nil || objectStorage.Prefix != nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("details").Child("wallet").Child("objectStorage").Child("bucket"),
+				"the bucket is required to upload the wallet to Object Storage"))
+	}
+
+	// wallet download retries
+	if interval := adb.Spec.Details.Wallet.DownloadInterval; interval != nil && interval.Duration <= 0 {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("details").Child("wallet").Child("downloadInterval"), interval.Duration.String(),
+				"downloadInterval must be positive"))
+	}
+
+	// wallet host rewriting
+	if domain := adb.Spec.Details.Wallet.HostRewrite; domain != nil {
+		if msgs := validation.IsDNS1123Subdomain(*domain); len(msgs) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("details").Child("wallet").Child("hostRewrite"), *domain,
+					strings.Join(msgs, "; ")))
+		}
+	}
+
+	// customer contacts
+	for i, email := range adb.Spec.Details.CustomerContacts {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("details").Child("customerContacts").Index(i), email,
+					"must be a valid email address"))
+		}
+	}
+
+	// the tags in the ignored namespaces are never applied
+	for namespace := range adb.Spec.Details.DefinedTags {
+		if adb.IsIgnoredTagNamespace(namespace) {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("details").Child("definedTags").Key(namespace),
+					fmt.Sprintf("cannot apply the tags in the ignored tag namespace %s", namespace)))
+		}
+	}
+
+	// backup retention
+	if adb.Spec.Details.BackupRetentionPeriodInDays != nil &&
+		(*adb.Spec.Details.BackupRetentionPeriodInDays < minBackupRetentionPeriodInDays ||
+			*adb.Spec.Details.BackupRetentionPeriodInDays > maxBackupRetentionPeriodInDays) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("details").Child("backupRetentionPeriodInDays"),
+				*adb.Spec.Details.BackupRetentionPeriodInDays,
+				fmt.Sprintf("backupRetentionPeriodInDays must be between %d and %d", minBackupRetentionPeriodInDays, maxBackupRetentionPeriodInDays)))
+	}
+
+	return allErrs
+}
+
+// validateOCIConfig checks the ConfigMap and the Secret are referenced by their names in the namespace of the resource,
+// and the endpointOverride is a well-formed https URL, e.g. https://database.region.oraclecloud.com. The requests carry
+// the signed credentials of the OCI config, so they are never sent in plain text.
+func validateOCIConfig(ociConfig OCIConfigSpec, allErrs field.ErrorList) field.ErrorList {
+	allErrs = validateLocalReference(field.NewPath("spec").Child("ociConfig").Child("configMapName"), ociConfig.ConfigMapName, allErrs)
+	allErrs = validateLocalReference(field.NewPath("spec").Child("ociConfig").Child("secretName"), ociConfig.SecretName, allErrs)
+
+	if ociConfig.EndpointOverride == nil {
+		return allErrs
+	}
+
+	endpoint, err := url.Parse(*ociConfig.EndpointOverride)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("ociConfig").Child("endpointOverride"), *ociConfig.EndpointOverride,
+				"must be an https URL with the host, e.g. https://database.us-ashburn-1.oraclecloud.com"))
+	}
+
+	return allErrs
+}
+
+// validatePasswordReferences checks the Secrets of the admin password and the wallet password are referenced by their
+// names in the namespace of the resource, and the password files are referenced by absolute paths
+func validatePasswordReferences(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	allErrs = validateVolumePath(field.NewPath("spec").Child("details").Child("adminPassword").Child("volumePath"),
+		adb.Spec.Details.AdminPassword.VolumePath, allErrs)
+	allErrs = validateVolumePath(field.NewPath("spec").Child("details").Child("wallet").Child("password").Child("volumePath"),
+		adb.Spec.Details.Wallet.Password.VolumePath, allErrs)
+	allErrs = validateLocalReference(field.NewPath("spec").Child("details").Child("adminPassword").Child("k8sSecret").Child("name"),
+		adb.Spec.Details.AdminPassword.K8sSecret.Name, allErrs)
+	allErrs = validateLocalReference(field.NewPath("spec").Child("details").Child("wallet").Child("password").Child("k8sSecret").Child("name"),
+		adb.Spec.Details.Wallet.Password.K8sSecret.Name, allErrs)
+	return allErrs
+}
+
+// validateVolumePath checks the path of a password file is absolute and has no parent directory reference. Whether the
+// file is under the password volume root is checked by the controller, which knows the root.
+func validateVolumePath(path *field.Path, volumePath *string, allErrs field.ErrorList) field.ErrorList {
+	if volumePath == nil {
+		return allErrs
+	}
+
+	if !strings.HasPrefix(*volumePath, "/") {
+		return append(allErrs, field.Invalid(path, *volumePath, "must be an absolute path"))
+	}
+
+	for _, elem := range strings.Split(*volumePath, "/") {
+		if elem == ".." {
+			return append(allErrs, field.Invalid(path, *volumePath, "must not contain .."))
+		}
+	}
+
+	return allErrs
+}
+
+// validateLocalReference checks the name is the name of an object, so that the reference can only be resolved in the
+// namespace of the resource. A namespace-qualified reference, e.g. other-namespace/secret, is rejected, so that the
+// credentials of another tenant cannot be read through the operator.
+func validateLocalReference(path *field.Path, name *string, allErrs field.ErrorList) field.ErrorList {
+	if name == nil {
+		return allErrs
+	}
+
+	if strings.Contains(*name, "/") {
+		return append(allErrs,
+			field.Forbidden(path, "cross-namespace references are not allowed; the object must be in the namespace of the resource"))
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(*name); len(msgs) > 0 {
+		allErrs = append(allErrs, field.Invalid(path, *name, strings.Join(msgs, "; ")))
+	}
+
+	return allErrs
+}
+
+// oracleIdentifierPattern matches a nonquoted Oracle identifier: a letter followed by letters, digits, _, $ and #, up to 128 bytes
+var oracleIdentifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_$#]{0,127}$`)
+
+// validateAdminUsername checks the adminUsername against the Oracle identifier rules. OCI only accepts a name other
+// than ADMIN for a dedicated database.
+func validateAdminUsername(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	username := adb.Spec.Details.AdminUsername
+	if username == nil {
+		return allErrs
+	}
+
+	if !oracleIdentifierPattern.MatchString(*username) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("details").Child("adminUsername"), *username,
+				"adminUsername must start with a letter, and contain only letters, digits, _, $ and #, up to 128 characters"))
+	} else if !isDedicated(adb) && !strings.EqualFold(*username, DefaultAdminUsername) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("adminUsername"),
+				"adminUsername other than ADMIN is only applicable on a dedicated database"))
+	}
+
+	return allErrs
+}
+
+// alphanumericPattern matches the names which contain only the ASCII letters and digits
+var alphanumericPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// validateBootstrap checks that the Job of the bootstrap SQL can mount the wallet Secret and read the admin password
+// from a Secret, which have to be in the namespace of the resource
+func validateBootstrap(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.Bootstrap.ConfigMapName == nil {
+		return allErrs
+	}
+
+	if adb.Spec.Details.AdminPassword.K8sSecret.Name == nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("details").Child("adminPassword").Child("k8sSecret").Child("name"),
+				"the bootstrap requires the admin password in a Secret"))
+	}
+
+	wallet := adb.Spec.Details.Wallet
+	walletRequested := wallet.Name != nil || wallet.Password.K8sSecret.Name != nil ||
+		wallet.Password.OCISecret.OCID != nil || wallet.Password.VolumePath != nil
+	if !walletRequested || wallet.ObjectStorage.Bucket != nil ||
+		(wallet.Namespace != nil && *wallet.Namespace != "" && *wallet.Namespace != adb.GetNamespace()) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("bootstrap").Child("configMapName"),
+				"the bootstrap requires the wallet Secret in the namespace of the resource"))
+	}
+
 	return allErrs
 }
 
+// dbNameMaxLength is the max length of the database name accepted by OCI
+const dbNameMaxLength = 30
+
+// displayNameMaxLength is the max length of the display name accepted by OCI
+const displayNameMaxLength = 255
+
+// oracleReservedWords are the Oracle SQL reserved words, which cannot be used as the database name
+var oracleReservedWords = map[string]bool{
+	"ACCESS": true, "ADD": true, "ALL": true, "ALTER": true, "AND": true, "ANY": true, "AS": true, "ASC": true,
+	"AUDIT": true, "BETWEEN": true, "BY": true, "CHAR": true, "CHECK": true, "CLUSTER": true, "COLUMN": true,
+	"COMMENT": true, "COMPRESS": true, "CONNECT": true, "CREATE": true, "CURRENT": true, "DATE": true,
+	"DECIMAL": true, "DEFAULT": true, "DELETE": true, "DESC": true, "DISTINCT": true, "DROP": true, "ELSE": true,
+	"EXCLUSIVE": true, "EXISTS": true, "FILE": true, "FLOAT": true, "FOR": true, "FROM": true, "GRANT": true,
+	"GROUP": true, "HAVING": true, "IDENTIFIED": true, "IMMEDIATE": true, "IN": true, "INCREMENT": true,
+	"INDEX": true, "INITIAL": true, "INSERT": true, "INTEGER": true, "INTERSECT": true, "INTO": true, "IS": true,
+	"LEVEL": true, "LIKE": true, "LOCK": true, "LONG": true, "MAXEXTENTS": true, "MINUS": true, "MLSLABEL": true,
+	"MODE": true, "MODIFY": true, "NOAUDIT": true, "NOCOMPRESS": true, "NOT": true, "NOWAIT": true, "NULL": true,
+	"NUMBER": true, "OF": true, "OFFLINE": true, "ON": true, "ONLINE": true, "OPTION": true, "OR": true,
+	"ORDER": true, "PCTFREE": true, "PRIOR": true, "PUBLIC": true, "RAW": true, "RENAME": true, "RESOURCE": true,
+	"REVOKE": true, "ROW": true, "ROWID": true, "ROWNUM": true, "ROWS": true, "SELECT": true, "SESSION": true,
+	"SET": true, "SHARE": true, "SIZE": true, "SMALLINT": true, "START": true, "SUCCESSFUL": true, "SYNONYM": true,
+	"SYSDATE": true, "TABLE": true, "THEN": true, "TO": true, "TRIGGER": true, "UID": true, "UNION": true,
+	"UNIQUE": true, "UPDATE": true, "USER": true, "VALIDATE": true, "VALUES": true, "VARCHAR": true,
+	"VARCHAR2": true, "VIEW": true, "WHENEVER": true, "WHERE": true, "WITH": true,
+}
+
+// checkDbName returns the rule of OCI which the database name violates, or an empty string if the name is valid
+func checkDbName(name string) string {
+	switch {
+	case name == "":
+		return "dbName cannot be empty"
+	case len(name) > dbNameMaxLength:
+		return fmt.Sprintf("dbName must be at most %d characters", dbNameMaxLength)
+	case !alphanumericPattern.MatchString(name):
+		return "dbName must contain only letters and digits"
+	case !isLetter(name[0]):
+		return "dbName must start with a letter"
+	case oracleReservedWords[strings.ToUpper(name)]:
+		return fmt.Sprintf("dbName cannot be the reserved word %s", strings.ToUpper(name))
+	}
+	return ""
+}
+
+// checkDisplayName returns the rule of OCI which the display name violates, or an empty string if the name is valid
+func checkDisplayName(name string) string {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return "displayName cannot be blank"
+	case utf8.RuneCountInString(name) > displayNameMaxLength:
+		return fmt.Sprintf("displayName must be at most %d characters", displayNameMaxLength)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return "displayName cannot contain control characters"
+	}
+	return ""
+}
+
+// validateNameSuffix checks that the names still fit in the limits of OCI once the suffix is appended. The generated
+// names are never the same as an existing database, so they cannot be used to look up the database.
+func validateNameSuffix(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.Details.GenerateNameSuffix == nil || !*adb.Spec.Details.GenerateNameSuffix {
+		return allErrs
+	}
+
+	detailsPath := field.NewPath("spec").Child("details")
+
+	if adb.Spec.Details.CreateIfMissing != nil && *adb.Spec.Details.CreateIfMissing {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("generateNameSuffix"),
+				"cannot apply generateNameSuffix and createIfMissing at the same time"))
+	}
+
+	if dbName := adb.Spec.Details.DbName; dbName != nil && len(*dbName) > dbNameMaxLength-NameSuffixLength {
+		allErrs = append(allErrs,
+			field.Invalid(detailsPath.Child("dbName"), *dbName,
+				fmt.Sprintf("dbName must be at most %d characters when generateNameSuffix is true",
+					dbNameMaxLength-NameSuffixLength)))
+	}
+
+	// The suffix is separated from the displayName by a hyphen
+	if displayName := adb.Spec.Details.DisplayName; displayName != nil &&
+		utf8.RuneCountInString(*displayName) > displayNameMaxLength-NameSuffixLength-1 {
+		allErrs = append(allErrs,
+			field.Invalid(detailsPath.Child("displayName"), *displayName,
+				fmt.Sprintf("displayName must be at most %d characters when generateNameSuffix is true",
+					displayNameMaxLength-NameSuffixLength-1)))
+	}
+
+	return allErrs
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// validateNames checks the dbName and the displayName against the rules of OCI, so that an invalid name is reported
+// right away instead of failing the provisioning in OCI
+func validateNames(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if dbName := adb.Spec.Details.DbName; dbName != nil {
+		if msg := checkDbName(*dbName); msg != "" {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("details").Child("dbName"), *dbName, msg))
+		}
+	}
+
+	if displayName := adb.Spec.Details.DisplayName; displayName != nil {
+		if msg := checkDisplayName(*displayName); msg != "" {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("details").Child("displayName"), *displayName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDeploymentType checks the fields against the deployment type. A dedicated database is provisioned in an
+// Autonomous Container Database, while the serverless one is not.
+func validateDeploymentType(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	// OCI places a serverless database in the availability domains itself
+	if adb.Spec.Details.AvailabilityDomain != nil && !isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("availabilityDomain"),
+				"availabilityDomain is only applicable on a dedicated database"))
+	}
+
+	// A serverless database is placed on the shared Exadata infrastructure
+	if adb.Spec.Details.AutonomousVMClusterOCID != nil && !isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("autonomousVmClusterOCID"),
+				"autonomousVmClusterOCID is only applicable on a dedicated database"))
+	}
+	if adb.Spec.Details.CloudExadataInfrastructureOCID != nil && !isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("cloudExadataInfrastructureOCID"),
+				"cloudExadataInfrastructureOCID is only applicable on a dedicated database"))
+	}
+
+	// The preview versions are only available on the shared Exadata infrastructure
+	if adb.Spec.Details.AllowPreviewVersions != nil && *adb.Spec.Details.AllowPreviewVersions && isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("allowPreviewVersions"),
+				"allowPreviewVersions is not applicable on a dedicated database"))
+	}
+
+	if adb.Spec.Details.IsDedicated == nil {
+		return allErrs
+	}
+
+	if *adb.Spec.Details.IsDedicated {
+		if !hasContainerDatabase(adb) {
+			allErrs = append(allErrs,
+				field.Required(field.NewPath("spec").Child("details").Child("autonomousContainerDatabase"),
+					"autonomousContainerDatabase is required for a dedicated database"))
+		}
+
+		// The retention of the automatic backups is configured on the Autonomous Container Database
+		if adb.Spec.Details.BackupRetentionPeriodInDays != nil {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("details").Child("backupRetentionPeriodInDays"),
+					"backupRetentionPeriodInDays is not applicable on a dedicated database"))
+		}
+	} else if hasContainerDatabase(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("autonomousContainerDatabase"),
+				"autonomousContainerDatabase is not applicable on a serverless database"))
+	}
+
+	return allErrs
+}
+
+// validateDisasterRecovery checks the disaster recovery types against the allowed values. The disaster recovery
+// is configured on the serverless databases only.
+func validateDisasterRecovery(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	drTypes := []string{string(DisasterRecoveryTypeADG), string(DisasterRecoveryTypeBackupBased)}
+
+	if adb.Spec.Details.DisasterRecoveryType == "" && len(adb.GetDisasterRecoveryPeers()) == 0 {
+		return allErrs
+	}
+
+	if isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("disasterRecoveryType"),
+				"the disaster recovery is not applicable on a dedicated database"))
+	}
+
+	if !isDisasterRecoveryType(adb.Spec.Details.DisasterRecoveryType) {
+		allErrs = append(allErrs,
+			field.NotSupported(field.NewPath("spec").Child("details").Child("disasterRecoveryType"),
+				adb.Spec.Details.DisasterRecoveryType, drTypes))
+	}
+
+	allErrs = validateDisasterRecoveryPeers(adb, allErrs)
+
+	return allErrs
+}
+
+// validateDisasterRecoveryPeers checks the regions of the cross-region standby peers, including the peer of the
+// deprecated disasterRecoveryPeer, are valid OCI regions, which differ from each other and from the region of the
+// primary database
+func validateDisasterRecoveryPeers(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	drTypes := []string{string(DisasterRecoveryTypeADG), string(DisasterRecoveryTypeBackupBased)}
+	primaryRegion := regionFromOCID(adb.GetAutonomousDatabaseOCID())
+	regions := make(map[string]bool)
+
+	validatePeer := func(peer DisasterRecoveryPeerSpec, peerPath *field.Path) {
+		if peer.Region == nil || *peer.Region == "" {
+			allErrs = append(allErrs,
+				field.Required(peerPath.Child("region"),
+					"the region of the disaster recovery peer is required"))
+			return
+		}
+
+		region := strings.ToLower(*peer.Region)
+		if _, err := common.Region(region).RealmID(); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(peerPath.Child("region"), *peer.Region,
+					"the region of the disaster recovery peer is not a valid OCI region"))
+		} else if region == primaryRegion {
+			allErrs = append(allErrs,
+				field.Invalid(peerPath.Child("region"), *peer.Region,
+					"the region of the disaster recovery peer must differ from the region of the primary database"))
+		}
+		if regions[region] {
+			allErrs = append(allErrs,
+				field.Duplicate(peerPath.Child("region"), *peer.Region))
+		}
+		regions[region] = true
+
+		if !isDisasterRecoveryType(peer.DisasterRecoveryType) {
+			allErrs = append(allErrs,
+				field.NotSupported(peerPath.Child("disasterRecoveryType"),
+					peer.DisasterRecoveryType, drTypes))
+		}
+	}
+
+	for i, peer := range adb.Spec.Details.DisasterRecoveryPeers {
+		validatePeer(peer, field.NewPath("spec").Child("details").Child("disasterRecoveryPeers").Index(i))
+	}
+	if peer := adb.Spec.Details.DisasterRecoveryPeer; peer.Region != nil || peer.DisasterRecoveryType != "" {
+		validatePeer(peer, field.NewPath("spec").Child("details").Child("disasterRecoveryPeer"))
+	}
+
+	return allErrs
+}
+
+// validateDisasterRecoveryPeerType checks the disasterRecoveryType of the peer is not changed if the peer has been
+// created in its region
+func validateDisasterRecoveryPeerType(peer DisasterRecoveryPeerSpec, created DisasterRecoveryPeerStatus, peerPath *field.Path, allErrs field.ErrorList) field.ErrorList {
+	if peer.Region != nil && strings.EqualFold(*peer.Region, created.Region) &&
+		peer.DisasterRecoveryType != created.DisasterRecoveryType {
+		allErrs = append(allErrs,
+			field.Forbidden(peerPath.Child("disasterRecoveryType"),
+				"cannot change the disasterRecoveryType of the peer in "+created.Region))
+	}
+	return allErrs
+}
+
+// regionFromOCID returns the region of an OCID, e.g. us-phoenix-1 of ocid1.autonomousdatabase.oc1.phx.xxx, or an
+// empty string if the region is unknown
+func regionFromOCID(ocid *string) string {
+	if ocid == nil {
+		return ""
+	}
+	parts := strings.Split(*ocid, ".")
+	if len(parts) < 5 || parts[3] == "" {
+		return ""
+	}
+
+	// The OCIDs of the older regions have the short names, e.g. phx
+	region := common.Region(parts[3])
+	if _, err := region.RealmID(); err != nil {
+		region = common.StringToRegion(parts[3])
+		if _, err := region.RealmID(); err != nil {
+			return ""
+		}
+	}
+	return string(region)
+}
+
+// isDisasterRecoveryType returns true if the type is empty or one of the allowed values
+func isDisasterRecoveryType(drType DisasterRecoveryTypeEnum) bool {
+	return drType == "" || drType == DisasterRecoveryTypeADG || drType == DisasterRecoveryTypeBackupBased
+}
+
+// isAccessControlDisabled returns true if the database-level access control is explicitly disabled
+func isAccessControlDisabled(adb *AutonomousDatabase) bool {
+	return adb.Spec.Details.NetworkAccess.IsAccessControlEnabled != nil && !*adb.Spec.Details.NetworkAccess.IsAccessControlEnabled
+}
+
 func validateNetworkAccess(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
 	if !isDedicated(adb) {
 		// Shared database
+		// The whitelist is kept in OCI but inactive while the access control is disabled, so the accessControlList is
+		// not required to keep the RESTRICTED access type of a provisioned database
 		if adb.Spec.Details.NetworkAccess.AccessType == NetworkAccessTypeRestricted {
-			if adb.Spec.Details.NetworkAccess.AccessControlList == nil {
+			if adb.Spec.Details.NetworkAccess.AccessControlList == nil &&
+				!(isAccessControlDisabled(adb) && adb.Spec.Details.AutonomousDatabaseOCID != nil) {
 				allErrs = append(allErrs,
 					field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("accessControlList"),
 						fmt.Sprintf("accessControlList cannot be empty when the network access type is %s", NetworkAccessTypeRestricted)))
@@ -225,18 +915,30 @@ func validateNetworkAccess(adb *AutonomousDatabase, allErrs field.ErrorList) fie
 						fmt.Sprintf("subnetOCID cannot be empty when the network access type is %s", NetworkAccessTypePrivate)))
 			}
 
-			if adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs == nil {
+			// the network security groups can be specified either by OCIDs or by display names
+			if adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs == nil && adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames == nil {
 				allErrs = append(allErrs,
 					field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("privateEndpoint").Child("nsgOCIDs"),
-						fmt.Sprintf("nsgOCIDs cannot be empty when the network access type is %s", NetworkAccessTypePrivate)))
+						fmt.Sprintf("nsgOCIDs cannot be empty when the network access type is %s unless nsgNames is specified", NetworkAccessTypePrivate)))
+			} else if adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs != nil && adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames != nil {
+				allErrs = append(allErrs,
+					field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("privateEndpoint").Child("nsgNames"),
+						"cannot specify both nsgOCIDs and nsgNames"))
+			}
+
+			// the subnet CIDR is unknown to the webhook, so it's checked by the controller before the provision
+			if ip := adb.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP; ip != nil && net.ParseIP(*ip).To4() == nil {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec").Child("details").Child("networkAccess").Child("privateEndpoint").Child("privateEndpointIP"),
+						*ip, "privateEndpointIP must be an IPv4 address"))
 			}
 		}
 
-		// IsAccessControlEnabled is not applicable to a shared database
-		if adb.Spec.Details.NetworkAccess.IsAccessControlEnabled != nil {
+		// The access control is enabled along with the whitelist if it's not set
+		if adb.Spec.Details.NetworkAccess.AccessControlList != nil && isAccessControlDisabled(adb) {
 			allErrs = append(allErrs,
-				field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("IsAccessControlEnabled"),
-					"isAccessControlEnabled is not applicable on a shared Autonomous Database"))
+				field.Forbidden(field.NewPath("spec").Child("details").Child("networkAccess").Child("accessControlList"),
+					"access control list cannot be provided when Autonomous Database's access control is disabled"))
 		}
 	} else {
 		// Dedicated database
@@ -260,17 +962,178 @@ func validateNetworkAccess(adb *AutonomousDatabase, allErrs field.ErrorList) fie
 	return allErrs
 }
 
+// The range of the retention period that OCI allows for the automatic backups
+const (
+	minBackupRetentionPeriodInDays = 1
+	maxBackupRetentionPeriodInDays = 60
+)
+
+// The storage limits of a shared Autonomous Database by the compute model
+type storageLimits struct {
+	perCPUInTBs int
+	maxInTBs    int
+}
+
+const (
+	computeModelOCPU = "OCPU"
+	computeModelECPU = "ECPU"
+)
+
+// The fixed CPU and storage of an Always Free database
+const (
+	freeTierCPUCoreCount         = 1
+	freeTierDataStorageSizeInTBs = 1
+)
+
+var storageLimitsByComputeModel = map[string]storageLimits{
+	computeModelOCPU: {perCPUInTBs: 128, maxInTBs: 384},
+	computeModelECPU: {perCPUInTBs: 32, maxInTBs: 384},
+}
+
+// maxStorageSizeInTBs returns the max storage size for the number of CPUs in the compute model.
+// Returns false if the compute model has no limits.
+func maxStorageSizeInTBs(computeModel string, cpuCount int) (int, bool) {
+	limits, ok := storageLimitsByComputeModel[computeModel]
+	if !ok {
+		return 0, false
+	}
+
+	maxSize := limits.perCPUInTBs * cpuCount
+	if maxSize > limits.maxInTBs {
+		maxSize = limits.maxInTBs
+	}
+	return maxSize, true
+}
+
+// validateFreeTier rejects the fields which don't apply to an Always Free database. The CPU and the storage are fixed,
+// and the auto scaling is not available.
+func validateFreeTier(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.Details.IsFreeTier == nil || !*adb.Spec.Details.IsFreeTier {
+		return allErrs
+	}
+
+	detailsPath := field.NewPath("spec").Child("details")
+
+	if isDedicated(adb) {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("isFreeTier"),
+				"the Always Free tier is not applicable on a dedicated database"))
+	}
+	if adb.Spec.Details.CPUCoreCount != nil && *adb.Spec.Details.CPUCoreCount != freeTierCPUCoreCount {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("cpuCoreCount"),
+				fmt.Sprintf("cpuCoreCount of an Always Free database can only be %d", freeTierCPUCoreCount)))
+	}
+	if adb.Spec.Details.DataStorageSizeInTBs != nil && *adb.Spec.Details.DataStorageSizeInTBs != freeTierDataStorageSizeInTBs {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("dataStorageSizeInTBs"),
+				fmt.Sprintf("dataStorageSizeInTBs of an Always Free database can only be %d", freeTierDataStorageSizeInTBs)))
+	}
+	if adb.Spec.Details.IsAutoScalingEnabled != nil && *adb.Spec.Details.IsAutoScalingEnabled {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("isAutoScalingEnabled"),
+				"the auto scaling is not available on an Always Free database"))
+	}
+	if adb.Spec.Details.IsAutoScalingForStorageEnabled != nil && *adb.Spec.Details.IsAutoScalingForStorageEnabled {
+		allErrs = append(allErrs,
+			field.Forbidden(detailsPath.Child("isAutoScalingForStorageEnabled"),
+				"the storage auto scaling is not available on an Always Free database"))
+	}
+
+	return allErrs
+}
+
+// validateStorageLimits rejects the storage size which exceeds the max storage size for the CPU core count in the
+// compute model, instead of waiting for the 400 error from OCI.
+// The limits don't apply to a dedicated database, which allocates the storage from the Exadata infrastructure.
+func validateStorageLimits(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.Details.DataStorageSizeInTBs != nil && adb.Spec.Details.DataStorageSizeInGBs != nil {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("dataStorageSizeInGBs"),
+				"cannot apply dataStorageSizeInTBs and dataStorageSizeInGBs at the same time"))
+	}
+
+	if isDedicated(adb) ||
+		adb.Spec.Details.CPUCoreCount == nil ||
+		adb.Spec.Details.DataStorageSizeInTBs == nil {
+		return allErrs
+	}
+
+	computeModel := adb.Spec.Details.ComputeModel
+	if computeModel == "" {
+		computeModel = computeModelOCPU
+	}
+
+	maxSize, ok := maxStorageSizeInTBs(computeModel, *adb.Spec.Details.CPUCoreCount)
+	if ok && *adb.Spec.Details.DataStorageSizeInTBs > maxSize {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("dataStorageSizeInTBs"),
+				fmt.Sprintf("dataStorageSizeInTBs cannot exceed %d TB with %d %s(s)", maxSize, *adb.Spec.Details.CPUCoreCount, computeModel)))
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+// ValidateDelete requires the ConfirmTerminateAnnotation to delete a resource whose hardLink is true, so that a
+// hardLink flipped to true by mistake doesn't terminate the database in OCI.
 func (r *AutonomousDatabase) ValidateDelete() error {
 	autonomousdatabaselog.Info("validate delete", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object deletion.
-	return nil
+	var allErrs field.ErrorList
+
+	if msg := checkTerminateConfirmation(r); msg != "" {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("metadata").Child("annotations").Key(ConfirmTerminateAnnotation), msg))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "database.oracle.com", Kind: "AutonomousDatabase"},
+		r.Name, allErrs)
+}
+
+// checkTerminateConfirmation returns the reason why the deletion of the resource is not confirmed, or an empty
+// string if the deletion doesn't terminate a database or the ConfirmTerminateAnnotation matches the dbName
+func checkTerminateConfirmation(adb *AutonomousDatabase) string {
+	if adb.Spec.HardLink == nil || !*adb.Spec.HardLink || adb.GetAutonomousDatabaseOCID() == nil {
+		return ""
+	}
+	return checkConfirmation(adb, ConfirmTerminateAnnotation, "hardLink is true", "the termination of the database")
+}
+
+// checkConfirmation returns the reason why the operation is not confirmed by the annotation, or an empty string if
+// the annotation matches the dbName
+func checkConfirmation(adb *AutonomousDatabase, annotation string, cause string, operation string) string {
+	if adb.IsConfirmed(annotation) {
+		return ""
+	}
+	if _, ok := adb.GetAnnotations()[annotation]; !ok {
+		return fmt.Sprintf("%s; set the annotation %s=%s to confirm %s",
+			cause, annotation, adb.ConfirmationDbName(), operation)
+	}
+	return fmt.Sprintf("the annotation %s must be the dbName %s to confirm %s",
+		annotation, adb.ConfirmationDbName(), operation)
 }
 
-// Returns true if AutonomousContainerDatabaseOCID has value.
-// We don't use Details.IsDedicated because the parameter might be null when it's a provision operation.
+// adminUsername returns the spec.details.adminUsername, or ADMIN if it's not provided
+func adminUsername(adb *AutonomousDatabase) string {
+	if adb.Spec.Details.AdminUsername == nil {
+		return DefaultAdminUsername
+	}
+	return *adb.Spec.Details.AdminUsername
+}
+
+// Returns true if the isDedicated is true or the AutonomousContainerDatabase has value.
+// We don't rely on Details.IsDedicated only because the parameter might be null when it's a provision operation.
 func isDedicated(adb *AutonomousDatabase) bool {
+	return (adb.Spec.Details.IsDedicated != nil && *adb.Spec.Details.IsDedicated) || hasContainerDatabase(adb)
+}
+
+// Returns true if either the K8s or the OCI AutonomousContainerDatabase has value.
+func hasContainerDatabase(adb *AutonomousDatabase) bool {
 	return adb.Spec.Details.AutonomousContainerDatabase.K8sACD.Name != nil ||
 		adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID != nil
 }
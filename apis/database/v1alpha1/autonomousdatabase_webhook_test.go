@@ -40,12 +40,14 @@ package v1alpha1
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -120,7 +122,7 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 				Spec: AutonomousDatabaseSpec{
 					Details: AutonomousDatabaseDetails{
 						CompartmentOCID: common.String("fake-compartment-ocid"),
-						DbName:          common.String("fake-dbName"),
+						DbName:          common.String("fakeDbName"),
 						DisplayName:     common.String("fake-displayName"),
 						CPUCoreCount:    common.Int(1),
 						AdminPassword: PasswordSpec{
@@ -153,6 +155,199 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 			validateInvalidTest(adb, false, errMsg)
 		})
 
+		It("Should not apply values to adminPassword.volumePath and adminPassword.k8sSecret at the same time", func() {
+			var errMsg string = "cannot apply volumePath with k8sSecret.name or ociSecret.ocid at the same time"
+
+			adb.Spec.Details.AdminPassword.K8sSecret.Name = common.String("test-admin-password")
+			adb.Spec.Details.AdminPassword.VolumePath = common.String("/etc/secrets/admin-password")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not read the admin password from a path with a parent directory reference", func() {
+			var errMsg string = "must not contain .."
+
+			adb.Spec.Details.AdminPassword.VolumePath = common.String("/etc/adb-secrets/../../var/run/secrets/kubernetes.io/serviceaccount/token")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not reference the admin password Secret in another namespace", func() {
+			var errMsg string = "cross-namespace references are not allowed"
+
+			adb.Spec.Details.AdminPassword.K8sSecret.Name = common.String("other-namespace/admin-password")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not reference the wallet password Secret in another namespace", func() {
+			var errMsg string = "cross-namespace references are not allowed"
+
+			adb.Spec.Details.Wallet.Password.K8sSecret.Name = common.String("other-namespace/wallet-password")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not reference the OCI config in another namespace", func() {
+			var errMsg string = "cross-namespace references are not allowed"
+
+			adb.Spec.OCIConfig.ConfigMapName = common.String("oci-cred")
+			adb.Spec.OCIConfig.SecretName = common.String("other-namespace/oci-privatekey")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply a downloadInterval which is not positive", func() {
+			var errMsg string = "downloadInterval must be positive"
+
+			adb.Spec.Details.Wallet.DownloadInterval = &metav1.Duration{Duration: 0}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not upload the wallet to Object Storage without the namespace", func() {
+			var errMsg string = "the Object Storage namespace of the bucket is required"
+
+			adb.Spec.Details.Wallet.ObjectStorage.Bucket = common.String("wallets")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply splitProfiles when the wallet is uploaded to Object Storage", func() {
+			var errMsg string = "cannot apply minTlsVersion, splitProfiles or hostRewrite when the wallet is uploaded to Object Storage"
+
+			adb.Spec.Details.Wallet.ObjectStorage.Bucket = common.String("wallets")
+			adb.Spec.Details.Wallet.ObjectStorage.Namespace = common.String("fake-namespace")
+			adb.Spec.Details.Wallet.SplitProfiles = common.Bool(true)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply a hostRewrite which is not a domain name", func() {
+			var errMsg string = "spec.details.wallet.hostRewrite"
+
+			adb.Spec.Details.Wallet.HostRewrite = common.String("https://example.com")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not bootstrap without the wallet Secret in the namespace of the resource", func() {
+			var errMsg string = "the bootstrap requires the wallet Secret in the namespace of the resource"
+
+			adb.Spec.Bootstrap.ConfigMapName = common.String("bootstrap-sql")
+			adb.Spec.Details.Wallet.Name = common.String("fake-wallet")
+			adb.Spec.Details.Wallet.Namespace = common.String("other-namespace")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply a backupRetentionPeriodInDays out of the range", func() {
+			var errMsg string = "backupRetentionPeriodInDays must be between 1 and 60"
+
+			adb.Spec.Details.BackupRetentionPeriodInDays = common.Int(61)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply an adminUsername which is not an Oracle identifier", func() {
+			var errMsg string = "adminUsername must start with a letter"
+
+			adb.Spec.Details.AdminUsername = common.String("1admin")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply a customer contact which is not an email address", func() {
+			var errMsg string = "must be a valid email address"
+
+			adb.Spec.Details.CustomerContacts = []string{"ops@example.com", "Ops <ops@example.com>"}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should only ignore the fields in spec.details", func() {
+			var errMsg string = "ignoreFields must be the paths of the fields in spec.details"
+
+			adb.Spec.Details.IgnoreFields = []string{"freeformTags", "networkAccess.accessControlList", "networkAccess.unknown"}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not set and remove the same freeform tag", func() {
+			var errMsg string = "tag \"env\" cannot be in both freeformTags and removeTags"
+
+			adb.Spec.Details.FreeformTags = map[string]string{"env": "prod"}
+			adb.Spec.Details.RemoveTags = []string{"env"}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Cannot apply a dataStorageSizeInTBs which exceeds the limit of the cpuCoreCount", func() {
+			var errMsg string = "dataStorageSizeInTBs cannot exceed 128 TB with 1 OCPU(s)"
+
+			adb.Spec.Details.CPUCoreCount = common.Int(1)
+			adb.Spec.Details.DataStorageSizeInTBs = common.Int(129)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Cannot apply a dataStorageSizeInTBs which exceeds the limit of the ECPUs", func() {
+			var errMsg string = "dataStorageSizeInTBs cannot exceed 64 TB with 2 ECPU(s)"
+
+			adb.Spec.Details.ComputeModel = "ECPU"
+			adb.Spec.Details.CPUCoreCount = common.Int(2)
+			adb.Spec.Details.DataStorageSizeInTBs = common.Int(65)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Cannot apply both dataStorageSizeInTBs and dataStorageSizeInGBs", func() {
+			var errMsg string = "cannot apply dataStorageSizeInTBs and dataStorageSizeInGBs at the same time"
+
+			adb.Spec.Details.DataStorageSizeInTBs = common.Int(1)
+			adb.Spec.Details.DataStorageSizeInGBs = common.Int(1024)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		Context("Always Free", func() {
+			BeforeEach(func() {
+				adb.Spec.Details.IsFreeTier = common.Bool(true)
+			})
+
+			It("Cannot apply a cpuCoreCount other than the fixed one", func() {
+				var errMsg string = "cpuCoreCount of an Always Free database can only be 1"
+
+				adb.Spec.Details.CPUCoreCount = common.Int(2)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("Cannot apply a dataStorageSizeInTBs other than the fixed one", func() {
+				var errMsg string = "dataStorageSizeInTBs of an Always Free database can only be 1"
+
+				adb.Spec.Details.DataStorageSizeInTBs = common.Int(2)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("Cannot enable the auto scaling", func() {
+				var errMsg string = "the auto scaling is not available on an Always Free database"
+
+				adb.Spec.Details.IsAutoScalingEnabled = common.Bool(true)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("Cannot enable the storage auto scaling", func() {
+				var errMsg string = "the storage auto scaling is not available on an Always Free database"
+
+				adb.Spec.Details.IsAutoScalingForStorageEnabled = common.Bool(true)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+		})
+
 		// Network validation
 		Context("Shared Autonomous Database", func() {
 			It("AccessControlList cannot be empty when the network access type is RESTRICTED", func() {
@@ -175,10 +370,34 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 				validateInvalidTest(adb, false, errMsg1, errMsg2)
 			})
 
-			It("IsAccessControlEnabled is not applicable on a shared Autonomous Database", func() {
-				var errMsg string = "isAccessControlEnabled is not applicable on a shared Autonomous Database"
+			It("Should not specify both nsgOCIDs and nsgNames", func() {
+				var errMsg string = "cannot specify both nsgOCIDs and nsgNames"
+
+				adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePrivate
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = common.String("ocid1.subnet.oc1..fake")
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = []string{"ocid1.networksecuritygroup.oc1..fake"}
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = []string{"fake-nsg"}
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("PrivateEndpointIP should be an IPv4 address", func() {
+				var errMsg string = "privateEndpointIP must be an IPv4 address"
+
+				adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePrivate
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = common.String("ocid1.subnet.oc1..fake")
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = []string{"ocid1.networksecuritygroup.oc1..fake"}
+				adb.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP = common.String("10.0.0.256")
 
-				adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = common.Bool(true)
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AccessControlList cannot be provided when the access control is disabled", func() {
+				var errMsg string = "access control list cannot be provided when Autonomous Database's access control is disabled"
+
+				adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypeRestricted
+				adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = common.Bool(false)
+				adb.Spec.Details.NetworkAccess.AccessControlList = []string{"192.168.1.1"}
 
 				validateInvalidTest(adb, false, errMsg)
 			})
@@ -209,6 +428,191 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 
 		})
 
+		// Deployment type validation
+		Context("Deployment type", func() {
+			It("AutonomousContainerDatabase is required for a dedicated database", func() {
+				var errMsg string = "autonomousContainerDatabase is required for a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(true)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("BackupRetentionPeriodInDays is not applicable on a dedicated database", func() {
+				var errMsg string = "backupRetentionPeriodInDays is not applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(true)
+				adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID = common.String("fake-acd-ocid")
+				adb.Spec.Details.BackupRetentionPeriodInDays = common.Int(30)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("Should reject an invalid dbName", func() {
+				var errMsg string = "dbName must start with a letter"
+
+				adb.Spec.Details.DbName = common.String("1adb")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AdminUsername other than ADMIN is not applicable on a serverless database", func() {
+				var errMsg string = "adminUsername other than ADMIN is only applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(false)
+				adb.Spec.Details.AdminUsername = common.String("DBA_ADMIN")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AvailabilityDomain is not applicable on a serverless database", func() {
+				var errMsg string = "availabilityDomain is only applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(false)
+				adb.Spec.Details.AvailabilityDomain = common.String("Uocm:PHX-AD-1")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AutonomousVMClusterOCID is not applicable on a serverless database", func() {
+				var errMsg string = "autonomousVmClusterOCID is only applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(false)
+				adb.Spec.Details.AutonomousVMClusterOCID = common.String("ocid1.cloudautonomousvmcluster.oc1..fake")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("CloudExadataInfrastructureOCID is not applicable on a serverless database", func() {
+				var errMsg string = "cloudExadataInfrastructureOCID is only applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(false)
+				adb.Spec.Details.CloudExadataInfrastructureOCID = common.String("ocid1.cloudexadatainfrastructure.oc1..fake")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AllowPreviewVersions is not applicable on a dedicated database", func() {
+				var errMsg string = "allowPreviewVersions is not applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(true)
+				adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID = common.String("fake-acd-ocid")
+				adb.Spec.Details.AllowPreviewVersions = common.Bool(true)
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("AutonomousContainerDatabase is not applicable on a serverless database", func() {
+				var errMsg string = "autonomousContainerDatabase is not applicable on a serverless database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(false)
+				adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID = common.String("fake-acd-ocid")
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+		})
+
+		It("Cannot apply the tags in an ignored tag namespace", func() {
+			var errMsg string = "cannot apply the tags in the ignored tag namespace Operations"
+
+			adb.Spec.Details.IgnoredTagNamespaces = []string{"Operations"}
+			adb.Spec.Details.DefinedTags = map[string]map[string]string{
+				"Operations": {"CostCenter": "42"},
+			}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply an endpointOverride which is not a well-formed URL", func() {
+			var errMsg string = "must be an https URL with the host"
+
+			adb.Spec.OCIConfig.EndpointOverride = common.String("database.us-ashburn-1.oraclecloud.com")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Should not apply an endpointOverride which is not https", func() {
+			var errMsg string = "must be an https URL with the host"
+
+			adb.Spec.OCIConfig.EndpointOverride = common.String("http://database.us-ashburn-1.oraclecloud.com")
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		Context("Disaster recovery", func() {
+			It("Should only apply the allowed disasterRecoveryType", func() {
+				var errMsg string = "Unsupported value"
+
+				adb.Spec.Details.DisasterRecoveryType = "LOCAL"
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The disaster recovery is not applicable on a dedicated database", func() {
+				var errMsg string = "the disaster recovery is not applicable on a dedicated database"
+
+				adb.Spec.Details.IsDedicated = common.Bool(true)
+				adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID = common.String("fake-acd-ocid")
+				adb.Spec.Details.DisasterRecoveryType = DisasterRecoveryTypeADG
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The region of the disaster recovery peer is required", func() {
+				var errMsg string = "the region of the disaster recovery peer is required"
+
+				adb.Spec.Details.DisasterRecoveryPeer.DisasterRecoveryType = DisasterRecoveryTypeBackupBased
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The region of a standby peer must be a valid OCI region", func() {
+				var errMsg string = "the region of the disaster recovery peer is not a valid OCI region"
+
+				adb.Spec.Details.DisasterRecoveryPeers = []DisasterRecoveryPeerSpec{
+					{Region: common.String("us-nowhere-1")},
+				}
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The region of a standby peer must differ from the region of the primary database", func() {
+				var errMsg string = "the region of the disaster recovery peer must differ from the region of the primary database"
+
+				adb.Spec.Details.AutonomousDatabaseOCID = common.String("ocid1.autonomousdatabase.oc1.phx.fake")
+				adb.Spec.Details.DisasterRecoveryPeers = []DisasterRecoveryPeerSpec{
+					{Region: common.String("us-phoenix-1")},
+				}
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The regions of the standby peers must be unique", func() {
+				var errMsg string = "Duplicate value"
+
+				adb.Spec.Details.DisasterRecoveryPeers = []DisasterRecoveryPeerSpec{
+					{Region: common.String("us-phoenix-1")},
+					{Region: common.String("US-PHOENIX-1")},
+				}
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+
+			It("The region of disasterRecoveryPeer must differ from the regions of disasterRecoveryPeers", func() {
+				var errMsg string = "Duplicate value"
+
+				adb.Spec.Details.DisasterRecoveryPeer = DisasterRecoveryPeerSpec{
+					Region:               common.String("us-phoenix-1"),
+					DisasterRecoveryType: DisasterRecoveryTypeADG,
+				}
+				adb.Spec.Details.DisasterRecoveryPeers = []DisasterRecoveryPeerSpec{
+					{Region: common.String("us-phoenix-1")},
+				}
+
+				validateInvalidTest(adb, false, errMsg)
+			})
+		})
+
 		// Others
 		It("Cannot apply lifecycleState to a provision operation", func() {
 			var errMsg string = "cannot apply lifecycleState to a provision operation"
@@ -217,6 +621,42 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 
 			validateInvalidTest(adb, false, errMsg)
 		})
+
+		It("DisplayName is required when createIfMissing is true", func() {
+			var errMsg string = "displayName is required when createIfMissing is true"
+
+			adb.Spec.Details.DisplayName = nil
+			adb.Spec.Details.CreateIfMissing = common.Bool(true)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("DbName must leave room for the generated suffix", func() {
+			var errMsg string = "dbName must be at most 25 characters when generateNameSuffix is true"
+
+			adb.Spec.Details.DbName = common.String("abcdefghijklmnopqrstuvwxyz")
+			adb.Spec.Details.GenerateNameSuffix = common.Bool(true)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Cannot apply generateNameSuffix and createIfMissing at the same time", func() {
+			var errMsg string = "cannot apply generateNameSuffix and createIfMissing at the same time"
+
+			adb.Spec.Details.GenerateNameSuffix = common.Bool(true)
+			adb.Spec.Details.CreateIfMissing = common.Bool(true)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
+		It("Cannot apply createIfMissing to a bind operation", func() {
+			var errMsg string = "cannot apply createIfMissing to a bind operation"
+
+			adb.Spec.Details.AutonomousDatabaseOCID = common.String("fake-adb-ocid")
+			adb.Spec.Details.CreateIfMissing = common.Bool(true)
+
+			validateInvalidTest(adb, false, errMsg)
+		})
 	})
 
 	// Skip the common and network validations since they're already verified in the test for ValidateCreate
@@ -245,7 +685,7 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 					Details: AutonomousDatabaseDetails{
 						CompartmentOCID:        common.String("fake-compartment-ocid"),
 						AutonomousDatabaseOCID: common.String("fake-adb-ocid"),
-						DbName:                 common.String("fake-dbName"),
+						DbName:                 common.String("fakeDbName"),
 						DisplayName:            common.String("fake-displayName"),
 						CPUCoreCount:           common.Int(1),
 						DataStorageSizeInTBs:   common.Int(1),
@@ -285,7 +725,16 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateUpdating
 			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
 
-			adb.Spec.Details.DbName = common.String("modified-db-name")
+			adb.Spec.Details.DbName = common.String("modifiedDbName")
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot disable the access control without the accessControlList when changing the access type to RESTRICTED", func() {
+			var errMsg string = "accessControlList is required to change the network access type to RESTRICTED"
+
+			adb.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypeRestricted
+			adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = common.Bool(false)
 
 			validateInvalidTest(adb, true, errMsg)
 		})
@@ -298,6 +747,17 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 			validateInvalidTest(adb, true, errMsg)
 		})
 
+		It("AutonomousDatabaseOCID cannot be different from the provisioned database", func() {
+			var errMsg string = "autonomousDatabaseOCID cannot be different from status.autonomousDatabaseOCID"
+
+			adb.Status.AutonomousDatabaseOCID = "provisioned-adb-ocid"
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.AutonomousDatabaseOCID = common.String("another-adb-ocid")
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
 		It("Cannot change lifecycleState with other spec attributes at the same time", func() {
 			var errMsg string = "cannot change lifecycleState with other spec attributes at the same time"
 
@@ -306,5 +766,314 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 
 			validateInvalidTest(adb, true, errMsg)
 		})
+
+		It("Cannot change the disasterRecoveryType of a peer once the peer is created", func() {
+			var errMsg string = "cannot change the disasterRecoveryType of the peer in us-phoenix-1"
+
+			adb.Status.DisasterRecovery.Peers = []DisasterRecoveryPeerStatus{{
+				Region:               "us-phoenix-1",
+				DisasterRecoveryType: DisasterRecoveryTypeADG,
+			}}
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.DisasterRecoveryPeer.Region = common.String("us-phoenix-1")
+			adb.Spec.Details.DisasterRecoveryPeer.DisasterRecoveryType = DisasterRecoveryTypeBackupBased
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot rotate the encryption key if the database uses an Oracle-managed key", func() {
+			var errMsg string = "rotateEncryptionKey requires the database to use a customer-managed key"
+
+			adb.Status.EncryptionKey.KmsKeyOCID = "ORACLE_MANAGED_KEY"
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Action = ADBActionRotateEncryptionKey
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot refresh the connection URLs if the database is not an APEX or AJD database", func() {
+			var errMsg string = "refreshConnectionURLs requires the dbWorkload to be APEX or AJD"
+
+			adb.Status.DbWorkload = database.AutonomousDatabaseDbWorkloadOltp
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Action = ADBActionRefreshConnectionURLs
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot shrink the storage of a dedicated database", func() {
+			var errMsg string = "shrink is not supported on a dedicated or an Always Free database"
+
+			adb.Status.IsDedicated = true
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Action = ADBActionShrink
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot shrink the storage unless the database is AVAILABLE", func() {
+			var errMsg string = "shrink requires the database to be AVAILABLE"
+
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Action = ADBActionShrink
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot detach a database which is not a refreshable clone", func() {
+			var errMsg string = "detachClone requires the database to be a refreshable clone"
+
+			adb.Spec.Action = ADBActionDetachClone
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot detach a refreshable clone without the confirmation", func() {
+			var errMsg string = "detachClone cannot be undone; set the annotation database.oracle.com/confirm-detach=fakeDbName"
+
+			adb.Status.IsRefreshableClone = true
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Action = ADBActionDetachClone
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot detach a refreshable clone if the confirmation doesn't match the dbName", func() {
+			var errMsg string = "the annotation database.oracle.com/confirm-detach must be the dbName fakeDbName"
+
+			adb.Status.IsRefreshableClone = true
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Annotations[ConfirmDetachAnnotation] = "anotherDbName"
+			adb.Spec.Action = ADBActionDetachClone
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("IsFreeTier cannot be modified", func() {
+			var errMsg string = "isFreeTier cannot be modified"
+
+			adb.Spec.Details.IsFreeTier = common.Bool(false)
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.IsFreeTier = common.Bool(true)
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("AdminUsername cannot be modified", func() {
+			var errMsg string = "adminUsername cannot be modified"
+
+			adb.Spec.Details.AdminUsername = common.String("DBA_ADMIN")
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Should allow changing dbWorkload from OLTP to DW", func() {
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadOltp
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadDw
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+		})
+
+		It("Cannot enable Operations Insights unless the database is AVAILABLE", func() {
+			var errMsg string = "Operations Insights can only be enabled when the database is AVAILABLE"
+
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+			Expect(k8sClient.Status().Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.IsOperationsInsightsEnabled = common.Bool(true)
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+
+		It("Cannot change dbWorkload from OLTP to AJD", func() {
+			var errMsg string = "cannot change dbWorkload from OLTP to AJD"
+
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadOltp
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadAjd
+
+			validateInvalidTest(adb, true, errMsg)
+		})
+	})
+
+	Describe("Test ValidateDelete of the AutonomousDatabase validating webhook", func() {
+		var (
+			resourceName = "testadb"
+			namespace    = "default"
+			adbLookupKey = types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			adb *AutonomousDatabase
+		)
+
+		BeforeEach(func() {
+			adb = &AutonomousDatabase{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "database.oracle.com/v1alpha1",
+					Kind:       "AutonomousDatabase",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: AutonomousDatabaseSpec{
+					Details: AutonomousDatabaseDetails{
+						AutonomousDatabaseOCID: common.String("fake-adb-ocid"),
+						DbName:                 common.String("fakeDbName"),
+					},
+					HardLink: common.Bool(true),
+				},
+			}
+
+			Expect(k8sClient.Create(context.TODO(), adb)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			// Unlink the database if the deletion in the test is rejected
+			if err := k8sClient.Get(context.TODO(), adbLookupKey, adb); err == nil {
+				adb.Spec.HardLink = common.Bool(false)
+				Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+				Expect(k8sClient.Delete(context.TODO(), adb)).To(Succeed())
+			}
+		})
+
+		It("Cannot delete a resource whose hardLink is true without the confirmation", func() {
+			var errMsg string = "hardLink is true; set the annotation database.oracle.com/confirm-terminate=fakeDbName"
+
+			err := k8sClient.Delete(context.TODO(), adb)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errMsg))
+		})
+
+		It("Cannot delete a resource whose hardLink is true if the confirmation doesn't match the dbName", func() {
+			var errMsg string = "the annotation database.oracle.com/confirm-terminate must be the dbName fakeDbName"
+
+			adb.SetAnnotations(map[string]string{ConfirmTerminateAnnotation: "anotherDbName"})
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			err := k8sClient.Delete(context.TODO(), adb)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errMsg))
+		})
+
+		It("Should delete a resource whose hardLink is true with the confirmation", func() {
+			adb.SetAnnotations(map[string]string{ConfirmTerminateAnnotation: "fakeDbName"})
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			Expect(k8sClient.Delete(context.TODO(), adb)).To(Succeed())
+		})
+
+		It("Should delete a resource whose hardLink is false without the confirmation", func() {
+			adb.Spec.HardLink = common.Bool(false)
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			Expect(k8sClient.Delete(context.TODO(), adb)).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("test the storage limits of AutonomousDatabase", func() {
+	expectMaxStorageSize := func(computeModel string, cpuCount int, expectedSize int) {
+		maxSize, ok := maxStorageSizeInTBs(computeModel, cpuCount)
+		Expect(ok).To(BeTrue())
+		Expect(maxSize).To(Equal(expectedSize))
+	}
+
+	It("Should limit the storage size by the number of OCPUs", func() {
+		expectMaxStorageSize(computeModelOCPU, 1, 128)
+		expectMaxStorageSize(computeModelOCPU, 2, 256)
+		expectMaxStorageSize(computeModelOCPU, 4, 384)
+	})
+
+	It("Should limit the storage size by the number of ECPUs", func() {
+		expectMaxStorageSize(computeModelECPU, 2, 64)
+		expectMaxStorageSize(computeModelECPU, 4, 128)
+		expectMaxStorageSize(computeModelECPU, 16, 384)
 	})
+
+	It("Should not limit the storage size of an unknown compute model", func() {
+		_, ok := maxStorageSizeInTBs("unknown", 1)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("test the names of AutonomousDatabase", func() {
+	DescribeTable("dbName",
+		func(name string, errMsg string) {
+			Expect(checkDbName(name)).To(Equal(errMsg))
+		},
+		Entry("letters and digits", "NewADB2", ""),
+		Entry("30 characters", strings.Repeat("a", 30), ""),
+		Entry("empty", "", "dbName cannot be empty"),
+		Entry("31 characters", strings.Repeat("a", 31), "dbName must be at most 30 characters"),
+		Entry("starting with a digit", "1adb", "dbName must start with a letter"),
+		Entry("hyphen", "new-adb", "dbName must contain only letters and digits"),
+		Entry("underscore", "new_adb", "dbName must contain only letters and digits"),
+		Entry("non-ASCII letter", "adbé", "dbName must contain only letters and digits"),
+		Entry("reserved word", "select", "dbName cannot be the reserved word SELECT"),
+	)
+
+	DescribeTable("region of an OCID",
+		func(ocid *string, region string) {
+			Expect(regionFromOCID(ocid)).To(Equal(region))
+		},
+		Entry("short name", common.String("ocid1.autonomousdatabase.oc1.phx.abc"), "us-phoenix-1"),
+		Entry("region name", common.String("ocid1.autonomousdatabase.oc1.ap-mumbai-1.abc"), "ap-mumbai-1"),
+		Entry("no region", common.String("ocid1.autonomousdatabase.oc1..abc"), ""),
+		Entry("malformed", common.String("fake-adb-ocid"), ""),
+		Entry("nil", nil, ""),
+	)
+
+	DescribeTable("reference to an object in the namespace of the resource",
+		func(name *string, errMsg string) {
+			allErrs := validateLocalReference(field.NewPath("spec").Child("ociConfig").Child("secretName"), name, nil)
+			if errMsg == "" {
+				Expect(allErrs).To(BeNil())
+			} else {
+				Expect(allErrs.ToAggregate().Error()).To(ContainSubstring(errMsg))
+			}
+		},
+		Entry("name", common.String("oci-privatekey"), ""),
+		Entry("dotted name", common.String("oci.privatekey"), ""),
+		Entry("not set", nil, ""),
+		Entry("namespace-qualified name", common.String("tenant-b/oci-privatekey"), "cross-namespace references are not allowed"),
+		Entry("uppercase letters", common.String("OCI_PrivateKey"), "spec.ociConfig.secretName: Invalid value"),
+	)
+
+	DescribeTable("console URL",
+		func(ocid *string, url string) {
+			adb := &AutonomousDatabase{}
+			adb.Spec.Details.AutonomousDatabaseOCID = ocid
+			Expect(adb.ConsoleURL()).To(Equal(url))
+		},
+		Entry("short name", common.String("ocid1.autonomousdatabase.oc1.phx.abc"),
+			"https://cloud.oracle.com/db/adbs/ocid1.autonomousdatabase.oc1.phx.abc?region=us-phoenix-1"),
+		Entry("region name", common.String("ocid1.autonomousdatabase.oc1.ap-mumbai-1.abc"),
+			"https://cloud.oracle.com/db/adbs/ocid1.autonomousdatabase.oc1.ap-mumbai-1.abc?region=ap-mumbai-1"),
+		Entry("government realm", common.String("ocid1.autonomousdatabase.oc2.us-langley-1.abc"), ""),
+		Entry("malformed", common.String("fake-adb-ocid"), ""),
+		Entry("not provisioned", nil, ""),
+	)
+
+	DescribeTable("displayName",
+		func(name string, errMsg string) {
+			Expect(checkDisplayName(name)).To(Equal(errMsg))
+		},
+		Entry("any characters", "New ADB (prod) #1 é", ""),
+		Entry("255 characters", strings.Repeat("a", 255), ""),
+		Entry("blank", "  ", "displayName cannot be blank"),
+		Entry("256 characters", strings.Repeat("a", 256), "displayName must be at most 255 characters"),
+		Entry("control character", "new\nadb", "displayName cannot contain control characters"),
+	)
 })
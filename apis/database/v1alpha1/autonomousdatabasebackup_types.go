@@ -48,6 +48,12 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// ADBBackupFinalizer is added to the long-term backups, which are deleted in OCI with the resource
+const ADBBackupFinalizer = "database.oracle.com/adb-backup-finalizer"
+
+// backupTypeLongTerm is the type of the long-term backups, which is missing from the SDK
+const backupTypeLongTerm database.AutonomousDatabaseBackupTypeEnum = "LONGTERM"
+
 // AutonomousDatabaseBackupSpec defines the desired state of AutonomousDatabaseBackup
 type AutonomousDatabaseBackupSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -56,9 +62,24 @@ type AutonomousDatabaseBackupSpec struct {
 	DisplayName                  *string    `json:"displayName,omitempty"`
 	AutonomousDatabaseBackupOCID *string    `json:"autonomousDatabaseBackupOCID,omitempty"`
 
+	// Create a long-term backup which is retained for the given number of days, from 90 to 3650.
+	// The backup retention period of the database is used if it's not provided.
+	RetentionPeriodInDays *int `json:"retentionPeriodInDays,omitempty"`
+
+	// Copy the backup to another region once the backup is ACTIVE, e.g. for disaster recovery.
+	CrossRegionCopy BackupCopySpec `json:"crossRegionCopy,omitempty"`
+
 	OCIConfig OCIConfigSpec `json:"ociConfig,omitempty"`
 }
 
+// BackupCopySpec defines where the copy of the backup is created
+type BackupCopySpec struct {
+	// The region where the backup is copied to, e.g. us-ashburn-1.
+	Region *string `json:"region,omitempty"`
+	// The compartment of the copy. The compartment of the source backup is used if it's not provided.
+	CompartmentOCID *string `json:"compartmentOCID,omitempty"`
+}
+
 // AutonomousDatabaseBackupStatus defines the observed state of AutonomousDatabaseBackup
 type AutonomousDatabaseBackupStatus struct {
 	LifecycleState         database.AutonomousDatabaseBackupLifecycleStateEnum `json:"lifecycleState"`
@@ -70,6 +91,16 @@ type AutonomousDatabaseBackupStatus struct {
 	CompartmentOCID        string                                              `json:"compartmentOCID"`
 	DBName                 string                                              `json:"dbName"`
 	DBDisplayName          string                                              `json:"dbDisplayName"`
+
+	// The backup in the region of spec.crossRegionCopy.region
+	CrossRegionCopy BackupCopyStatus `json:"crossRegionCopy,omitempty"`
+}
+
+// BackupCopyStatus defines the observed state of the copy of the backup
+type BackupCopyStatus struct {
+	Region                       string                                              `json:"region,omitempty"`
+	AutonomousDatabaseBackupOCID string                                              `json:"autonomousDatabaseBackupOCID,omitempty"`
+	LifecycleState               database.AutonomousDatabaseBackupLifecycleStateEnum `json:"lifecycleState,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -118,6 +149,22 @@ func (b *AutonomousDatabaseBackup) UpdateStatusFromOCIBackup(ociBackup database.
 	b.Status.DBName = *ociADB.DbName
 }
 
+// IsLongTermBackup returns true if the backup is a long-term backup, which is kept until its retention period ends
+// and can be deleted. The automatic backups are managed by OCI.
+func (b *AutonomousDatabaseBackup) IsLongTermBackup() bool {
+	if b.Status.IsAutomatic {
+		return false
+	}
+	return b.Status.Type == backupTypeLongTerm || b.Spec.RetentionPeriodInDays != nil
+}
+
+// UpdateCopyStatusFromOCIBackup updates the status of the copy of the backup
+func (b *AutonomousDatabaseBackup) UpdateCopyStatusFromOCIBackup(region string, ociBackup database.AutonomousDatabaseBackup) {
+	b.Status.CrossRegionCopy.Region = region
+	b.Status.CrossRegionCopy.AutonomousDatabaseBackupOCID = *ociBackup.Id
+	b.Status.CrossRegionCopy.LifecycleState = ociBackup.LifecycleState
+}
+
 // GetTimeEnded returns the status.timeEnded in SDKTime format
 func (b *AutonomousDatabaseBackup) GetTimeEnded() (*common.SDKTime, error) {
 	return parseDisplayTime(b.Status.TimeEnded)
@@ -91,6 +91,20 @@ func (r *AutonomousDatabaseBackup) ValidateCreate() error {
 			field.Forbidden(field.NewPath("spec").Child("target"), "specify either k8sADB or ociADB, but not both"))
 	}
 
+	if r.Spec.CrossRegionCopy.Region == nil && r.Spec.CrossRegionCopy.CompartmentOCID != nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("crossRegionCopy").Child("region"), "the region of the copy is empty"))
+	}
+
+	if r.Spec.RetentionPeriodInDays != nil &&
+		(*r.Spec.RetentionPeriodInDays < 90 || *r.Spec.RetentionPeriodInDays > 3650) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("retentionPeriodInDays"), *r.Spec.RetentionPeriodInDays,
+				"the retention period of a long-term backup must be from 90 to 3650 days"))
+	}
+
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -131,6 +145,12 @@ func (r *AutonomousDatabaseBackup) ValidateUpdate(old runtime.Object) error {
 			field.Forbidden(field.NewPath("spec").Child("displayName"), "cannot assign a new displayName to this backup"))
 	}
 
+	if oldBackup.Spec.CrossRegionCopy.Region != nil &&
+		(r.Spec.CrossRegionCopy.Region == nil || *oldBackup.Spec.CrossRegionCopy.Region != *r.Spec.CrossRegionCopy.Region) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("crossRegionCopy").Child("region"), "cannot assign a new region to the copy of this backup"))
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
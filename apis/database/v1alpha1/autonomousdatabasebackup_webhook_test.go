@@ -89,6 +89,24 @@ var _ = Describe("test AutonomousDatabaseBackup webhook", func() {
 
 			validateInvalidTest(backup, false, errMsg)
 		})
+
+		It("Should specify the region of the copy", func() {
+			var errMsg string = "the region of the copy is empty"
+
+			backup.Spec.Target.K8sADB.Name = common.String("fake-target-adb")
+			backup.Spec.CrossRegionCopy.CompartmentOCID = common.String("fake.ocid1.compartment.oc1...")
+
+			validateInvalidTest(backup, false, errMsg)
+		})
+
+		It("Should specify the retention period of a long-term backup from 90 to 3650 days", func() {
+			var errMsg string = "the retention period of a long-term backup must be from 90 to 3650 days"
+
+			backup.Spec.Target.K8sADB.Name = common.String("fake-target-adb")
+			backup.Spec.RetentionPeriodInDays = common.Int(30)
+
+			validateInvalidTest(backup, false, errMsg)
+		})
 	})
 
 	Describe("Test ValidateUpdate of the AutonomousDatabaseBackup validating webhook", func() {
@@ -155,6 +173,21 @@ var _ = Describe("test AutonomousDatabaseBackup webhook", func() {
 			})
 		})
 
+		Context("The backup is copied to another region", func() {
+			BeforeEach(func() {
+				backup.Spec.Target.K8sADB.Name = common.String("fake-target-adb")
+				backup.Spec.CrossRegionCopy.Region = common.String("us-ashburn-1")
+			})
+
+			It("Cannot assign a new region to the copy", func() {
+				var errMsg string = "cannot assign a new region to the copy of this backup"
+
+				backup.Spec.CrossRegionCopy.Region = common.String("us-phoenix-1")
+
+				validateInvalidTest(backup, true, errMsg)
+			})
+		})
+
 		Context("The bakcup is using target.ociADB.ocid", func() {
 			BeforeEach(func() {
 				backup.Spec.Target.OCIADB.OCID = common.String("fake.ocid1.autonomousdatabase.oc1...")
@@ -103,6 +103,8 @@ func (r *AutonomousDatabaseRestore) ValidateCreate() error {
 		}
 	}
 
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -0,0 +1,100 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduledAutonomousDatabaseBackupSpec defines the desired state of ScheduledAutonomousDatabaseBackup
+type ScheduledAutonomousDatabaseBackupSpec struct {
+	Target TargetSpec `json:"target,omitempty"`
+
+	// The cron expression of the backups in UTC, e.g. "0 2 * * *" or "@daily".
+	Schedule string `json:"schedule"`
+
+	// The retention period of each backup, from 90 to 3650 days. The backups are long-term backups if it's provided.
+	RetentionPeriodInDays *int `json:"retentionPeriodInDays,omitempty"`
+
+	// The number of AutonomousDatabaseBackup resources to keep, 3 by default. The oldest ones are deleted once the limit is exceeded.
+	HistoryLimit *int `json:"historyLimit,omitempty"`
+
+	// Stop creating new backups. The existing backups are kept.
+	Suspend *bool `json:"suspend,omitempty"`
+
+	OCIConfig OCIConfigSpec `json:"ociConfig,omitempty"`
+}
+
+// ScheduledAutonomousDatabaseBackupStatus defines the observed state of ScheduledAutonomousDatabaseBackup
+type ScheduledAutonomousDatabaseBackupStatus struct {
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// The name of the AutonomousDatabaseBackup created at the lastScheduleTime
+	LastBackupName string `json:"lastBackupName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName="sadbbu";"sadbbus"
+//+kubebuilder:printcolumn:JSONPath=".spec.schedule",name="Schedule",type=string
+//+kubebuilder:printcolumn:JSONPath=".status.lastScheduleTime",name="Last Schedule",type=date
+//+kubebuilder:printcolumn:JSONPath=".status.nextScheduleTime",name="Next Schedule",type=date
+//+kubebuilder:printcolumn:JSONPath=".status.lastBackupName",name="Last Backup",type=string
+
+// ScheduledAutonomousDatabaseBackup is the Schema for the scheduledautonomousdatabasebackups API
+type ScheduledAutonomousDatabaseBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduledAutonomousDatabaseBackupSpec   `json:"spec,omitempty"`
+	Status ScheduledAutonomousDatabaseBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ScheduledAutonomousDatabaseBackupList contains a list of ScheduledAutonomousDatabaseBackup
+type ScheduledAutonomousDatabaseBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledAutonomousDatabaseBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScheduledAutonomousDatabaseBackup{}, &ScheduledAutonomousDatabaseBackupList{})
+}
@@ -0,0 +1,142 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/oracle/oracle-database-operator/commons/cron"
+)
+
+// log is for logging in this package.
+var scheduledautonomousdatabasebackuplog = logf.Log.WithName("scheduledautonomousdatabasebackup-resource")
+
+// DefaultBackupHistoryLimit is the number of backups kept by a ScheduledAutonomousDatabaseBackup if spec.historyLimit is not provided
+const DefaultBackupHistoryLimit = 3
+
+func (r *ScheduledAutonomousDatabaseBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-database-oracle-com-v1alpha1-scheduledautonomousdatabasebackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=scheduledautonomousdatabasebackups,verbs=create;update,versions=v1alpha1,name=mscheduledautonomousdatabasebackup.kb.io,admissionReviewVersions={v1}
+
+var _ webhook.Defaulter = &ScheduledAutonomousDatabaseBackup{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *ScheduledAutonomousDatabaseBackup) Default() {
+	scheduledautonomousdatabasebackuplog.Info("default", "name", r.Name)
+
+	if r.Spec.HistoryLimit == nil {
+		limit := DefaultBackupHistoryLimit
+		r.Spec.HistoryLimit = &limit
+	}
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-database-oracle-com-v1alpha1-scheduledautonomousdatabasebackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=scheduledautonomousdatabasebackups,versions=v1alpha1,name=vscheduledautonomousdatabasebackup.kb.io,admissionReviewVersions={v1}
+
+var _ webhook.Validator = &ScheduledAutonomousDatabaseBackup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledAutonomousDatabaseBackup) ValidateCreate() error {
+	scheduledautonomousdatabasebackuplog.Info("validate create", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledAutonomousDatabaseBackup) ValidateUpdate(old runtime.Object) error {
+	scheduledautonomousdatabasebackuplog.Info("validate update", "name", r.Name)
+
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ScheduledAutonomousDatabaseBackup) ValidateDelete() error {
+	scheduledautonomousdatabasebackuplog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *ScheduledAutonomousDatabaseBackup) validate() error {
+	var allErrs field.ErrorList
+
+	if r.Spec.Target.K8sADB.Name == nil && r.Spec.Target.OCIADB.OCID == nil {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("target"), "target ADB is empty"))
+	}
+
+	if r.Spec.Target.K8sADB.Name != nil && r.Spec.Target.OCIADB.OCID != nil {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("target"), "specify either k8sADB or ociADB, but not both"))
+	}
+
+	if _, err := cron.Parse(r.Spec.Schedule); err != nil {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("schedule"), r.Spec.Schedule, err.Error()))
+	}
+
+	if r.Spec.RetentionPeriodInDays != nil &&
+		(*r.Spec.RetentionPeriodInDays < 90 || *r.Spec.RetentionPeriodInDays > 3650) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("retentionPeriodInDays"), *r.Spec.RetentionPeriodInDays,
+				"the retention period of a long-term backup must be from 90 to 3650 days"))
+	}
+
+	if r.Spec.HistoryLimit != nil && *r.Spec.HistoryLimit < 1 {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("historyLimit"), *r.Spec.HistoryLimit, "historyLimit must be at least 1"))
+	}
+
+	allErrs = validateOCIConfig(r.Spec.OCIConfig, allErrs)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "database.oracle.com", Kind: "ScheduledAutonomousDatabaseBackup"},
+		r.Name, allErrs)
+}
@@ -0,0 +1,107 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"github.com/oracle/oci-go-sdk/v64/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	// +kubebuilder:scaffold:imports
+)
+
+var _ = Describe("test ScheduledAutonomousDatabaseBackup webhook", func() {
+	Describe("Test ValidateCreate of the ScheduledAutonomousDatabaseBackup validating webhook", func() {
+		var (
+			resourceName = "testscheduledbackup"
+			namespace    = "default"
+
+			scheduled *ScheduledAutonomousDatabaseBackup
+		)
+
+		BeforeEach(func() {
+			scheduled = &ScheduledAutonomousDatabaseBackup{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "database.oracle.com/v1alpha1",
+					Kind:       "ScheduledAutonomousDatabaseBackup",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: ScheduledAutonomousDatabaseBackupSpec{
+					Target: TargetSpec{
+						K8sADB: K8sADBSpec{Name: common.String("fake-target-adb")},
+					},
+					Schedule: "@daily",
+				},
+			}
+		})
+
+		It("Should specify at least one of the k8sADB and ociADB", func() {
+			var errMsg string = "target ADB is empty"
+
+			scheduled.Spec.Target.K8sADB.Name = nil
+
+			validateInvalidTest(scheduled, false, errMsg)
+		})
+
+		It("Should specify a valid cron schedule", func() {
+			var errMsg string = "spec.schedule"
+
+			scheduled.Spec.Schedule = "0 25 * * *"
+
+			validateInvalidTest(scheduled, false, errMsg)
+		})
+
+		It("Should specify the retention period from 90 to 3650 days", func() {
+			var errMsg string = "the retention period of a long-term backup must be from 90 to 3650 days"
+
+			scheduled.Spec.RetentionPeriodInDays = common.Int(4000)
+
+			validateInvalidTest(scheduled, false, errMsg)
+		})
+
+		It("Should keep at least one backup", func() {
+			var errMsg string = "historyLimit must be at least 1"
+
+			scheduled.Spec.HistoryLimit = common.Int(0)
+
+			validateInvalidTest(scheduled, false, errMsg)
+		})
+	})
+})
@@ -77,6 +77,8 @@ var (
 	It               = ginkgo.It
 	FIt              = ginkgo.FIt
 	PIt              = ginkgo.PIt
+	DescribeTable    = ginkgo.DescribeTable
+	Entry            = ginkgo.Entry
 	Eventually       = gomega.Eventually
 	Expect           = gomega.Expect
 	Succeed          = gomega.Succeed
@@ -156,6 +158,9 @@ var _ = BeforeSuite(func() {
 	err = (&AutonomousContainerDatabase{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&ScheduledAutonomousDatabaseBackup{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:webhook
 
 	go func() {
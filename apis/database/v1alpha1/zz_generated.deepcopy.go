@@ -72,7 +72,7 @@ func (in *AutonomousContainerDatabase) DeepCopyInto(out *AutonomousContainerData
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousContainerDatabase.
@@ -155,6 +155,11 @@ func (in *AutonomousContainerDatabaseSpec) DeepCopyInto(out *AutonomousContainer
 			(*out)[key] = val
 		}
 	}
+	if in.IsAutomaticFailoverEnabled != nil {
+		in, out := &in.IsAutomaticFailoverEnabled, &out.IsAutomaticFailoverEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	in.OCIConfig.DeepCopyInto(&out.OCIConfig)
 	if in.HardLink != nil {
 		in, out := &in.HardLink, &out.HardLink
@@ -176,6 +181,11 @@ func (in *AutonomousContainerDatabaseSpec) DeepCopy() *AutonomousContainerDataba
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutonomousContainerDatabaseStatus) DeepCopyInto(out *AutonomousContainerDatabaseStatus) {
 	*out = *in
+	if in.IsAutomaticFailoverEnabled != nil {
+		in, out := &in.IsAutomaticFailoverEnabled, &out.IsAutomaticFailoverEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousContainerDatabaseStatus.
@@ -288,6 +298,12 @@ func (in *AutonomousDatabaseBackupSpec) DeepCopyInto(out *AutonomousDatabaseBack
 		*out = new(string)
 		**out = **in
 	}
+	if in.RetentionPeriodInDays != nil {
+		in, out := &in.RetentionPeriodInDays, &out.RetentionPeriodInDays
+		*out = new(int)
+		**out = **in
+	}
+	in.CrossRegionCopy.DeepCopyInto(&out.CrossRegionCopy)
 	in.OCIConfig.DeepCopyInto(&out.OCIConfig)
 }
 
@@ -304,6 +320,7 @@ func (in *AutonomousDatabaseBackupSpec) DeepCopy() *AutonomousDatabaseBackupSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutonomousDatabaseBackupStatus) DeepCopyInto(out *AutonomousDatabaseBackupStatus) {
 	*out = *in
+	out.CrossRegionCopy = in.CrossRegionCopy
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseBackupStatus.
@@ -340,6 +357,11 @@ func (in *AutonomousDatabaseDetails) DeepCopyInto(out *AutonomousDatabaseDetails
 		*out = new(string)
 		**out = **in
 	}
+	if in.CompartmentName != nil {
+		in, out := &in.CompartmentName, &out.CompartmentName
+		*out = new(string)
+		**out = **in
+	}
 	if in.DbVersion != nil {
 		in, out := &in.DbVersion, &out.DbVersion
 		*out = new(string)
@@ -350,6 +372,11 @@ func (in *AutonomousDatabaseDetails) DeepCopyInto(out *AutonomousDatabaseDetails
 		*out = new(int)
 		**out = **in
 	}
+	if in.DataStorageSizeInGBs != nil {
+		in, out := &in.DataStorageSizeInGBs, &out.DataStorageSizeInGBs
+		*out = new(int)
+		**out = **in
+	}
 	if in.CPUCoreCount != nil {
 		in, out := &in.CPUCoreCount, &out.CPUCoreCount
 		*out = new(int)
@@ -361,11 +388,71 @@ func (in *AutonomousDatabaseDetails) DeepCopyInto(out *AutonomousDatabaseDetails
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IsAutoScalingForStorageEnabled != nil {
+		in, out := &in.IsAutoScalingForStorageEnabled, &out.IsAutoScalingForStorageEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.IsDedicated != nil {
 		in, out := &in.IsDedicated, &out.IsDedicated
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StopBeforeScaling != nil {
+		in, out := &in.StopBeforeScaling, &out.StopBeforeScaling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupRetentionPeriodInDays != nil {
+		in, out := &in.BackupRetentionPeriodInDays, &out.BackupRetentionPeriodInDays
+		*out = new(int)
+		**out = **in
+	}
+	if in.AdminUsername != nil {
+		in, out := &in.AdminUsername, &out.AdminUsername
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsFreeTier != nil {
+		in, out := &in.IsFreeTier, &out.IsFreeTier
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AvailabilityDomain != nil {
+		in, out := &in.AvailabilityDomain, &out.AvailabilityDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutonomousVMClusterOCID != nil {
+		in, out := &in.AutonomousVMClusterOCID, &out.AutonomousVMClusterOCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloudExadataInfrastructureOCID != nil {
+		in, out := &in.CloudExadataInfrastructureOCID, &out.CloudExadataInfrastructureOCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowPreviewVersions != nil {
+		in, out := &in.AllowPreviewVersions, &out.AllowPreviewVersions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CreateIfMissing != nil {
+		in, out := &in.CreateIfMissing, &out.CreateIfMissing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RebindOnMissing != nil {
+		in, out := &in.RebindOnMissing, &out.RebindOnMissing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GenerateNameSuffix != nil {
+		in, out := &in.GenerateNameSuffix, &out.GenerateNameSuffix
+		*out = new(bool)
+		**out = **in
+	}
 	in.NetworkAccess.DeepCopyInto(&out.NetworkAccess)
 	if in.FreeformTags != nil {
 		in, out := &in.FreeformTags, &out.FreeformTags
@@ -374,7 +461,72 @@ func (in *AutonomousDatabaseDetails) DeepCopyInto(out *AutonomousDatabaseDetails
 			(*out)[key] = val
 		}
 	}
+	if in.RemoveTags != nil {
+		in, out := &in.RemoveTags, &out.RemoveTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefinedTags != nil {
+		in, out := &in.DefinedTags, &out.DefinedTags
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.IgnoredTagNamespaces != nil {
+		in, out := &in.IgnoredTagNamespaces, &out.IgnoredTagNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DisasterRecoveryPeer.DeepCopyInto(&out.DisasterRecoveryPeer)
+	if in.DisasterRecoveryPeers != nil {
+		in, out := &in.DisasterRecoveryPeers, &out.DisasterRecoveryPeers
+		*out = make([]DisasterRecoveryPeerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CustomerContacts != nil {
+		in, out := &in.CustomerContacts, &out.CustomerContacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IsDatabaseManagementEnabled != nil {
+		in, out := &in.IsDatabaseManagementEnabled, &out.IsDatabaseManagementEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsOperationsInsightsEnabled != nil {
+		in, out := &in.IsOperationsInsightsEnabled, &out.IsOperationsInsightsEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	in.Wallet.DeepCopyInto(&out.Wallet)
+	if in.ManageWallet != nil {
+		in, out := &in.ManageWallet, &out.ManageWallet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadyRequiresWallet != nil {
+		in, out := &in.ReadyRequiresWallet, &out.ReadyRequiresWallet
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseDetails.
@@ -521,6 +673,8 @@ func (in *AutonomousDatabaseSpec) DeepCopyInto(out *AutonomousDatabaseSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+	in.Bootstrap.DeepCopyInto(&out.Bootstrap)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseSpec.
@@ -543,6 +697,69 @@ func (in *AutonomousDatabaseStatus) DeepCopyInto(out *AutonomousDatabaseStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.ConnectionURLs = in.ConnectionURLs
+	if in.LifecycleHistory != nil {
+		in, out := &in.LifecycleHistory, &out.LifecycleHistory
+		*out = make([]LifecycleTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FreeformTags != nil {
+		in, out := &in.FreeformTags, &out.FreeformTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefinedTags != nil {
+		in, out := &in.DefinedTags, &out.DefinedTags
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.CustomerContacts != nil {
+		in, out := &in.CustomerContacts, &out.CustomerContacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NetworkAccess.DeepCopyInto(&out.NetworkAccess)
+	in.DisasterRecovery.DeepCopyInto(&out.DisasterRecovery)
+	out.EncryptionKey = in.EncryptionKey
+	out.Shrink = in.Shrink
+	out.Maintenance = in.Maintenance
+	if in.WalletExpiryTime != nil {
+		in, out := &in.WalletExpiryTime, &out.WalletExpiryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.WalletMTLSConnectionRequired != nil {
+		in, out := &in.WalletMTLSConnectionRequired, &out.WalletMTLSConnectionRequired
+		*out = new(bool)
+		**out = **in
+	}
+	in.Bootstrap.DeepCopyInto(&out.Bootstrap)
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseStatus.
@@ -555,6 +772,46 @@ func (in *AutonomousDatabaseStatus) DeepCopy() *AutonomousDatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCopySpec) DeepCopyInto(out *BackupCopySpec) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompartmentOCID != nil {
+		in, out := &in.CompartmentOCID, &out.CompartmentOCID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCopySpec.
+func (in *BackupCopySpec) DeepCopy() *BackupCopySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCopySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCopyStatus) DeepCopyInto(out *BackupCopyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCopyStatus.
+func (in *BackupCopyStatus) DeepCopy() *BackupCopyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCopyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backupconfig) DeepCopyInto(out *Backupconfig) {
 	*out = *in
@@ -590,6 +847,60 @@ func (in *Backupconfig) DeepCopy() *Backupconfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapSpec) DeepCopyInto(out *BootstrapSpec) {
+	*out = *in
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.TNSAlias != nil {
+		in, out := &in.TNSAlias, &out.TNSAlias
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapSpec.
+func (in *BootstrapSpec) DeepCopy() *BootstrapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapStatus) DeepCopyInto(out *BootstrapStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapStatus.
+func (in *BootstrapStatus) DeepCopy() *BootstrapStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDB) DeepCopyInto(out *CDB) {
 	*out = *in
@@ -875,6 +1186,21 @@ func (in *ConnectionStringSpec) DeepCopy() *ConnectionStringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionURLsStatus) DeepCopyInto(out *ConnectionURLsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionURLsStatus.
+func (in *ConnectionURLsStatus) DeepCopy() *ConnectionURLsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionURLsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DbStatus) DeepCopyInto(out *DbStatus) {
 	*out = *in
@@ -1093,6 +1419,82 @@ func (in *DbcsSystemStatus) DeepCopy() *DbcsSystemStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoveryPeerSpec) DeepCopyInto(out *DisasterRecoveryPeerSpec) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryPeerSpec.
+func (in *DisasterRecoveryPeerSpec) DeepCopy() *DisasterRecoveryPeerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoveryPeerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoveryPeerStatus) DeepCopyInto(out *DisasterRecoveryPeerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryPeerStatus.
+func (in *DisasterRecoveryPeerStatus) DeepCopy() *DisasterRecoveryPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoveryPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoveryStatus) DeepCopyInto(out *DisasterRecoveryStatus) {
+	*out = *in
+	if in.PeerAutonomousDatabaseOCIDs != nil {
+		in, out := &in.PeerAutonomousDatabaseOCIDs, &out.PeerAutonomousDatabaseOCIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Peer = in.Peer
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]DisasterRecoveryPeerStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryStatus.
+func (in *DisasterRecoveryStatus) DeepCopy() *DisasterRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyStatus) DeepCopyInto(out *EncryptionKeyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyStatus.
+func (in *EncryptionKeyStatus) DeepCopy() *EncryptionKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentVariable) DeepCopyInto(out *EnvironmentVariable) {
 	*out = *in
@@ -1196,6 +1598,36 @@ func (in *GsmStatusDetails) DeepCopy() *GsmStatusDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
+func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8sACDSpec) DeepCopyInto(out *K8sACDSpec) {
 	*out = *in
@@ -1276,6 +1708,37 @@ func (in *K8sSecretSpec) DeepCopy() *K8sSecretSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleTransition) DeepCopyInto(out *LifecycleTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleTransition.
+func (in *LifecycleTransition) DeepCopy() *LifecycleTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkAccessSpec) DeepCopyInto(out *NetworkAccessSpec) {
 	*out = *in
@@ -1360,6 +1823,11 @@ func (in *OCIConfigSpec) DeepCopyInto(out *OCIConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EndpointOverride != nil {
+		in, out := &in.EndpointOverride, &out.EndpointOverride
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIConfigSpec.
@@ -1833,6 +2301,11 @@ func (in *PasswordSpec) DeepCopyInto(out *PasswordSpec) {
 	*out = *in
 	in.K8sSecret.DeepCopyInto(&out.K8sSecret)
 	in.OCISecret.DeepCopyInto(&out.OCISecret)
+	if in.VolumePath != nil {
+		in, out := &in.VolumePath, &out.VolumePath
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSpec.
@@ -1873,11 +2346,21 @@ func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NsgNames != nil {
+		in, out := &in.NsgNames, &out.NsgNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.HostnamePrefix != nil {
 		in, out := &in.HostnamePrefix, &out.HostnamePrefix
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrivateEndpointIP != nil {
+		in, out := &in.PrivateEndpointIP, &out.PrivateEndpointIP
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
@@ -1890,6 +2373,120 @@ func (in *PrivateEndpointSpec) DeepCopy() *PrivateEndpointSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledAutonomousDatabaseBackup) DeepCopyInto(out *ScheduledAutonomousDatabaseBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledAutonomousDatabaseBackup.
+func (in *ScheduledAutonomousDatabaseBackup) DeepCopy() *ScheduledAutonomousDatabaseBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledAutonomousDatabaseBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledAutonomousDatabaseBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledAutonomousDatabaseBackupList) DeepCopyInto(out *ScheduledAutonomousDatabaseBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduledAutonomousDatabaseBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledAutonomousDatabaseBackupList.
+func (in *ScheduledAutonomousDatabaseBackupList) DeepCopy() *ScheduledAutonomousDatabaseBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledAutonomousDatabaseBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledAutonomousDatabaseBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledAutonomousDatabaseBackupSpec) DeepCopyInto(out *ScheduledAutonomousDatabaseBackupSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	if in.RetentionPeriodInDays != nil {
+		in, out := &in.RetentionPeriodInDays, &out.RetentionPeriodInDays
+		*out = new(int)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	in.OCIConfig.DeepCopyInto(&out.OCIConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledAutonomousDatabaseBackupSpec.
+func (in *ScheduledAutonomousDatabaseBackupSpec) DeepCopy() *ScheduledAutonomousDatabaseBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledAutonomousDatabaseBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledAutonomousDatabaseBackupStatus) DeepCopyInto(out *ScheduledAutonomousDatabaseBackupStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledAutonomousDatabaseBackupStatus.
+func (in *ScheduledAutonomousDatabaseBackupStatus) DeepCopy() *ScheduledAutonomousDatabaseBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledAutonomousDatabaseBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardSpec) DeepCopyInto(out *ShardSpec) {
 	*out = *in
@@ -2078,6 +2675,21 @@ func (in *ShardingDatabaseStatus) DeepCopy() *ShardingDatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShrinkStatus) DeepCopyInto(out *ShrinkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShrinkStatus.
+func (in *ShrinkStatus) DeepCopy() *ShrinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShrinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleInstanceDatabase) DeepCopyInto(out *SingleInstanceDatabase) {
 	*out = *in
@@ -2380,7 +2992,73 @@ func (in *WalletSpec) DeepCopyInto(out *WalletSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
 	in.Password.DeepCopyInto(&out.Password)
+	if in.MinTLSVersion != nil {
+		in, out := &in.MinTLSVersion, &out.MinTLSVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.SplitProfiles != nil {
+		in, out := &in.SplitProfiles, &out.SplitProfiles
+		*out = new(bool)
+		**out = **in
+	}
+	in.ObjectStorage.DeepCopyInto(&out.ObjectStorage)
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DownloadRetries != nil {
+		in, out := &in.DownloadRetries, &out.DownloadRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.DownloadInterval != nil {
+		in, out := &in.DownloadInterval, &out.DownloadInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HostRewrite != nil {
+		in, out := &in.HostRewrite, &out.HostRewrite
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WalletObjectStorageSpec) DeepCopyInto(out *WalletObjectStorageSpec) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletObjectStorageSpec.
+func (in *WalletObjectStorageSpec) DeepCopy() *WalletObjectStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WalletObjectStorageSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WalletSpec.
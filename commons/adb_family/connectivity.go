@@ -0,0 +1,184 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+const defaultListenerPort = "1521"
+
+var (
+	descriptorHostRegex = regexp.MustCompile(`(?i)\(\s*host\s*=\s*([^)\s]+)\s*\)`)
+	descriptorPortRegex = regexp.MustCompile(`(?i)\(\s*port\s*=\s*(\d+)\s*\)`)
+)
+
+// GetListenerAddress returns the "host:port" of the listener from a connection string.
+// The connection string can be either a connect descriptor (long format), or an Easy Connect string (short format).
+func GetListenerAddress(connStr string) (string, error) {
+	connStr = strings.TrimSpace(connStr)
+	if connStr == "" {
+		return "", errors.New("connection string is empty")
+	}
+
+	// Long format, e.g. (description=(address=(protocol=tcps)(port=1522)(host=adb.us-ashburn-1.oraclecloud.com))...)
+	if strings.HasPrefix(connStr, "(") {
+		host := descriptorHostRegex.FindStringSubmatch(connStr)
+		if host == nil {
+			return "", fmt.Errorf("host not found in the connection string %s", connStr)
+		}
+
+		port := defaultListenerPort
+		if match := descriptorPortRegex.FindStringSubmatch(connStr); match != nil {
+			port = match[1]
+		}
+
+		return net.JoinHostPort(host[1], port), nil
+	}
+
+	// Short format, e.g. tcps://adb.us-ashburn-1.oraclecloud.com:1522/xxx_high.adb.oraclecloud.com?retry_count=20
+	if i := strings.Index(connStr, "://"); i >= 0 {
+		connStr = connStr[i+len("://"):]
+	}
+	if i := strings.IndexAny(connStr, "/?"); i >= 0 {
+		connStr = connStr[:i]
+	}
+
+	if connStr == "" {
+		return "", errors.New("host not found in the connection string")
+	}
+
+	host, port, err := net.SplitHostPort(connStr)
+	if err != nil {
+		// No port specified
+		return net.JoinHostPort(connStr, defaultListenerPort), nil
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// GetProbeAddress returns the listener address of the first connection string in the status
+func GetProbeAddress(adb *dbv1alpha1.AutonomousDatabase) (string, error) {
+	conn, err := getProbeConnection(adb)
+	if err != nil {
+		return "", err
+	}
+	return GetListenerAddress(conn.ConnectionString)
+}
+
+// GetProbeTNSName returns the TNS name of the first connection string in the status, or an empty string if there is
+// none
+func GetProbeTNSName(adb *dbv1alpha1.AutonomousDatabase) string {
+	conn, err := getProbeConnection(adb)
+	if err != nil {
+		return ""
+	}
+	return conn.TNSName
+}
+
+func getProbeConnection(adb *dbv1alpha1.AutonomousDatabase) (dbv1alpha1.ConnectionStringSpec, error) {
+	for _, profile := range adb.Status.AllConnectionStrings {
+		for _, conn := range profile.ConnectionStrings {
+			if conn.ConnectionString == "" {
+				continue
+			}
+			return conn, nil
+		}
+	}
+
+	return dbv1alpha1.ConnectionStringSpec{}, errors.New("no connection string found in the status")
+}
+
+// PingListener verifies that the database listener accepts connections by opening a TCP connection to the address.
+// It only proves the port is open, so HandshakeListener is used instead if the wallet is available.
+func PingListener(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// ErrHandshakeFailed is returned by HandshakeListener if the listener accepts the TCP connection but the TLS
+// handshake fails, e.g. the certificate of the listener is not issued by the CA of the wallet
+var ErrHandshakeFailed = errors.New("TLS handshake failed")
+
+// HandshakeListener verifies the database listener at the address by a TLS handshake. The certificate of the listener
+// must be issued for the host by one of the rootCAs, which are the CA certificates of the wallet. The private key of
+// the wallet is encrypted by the wallet password, so no client certificate is sent. If the listener requires mutual
+// TLS, the check succeeds once the certificate of the listener is verified, even if the listener then rejects the
+// handshake for the missing client certificate.
+func HandshakeListener(address string, host string, rootCAs *x509.CertPool, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	clientCertRequested := false
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: host,
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+		// Only called after the certificate of the listener is verified
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			clientCertRequested = true
+			return &tls.Certificate{}, nil
+		},
+	})
+
+	if err := tlsConn.Handshake(); err != nil && !clientCertRequested {
+		return fmt.Errorf("%w with %s: %s", ErrHandshakeFailed, address, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("Connectivity check", func() {
+	Describe("GetListenerAddress", func() {
+		It("should parse the long format", func() {
+			connStr := "(description= (retry_count=20)(retry_delay=3)(address=(protocol=tcps)(port=1522)(host=adb.us-ashburn-1.oraclecloud.com))(connect_data=(service_name=abc_high.adb.oraclecloud.com))(security=(ssl_server_dn_match=yes)))"
+			Expect(GetListenerAddress(connStr)).To(Equal("adb.us-ashburn-1.oraclecloud.com:1522"))
+		})
+
+		It("should parse the short format", func() {
+			Expect(GetListenerAddress("adb.us-ashburn-1.oraclecloud.com:1522/abc_high.adb.oraclecloud.com")).To(Equal("adb.us-ashburn-1.oraclecloud.com:1522"))
+			Expect(GetListenerAddress("tcps://adb.us-ashburn-1.oraclecloud.com:1522/abc_high.adb.oraclecloud.com?retry_count=20")).To(Equal("adb.us-ashburn-1.oraclecloud.com:1522"))
+		})
+
+		It("should use the default port if the port is missing", func() {
+			Expect(GetListenerAddress("host.example.com/service")).To(Equal("host.example.com:1521"))
+			Expect(GetListenerAddress("(description=(address=(protocol=tcp)(host=host.example.com))(connect_data=(service_name=svc)))")).To(Equal("host.example.com:1521"))
+		})
+
+		It("should return an error if the host is missing", func() {
+			_, err := GetListenerAddress("")
+			Expect(err).To(HaveOccurred())
+
+			_, err = GetListenerAddress("(description=(address=(protocol=tcps)(port=1522)))")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("GetProbeAddress", func() {
+		It("should skip the empty connection strings", func() {
+			adb := &dbv1alpha1.AutonomousDatabase{
+				Status: dbv1alpha1.AutonomousDatabaseStatus{
+					AllConnectionStrings: []dbv1alpha1.ConnectionStringProfile{
+						{
+							ConnectionStrings: []dbv1alpha1.ConnectionStringSpec{
+								{},
+								{TNSName: "high", ConnectionString: "host.example.com:1522/high"},
+							},
+						},
+					},
+				},
+			}
+			Expect(GetProbeAddress(adb)).To(Equal("host.example.com:1522"))
+		})
+
+		It("should return an error if there is no connection string", func() {
+			_, err := GetProbeAddress(&dbv1alpha1.AutonomousDatabase{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("PingListener", func() {
+		It("should succeed if the listener accepts connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			Expect(PingListener(listener.Addr().String(), time.Second)).To(Succeed())
+		})
+
+		It("should fail if the listener is down", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			address := listener.Addr().String()
+			listener.Close()
+
+			Expect(PingListener(address, time.Second)).ToNot(Succeed())
+		})
+	})
+
+	Describe("HandshakeListener", func() {
+		// newCA returns a CA certificate and its key
+		newCA := func() (*x509.Certificate, *ecdsa.PrivateKey) {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber:          big.NewInt(1),
+				Subject:               pkix.Name{CommonName: "Fake Wallet CA"},
+				NotBefore:             time.Now().Add(-time.Hour),
+				NotAfter:              time.Now().Add(time.Hour),
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := x509.ParseCertificate(der)
+			Expect(err).ToNot(HaveOccurred())
+			return cert, key
+		}
+
+		// startListener starts a TLS listener whose certificate is issued for the host by the CA
+		startListener := func(ca *x509.Certificate, caKey *ecdsa.PrivateKey, host string, clientAuth tls.ClientAuthType) net.Listener {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(2),
+				Subject:      pkix.Name{CommonName: host},
+				DNSNames:     []string{host},
+				NotBefore:    time.Now().Add(-time.Hour),
+				NotAfter:     time.Now().Add(time.Hour),
+				KeyUsage:     x509.KeyUsageDigitalSignature,
+				ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+				Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+				ClientAuth:   clientAuth,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					_ = conn.(*tls.Conn).Handshake()
+					conn.Close()
+				}
+			}()
+			return listener
+		}
+
+		It("should succeed if the certificate of the listener is issued by the wallet CA", func() {
+			ca, caKey := newCA()
+			listener := startListener(ca, caKey, "adb.fake.oraclecloud.com", tls.NoClientCert)
+			defer listener.Close()
+
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(ca)
+			Expect(HandshakeListener(listener.Addr().String(), "adb.fake.oraclecloud.com", rootCAs, time.Second)).To(Succeed())
+		})
+
+		It("should succeed once the certificate is verified if the listener requires mutual TLS", func() {
+			ca, caKey := newCA()
+			listener := startListener(ca, caKey, "adb.fake.oraclecloud.com", tls.RequireAnyClientCert)
+			defer listener.Close()
+
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(ca)
+			Expect(HandshakeListener(listener.Addr().String(), "adb.fake.oraclecloud.com", rootCAs, time.Second)).To(Succeed())
+		})
+
+		It("should fail the handshake if the certificate is issued by another CA", func() {
+			ca, caKey := newCA()
+			listener := startListener(ca, caKey, "adb.fake.oraclecloud.com", tls.RequireAnyClientCert)
+			defer listener.Close()
+
+			walletCA, _ := newCA()
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(walletCA)
+			err := HandshakeListener(listener.Addr().String(), "adb.fake.oraclecloud.com", rootCAs, time.Second)
+			Expect(err).To(MatchError(ErrHandshakeFailed))
+		})
+
+		It("should fail the handshake if the certificate is issued for another host", func() {
+			ca, caKey := newCA()
+			listener := startListener(ca, caKey, "other.fake.oraclecloud.com", tls.NoClientCert)
+			defer listener.Close()
+
+			rootCAs := x509.NewCertPool()
+			rootCAs.AddCert(ca)
+			err := HandshakeListener(listener.Addr().String(), "adb.fake.oraclecloud.com", rootCAs, time.Second)
+			Expect(err).To(MatchError(ErrHandshakeFailed))
+		})
+
+		It("should fail the handshake if the listener doesn't speak TLS", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err == nil {
+					conn.Close()
+				}
+			}()
+
+			err = HandshakeListener(listener.Addr().String(), "adb.fake.oraclecloud.com", x509.NewCertPool(), time.Second)
+			Expect(err).To(MatchError(ErrHandshakeFailed))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"context"
+	"sort"
+
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// ADBFilter selects the databases returned by ListManagedADBs. An empty field matches all the databases.
+type ADBFilter struct {
+	Namespace       string
+	CompartmentOCID string
+	DbWorkload      database.AutonomousDatabaseDbWorkloadEnum
+	LifecycleState  database.AutonomousDatabaseLifecycleStateEnum
+	// The maximum number of databases in a page. All the databases are returned if it's not positive.
+	Limit int
+	// The Continue token returned with the previous page
+	Continue string
+}
+
+// ManagedADB is an AutonomousDatabase resource with the state of the database in OCI, as reported in the status
+type ManagedADB struct {
+	Namespace              string
+	Name                   string
+	AutonomousDatabaseOCID string
+	DisplayName            string
+	CompartmentOCID        string
+	DbWorkload             database.AutonomousDatabaseDbWorkloadEnum
+	LifecycleState         database.AutonomousDatabaseLifecycleStateEnum
+}
+
+// ManagedADBList is a page of the databases returned by ListManagedADBs. Continue is empty on the last page.
+type ManagedADBList struct {
+	Items    []ManagedADB
+	Continue string
+}
+
+// ListManagedADBs returns the AutonomousDatabase resources that match the filter, sorted by namespace and name.
+// The filters are applied to the status, so no request is sent to OCI, and the resources are read from the
+// informer cache if the kubeClient is the client of the manager.
+func ListManagedADBs(ctx context.Context, kubeClient client.Reader, filter ADBFilter) (*ManagedADBList, error) {
+	adbList := &dbv1alpha1.AutonomousDatabaseList{}
+	if err := kubeClient.List(ctx, adbList, client.InNamespace(filter.Namespace)); err != nil {
+		return nil, err
+	}
+
+	items := []ManagedADB{}
+	for _, adb := range adbList.Items {
+		if !filter.matches(&adb) {
+			continue
+		}
+
+		items = append(items, ManagedADB{
+			Namespace:              adb.Namespace,
+			Name:                   adb.Name,
+			AutonomousDatabaseOCID: derefString(adb.GetAutonomousDatabaseOCID()),
+			DisplayName:            adb.Status.DisplayName,
+			CompartmentOCID:        adb.Status.CompartmentOCID,
+			DbWorkload:             adb.Status.DbWorkload,
+			LifecycleState:         adb.Status.LifecycleState,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key() < items[j].key()
+	})
+
+	// The Continue token is the key of the last database of the previous page
+	if filter.Continue != "" {
+		start := sort.Search(len(items), func(i int) bool {
+			return items[i].key() > filter.Continue
+		})
+		items = items[start:]
+	}
+
+	result := &ManagedADBList{Items: items}
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		result.Items = items[:filter.Limit]
+		result.Continue = result.Items[filter.Limit-1].key()
+	}
+
+	return result, nil
+}
+
+func (f ADBFilter) matches(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return (f.CompartmentOCID == "" || adb.Status.CompartmentOCID == f.CompartmentOCID) &&
+		(f.DbWorkload == "" || adb.Status.DbWorkload == f.DbWorkload) &&
+		(f.LifecycleState == "" || adb.Status.LifecycleState == f.LifecycleState)
+}
+
+func (m ManagedADB) key() string {
+	return m.Namespace + "/" + m.Name
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,163 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("ListManagedADBs", func() {
+	var kubeClient client.Client
+
+	newADB := func(namespace string, name string, compartmentOCID string,
+		workload database.AutonomousDatabaseDbWorkloadEnum,
+		state database.AutonomousDatabaseLifecycleStateEnum) *dbv1alpha1.AutonomousDatabase {
+		return &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1.." + name),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				DisplayName:     name,
+				CompartmentOCID: compartmentOCID,
+				DbWorkload:      workload,
+				LifecycleState:  state,
+			},
+		}
+	}
+
+	names := func(list *ManagedADBList) []string {
+		result := []string{}
+		for _, item := range list.Items {
+			result = append(result, item.Namespace+"/"+item.Name)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newADB("default", "adb-c", "ocid1.compartment.oc1..a", database.AutonomousDatabaseDbWorkloadOltp, database.AutonomousDatabaseLifecycleStateAvailable),
+			newADB("default", "adb-a", "ocid1.compartment.oc1..a", database.AutonomousDatabaseDbWorkloadDw, database.AutonomousDatabaseLifecycleStateStopped),
+			newADB("default", "adb-b", "ocid1.compartment.oc1..b", database.AutonomousDatabaseDbWorkloadOltp, database.AutonomousDatabaseLifecycleStateStopped),
+			newADB("other", "adb-d", "ocid1.compartment.oc1..b", database.AutonomousDatabaseDbWorkloadAjd, database.AutonomousDatabaseLifecycleStateAvailable),
+		).Build()
+	})
+
+	It("should return all the databases sorted by namespace and name with the state in the status", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-a", "default/adb-b", "default/adb-c", "other/adb-d"}))
+		Expect(list.Continue).To(BeEmpty())
+
+		Expect(list.Items[0]).To(Equal(ManagedADB{
+			Namespace:              "default",
+			Name:                   "adb-a",
+			AutonomousDatabaseOCID: "ocid1.autonomousdatabase.oc1..adb-a",
+			DisplayName:            "adb-a",
+			CompartmentOCID:        "ocid1.compartment.oc1..a",
+			DbWorkload:             database.AutonomousDatabaseDbWorkloadDw,
+			LifecycleState:         database.AutonomousDatabaseLifecycleStateStopped,
+		}))
+	})
+
+	It("should filter by namespace", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{Namespace: "other"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"other/adb-d"}))
+	})
+
+	It("should filter by compartment", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{CompartmentOCID: "ocid1.compartment.oc1..b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-b", "other/adb-d"}))
+	})
+
+	It("should filter by workload", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{DbWorkload: database.AutonomousDatabaseDbWorkloadOltp})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-b", "default/adb-c"}))
+	})
+
+	It("should filter by lifecycle state", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{LifecycleState: database.AutonomousDatabaseLifecycleStateStopped})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-a", "default/adb-b"}))
+	})
+
+	It("should combine the filters", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{
+			CompartmentOCID: "ocid1.compartment.oc1..a",
+			LifecycleState:  database.AutonomousDatabaseLifecycleStateAvailable,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-c"}))
+	})
+
+	It("should return the databases in pages", func() {
+		list, err := ListManagedADBs(context.TODO(), kubeClient, ADBFilter{Limit: 3})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"default/adb-a", "default/adb-b", "default/adb-c"}))
+		Expect(list.Continue).NotTo(BeEmpty())
+
+		list, err = ListManagedADBs(context.TODO(), kubeClient, ADBFilter{Limit: 3, Continue: list.Continue})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(list)).To(Equal([]string{"other/adb-d"}))
+		Expect(list.Continue).To(BeEmpty())
+	})
+})
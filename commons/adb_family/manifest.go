@@ -0,0 +1,118 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"encoding/json"
+
+	"github.com/oracle/oci-go-sdk/v64/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// adbManifest is the exported AutonomousDatabase. Only the name and the namespace are kept in the metadata.
+type adbManifest struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        manifestMetadata                  `json:"metadata"`
+	Spec            dbv1alpha1.AutonomousDatabaseSpec `json:"spec"`
+}
+
+type manifestMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ExportManifest returns a YAML manifest of the AutonomousDatabase whose spec reflects the live OCI config, so that
+// an existing database can be managed from a manifest. The secret references are redacted: the admin password, the
+// wallet, and the secretName of the ociConfig are left out, and have to be filled in before applying the manifest.
+func ExportManifest(adb *dbv1alpha1.AutonomousDatabase, ociADB database.AutonomousDatabase) ([]byte, error) {
+	exported := &dbv1alpha1.AutonomousDatabase{}
+	// The backupRetentionPeriodInDays is missing from the OCI object, so it's taken from the status
+	exported.Status.BackupRetentionPeriodInDays = adb.Status.BackupRetentionPeriodInDays
+	// The tags in the ignored namespaces are left out
+	exported.Spec.Details.IgnoredTagNamespaces = adb.Spec.Details.IgnoredTagNamespaces
+	exported.UpdateFromOCIADB(ociADB)
+
+	manifest := adbManifest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: dbv1alpha1.GroupVersion.String(),
+			Kind:       "AutonomousDatabase",
+		},
+		Metadata: manifestMetadata{
+			Name:      adb.GetName(),
+			Namespace: adb.GetNamespace(),
+		},
+		Spec: dbv1alpha1.AutonomousDatabaseSpec{
+			Details: exported.Spec.Details,
+			OCIConfig: dbv1alpha1.OCIConfigSpec{
+				ConfigMapName: adb.Spec.OCIConfig.ConfigMapName,
+			},
+			HardLink: adb.Spec.HardLink,
+		},
+	}
+
+	// Remove the empty structs, e.g. the unset adminPassword, which are not omitted by the marshaller
+	out, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(out, &obj); err != nil {
+		return nil, err
+	}
+	pruneEmptyMaps(obj)
+
+	return yaml.Marshal(obj)
+}
+
+// pruneEmptyMaps removes the keys whose values are empty maps, recursively
+func pruneEmptyMaps(obj map[string]interface{}) {
+	for key, val := range obj {
+		child, ok := val.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pruneEmptyMaps(child)
+		if len(child) == 0 {
+			delete(obj, key)
+		}
+	}
+}
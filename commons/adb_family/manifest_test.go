@@ -0,0 +1,113 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package adbfamily
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("ExportManifest", func() {
+	var (
+		adb    *dbv1alpha1.AutonomousDatabase
+		ociADB database.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "export-db",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.ExportManifestAnnotation: ""},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1.iad.example"),
+					AdminPassword: dbv1alpha1.PasswordSpec{
+						K8sSecret: dbv1alpha1.K8sSecretSpec{Name: common.String("admin-password")},
+					},
+					Wallet: dbv1alpha1.WalletSpec{
+						Name: common.String("export-db-wallet"),
+						Password: dbv1alpha1.PasswordSpec{
+							OCISecret: dbv1alpha1.OCISecretSpec{OCID: common.String("ocid1.vaultsecret.oc1.iad.example")},
+						},
+					},
+				},
+				OCIConfig: dbv1alpha1.OCIConfigSpec{
+					ConfigMapName: common.String("oci-cred"),
+					SecretName:    common.String("oci-privatekey"),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				BackupRetentionPeriodInDays: 7,
+			},
+		}
+
+		fixture, err := ioutil.ReadFile("testdata/oci_autonomous_database.json")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(json.Unmarshal(fixture, &ociADB)).To(Succeed())
+	})
+
+	It("should generate the manifest from the OCI response", func() {
+		expected, err := ioutil.ReadFile("testdata/autonomous_database_manifest.yaml")
+		Expect(err).ToNot(HaveOccurred())
+
+		manifest, err := ExportManifest(adb, ociADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(manifest)).To(Equal(string(expected)))
+	})
+
+	It("should redact the secret references", func() {
+		manifest, err := ExportManifest(adb, ociADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(manifest)).ToNot(ContainSubstring("admin-password"))
+		Expect(string(manifest)).ToNot(ContainSubstring("vaultsecret"))
+		Expect(string(manifest)).ToNot(ContainSubstring("oci-privatekey"))
+		Expect(string(manifest)).ToNot(ContainSubstring(dbv1alpha1.ExportManifestAnnotation))
+	})
+})
@@ -41,6 +41,7 @@ package annotations
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,7 +70,7 @@ func PatchAnnotations(kubeClient client.Client, obj client.Object, anns map[stri
 	for key, val := range anns {
 		payload = append(payload, PatchValue{
 			Op:    "replace",
-			Path:  "/metadata/annotations/" + key,
+			Path:  "/metadata/annotations/" + escapeJSONPointer(key),
 			Value: val,
 		})
 	}
@@ -82,3 +83,37 @@ func PatchAnnotations(kubeClient client.Client, obj client.Object, anns map[stri
 	patch := client.RawPatch(types.JSONPatchType, payloadBytes)
 	return kubeClient.Patch(context.TODO(), obj, patch)
 }
+
+// RemoveAnnotations removes the given keys from the annotations of the target object.
+// The keys which don't exist are skipped. The obj will be updated with the content returned by the cluster
+func RemoveAnnotations(kubeClient client.Client, obj client.Object, keys ...string) error {
+	payload := []PatchValue{}
+
+	for _, key := range keys {
+		if _, ok := obj.GetAnnotations()[key]; !ok {
+			continue
+		}
+
+		payload = append(payload, PatchValue{
+			Op:   "remove",
+			Path: "/metadata/annotations/" + escapeJSONPointer(key),
+		})
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	patch := client.RawPatch(types.JSONPatchType, payloadBytes)
+	return kubeClient.Patch(context.TODO(), obj, patch)
+}
+
+// escapeJSONPointer escapes the "~" and "/" in the key as described in RFC 6901
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,85 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// 2022-06-15 is a Wednesday
+	from := time.Date(2022, 6, 15, 10, 30, 45, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(spec string, expected time.Time) {
+			schedule, err := Parse(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schedule.Next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2022, 6, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("step of minutes", "*/20 * * * *", time.Date(2022, 6, 15, 10, 40, 0, 0, time.UTC)),
+		Entry("daily at 2am", "0 2 * * *", time.Date(2022, 6, 16, 2, 0, 0, 0, time.UTC)),
+		Entry("shortcut", "@hourly", time.Date(2022, 6, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("list of hours", "15 9,12,18 * * *", time.Date(2022, 6, 15, 12, 15, 0, 0, time.UTC)),
+		Entry("weekdays", "0 1 * * 1-5", time.Date(2022, 6, 16, 1, 0, 0, 0, time.UTC)),
+		Entry("sunday", "@weekly", time.Date(2022, 6, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("next month", "0 0 1 * *", time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("next year", "0 0 1 1 *", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 20 * 5", time.Date(2022, 6, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	DescribeTable("Parse errors",
+		func(spec string) {
+			_, err := Parse(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "0 2 * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("invalid range", "0 5-2 * * *"),
+		Entry("invalid step", "*/0 * * * *"),
+		Entry("not a number", "0 two * * *"),
+		Entry("unknown shortcut", "@sometimes"),
+	)
+})
@@ -48,11 +48,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func CreateSecret(kubeClient client.Client, namespace string, name string, data map[string][]byte, owner client.Object, label map[string]string) error {
-	ownerReference := NewOwnerReference(owner)
-
+// CreateSecret creates a Secret with the data. The owner is set as the controller of the Secret, so the Secret is
+// garbage-collected with the owner. The owner reference is not set if the owner is nil.
+func CreateSecret(kubeClient client.Client, namespace string, name string, data map[string][]byte, owner client.Object, label map[string]string, annotations map[string]string) error {
 	// Create the secret with the wallet data
 	stringData := map[string]string{}
 	for key, val := range data {
@@ -61,20 +62,46 @@ func CreateSecret(kubeClient client.Client, namespace string, name string, data
 
 	walletSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:       namespace,
-			Name:            name,
-			OwnerReferences: ownerReference,
-			Labels:          label,
+			Namespace: namespace,
+			Name:      name,
+			Labels:      label,
+			Annotations: annotations,
 		},
 		StringData: stringData,
 	}
 
+	if owner != nil {
+		if err := controllerutil.SetControllerReference(owner, walletSecret, kubeClient.Scheme()); err != nil {
+			return err
+		}
+	}
+
 	if err := kubeClient.Create(context.TODO(), walletSecret); err != nil {
 		return err
 	}
 	return nil
 }
 
+// CreateOrUpdateConfigMap creates a ConfigMap with the data, or replaces the data if the ConfigMap exists.
+// The owner reference is not set if the owner is nil.
+func CreateOrUpdateConfigMap(kubeClient client.Client, namespace string, name string, data map[string]string, owner client.Object) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), kubeClient, configMap, func() error {
+		if owner != nil {
+			configMap.OwnerReferences = NewOwnerReference(owner)
+		}
+		configMap.Data = data
+		return nil
+	})
+	return err
+}
+
 func CreateAutonomousBackup(kubeClient client.Client,
 	backupName string,
 	backupSummary database.AutonomousDatabaseBackupSummary,
@@ -73,7 +73,7 @@ func FetchAutonomousDatabaseWithOCID(kubeClient client.Client, namespace string,
 	}
 
 	for _, adb := range adbList.Items {
-		if adb.Spec.Details.AutonomousDatabaseOCID != nil && *adb.Spec.Details.AutonomousDatabaseOCID == ocid {
+		if adb.GetAutonomousDatabaseOCID() != nil && *adb.GetAutonomousDatabaseOCID() == ocid {
 			return &adb, nil
 		}
 	}
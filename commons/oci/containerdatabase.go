@@ -41,6 +41,9 @@ package oci
 import (
 	"context"
 
+	"fmt"
+	"strings"
+
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 
@@ -50,7 +53,7 @@ import (
 /********************************
  * Autonomous Container Database
  *******************************/
-func (d *databaseService) CreateAutonomousContainerDatabase(acd *dbv1alpha1.AutonomousContainerDatabase) (database.CreateAutonomousContainerDatabaseResponse, error) {
+func (d *databaseService) CreateAutonomousContainerDatabase(ctx context.Context, acd *dbv1alpha1.AutonomousContainerDatabase) (database.CreateAutonomousContainerDatabaseResponse, error) {
 	createAutonomousContainerDatabaseRequest := database.CreateAutonomousContainerDatabaseRequest{
 		CreateAutonomousContainerDatabaseDetails: database.CreateAutonomousContainerDatabaseDetails{
 			CompartmentId:              acd.Spec.CompartmentOCID,
@@ -60,18 +63,115 @@ func (d *databaseService) CreateAutonomousContainerDatabase(acd *dbv1alpha1.Auto
 		},
 	}
 
-	return d.dbClient.CreateAutonomousContainerDatabase(context.TODO(), createAutonomousContainerDatabaseRequest)
+	return d.dbClient.CreateAutonomousContainerDatabase(ctx, createAutonomousContainerDatabaseRequest)
 }
 
-func (d *databaseService) GetAutonomousContainerDatabase(acdOCID string) (database.GetAutonomousContainerDatabaseResponse, error) {
+func (d *databaseService) GetAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.GetAutonomousContainerDatabaseResponse, error) {
 	getAutonomousContainerDatabaseRequest := database.GetAutonomousContainerDatabaseRequest{
 		AutonomousContainerDatabaseId: common.String(acdOCID),
 	}
 
-	return d.dbClient.GetAutonomousContainerDatabase(context.TODO(), getAutonomousContainerDatabaseRequest)
+	return d.dbClient.GetAutonomousContainerDatabase(ctx, getAutonomousContainerDatabaseRequest)
+}
+
+// GetContainerDatabasePlacement returns the OCIDs of the Autonomous VM Cluster and the Exadata infrastructure where
+// the Autonomous Container Database is placed. The VM cluster is empty on the legacy Autonomous Exadata Infrastructure.
+func (d *databaseService) GetContainerDatabasePlacement(ctx context.Context, acd database.AutonomousContainerDatabase) (vmClusterOCID string, infrastructureOCID string, err error) {
+	switch {
+	case acd.CloudAutonomousVmClusterId != nil:
+		resp, err := d.dbClient.GetCloudAutonomousVmCluster(ctx, database.GetCloudAutonomousVmClusterRequest{
+			CloudAutonomousVmClusterId: acd.CloudAutonomousVmClusterId,
+		})
+		if err != nil {
+			return "", "", err
+		}
+		return *acd.CloudAutonomousVmClusterId, *resp.CloudExadataInfrastructureId, nil
+	case acd.AutonomousVmClusterId != nil:
+		resp, err := d.dbClient.GetAutonomousVmCluster(ctx, database.GetAutonomousVmClusterRequest{
+			AutonomousVmClusterId: acd.AutonomousVmClusterId,
+		})
+		if err != nil {
+			return "", "", err
+		}
+		return *acd.AutonomousVmClusterId, *resp.ExadataInfrastructureId, nil
+	case acd.AutonomousExadataInfrastructureId != nil:
+		return "", *acd.AutonomousExadataInfrastructureId, nil
+	default:
+		return "", "", nil
+	}
+}
+
+// checkPlacement returns an error if the Autonomous Container Database is not on the VM cluster or the Exadata
+// infrastructure
+func (d *databaseService) checkPlacement(ctx context.Context, acdOCID string, vmClusterOCID *string, infrastructureOCID *string) error {
+	resp, err := d.GetAutonomousContainerDatabase(ctx, acdOCID)
+	if err != nil {
+		return err
+	}
+
+	acdVMClusterOCID, acdInfrastructureOCID, err := d.GetContainerDatabasePlacement(ctx, resp.AutonomousContainerDatabase)
+	if err != nil {
+		return err
+	}
+
+	return validatePlacement(vmClusterOCID, infrastructureOCID, acdVMClusterOCID, acdInfrastructureOCID)
+}
+
+// findContainerDatabase returns the OCID of the only AVAILABLE Autonomous Container Database on the VM cluster in
+// the compartment
+func (d *databaseService) findContainerDatabase(ctx context.Context, compartmentOCID string, vmClusterOCID string) (*string, error) {
+	listRequest := database.ListAutonomousContainerDatabasesRequest{
+		CompartmentId:  common.String(compartmentOCID),
+		LifecycleState: database.AutonomousContainerDatabaseSummaryLifecycleStateAvailable,
+	}
+	// The VM clusters on Exadata Cloud@Customer have another type of OCID than the ones in the cloud
+	if strings.HasPrefix(vmClusterOCID, "ocid1.autonomousvmcluster.") {
+		listRequest.AutonomousVmClusterId = common.String(vmClusterOCID)
+	} else {
+		listRequest.CloudAutonomousVmClusterId = common.String(vmClusterOCID)
+	}
+
+	resp, err := d.dbClient.ListAutonomousContainerDatabases(ctx, listRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectContainerDatabase(vmClusterOCID, resp.Items)
 }
 
-func (d *databaseService) UpdateAutonomousContainerDatabase(acdOCID string, difACD *dbv1alpha1.AutonomousContainerDatabase) (database.UpdateAutonomousContainerDatabaseResponse, error) {
+// selectContainerDatabase returns the OCID of the only Autonomous Container Database in the list, or an error if
+// there is none or more than one
+func selectContainerDatabase(vmClusterOCID string, acds []database.AutonomousContainerDatabaseSummary) (*string, error) {
+	switch len(acds) {
+	case 0:
+		return nil, fmt.Errorf("no AVAILABLE AutonomousContainerDatabase is on the VM cluster %s", vmClusterOCID)
+	case 1:
+		return acds[0].Id, nil
+	default:
+		var ocids []string
+		for _, acd := range acds {
+			ocids = append(ocids, *acd.Id)
+		}
+		return nil, fmt.Errorf("more than one AutonomousContainerDatabase is on the VM cluster %s; "+
+			"set the autonomousContainerDatabase to one of %s", vmClusterOCID, strings.Join(ocids, ", "))
+	}
+}
+
+// validatePlacement returns an error if the VM cluster or the Exadata infrastructure of the Autonomous Container
+// Database is different from the expected one
+func validatePlacement(vmClusterOCID *string, infrastructureOCID *string, acdVMClusterOCID string, acdInfrastructureOCID string) error {
+	if vmClusterOCID != nil && *vmClusterOCID != acdVMClusterOCID {
+		return fmt.Errorf("the autonomousVmClusterOCID %s is different from the VM cluster %s of the AutonomousContainerDatabase",
+			*vmClusterOCID, acdVMClusterOCID)
+	}
+	if infrastructureOCID != nil && *infrastructureOCID != acdInfrastructureOCID {
+		return fmt.Errorf("the cloudExadataInfrastructureOCID %s is different from the Exadata infrastructure %s of the AutonomousContainerDatabase",
+			*infrastructureOCID, acdInfrastructureOCID)
+	}
+	return nil
+}
+
+func (d *databaseService) UpdateAutonomousContainerDatabase(ctx context.Context, acdOCID string, difACD *dbv1alpha1.AutonomousContainerDatabase) (database.UpdateAutonomousContainerDatabaseResponse, error) {
 	updateAutonomousContainerDatabaseRequest := database.UpdateAutonomousContainerDatabaseRequest{
 		AutonomousContainerDatabaseId: common.String(acdOCID),
 		UpdateAutonomousContainerDatabaseDetails: database.UpdateAutonomousContainerDatabaseDetails{
@@ -81,21 +181,47 @@ func (d *databaseService) UpdateAutonomousContainerDatabase(acdOCID string, difA
 		},
 	}
 
-	return d.dbClient.UpdateAutonomousContainerDatabase(context.TODO(), updateAutonomousContainerDatabaseRequest)
+	return d.dbClient.UpdateAutonomousContainerDatabase(ctx, updateAutonomousContainerDatabaseRequest)
 }
 
-func (d *databaseService) RestartAutonomousContainerDatabase(acdOCID string) (database.RestartAutonomousContainerDatabaseResponse, error) {
+func (d *databaseService) RestartAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.RestartAutonomousContainerDatabaseResponse, error) {
 	restartRequest := database.RestartAutonomousContainerDatabaseRequest{
 		AutonomousContainerDatabaseId: common.String(acdOCID),
 	}
 
-	return d.dbClient.RestartAutonomousContainerDatabase(context.TODO(), restartRequest)
+	return d.dbClient.RestartAutonomousContainerDatabase(ctx, restartRequest)
 }
 
-func (d *databaseService) TerminateAutonomousContainerDatabase(acdOCID string) (database.TerminateAutonomousContainerDatabaseResponse, error) {
+func (d *databaseService) TerminateAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.TerminateAutonomousContainerDatabaseResponse, error) {
 	terminateRequest := database.TerminateAutonomousContainerDatabaseRequest{
 		AutonomousContainerDatabaseId: common.String(acdOCID),
 	}
 
-	return d.dbClient.TerminateAutonomousContainerDatabase(context.TODO(), terminateRequest)
+	return d.dbClient.TerminateAutonomousContainerDatabase(ctx, terminateRequest)
+}
+
+// ListAutonomousContainerDatabaseDataguardAssociations returns the Data Guard associations of the Autonomous
+// Container Database
+func (d *databaseService) ListAutonomousContainerDatabaseDataguardAssociations(ctx context.Context, acdOCID string) ([]database.AutonomousContainerDatabaseDataguardAssociation, error) {
+	resp, err := d.dbClient.ListAutonomousContainerDatabaseDataguardAssociations(ctx, database.ListAutonomousContainerDatabaseDataguardAssociationsRequest{
+		AutonomousContainerDatabaseId: common.String(acdOCID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// UpdateAutomaticFailover enables or disables the automatic failover of the Data Guard association of the
+// Autonomous Container Database, which applies to all the databases in the container
+func (d *databaseService) UpdateAutomaticFailover(ctx context.Context, acdOCID string, associationOCID string, enabled bool) (database.UpdateAutonomousContainerDatabaseDataguardAssociationResponse, error) {
+	updateRequest := database.UpdateAutonomousContainerDatabaseDataguardAssociationRequest{
+		AutonomousContainerDatabaseId:                     common.String(acdOCID),
+		AutonomousContainerDatabaseDataguardAssociationId: common.String(associationOCID),
+		UpdateAutonomousContainerDatabaseDataGuardAssociationDetails: database.UpdateAutonomousContainerDatabaseDataGuardAssociationDetails{
+			IsAutomaticFailoverEnabled: common.Bool(enabled),
+		},
+	}
+
+	return d.dbClient.UpdateAutonomousContainerDatabaseDataguardAssociation(ctx, updateRequest)
 }
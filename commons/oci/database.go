@@ -39,11 +39,22 @@
 package oci
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/core"
 	"github.com/oracle/oci-go-sdk/v64/database"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,49 +63,85 @@ import (
 )
 
 type DatabaseService interface {
-	CreateAutonomousDatabase(adb *dbv1alpha1.AutonomousDatabase) (database.CreateAutonomousDatabaseResponse, error)
-	GetAutonomousDatabase(adbOCID string) (database.GetAutonomousDatabaseResponse, error)
-	UpdateAutonomousDatabaseGeneralFields(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateAutonomousDatabaseDBWorkload(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateAutonomousDatabaseLicenseModel(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateAutonomousDatabaseAdminPassword(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateAutonomousDatabaseScalingFields(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateNetworkAccessMTLSRequired(adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateNetworkAccessMTLS(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateNetworkAccessPublic(lastAccessType dbv1alpha1.NetworkAccessTypeEnum, adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	UpdateNetworkAccess(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
-	StartAutonomousDatabase(adbOCID string) (database.StartAutonomousDatabaseResponse, error)
-	StopAutonomousDatabase(adbOCID string) (database.StopAutonomousDatabaseResponse, error)
-	DeleteAutonomousDatabase(adbOCID string) (database.DeleteAutonomousDatabaseResponse, error)
-	DownloadWallet(adb *dbv1alpha1.AutonomousDatabase) (database.GenerateAutonomousDatabaseWalletResponse, error)
-	RestoreAutonomousDatabase(adbOCID string, sdkTime common.SDKTime) (database.RestoreAutonomousDatabaseResponse, error)
-	ListAutonomousDatabaseBackups(adbOCID string) (database.ListAutonomousDatabaseBackupsResponse, error)
-	CreateAutonomousDatabaseBackup(adbBackup *dbv1alpha1.AutonomousDatabaseBackup, adbOCID string) (database.CreateAutonomousDatabaseBackupResponse, error)
-	GetAutonomousDatabaseBackup(backupOCID string) (database.GetAutonomousDatabaseBackupResponse, error)
-	CreateAutonomousContainerDatabase(acd *dbv1alpha1.AutonomousContainerDatabase) (database.CreateAutonomousContainerDatabaseResponse, error)
-	GetAutonomousContainerDatabase(acdOCID string) (database.GetAutonomousContainerDatabaseResponse, error)
-	UpdateAutonomousContainerDatabase(acdOCID string, difACD *dbv1alpha1.AutonomousContainerDatabase) (database.UpdateAutonomousContainerDatabaseResponse, error)
-	RestartAutonomousContainerDatabase(acdOCID string) (database.RestartAutonomousContainerDatabaseResponse, error)
-	TerminateAutonomousContainerDatabase(acdOCID string) (database.TerminateAutonomousContainerDatabaseResponse, error)
+	CreateAutonomousDatabase(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (database.CreateAutonomousDatabaseResponse, error)
+	GetAutonomousDatabase(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseResponse, error)
+	ListAutonomousDatabasesByDisplayName(ctx context.Context, compartmentOCID string, displayName string) (database.ListAutonomousDatabasesResponse, error)
+	ListAutonomousDatabases(ctx context.Context, compartmentOCID string) ([]database.AutonomousDatabaseSummary, error)
+	UpdateAutonomousDatabaseGeneralFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseDBWorkload(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseLicenseModel(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseAdminPassword(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseScalingFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseAutoScalingFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseBackupRetention(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	ChangeDisasterRecoveryConfiguration(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	CreateDisasterRecoveryPeer(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase, peer dbv1alpha1.DisasterRecoveryPeerSpec) (resp database.CreateAutonomousDatabaseResponse, err error)
+	GetAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.GetAutonomousDatabaseResponse, error)
+	DeleteAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.DeleteAutonomousDatabaseResponse, error)
+	UpdateNetworkAccessMTLSRequired(ctx context.Context, adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateNetworkAccessMTLS(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateNetworkAccessPublic(ctx context.Context, lastAccessType dbv1alpha1.NetworkAccessTypeEnum, adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateNetworkAccess(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	StartAutonomousDatabase(ctx context.Context, adbOCID string) (database.StartAutonomousDatabaseResponse, error)
+	StopAutonomousDatabase(ctx context.Context, adbOCID string) (database.StopAutonomousDatabaseResponse, error)
+	DeleteAutonomousDatabase(ctx context.Context, adbOCID string) (database.DeleteAutonomousDatabaseResponse, error)
+	DownloadWallet(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (database.GenerateAutonomousDatabaseWalletResponse, error)
+	RotateWallet(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseWalletResponse, error)
+	GetWallet(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseWalletResponse, error)
+	RotateEncryptionKey(ctx context.Context, adbOCID string) (database.RotateAutonomousDatabaseEncryptionKeyResponse, error)
+	ShrinkAutonomousDatabase(ctx context.Context, adbOCID string) (database.ShrinkAutonomousDatabaseResponse, error)
+	DetachRefreshableClone(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseResponse, error)
+	EnableDatabaseManagement(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseManagementResponse, error)
+	DisableDatabaseManagement(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseManagementResponse, error)
+	EnableOperationsInsights(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseOperationsInsightsResponse, error)
+	DisableOperationsInsights(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseOperationsInsightsResponse, error)
+	RestoreAutonomousDatabase(ctx context.Context, adbOCID string, sdkTime common.SDKTime) (database.RestoreAutonomousDatabaseResponse, error)
+	ListAutonomousDatabaseBackups(ctx context.Context, adbOCID string) (database.ListAutonomousDatabaseBackupsResponse, error)
+	CreateAutonomousDatabaseBackup(ctx context.Context, adbBackup *dbv1alpha1.AutonomousDatabaseBackup, adbOCID string) (database.CreateAutonomousDatabaseBackupResponse, error)
+	GetAutonomousDatabaseBackup(ctx context.Context, backupOCID string) (database.GetAutonomousDatabaseBackupResponse, error)
+	DeleteAutonomousDatabaseBackup(ctx context.Context, backupOCID string) error
+	CopyAutonomousDatabaseBackup(ctx context.Context, adbBackup *dbv1alpha1.AutonomousDatabaseBackup) (database.CreateAutonomousDatabaseBackupResponse, error)
+	GetAutonomousDatabaseBackupInRegion(ctx context.Context, backupOCID string, region string) (database.GetAutonomousDatabaseBackupResponse, error)
+	CreateAutonomousContainerDatabase(ctx context.Context, acd *dbv1alpha1.AutonomousContainerDatabase) (database.CreateAutonomousContainerDatabaseResponse, error)
+	GetAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.GetAutonomousContainerDatabaseResponse, error)
+	GetContainerDatabasePlacement(ctx context.Context, acd database.AutonomousContainerDatabase) (vmClusterOCID string, infrastructureOCID string, err error)
+	UpdateAutonomousContainerDatabase(ctx context.Context, acdOCID string, difACD *dbv1alpha1.AutonomousContainerDatabase) (database.UpdateAutonomousContainerDatabaseResponse, error)
+	RestartAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.RestartAutonomousContainerDatabaseResponse, error)
+	TerminateAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.TerminateAutonomousContainerDatabaseResponse, error)
+	ListAutonomousContainerDatabaseDataguardAssociations(ctx context.Context, acdOCID string) ([]database.AutonomousContainerDatabaseDataguardAssociation, error)
+	UpdateAutomaticFailover(ctx context.Context, acdOCID string, associationOCID string, enabled bool) (database.UpdateAutonomousContainerDatabaseDataguardAssociationResponse, error)
 }
 
 type databaseService struct {
 	logger       logr.Logger
 	kubeClient   client.Client
+	provider     common.ConfigurationProvider
 	dbClient     database.DatabaseClient
 	vaultService VaultService
 }
 
+// NewDatabaseService returns a DatabaseService. The requests are sent to the endpointOverride instead of the
+// default endpoint of the region if it's not nil, which must be an https URL. The endpointOverride only applies to
+// the dbClient; the regional clients and the network client use their default endpoints.
 func NewDatabaseService(
 	logger logr.Logger,
 	kubeClient client.Client,
-	provider common.ConfigurationProvider) (DatabaseService, error) {
+	provider common.ConfigurationProvider,
+	endpointOverride *string) (DatabaseService, error) {
 
 	dbClient, err := database.NewDatabaseClientWithConfigurationProvider(provider)
 	if err != nil {
 		return nil, err
 	}
 
+	if endpointOverride != nil {
+		if err := checkEndpointOverride(*endpointOverride); err != nil {
+			return nil, err
+		}
+		dbClient.Host = *endpointOverride
+	}
+	logRequests(logger, &dbClient.BaseClient)
+
 	vaultService, err := NewVaultService(logger, provider)
 	if err != nil {
 		return nil, err
@@ -103,23 +150,38 @@ func NewDatabaseService(
 	return &databaseService{
 		logger:       logger.WithName("dbService"),
 		kubeClient:   kubeClient,
+		provider:     provider,
 		dbClient:     dbClient,
 		vaultService: vaultService,
 	}, nil
 }
 
+// checkEndpointOverride returns an error if the endpoint is not an https URL with the host. The webhook validates the
+// endpoint too, but it's checked again since the requests carry the signed credentials of the OCI config.
+func checkEndpointOverride(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("the endpointOverride %q is not an https URL with the host", endpoint)
+	}
+	return nil
+}
+
 /********************************
  * Autonomous Database
  *******************************/
 
 // ReadPassword reads the password from passwordSpec, and returns the pointer to the read password string.
 // The function returns a nil if nothing is read
-func (d *databaseService) readPassword(namespace string, passwordSpec dbv1alpha1.PasswordSpec) (*string, error) {
+func (d *databaseService) readPassword(ctx context.Context, namespace string, passwordSpec dbv1alpha1.PasswordSpec) (*string, error) {
 	logger := d.logger.WithName("readPassword")
 
 	if passwordSpec.K8sSecret.Name != nil {
 		logger.Info(fmt.Sprintf("Getting password from Secret %s", *passwordSpec.K8sSecret.Name))
 
+		if err := checkLocalReference("Secret", *passwordSpec.K8sSecret.Name); err != nil {
+			return nil, err
+		}
+
 		key := *passwordSpec.K8sSecret.Name
 		password, err := k8s.GetSecretValue(d.kubeClient, namespace, *passwordSpec.K8sSecret.Name, key)
 		if err != nil {
@@ -132,7 +194,17 @@ func (d *databaseService) readPassword(namespace string, passwordSpec dbv1alpha1
 	if passwordSpec.OCISecret.OCID != nil {
 		logger.Info(fmt.Sprintf("Getting password from OCI Vault Secret OCID %s", *passwordSpec.OCISecret.OCID))
 
-		password, err := d.vaultService.GetSecretValue(*passwordSpec.OCISecret.OCID)
+		password, err := d.vaultService.GetSecretValue(ctx, *passwordSpec.OCISecret.OCID)
+		if err != nil {
+			return nil, err
+		}
+		return common.String(password), nil
+	}
+
+	if passwordSpec.VolumePath != nil {
+		logger.Info(fmt.Sprintf("Getting password from file %s", *passwordSpec.VolumePath))
+
+		password, err := ReadPasswordFile(*passwordSpec.VolumePath)
 		if err != nil {
 			return nil, err
 		}
@@ -142,6 +214,75 @@ func (d *databaseService) readPassword(namespace string, passwordSpec dbv1alpha1
 	return nil, nil
 }
 
+// DefaultPasswordVolumeRoot is the directory where the volumes of the password files are mounted by default
+const DefaultPasswordVolumeRoot = "/etc/adb-secrets"
+
+// PasswordVolumeRoot is the directory under which the password files are read. The files outside of the directory,
+// e.g. the token of the ServiceAccount of the operator, are never read, since the path is given by the resource.
+var PasswordVolumeRoot = DefaultPasswordVolumeRoot
+
+// resolvePasswordPath returns the path of the password file after the symbolic links are resolved. An error is returned
+// if the path is not absolute, has a parent directory reference, or is not under the root.
+func resolvePasswordPath(root string, path string) (string, error) {
+	if root == "" {
+		return "", errors.New("password files are not allowed since no password volume root is configured")
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("password file %s must be an absolute path", path)
+	}
+
+	for _, elem := range strings.Split(filepath.ToSlash(path), "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("password file %s must not contain ..", path)
+		}
+	}
+
+	// The volumes mounted from a Secret or a projected volume are symbolic links, which have to stay under the root
+	resolvedRoot, err := filepath.EvalSymlinks(filepath.Clean(root))
+	if err != nil {
+		return "", fmt.Errorf("password volume root %s is not readable: %w", root, err)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("password file %s not found", path)
+		}
+		return "", err
+	}
+
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("password file %s is not under the password volume root %s", path, root)
+	}
+
+	return resolved, nil
+}
+
+// ReadPasswordFile reads the password from the file in the path, which has to be under the PasswordVolumeRoot. The
+// trailing newline is removed. An error is returned if the file doesn't exist or the password is empty.
+func ReadPasswordFile(path string) (string, error) {
+	resolved, err := resolvePasswordPath(PasswordVolumeRoot, path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(resolved)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("password file %s not found", path)
+		}
+		return "", err
+	}
+
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", path)
+	}
+
+	return password, nil
+}
+
 func (d *databaseService) readACD_OCID(acd *dbv1alpha1.ACDSpec, namespace string) (*string, error) {
 	if acd.OCIACD.OCID != nil {
 		return acd.OCIACD.OCID, nil
@@ -159,9 +300,53 @@ func (d *databaseService) readACD_OCID(acd *dbv1alpha1.ACDSpec, namespace string
 	return nil, nil
 }
 
+// createAutonomousDatabaseDetails builds the details of the create request. A dedicated database is provisioned in
+// the Autonomous Container Database, so the fields which only apply to a serverless database are left out, and vice
+// versa.
+func createAutonomousDatabaseDetails(adb *dbv1alpha1.AutonomousDatabase, adminPassword *string, acdOCID *string) database.CreateAutonomousDatabaseDetails {
+	details := database.CreateAutonomousDatabaseDetails{
+		CompartmentId:                  adb.Spec.Details.CompartmentOCID,
+		DbName:                         adb.Spec.Details.DbName,
+		CpuCoreCount:                   adb.Spec.Details.CPUCoreCount,
+		DataStorageSizeInTBs:           adb.Spec.Details.DataStorageSizeInTBs,
+		DataStorageSizeInGBs:           adb.Spec.Details.DataStorageSizeInGBs,
+		AdminPassword:                  adminPassword,
+		DisplayName:                    adb.Spec.Details.DisplayName,
+		IsAutoScalingEnabled:           adb.Spec.Details.IsAutoScalingEnabled,
+		IsAutoScalingForStorageEnabled: adb.Spec.Details.IsAutoScalingForStorageEnabled,
+		IsFreeTier:                     adb.Spec.Details.IsFreeTier,
+		DbVersion:                      adb.Spec.Details.DbVersion,
+
+		IsPreviewVersionWithServiceTermsAccepted: adb.Spec.Details.AllowPreviewVersions,
+		DbWorkload: database.CreateAutonomousDatabaseBaseDbWorkloadEnum(
+			adb.Spec.Details.DbWorkload),
+		LicenseModel:   database.CreateAutonomousDatabaseBaseLicenseModelEnum(adb.Spec.Details.LicenseModel),
+		WhitelistedIps: adb.Spec.Details.NetworkAccess.AccessControlList,
+
+		IsAccessControlEnabled: adb.Spec.Details.NetworkAccess.IsAccessControlEnabled,
+
+		FreeformTags:     adb.Spec.Details.FreeformTags,
+		DefinedTags:      definedTags(adb.Spec.Details.DefinedTags),
+		CustomerContacts: customerContacts(adb.Spec.Details.CustomerContacts),
+	}
+
+	if acdOCID != nil { // Dedicated database
+		details.IsDedicated = common.Bool(true)
+		details.AutonomousContainerDatabaseId = acdOCID
+	} else { // Serverless database
+		details.IsDedicated = common.Bool(false)
+		details.IsMtlsConnectionRequired = adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired
+		details.SubnetId = adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID
+		details.NsgIds = adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs
+		details.PrivateEndpointLabel = adb.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix
+	}
+
+	return details
+}
+
 // CreateAutonomousDatabase sends a request to OCI to provision a database and returns the AutonomousDatabase OCID.
-func (d *databaseService) CreateAutonomousDatabase(adb *dbv1alpha1.AutonomousDatabase) (resp database.CreateAutonomousDatabaseResponse, err error) {
-	adminPassword, err := d.readPassword(adb.Namespace, adb.Spec.Details.AdminPassword)
+func (d *databaseService) CreateAutonomousDatabase(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (resp database.CreateAutonomousDatabaseResponse, err error) {
+	adminPassword, err := d.readPassword(ctx, adb.Namespace, adb.Spec.Details.AdminPassword)
 	if err != nil {
 		return resp, err
 	}
@@ -171,35 +356,71 @@ func (d *databaseService) CreateAutonomousDatabase(adb *dbv1alpha1.AutonomousDat
 		return resp, err
 	}
 
-	createAutonomousDatabaseDetails := database.CreateAutonomousDatabaseDetails{
-		CompartmentId:                 adb.Spec.Details.CompartmentOCID,
-		DbName:                        adb.Spec.Details.DbName,
-		CpuCoreCount:                  adb.Spec.Details.CPUCoreCount,
-		DataStorageSizeInTBs:          adb.Spec.Details.DataStorageSizeInTBs,
-		AdminPassword:                 adminPassword,
-		DisplayName:                   adb.Spec.Details.DisplayName,
-		IsAutoScalingEnabled:          adb.Spec.Details.IsAutoScalingEnabled,
-		IsDedicated:                   adb.Spec.Details.IsDedicated,
-		AutonomousContainerDatabaseId: acdOCID,
-		DbVersion:                     adb.Spec.Details.DbVersion,
-		DbWorkload: database.CreateAutonomousDatabaseBaseDbWorkloadEnum(
-			adb.Spec.Details.DbWorkload),
-		LicenseModel:             database.CreateAutonomousDatabaseBaseLicenseModelEnum(adb.Spec.Details.LicenseModel),
-		IsAccessControlEnabled:   adb.Spec.Details.NetworkAccess.IsAccessControlEnabled,
-		WhitelistedIps:           adb.Spec.Details.NetworkAccess.AccessControlList,
-		IsMtlsConnectionRequired: adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired,
-		SubnetId:                 adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID,
-		NsgIds:                   adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs,
-		PrivateEndpointLabel:     adb.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix,
+	// A dedicated database is placed on the VM cluster through an Autonomous Container Database on it
+	vmClusterOCID := adb.Spec.Details.AutonomousVMClusterOCID
+	infrastructureOCID := adb.Spec.Details.CloudExadataInfrastructureOCID
+	if acdOCID == nil && vmClusterOCID != nil && adb.Spec.Details.CompartmentOCID != nil {
+		acdOCID, err = d.findContainerDatabase(ctx, *adb.Spec.Details.CompartmentOCID, *vmClusterOCID)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	// The AutonomousContainerDatabase in the cluster might not be provisioned yet
+	dedicated := adb.Spec.Details.IsDedicated != nil && *adb.Spec.Details.IsDedicated
+	if acdOCID == nil && (dedicated || adb.Spec.Details.AutonomousContainerDatabase.K8sACD.Name != nil) {
+		return resp, errors.New("the OCID of the AutonomousContainerDatabase is required to provision a dedicated database")
+	}
+
+	if acdOCID != nil && (vmClusterOCID != nil || infrastructureOCID != nil) {
+		if err := d.checkPlacement(ctx, *acdOCID, vmClusterOCID, infrastructureOCID); err != nil {
+			return resp, err
+		}
+	}
+
+	// A dedicated database is placed in the availability domain of the Autonomous Container Database
+	if acdOCID != nil && adb.Spec.Details.AvailabilityDomain != nil {
+		if err := d.checkAvailabilityDomain(ctx, *acdOCID, *adb.Spec.Details.AvailabilityDomain); err != nil {
+			return resp, err
+		}
+	}
+
+	// The preview versions are only available to a serverless database
+	if acdOCID == nil && adb.Spec.Details.DbVersion != nil {
+		if err := d.checkDbVersion(ctx, adb); err != nil {
+			return resp, err
+		}
+	}
 
-		FreeformTags: adb.Spec.Details.FreeformTags,
+	details := createAutonomousDatabaseDetails(adb, adminPassword, acdOCID)
+
+	// The attributes which are missing from the SDK details are added to the body of the request
+	extraDetails := map[string]interface{}{}
+
+	// The privateEndpointIp only applies to the private endpoint of a serverless database
+	privateEndpointIP := adb.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP
+	if acdOCID == nil && privateEndpointIP != nil {
+		if err := d.checkPrivateEndpointIP(ctx, details, *privateEndpointIP); err != nil {
+			return resp, err
+		}
+		extraDetails["privateEndpointIp"] = *privateEndpointIP
+	}
+
+	// The admin user can only be named on a dedicated database. OCI uses ADMIN if it's left out.
+	adminUsername := adb.Spec.Details.AdminUsername
+	if acdOCID != nil && adminUsername != nil && !strings.EqualFold(*adminUsername, dbv1alpha1.DefaultAdminUsername) {
+		extraDetails["adminUsername"] = *adminUsername
+	}
+
+	if len(extraDetails) > 0 {
+		return d.createAutonomousDatabaseWithExtraDetails(ctx, details, extraDetails)
 	}
 
 	createAutonomousDatabaseRequest := database.CreateAutonomousDatabaseRequest{
-		CreateAutonomousDatabaseDetails: createAutonomousDatabaseDetails,
+		CreateAutonomousDatabaseDetails: details,
 	}
 
-	resp, err = d.dbClient.CreateAutonomousDatabase(context.TODO(), createAutonomousDatabaseRequest)
+	resp, err = d.dbClient.CreateAutonomousDatabase(ctx, createAutonomousDatabaseRequest)
 	if err != nil {
 		return resp, err
 	}
@@ -207,49 +428,295 @@ func (d *databaseService) CreateAutonomousDatabase(adb *dbv1alpha1.AutonomousDat
 	return resp, nil
 }
 
-func (d *databaseService) GetAutonomousDatabase(adbOCID string) (database.GetAutonomousDatabaseResponse, error) {
+// createAutonomousDatabaseRequest provisions a database with the attributes which are missing from the
+// CreateAutonomousDatabaseDetails of the SDK. The body is the JSON of the SDK details with the extra attributes.
+type createAutonomousDatabaseRequest struct {
+	Details map[string]interface{} `contributesTo:"body"`
+}
+
+// createAutonomousDatabaseBody returns the JSON object of the details with the extra attributes
+func createAutonomousDatabaseBody(details database.CreateAutonomousDatabaseDetails, extraDetails map[string]interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+
+	// The SDK leaves out the unset attributes
+	for key, value := range body {
+		if value == nil {
+			delete(body, key)
+		}
+	}
+
+	for key, value := range extraDetails {
+		body[key] = value
+	}
+	return body, nil
+}
+
+// checkPrivateEndpointIP checks the IP is in the CIDR of the subnet before the database is provisioned,
+// since OCI only reports the error after the work request fails.
+func (d *databaseService) checkPrivateEndpointIP(ctx context.Context, details database.CreateAutonomousDatabaseDetails, privateEndpointIP string) error {
+	if details.SubnetId == nil {
+		return nil
+	}
+
+	cidr, err := d.getSubnetCIDR(ctx, *details.SubnetId)
+	if err != nil {
+		return err
+	}
+
+	return validatePrivateEndpointIP(privateEndpointIP, cidr)
+}
+
+// checkAvailabilityDomain returns an error if the Autonomous Container Database is not in the availability domain
+func (d *databaseService) checkAvailabilityDomain(ctx context.Context, acdOCID string, availabilityDomain string) error {
+	resp, err := d.GetAutonomousContainerDatabase(ctx, acdOCID)
+	if err != nil {
+		return err
+	}
+
+	return validateAvailabilityDomain(availabilityDomain, resp.AutonomousContainerDatabase.AvailabilityDomain)
+}
+
+// checkDbVersion returns an error if the dbVersion is a preview version and the preview versions are not allowed
+func (d *databaseService) checkDbVersion(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) error {
+	listRequest := database.ListAutonomousDbVersionsRequest{
+		CompartmentId: adb.Spec.Details.CompartmentOCID,
+		DbWorkload:    database.AutonomousDatabaseSummaryDbWorkloadEnum(adb.Spec.Details.DbWorkload),
+	}
+
+	resp, err := d.dbClient.ListAutonomousDbVersions(ctx, listRequest)
+	if err != nil {
+		return err
+	}
+
+	allowPreview := adb.Spec.Details.AllowPreviewVersions != nil && *adb.Spec.Details.AllowPreviewVersions
+	return validateDbVersion(*adb.Spec.Details.DbVersion, allowPreview, resp.Items)
+}
+
+// createAutonomousDatabaseWithExtraDetails sends the create request with the attributes which are missing from the SDK
+func (d *databaseService) createAutonomousDatabaseWithExtraDetails(
+	ctx context.Context,
+	details database.CreateAutonomousDatabaseDetails,
+	extraDetails map[string]interface{}) (resp database.CreateAutonomousDatabaseResponse, err error) {
+
+	body, err := createAutonomousDatabaseBody(details, extraDetails)
+	if err != nil {
+		return resp, err
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases", createAutonomousDatabaseRequest{Details: body})
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := d.dbClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
+}
+
+// getSubnetCIDR returns the IPv4 CIDR block of the subnet. The request is sent to the default endpoint of the
+// network service, since the endpointOverride is the endpoint of the database service.
+func (d *databaseService) getSubnetCIDR(ctx context.Context, subnetOCID string) (string, error) {
+	nwClient, err := core.NewVirtualNetworkClientWithConfigurationProvider(d.provider)
+	if err != nil {
+		return "", err
+	}
+	logRequests(d.logger, &nwClient.BaseClient)
+
+	resp, err := nwClient.GetSubnet(ctx, core.GetSubnetRequest{
+		SubnetId: common.String(subnetOCID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if resp.CidrBlock == nil {
+		return "", fmt.Errorf("the subnet %s has no CIDR block", subnetOCID)
+	}
+	return *resp.CidrBlock, nil
+}
+
+// validatePrivateEndpointIP returns an error if the IP is not in the CIDR
+func validatePrivateEndpointIP(ip string, cidr string) error {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	if !ipNet.Contains(net.ParseIP(ip)) {
+		return fmt.Errorf("the privateEndpointIP %s is not in the CIDR %s of the subnet", ip, cidr)
+	}
+	return nil
+}
+
+// validateAvailabilityDomain returns an error if the availability domain of the Autonomous Container Database is
+// known and different from the expected one
+func validateAvailabilityDomain(availabilityDomain string, acdAvailabilityDomain *string) error {
+	if acdAvailabilityDomain == nil || strings.EqualFold(availabilityDomain, *acdAvailabilityDomain) {
+		return nil
+	}
+	return fmt.Errorf("the availabilityDomain %s is different from the availability domain %s of the AutonomousContainerDatabase",
+		availabilityDomain, *acdAvailabilityDomain)
+}
+
+// validateDbVersion returns an error if the dbVersion is a preview version and the preview versions are not allowed.
+// OCI only describes a preview version in the details of the version, so the version is a preview version if the
+// details mention it. A version which is not in the list is left to OCI to validate.
+func validateDbVersion(dbVersion string, allowPreview bool, versions []database.AutonomousDbVersionSummary) error {
+	if allowPreview {
+		return nil
+	}
+
+	for _, version := range versions {
+		if version.Version == nil || *version.Version != dbVersion {
+			continue
+		}
+		if version.Details != nil && strings.Contains(strings.ToLower(*version.Details), "preview") {
+			return fmt.Errorf("the dbVersion %s is a preview version; set allowPreviewVersions to provision a preview version",
+				dbVersion)
+		}
+	}
+	return nil
+}
+
+func (d *databaseService) GetAutonomousDatabase(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseResponse, error) {
 	getAutonomousDatabaseRequest := database.GetAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 	}
 
-	return d.dbClient.GetAutonomousDatabase(context.TODO(), getAutonomousDatabaseRequest)
+	return d.dbClient.GetAutonomousDatabase(ctx, getAutonomousDatabaseRequest)
+}
+
+// ListAutonomousDatabasesByDisplayName lists the databases with the display name in the compartment.
+// OCI allows duplicate display names, so more than one database can be returned. The items of all the pages are
+// returned in a single response.
+func (d *databaseService) ListAutonomousDatabasesByDisplayName(ctx context.Context, compartmentOCID string, displayName string) (database.ListAutonomousDatabasesResponse, error) {
+	items, err := listAllAutonomousDatabases(func(page *string) (database.ListAutonomousDatabasesResponse, error) {
+		return d.dbClient.ListAutonomousDatabases(ctx, database.ListAutonomousDatabasesRequest{
+			CompartmentId: common.String(compartmentOCID),
+			DisplayName:   common.String(displayName),
+			Page:          page,
+		})
+	})
+	if err != nil {
+		return database.ListAutonomousDatabasesResponse{}, err
+	}
+
+	return database.ListAutonomousDatabasesResponse{Items: items}, nil
+}
+
+// ListAutonomousDatabases lists all the databases in the compartment
+func (d *databaseService) ListAutonomousDatabases(ctx context.Context, compartmentOCID string) ([]database.AutonomousDatabaseSummary, error) {
+	return listAllAutonomousDatabases(func(page *string) (database.ListAutonomousDatabasesResponse, error) {
+		return d.dbClient.ListAutonomousDatabases(ctx, database.ListAutonomousDatabasesRequest{
+			CompartmentId: common.String(compartmentOCID),
+			Page:          page,
+		})
+	})
+}
+
+// listAllAutonomousDatabases calls listPage with the OpcNextPage of the previous page until the last page is read
+func listAllAutonomousDatabases(listPage func(page *string) (database.ListAutonomousDatabasesResponse, error)) ([]database.AutonomousDatabaseSummary, error) {
+	var items []database.AutonomousDatabaseSummary
+	var page *string
+
+	for {
+		resp, err := listPage(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			return items, nil
+		}
+		page = resp.OpcNextPage
+	}
 }
 
-func (d *databaseService) UpdateAutonomousDatabaseGeneralFields(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateAutonomousDatabaseGeneralFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
-			DisplayName:  difADB.Spec.Details.DisplayName,
-			DbName:       difADB.Spec.Details.DbName,
-			DbVersion:    difADB.Spec.Details.DbVersion,
-			FreeformTags: difADB.Spec.Details.FreeformTags,
+			DisplayName:      difADB.Spec.Details.DisplayName,
+			DbName:           difADB.Spec.Details.DbName,
+			DbVersion:        difADB.Spec.Details.DbVersion,
+			FreeformTags:     difADB.Spec.Details.FreeformTags,
+			DefinedTags:      definedTags(difADB.Spec.Details.DefinedTags),
+			CustomerContacts: customerContacts(difADB.Spec.Details.CustomerContacts),
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
+}
+
+// definedTags converts the defined tags to the type of the requests. Returns nil if the map is nil, so that the tags
+// are left as is.
+func definedTags(tags map[string]map[string]string) map[string]map[string]interface{} {
+	if tags == nil {
+		return nil
+	}
+
+	converted := make(map[string]map[string]interface{}, len(tags))
+	for namespace, namespaceTags := range tags {
+		converted[namespace] = make(map[string]interface{}, len(namespaceTags))
+		for key, val := range namespaceTags {
+			converted[namespace][key] = val
+		}
+	}
+	return converted
+}
+
+// customerContacts converts the email addresses to the CustomerContacts of the requests. Returns nil if the list is
+// nil, so that the contacts are left as is. An empty list removes all the contacts.
+func customerContacts(emails []string) []database.CustomerContact {
+	if emails == nil {
+		return nil
+	}
+
+	contacts := make([]database.CustomerContact, len(emails))
+	for i, email := range emails {
+		contacts[i] = database.CustomerContact{Email: common.String(email)}
+	}
+	return contacts
 }
 
-func (d *databaseService) UpdateAutonomousDatabaseDBWorkload(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateAutonomousDatabaseDBWorkload(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
 			DbWorkload: database.UpdateAutonomousDatabaseDetailsDbWorkloadEnum(difADB.Spec.Details.DbWorkload),
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) UpdateAutonomousDatabaseLicenseModel(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateAutonomousDatabaseLicenseModel(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
 			LicenseModel: database.UpdateAutonomousDatabaseDetailsLicenseModelEnum(difADB.Spec.Details.LicenseModel),
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) UpdateAutonomousDatabaseAdminPassword(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
-	adminPassword, err := d.readPassword(difADB.Namespace, difADB.Spec.Details.AdminPassword)
+func (d *databaseService) UpdateAutonomousDatabaseAdminPassword(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+	adminPassword, err := d.readPassword(ctx, difADB.Namespace, difADB.Spec.Details.AdminPassword)
 	if err != nil {
 		return resp, err
 	}
@@ -260,42 +727,177 @@ func (d *databaseService) UpdateAutonomousDatabaseAdminPassword(adbOCID string,
 			AdminPassword: adminPassword,
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) UpdateAutonomousDatabaseScalingFields(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateAutonomousDatabaseScalingFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
 			DataStorageSizeInTBs: difADB.Spec.Details.DataStorageSizeInTBs,
+			DataStorageSizeInGBs: difADB.Spec.Details.DataStorageSizeInGBs,
 			CpuCoreCount:         difADB.Spec.Details.CPUCoreCount,
-			IsAutoScalingEnabled: difADB.Spec.Details.IsAutoScalingEnabled,
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
+}
+
+// UpdateAutonomousDatabaseAutoScalingFields toggles the CPU and storage auto scaling without touching the CPU or storage size
+func (d *databaseService) UpdateAutonomousDatabaseAutoScalingFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
+			IsAutoScalingEnabled:           difADB.Spec.Details.IsAutoScalingEnabled,
+			IsAutoScalingForStorageEnabled: difADB.Spec.Details.IsAutoScalingForStorageEnabled,
+		},
+	}
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
+}
+
+// updateBackupRetentionRequest is an UpdateAutonomousDatabase request with the backupRetentionPeriodInDays,
+// which is missing from the database.UpdateAutonomousDatabaseDetails of the SDK.
+type updateBackupRetentionRequest struct {
+	AutonomousDatabaseId *string                      `mandatory:"true" contributesTo:"path" name:"autonomousDatabaseId"`
+	Details              updateBackupRetentionDetails `contributesTo:"body"`
+}
+
+type updateBackupRetentionDetails struct {
+	BackupRetentionPeriodInDays *int `mandatory:"false" json:"backupRetentionPeriodInDays"`
+}
+
+// UpdateAutonomousDatabaseBackupRetention sets the retention period of the automatic backups
+func (d *databaseService) UpdateAutonomousDatabaseBackupRetention(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+	request := updateBackupRetentionRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		Details: updateBackupRetentionDetails{
+			BackupRetentionPeriodInDays: difADB.Spec.Details.BackupRetentionPeriodInDays,
+		},
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPut, "/autonomousDatabases/{autonomousDatabaseId}", request)
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := d.dbClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
 }
 
-func (d *databaseService) UpdateNetworkAccessMTLSRequired(adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+// changeDisasterRecoveryRequest changes the type of the local disaster recovery. The operation is missing from
+// the SDK.
+type changeDisasterRecoveryRequest struct {
+	AutonomousDatabaseId *string                       `mandatory:"true" contributesTo:"path" name:"autonomousDatabaseId"`
+	Details              changeDisasterRecoveryDetails `contributesTo:"body"`
+}
+
+type changeDisasterRecoveryDetails struct {
+	DisasterRecoveryType dbv1alpha1.DisasterRecoveryTypeEnum `mandatory:"true" json:"disasterRecoveryType"`
+}
+
+// ChangeDisasterRecoveryConfiguration sets the type of the local disaster recovery
+func (d *databaseService) ChangeDisasterRecoveryConfiguration(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+	request := changeDisasterRecoveryRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		Details: changeDisasterRecoveryDetails{
+			DisasterRecoveryType: difADB.Spec.Details.DisasterRecoveryType,
+		},
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases/{autonomousDatabaseId}/actions/changeDisasterRecoveryConfiguration", request)
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := d.dbClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
+}
+
+// createDisasterRecoveryPeerRequest creates a cross-region disaster recovery peer of the source database. The
+// request is sent to the region of the peer. The remoteDisasterRecoveryType is missing from the SDK.
+type createDisasterRecoveryPeerRequest struct {
+	Details createDisasterRecoveryPeerDetails `contributesTo:"body"`
+}
+
+type createDisasterRecoveryPeerDetails struct {
+	Source                     string                              `mandatory:"true" json:"source"`
+	SourceId                   *string                             `mandatory:"true" json:"sourceId"`
+	CompartmentId              *string                             `mandatory:"true" json:"compartmentId"`
+	RemoteDisasterRecoveryType dbv1alpha1.DisasterRecoveryTypeEnum `mandatory:"true" json:"remoteDisasterRecoveryType"`
+}
+
+// CreateDisasterRecoveryPeer creates the peer in the region of the peer, e.g. spec.details.disasterRecoveryPeers[].region.
+// The peer is created in the compartment of the source database.
+func (d *databaseService) CreateDisasterRecoveryPeer(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase, peer dbv1alpha1.DisasterRecoveryPeerSpec) (resp database.CreateAutonomousDatabaseResponse, err error) {
+	if peer.Region == nil {
+		return resp, errors.New("the region of the disaster recovery peer is empty")
+	}
+
+	request := createDisasterRecoveryPeerRequest{
+		Details: createDisasterRecoveryPeerDetails{
+			Source:                     "CROSS_REGION_DISASTER_RECOVERY",
+			SourceId:                   adb.GetAutonomousDatabaseOCID(),
+			CompartmentId:              common.String(adb.Status.CompartmentOCID),
+			RemoteDisasterRecoveryType: peer.DisasterRecoveryType,
+		},
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases", request)
+	if err != nil {
+		return resp, err
+	}
+
+	regionalClient, err := d.getRegionalDBClient(*peer.Region)
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := regionalClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
+}
+
+func (d *databaseService) UpdateNetworkAccessMTLSRequired(ctx context.Context, adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
 			IsMtlsConnectionRequired: common.Bool(true),
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) UpdateNetworkAccessMTLS(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateNetworkAccessMTLS(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
 			IsMtlsConnectionRequired: difADB.Spec.Details.NetworkAccess.IsMTLSConnectionRequired,
 		},
 	}
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
 func (d *databaseService) UpdateNetworkAccessPublic(
+	ctx context.Context,
 	lastAccessType dbv1alpha1.NetworkAccessTypeEnum,
 	adbOCID string) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 
@@ -312,10 +914,10 @@ func (d *databaseService) UpdateNetworkAccessPublic(
 		UpdateAutonomousDatabaseDetails: updateAutonomousDatabaseDetails,
 	}
 
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) UpdateNetworkAccess(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+func (d *databaseService) UpdateNetworkAccess(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
@@ -327,50 +929,110 @@ func (d *databaseService) UpdateNetworkAccess(adbOCID string, difADB *dbv1alpha1
 		},
 	}
 
-	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) StartAutonomousDatabase(adbOCID string) (database.StartAutonomousDatabaseResponse, error) {
+func (d *databaseService) StartAutonomousDatabase(ctx context.Context, adbOCID string) (database.StartAutonomousDatabaseResponse, error) {
 	startRequest := database.StartAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 	}
 
-	return d.dbClient.StartAutonomousDatabase(context.TODO(), startRequest)
+	return d.dbClient.StartAutonomousDatabase(ctx, startRequest)
 }
 
-func (d *databaseService) StopAutonomousDatabase(adbOCID string) (database.StopAutonomousDatabaseResponse, error) {
+func (d *databaseService) StopAutonomousDatabase(ctx context.Context, adbOCID string) (database.StopAutonomousDatabaseResponse, error) {
 	stopRequest := database.StopAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 	}
 
-	return d.dbClient.StopAutonomousDatabase(context.TODO(), stopRequest)
+	return d.dbClient.StopAutonomousDatabase(ctx, stopRequest)
+}
+
+func (d *databaseService) EnableDatabaseManagement(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseManagementResponse, error) {
+	enableRequest := database.EnableAutonomousDatabaseManagementRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.EnableAutonomousDatabaseManagement(ctx, enableRequest)
+}
+
+func (d *databaseService) DisableDatabaseManagement(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseManagementResponse, error) {
+	disableRequest := database.DisableAutonomousDatabaseManagementRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.DisableAutonomousDatabaseManagement(ctx, disableRequest)
+}
+
+func (d *databaseService) EnableOperationsInsights(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseOperationsInsightsResponse, error) {
+	enableRequest := database.EnableAutonomousDatabaseOperationsInsightsRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.EnableAutonomousDatabaseOperationsInsights(ctx, enableRequest)
+}
+
+func (d *databaseService) DisableOperationsInsights(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseOperationsInsightsResponse, error) {
+	disableRequest := database.DisableAutonomousDatabaseOperationsInsightsRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.DisableAutonomousDatabaseOperationsInsights(ctx, disableRequest)
+}
+
+func (d *databaseService) DeleteAutonomousDatabase(ctx context.Context, adbOCID string) (database.DeleteAutonomousDatabaseResponse, error) {
+	deleteRequest := database.DeleteAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.DeleteAutonomousDatabase(ctx, deleteRequest)
+}
+
+// GetAutonomousDatabaseInRegion gets the database from the given region, e.g. a cross-region standby peer
+func (d *databaseService) GetAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.GetAutonomousDatabaseResponse, error) {
+	regionalClient, err := d.getRegionalDBClient(region)
+	if err != nil {
+		return database.GetAutonomousDatabaseResponse{}, err
+	}
+
+	getAutonomousDatabaseRequest := database.GetAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return regionalClient.GetAutonomousDatabase(ctx, getAutonomousDatabaseRequest)
 }
 
-func (d *databaseService) DeleteAutonomousDatabase(adbOCID string) (database.DeleteAutonomousDatabaseResponse, error) {
+// DeleteAutonomousDatabaseInRegion terminates the database in the given region, e.g. a cross-region standby peer
+func (d *databaseService) DeleteAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.DeleteAutonomousDatabaseResponse, error) {
+	regionalClient, err := d.getRegionalDBClient(region)
+	if err != nil {
+		return database.DeleteAutonomousDatabaseResponse{}, err
+	}
+
 	deleteRequest := database.DeleteAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 	}
 
-	return d.dbClient.DeleteAutonomousDatabase(context.TODO(), deleteRequest)
+	return regionalClient.DeleteAutonomousDatabase(ctx, deleteRequest)
 }
 
-func (d *databaseService) DownloadWallet(adb *dbv1alpha1.AutonomousDatabase) (resp database.GenerateAutonomousDatabaseWalletResponse, err error) {
+func (d *databaseService) DownloadWallet(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (resp database.GenerateAutonomousDatabaseWalletResponse, err error) {
 	// Prepare wallet password
-	walletPassword, err := d.readPassword(adb.Namespace, adb.Spec.Details.Wallet.Password)
+	walletPassword, err := d.readPassword(ctx, adb.Namespace, adb.Spec.Details.Wallet.Password)
 	if err != nil {
 		return resp, err
 	}
 
 	// Download a Wallet
 	req := database.GenerateAutonomousDatabaseWalletRequest{
-		AutonomousDatabaseId: adb.Spec.Details.AutonomousDatabaseOCID,
+		AutonomousDatabaseId: adb.GetAutonomousDatabaseOCID(),
 		GenerateAutonomousDatabaseWalletDetails: database.GenerateAutonomousDatabaseWalletDetails{
 			Password: walletPassword,
 		},
 	}
 
 	// Send the request using the service client
-	resp, err = d.dbClient.GenerateAutonomousDatabaseWallet(context.TODO(), req)
+	resp, err = d.dbClient.GenerateAutonomousDatabaseWallet(ctx, req)
 	if err != nil {
 		return resp, err
 	}
@@ -378,33 +1040,87 @@ func (d *databaseService) DownloadWallet(adb *dbv1alpha1.AutonomousDatabase) (re
 	return resp, nil
 }
 
+// RotateWallet rotates the wallet of the database, which invalidates all the wallets downloaded before. The wallet is
+// UPDATING until the rotation completes.
+func (d *databaseService) RotateWallet(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseWalletResponse, error) {
+	rotateRequest := database.UpdateAutonomousDatabaseWalletRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		UpdateAutonomousDatabaseWalletDetails: database.UpdateAutonomousDatabaseWalletDetails{
+			ShouldRotate: common.Bool(true),
+		},
+	}
+
+	return d.dbClient.UpdateAutonomousDatabaseWallet(ctx, rotateRequest)
+}
+
+func (d *databaseService) GetWallet(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseWalletResponse, error) {
+	getRequest := database.GetAutonomousDatabaseWalletRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.GetAutonomousDatabaseWallet(ctx, getRequest)
+}
+
+// RotateEncryptionKey re-encrypts the database with the latest version of its customer-managed KMS key. The database
+// is UPDATING until the rotation completes.
+func (d *databaseService) RotateEncryptionKey(ctx context.Context, adbOCID string) (database.RotateAutonomousDatabaseEncryptionKeyResponse, error) {
+	rotateRequest := database.RotateAutonomousDatabaseEncryptionKeyRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.RotateAutonomousDatabaseEncryptionKey(ctx, rotateRequest)
+}
+
+// ShrinkAutonomousDatabase reclaims the allocated storage which is not used by the database. The database is UPDATING
+// until the shrink completes.
+func (d *databaseService) ShrinkAutonomousDatabase(ctx context.Context, adbOCID string) (database.ShrinkAutonomousDatabaseResponse, error) {
+	shrinkRequest := database.ShrinkAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+	}
+
+	return d.dbClient.ShrinkAutonomousDatabase(ctx, shrinkRequest)
+}
+
+// DetachRefreshableClone detaches a refreshable clone from its source database, which makes it a standalone read-write
+// database. The database is UPDATING until the detach completes.
+func (d *databaseService) DetachRefreshableClone(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseResponse, error) {
+	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
+			IsRefreshableClone: common.Bool(false),
+		},
+	}
+
+	return d.dbClient.UpdateAutonomousDatabase(ctx, updateAutonomousDatabaseRequest)
+}
+
 /********************************
  * Autonomous Database Restore
  *******************************/
 
-func (d *databaseService) RestoreAutonomousDatabase(adbOCID string, sdkTime common.SDKTime) (database.RestoreAutonomousDatabaseResponse, error) {
+func (d *databaseService) RestoreAutonomousDatabase(ctx context.Context, adbOCID string, sdkTime common.SDKTime) (database.RestoreAutonomousDatabaseResponse, error) {
 	request := database.RestoreAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 		RestoreAutonomousDatabaseDetails: database.RestoreAutonomousDatabaseDetails{
 			Timestamp: &sdkTime,
 		},
 	}
-	return d.dbClient.RestoreAutonomousDatabase(context.TODO(), request)
+	return d.dbClient.RestoreAutonomousDatabase(ctx, request)
 }
 
 /********************************
  * Autonomous Database Backup
  *******************************/
 
-func (d *databaseService) ListAutonomousDatabaseBackups(adbOCID string) (database.ListAutonomousDatabaseBackupsResponse, error) {
+func (d *databaseService) ListAutonomousDatabaseBackups(ctx context.Context, adbOCID string) (database.ListAutonomousDatabaseBackupsResponse, error) {
 	listBackupRequest := database.ListAutonomousDatabaseBackupsRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
 	}
 
-	return d.dbClient.ListAutonomousDatabaseBackups(context.TODO(), listBackupRequest)
+	return d.dbClient.ListAutonomousDatabaseBackups(ctx, listBackupRequest)
 }
 
-func (d *databaseService) CreateAutonomousDatabaseBackup(adbBackup *dbv1alpha1.AutonomousDatabaseBackup, adbOCID string) (database.CreateAutonomousDatabaseBackupResponse, error) {
+func (d *databaseService) CreateAutonomousDatabaseBackup(ctx context.Context, adbBackup *dbv1alpha1.AutonomousDatabaseBackup, adbOCID string) (database.CreateAutonomousDatabaseBackupResponse, error) {
 	createBackupRequest := database.CreateAutonomousDatabaseBackupRequest{
 		CreateAutonomousDatabaseBackupDetails: database.CreateAutonomousDatabaseBackupDetails{
 			AutonomousDatabaseId: common.String(adbOCID),
@@ -419,13 +1135,166 @@ func (d *databaseService) CreateAutonomousDatabaseBackup(adbBackup *dbv1alpha1.A
 		createBackupRequest.DisplayName = common.String(adbBackup.GetName())
 	}
 
-	return d.dbClient.CreateAutonomousDatabaseBackup(context.TODO(), createBackupRequest)
+	if adbBackup.Spec.RetentionPeriodInDays != nil {
+		return d.createLongTermBackup(ctx, createBackupRequest.CreateAutonomousDatabaseBackupDetails, *adbBackup.Spec.RetentionPeriodInDays)
+	}
+
+	return d.dbClient.CreateAutonomousDatabaseBackup(ctx, createBackupRequest)
+}
+
+// createLongTermBackupRequest is a CreateAutonomousDatabaseBackup request with the long-term backup fields,
+// which are missing from the SDK.
+type createLongTermBackupRequest struct {
+	Details createLongTermBackupDetails `contributesTo:"body"`
+}
+
+type createLongTermBackupDetails struct {
+	DisplayName           *string `mandatory:"true" json:"displayName"`
+	AutonomousDatabaseId  *string `mandatory:"true" json:"autonomousDatabaseId"`
+	IsLongTermBackup      *bool   `mandatory:"true" json:"isLongTermBackup"`
+	RetentionPeriodInDays *int    `mandatory:"true" json:"retentionPeriodInDays"`
+}
+
+// createLongTermBackup creates a backup which is retained for the given days instead of the backup retention period of the database
+func (d *databaseService) createLongTermBackup(ctx context.Context, details database.CreateAutonomousDatabaseBackupDetails, retentionDays int) (resp database.CreateAutonomousDatabaseBackupResponse, err error) {
+	request := createLongTermBackupRequest{
+		Details: createLongTermBackupDetails{
+			DisplayName:           details.DisplayName,
+			AutonomousDatabaseId:  details.AutonomousDatabaseId,
+			IsLongTermBackup:      common.Bool(true),
+			RetentionPeriodInDays: common.Int(retentionDays),
+		},
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabaseBackups", request)
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := d.dbClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
+}
+
+func (d *databaseService) GetAutonomousDatabaseBackup(ctx context.Context, backupOCID string) (database.GetAutonomousDatabaseBackupResponse, error) {
+	getBackupRequest := database.GetAutonomousDatabaseBackupRequest{
+		AutonomousDatabaseBackupId: common.String(backupOCID),
+	}
+
+	return d.dbClient.GetAutonomousDatabaseBackup(ctx, getBackupRequest)
+}
+
+// deleteBackupRequest is a DeleteAutonomousDatabaseBackup request, which is missing from the SDK
+type deleteBackupRequest struct {
+	AutonomousDatabaseBackupId *string `mandatory:"true" contributesTo:"path" name:"autonomousDatabaseBackupId"`
+}
+
+// DeleteAutonomousDatabaseBackup deletes a long-term backup. OCI rejects the request for the other backups.
+func (d *databaseService) DeleteAutonomousDatabaseBackup(ctx context.Context, backupOCID string) error {
+	request := deleteBackupRequest{
+		AutonomousDatabaseBackupId: common.String(backupOCID),
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodDelete, "/autonomousDatabaseBackups/{autonomousDatabaseBackupId}", request)
+	if err != nil {
+		return err
+	}
+
+	httpResponse, err := d.dbClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	return err
+}
+
+// getRegionalDBClient returns a database client of the given region. The region of the dbClient comes
+// from the provider, so the client of another region has to be built separately. The client uses the default
+// endpoint of the region, since the endpointOverride is the endpoint of the region of the provider.
+func (d *databaseService) getRegionalDBClient(region string) (database.DatabaseClient, error) {
+	dbClient, err := database.NewDatabaseClientWithConfigurationProvider(d.provider)
+	if err != nil {
+		return dbClient, err
+	}
+	dbClient.SetRegion(region)
+	logRequests(d.logger, &dbClient.BaseClient)
+
+	return dbClient, nil
+}
+
+// copyBackupRequest copies an Autonomous Database backup from the source region. The request is sent to the
+// destination region. The operation is missing from the SDK.
+type copyBackupRequest struct {
+	Details copyBackupDetails `contributesTo:"body"`
+}
+
+type copyBackupDetails struct {
+	SourceBackupId *string `mandatory:"true" json:"sourceBackupId"`
+	SourceRegion   *string `mandatory:"true" json:"sourceRegion"`
+	CompartmentId  *string `mandatory:"true" json:"compartmentId"`
+	DisplayName    *string `mandatory:"false" json:"displayName"`
+}
+
+// CopyAutonomousDatabaseBackup copies the backup to the region of spec.crossRegionCopy.region.
+// The compartment of the source backup is used if spec.crossRegionCopy.compartmentOCID is not provided.
+func (d *databaseService) CopyAutonomousDatabaseBackup(ctx context.Context, adbBackup *dbv1alpha1.AutonomousDatabaseBackup) (resp database.CreateAutonomousDatabaseBackupResponse, err error) {
+	if adbBackup.Spec.CrossRegionCopy.Region == nil {
+		return resp, errors.New("the region of the copy is empty")
+	}
+
+	sourceRegion, err := d.provider.Region()
+	if err != nil {
+		return resp, err
+	}
+
+	compartmentOCID := adbBackup.Spec.CrossRegionCopy.CompartmentOCID
+	if compartmentOCID == nil {
+		compartmentOCID = common.String(adbBackup.Status.CompartmentOCID)
+	}
+
+	request := copyBackupRequest{
+		Details: copyBackupDetails{
+			SourceBackupId: adbBackup.Spec.AutonomousDatabaseBackupOCID,
+			SourceRegion:   common.String(sourceRegion),
+			CompartmentId:  compartmentOCID,
+			DisplayName:    adbBackup.Spec.DisplayName,
+		},
+	}
+
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabaseBackups/actions/copy", request)
+	if err != nil {
+		return resp, err
+	}
+
+	regionalClient, err := d.getRegionalDBClient(*adbBackup.Spec.CrossRegionCopy.Region)
+	if err != nil {
+		return resp, err
+	}
+
+	httpResponse, err := regionalClient.Call(ctx, &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	resp.RawResponse = httpResponse
+	if err != nil {
+		return resp, err
+	}
+
+	err = common.UnmarshalResponse(httpResponse, &resp)
+	return resp, err
 }
 
-func (d *databaseService) GetAutonomousDatabaseBackup(backupOCID string) (database.GetAutonomousDatabaseBackupResponse, error) {
+// GetAutonomousDatabaseBackupInRegion gets the backup from the given region
+func (d *databaseService) GetAutonomousDatabaseBackupInRegion(ctx context.Context, backupOCID string, region string) (database.GetAutonomousDatabaseBackupResponse, error) {
+	regionalClient, err := d.getRegionalDBClient(region)
+	if err != nil {
+		return database.GetAutonomousDatabaseBackupResponse{}, err
+	}
+
 	getBackupRequest := database.GetAutonomousDatabaseBackupRequest{
 		AutonomousDatabaseBackupId: common.String(backupOCID),
 	}
 
-	return d.dbClient.GetAutonomousDatabaseBackup(context.TODO(), getBackupRequest)
+	return regionalClient.GetAutonomousDatabaseBackup(ctx, getBackupRequest)
 }
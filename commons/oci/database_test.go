@@ -0,0 +1,545 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("Database", func() {
+	Describe("ReadPasswordFile", func() {
+		var (
+			dir  string
+			root string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "password")
+			Expect(err).ToNot(HaveOccurred())
+
+			// The password files are only read under the root
+			root = PasswordVolumeRoot
+			PasswordVolumeRoot = dir
+		})
+
+		AfterEach(func() {
+			PasswordVolumeRoot = root
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("should read the password without the trailing newline", func() {
+			path := filepath.Join(dir, "admin-password")
+			Expect(ioutil.WriteFile(path, []byte("Welcome_123#\n"), 0600)).To(Succeed())
+
+			password, err := ReadPasswordFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(Equal("Welcome_123#"))
+		})
+
+		It("should return an error if the file doesn't exist", func() {
+			_, err := ReadPasswordFile(filepath.Join(dir, "missing"))
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("should return an error if the file is empty", func() {
+			path := filepath.Join(dir, "admin-password")
+			Expect(ioutil.WriteFile(path, []byte("\n"), 0600)).To(Succeed())
+
+			_, err := ReadPasswordFile(path)
+			Expect(err).To(MatchError(ContainSubstring("is empty")))
+		})
+
+		It("should reject a path which traverses out of the root", func() {
+			outside, err := ioutil.TempDir("", "outside")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(outside)
+			Expect(ioutil.WriteFile(filepath.Join(outside, "token"), []byte("secret-token"), 0600)).To(Succeed())
+
+			rel, err := filepath.Rel(dir, filepath.Join(outside, "token"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = ReadPasswordFile(filepath.Join(dir, rel))
+			Expect(err).To(HaveOccurred())
+			_, err = ReadPasswordFile(dir + "/" + rel)
+			Expect(err).To(MatchError(ContainSubstring("must not contain ..")))
+		})
+
+		It("should reject a path outside of the root", func() {
+			outside, err := ioutil.TempDir("", "outside")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(outside)
+			Expect(ioutil.WriteFile(filepath.Join(outside, "token"), []byte("secret-token"), 0600)).To(Succeed())
+
+			_, err = ReadPasswordFile(filepath.Join(outside, "token"))
+			Expect(err).To(MatchError(ContainSubstring("is not under the password volume root")))
+		})
+
+		It("should reject a symbolic link which points out of the root", func() {
+			outside, err := ioutil.TempDir("", "outside")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(outside)
+			Expect(ioutil.WriteFile(filepath.Join(outside, "token"), []byte("secret-token"), 0600)).To(Succeed())
+			Expect(os.Symlink(filepath.Join(outside, "token"), filepath.Join(dir, "admin-password"))).To(Succeed())
+
+			_, err = ReadPasswordFile(filepath.Join(dir, "admin-password"))
+			Expect(err).To(MatchError(ContainSubstring("is not under the password volume root")))
+		})
+
+		It("should reject a relative path", func() {
+			_, err := ReadPasswordFile("admin-password")
+			Expect(err).To(MatchError(ContainSubstring("must be an absolute path")))
+		})
+
+		It("should reject the password files if no root is configured", func() {
+			PasswordVolumeRoot = ""
+
+			_, err := ReadPasswordFile(filepath.Join(dir, "admin-password"))
+			Expect(err).To(MatchError(ContainSubstring("no password volume root is configured")))
+		})
+	})
+
+	Describe("readPassword", func() {
+		var d *databaseService
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+			// The Secret of the same name exists in both namespaces
+			objects := []client.Object{}
+			for _, namespace := range []string{"tenant-a", "tenant-b"} {
+				objects = append(objects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "admin-password", Namespace: namespace},
+					Data:       map[string][]byte{"admin-password": []byte("password-of-" + namespace)},
+				})
+			}
+			d = &databaseService{
+				logger:     logr.Discard(),
+				kubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			}
+		})
+
+		It("should read the Secret in the namespace of the resource", func() {
+			passwordSpec := dbv1alpha1.PasswordSpec{}
+			passwordSpec.K8sSecret.Name = common.String("admin-password")
+
+			password, err := d.readPassword(context.TODO(), "tenant-a", passwordSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(Equal(common.String("password-of-tenant-a")))
+		})
+
+		It("should reject the Secret in another namespace", func() {
+			passwordSpec := dbv1alpha1.PasswordSpec{}
+			passwordSpec.K8sSecret.Name = common.String("tenant-b/admin-password")
+
+			_, err := d.readPassword(context.TODO(), "tenant-a", passwordSpec)
+			Expect(err).To(MatchError(ContainSubstring("cross-namespace references are not allowed")))
+		})
+	})
+
+	Describe("createAutonomousDatabaseDetails", func() {
+		var adb *dbv1alpha1.AutonomousDatabase
+
+		BeforeEach(func() {
+			adb = &dbv1alpha1.AutonomousDatabase{}
+			adb.Spec.Details.CompartmentOCID = common.String("ocid1.compartment.oc1..fake")
+			adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = common.Bool(true)
+			adb.Spec.Details.NetworkAccess.AccessControlList = []string{"192.168.0.1"}
+			adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(true)
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = common.String("ocid1.subnet.oc1..fake")
+		})
+
+		It("should associate a dedicated database with the container database", func() {
+			acdOCID := common.String("ocid1.autonomouscontainerdatabase.oc1..fake")
+
+			details := createAutonomousDatabaseDetails(adb, common.String("password"), acdOCID)
+			Expect(details.IsDedicated).To(Equal(common.Bool(true)))
+			Expect(details.AutonomousContainerDatabaseId).To(Equal(acdOCID))
+			Expect(details.IsAccessControlEnabled).To(Equal(common.Bool(true)))
+			Expect(details.WhitelistedIps).To(Equal([]string{"192.168.0.1"}))
+			Expect(details.IsMtlsConnectionRequired).To(BeNil())
+			Expect(details.SubnetId).To(BeNil())
+		})
+
+		It("should leave out the container database of a serverless database", func() {
+			details := createAutonomousDatabaseDetails(adb, common.String("password"), nil)
+			Expect(details.IsDedicated).To(Equal(common.Bool(false)))
+			Expect(details.AutonomousContainerDatabaseId).To(BeNil())
+			Expect(details.IsAccessControlEnabled).To(Equal(common.Bool(true)))
+			Expect(details.IsMtlsConnectionRequired).To(Equal(common.Bool(true)))
+			Expect(details.SubnetId).To(Equal(common.String("ocid1.subnet.oc1..fake")))
+		})
+
+		It("should provision an Always Free database", func() {
+			details := createAutonomousDatabaseDetails(adb, common.String("password"), nil)
+			Expect(details.IsFreeTier).To(BeNil())
+
+			adb.Spec.Details.IsFreeTier = common.Bool(true)
+			details = createAutonomousDatabaseDetails(adb, common.String("password"), nil)
+			Expect(details.IsFreeTier).To(Equal(common.Bool(true)))
+		})
+
+		It("should convert the customer contacts", func() {
+			details := createAutonomousDatabaseDetails(adb, common.String("password"), nil)
+			Expect(details.CustomerContacts).To(BeNil())
+
+			adb.Spec.Details.CustomerContacts = []string{"ops@example.com", "dba@example.com"}
+			details = createAutonomousDatabaseDetails(adb, common.String("password"), nil)
+			Expect(details.CustomerContacts).To(Equal([]database.CustomerContact{
+				{Email: common.String("ops@example.com")},
+				{Email: common.String("dba@example.com")},
+			}))
+		})
+
+		It("should send an empty list to remove the customer contacts", func() {
+			Expect(customerContacts(nil)).To(BeNil())
+			Expect(customerContacts([]string{})).To(BeEmpty())
+			Expect(customerContacts([]string{})).ToNot(BeNil())
+		})
+	})
+
+	Describe("createAutonomousDatabaseBody", func() {
+		It("should add the extra attributes to the body of the SDK details", func() {
+			details := database.CreateAutonomousDatabaseDetails{
+				CompartmentId: common.String("ocid1.compartment.oc1..fake"),
+				CpuCoreCount:  common.Int(1),
+				SubnetId:      common.String("ocid1.subnet.oc1..fake"),
+			}
+
+			body, err := createAutonomousDatabaseBody(details, map[string]interface{}{"privateEndpointIp": "10.0.0.10"})
+			Expect(err).ToNot(HaveOccurred())
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases", createAutonomousDatabaseRequest{Details: body})
+			Expect(err).ToNot(HaveOccurred())
+			content, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content).To(MatchJSON(`{
+				"source": "NONE",
+				"compartmentId": "ocid1.compartment.oc1..fake",
+				"cpuCoreCount": 1,
+				"subnetId": "ocid1.subnet.oc1..fake",
+				"privateEndpointIp": "10.0.0.10"
+			}`))
+		})
+
+		It("should add the adminUsername of a dedicated database", func() {
+			details := database.CreateAutonomousDatabaseDetails{
+				CompartmentId:                 common.String("ocid1.compartment.oc1..fake"),
+				IsDedicated:                   common.Bool(true),
+				AutonomousContainerDatabaseId: common.String("ocid1.autonomouscontainerdatabase.oc1..fake"),
+			}
+
+			body, err := createAutonomousDatabaseBody(details, map[string]interface{}{"adminUsername": "DBA_ADMIN"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(HaveKeyWithValue("adminUsername", "DBA_ADMIN"))
+			Expect(body).To(HaveKeyWithValue("isDedicated", true))
+		})
+	})
+
+	Describe("checkEndpointOverride", func() {
+		It("should only accept an https URL with the host", func() {
+			Expect(checkEndpointOverride("https://database.us-ashburn-1.oraclecloud.com")).To(Succeed())
+			Expect(checkEndpointOverride("http://database.us-ashburn-1.oraclecloud.com")).To(MatchError(ContainSubstring("is not an https URL")))
+			Expect(checkEndpointOverride("database.us-ashburn-1.oraclecloud.com")).To(MatchError(ContainSubstring("is not an https URL")))
+		})
+	})
+
+	Describe("validatePrivateEndpointIP", func() {
+		It("should only accept the IP in the CIDR of the subnet", func() {
+			Expect(validatePrivateEndpointIP("10.0.1.10", "10.0.1.0/24")).To(Succeed())
+			Expect(validatePrivateEndpointIP("10.0.2.10", "10.0.1.0/24")).To(MatchError(ContainSubstring("is not in the CIDR")))
+		})
+	})
+
+	Describe("validateAvailabilityDomain", func() {
+		It("should only accept the availability domain of the AutonomousContainerDatabase", func() {
+			Expect(validateAvailabilityDomain("Uocm:PHX-AD-1", common.String("Uocm:PHX-AD-1"))).To(Succeed())
+			Expect(validateAvailabilityDomain("uocm:phx-ad-1", common.String("Uocm:PHX-AD-1"))).To(Succeed())
+			Expect(validateAvailabilityDomain("Uocm:PHX-AD-2", common.String("Uocm:PHX-AD-1"))).
+				To(MatchError(ContainSubstring("is different from the availability domain Uocm:PHX-AD-1")))
+			Expect(validateAvailabilityDomain("Uocm:PHX-AD-2", nil)).To(Succeed())
+		})
+	})
+
+	Describe("validatePlacement", func() {
+		vmCluster := "ocid1.cloudautonomousvmcluster.oc1..fake"
+		infrastructure := "ocid1.cloudexadatainfrastructure.oc1..fake"
+
+		It("should only accept the VM cluster and the infrastructure of the AutonomousContainerDatabase", func() {
+			Expect(validatePlacement(common.String(vmCluster), common.String(infrastructure), vmCluster, infrastructure)).To(Succeed())
+			Expect(validatePlacement(nil, common.String(infrastructure), vmCluster, infrastructure)).To(Succeed())
+			Expect(validatePlacement(nil, nil, vmCluster, infrastructure)).To(Succeed())
+			Expect(validatePlacement(common.String("ocid1.cloudautonomousvmcluster.oc1..other"), nil, vmCluster, infrastructure)).
+				To(MatchError(ContainSubstring("is different from the VM cluster " + vmCluster)))
+			Expect(validatePlacement(nil, common.String("ocid1.cloudexadatainfrastructure.oc1..other"), vmCluster, infrastructure)).
+				To(MatchError(ContainSubstring("is different from the Exadata infrastructure " + infrastructure)))
+		})
+	})
+
+	Describe("selectContainerDatabase", func() {
+		vmCluster := "ocid1.cloudautonomousvmcluster.oc1..fake"
+
+		It("should select the only AutonomousContainerDatabase on the VM cluster", func() {
+			acdOCID, err := selectContainerDatabase(vmCluster, []database.AutonomousContainerDatabaseSummary{
+				{Id: common.String("ocid1.autonomouscontainerdatabase.oc1..one")},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*acdOCID).To(Equal("ocid1.autonomouscontainerdatabase.oc1..one"))
+		})
+
+		It("should fail if there is no AutonomousContainerDatabase or more than one", func() {
+			_, err := selectContainerDatabase(vmCluster, nil)
+			Expect(err).To(MatchError(ContainSubstring("no AVAILABLE AutonomousContainerDatabase")))
+
+			_, err = selectContainerDatabase(vmCluster, []database.AutonomousContainerDatabaseSummary{
+				{Id: common.String("ocid1.autonomouscontainerdatabase.oc1..one")},
+				{Id: common.String("ocid1.autonomouscontainerdatabase.oc1..two")},
+			})
+			Expect(err).To(MatchError(ContainSubstring("set the autonomousContainerDatabase to one of " +
+				"ocid1.autonomouscontainerdatabase.oc1..one, ocid1.autonomouscontainerdatabase.oc1..two")))
+		})
+	})
+
+	Describe("validateDbVersion", func() {
+		versions := []database.AutonomousDbVersionSummary{
+			{Version: common.String("19c"), Details: common.String("Oracle Database 19c")},
+			{Version: common.String("23ai"), Details: common.String("Preview version of Oracle Database 23ai")},
+		}
+
+		It("should only accept a preview version if the preview versions are allowed", func() {
+			Expect(validateDbVersion("23ai", false, versions)).
+				To(MatchError(ContainSubstring("the dbVersion 23ai is a preview version")))
+			Expect(validateDbVersion("23ai", true, versions)).To(Succeed())
+		})
+
+		It("should accept the versions which are not preview versions", func() {
+			Expect(validateDbVersion("19c", false, versions)).To(Succeed())
+			Expect(validateDbVersion("21c", false, versions)).To(Succeed())
+			Expect(validateDbVersion("19c", false, nil)).To(Succeed())
+		})
+	})
+
+	Describe("listAllAutonomousDatabases", func() {
+		It("should read all the pages", func() {
+			pages := map[string]database.ListAutonomousDatabasesResponse{
+				"": {
+					Items:       []database.AutonomousDatabaseSummary{{Id: common.String("adb1")}, {Id: common.String("adb2")}},
+					OpcNextPage: common.String("page2"),
+				},
+				"page2": {
+					Items:       []database.AutonomousDatabaseSummary{{Id: common.String("adb3")}},
+					OpcNextPage: common.String("page3"),
+				},
+				"page3": {
+					Items: []database.AutonomousDatabaseSummary{{Id: common.String("adb4")}},
+				},
+			}
+
+			var requestedPages []string
+			items, err := listAllAutonomousDatabases(func(page *string) (database.ListAutonomousDatabasesResponse, error) {
+				key := ""
+				if page != nil {
+					key = *page
+				}
+				requestedPages = append(requestedPages, key)
+				return pages[key], nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requestedPages).To(Equal([]string{"", "page2", "page3"}))
+
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, *item.Id)
+			}
+			Expect(ids).To(Equal([]string{"adb1", "adb2", "adb3", "adb4"}))
+		})
+
+		It("should return the error of any page", func() {
+			_, err := listAllAutonomousDatabases(func(page *string) (database.ListAutonomousDatabasesResponse, error) {
+				if page != nil {
+					return database.ListAutonomousDatabasesResponse{}, errors.New("service unavailable")
+				}
+				return database.ListAutonomousDatabasesResponse{OpcNextPage: common.String("page2")}, nil
+			})
+			Expect(err).To(MatchError("service unavailable"))
+		})
+	})
+
+	Describe("updateBackupRetentionRequest", func() {
+		It("should send the backupRetentionPeriodInDays in the body", func() {
+			request := updateBackupRetentionRequest{
+				AutonomousDatabaseId: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				Details: updateBackupRetentionDetails{
+					BackupRetentionPeriodInDays: common.Int(30),
+				},
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPut, "/autonomousDatabases/{autonomousDatabaseId}", request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpRequest.URL.Path).To(Equal("/autonomousDatabases/ocid1.autonomousdatabase.oc1..fake"))
+
+			body, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"backupRetentionPeriodInDays": 30}`))
+		})
+	})
+
+	Describe("copyBackupRequest", func() {
+		It("should send the source backup in the body", func() {
+			request := copyBackupRequest{
+				Details: copyBackupDetails{
+					SourceBackupId: common.String("ocid1.autonomousdatabasebackup.oc1..fake"),
+					SourceRegion:   common.String("us-phoenix-1"),
+					CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+				},
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabaseBackups/actions/copy", request)
+			Expect(err).ToNot(HaveOccurred())
+
+			body, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"sourceBackupId": "ocid1.autonomousdatabasebackup.oc1..fake",
+				"sourceRegion": "us-phoenix-1",
+				"compartmentId": "ocid1.compartment.oc1..fake"
+			}`))
+		})
+	})
+
+	Describe("createLongTermBackupRequest", func() {
+		It("should send the retention period of the long-term backup in the body", func() {
+			request := createLongTermBackupRequest{
+				Details: createLongTermBackupDetails{
+					DisplayName:           common.String("nightly-202206150200"),
+					AutonomousDatabaseId:  common.String("ocid1.autonomousdatabase.oc1..fake"),
+					IsLongTermBackup:      common.Bool(true),
+					RetentionPeriodInDays: common.Int(90),
+				},
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabaseBackups", request)
+			Expect(err).ToNot(HaveOccurred())
+
+			body, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"displayName": "nightly-202206150200",
+				"autonomousDatabaseId": "ocid1.autonomousdatabase.oc1..fake",
+				"isLongTermBackup": true,
+				"retentionPeriodInDays": 90
+			}`))
+		})
+	})
+
+	Describe("deleteBackupRequest", func() {
+		It("should send the backup OCID in the path", func() {
+			request := deleteBackupRequest{
+				AutonomousDatabaseBackupId: common.String("ocid1.autonomousdatabasebackup.oc1..fake"),
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodDelete, "/autonomousDatabaseBackups/{autonomousDatabaseBackupId}", request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpRequest.Method).To(Equal(http.MethodDelete))
+			Expect(httpRequest.URL.Path).To(Equal("/autonomousDatabaseBackups/ocid1.autonomousdatabasebackup.oc1..fake"))
+		})
+	})
+
+	Describe("changeDisasterRecoveryRequest", func() {
+		It("should send the disasterRecoveryType in the body", func() {
+			request := changeDisasterRecoveryRequest{
+				AutonomousDatabaseId: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				Details: changeDisasterRecoveryDetails{
+					DisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeADG,
+				},
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases/{autonomousDatabaseId}/actions/changeDisasterRecoveryConfiguration", request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(httpRequest.URL.Path).To(Equal("/autonomousDatabases/ocid1.autonomousdatabase.oc1..fake/actions/changeDisasterRecoveryConfiguration"))
+
+			body, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"disasterRecoveryType": "ADG"}`))
+		})
+	})
+
+	Describe("createDisasterRecoveryPeerRequest", func() {
+		It("should send the source database and the remote type in the body", func() {
+			request := createDisasterRecoveryPeerRequest{
+				Details: createDisasterRecoveryPeerDetails{
+					Source:                     "CROSS_REGION_DISASTER_RECOVERY",
+					SourceId:                   common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CompartmentId:              common.String("ocid1.compartment.oc1..fake"),
+					RemoteDisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeBackupBased,
+				},
+			}
+
+			httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(http.MethodPost, "/autonomousDatabases", request)
+			Expect(err).ToNot(HaveOccurred())
+
+			body, err := ioutil.ReadAll(httpRequest.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"source": "CROSS_REGION_DISASTER_RECOVERY",
+				"sourceId": "ocid1.autonomousdatabase.oc1..fake",
+				"compartmentId": "ocid1.compartment.oc1..fake",
+				"remoteDisasterRecoveryType": "BACKUP_BASED"
+			}`))
+		})
+	})
+})
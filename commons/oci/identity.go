@@ -0,0 +1,201 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/identity"
+)
+
+// The resolved compartment OCIDs are cached for the duration, since the compartments are rarely renamed or moved
+const compartmentCacheTTL = 1 * time.Hour
+
+type cachedCompartment struct {
+	ocid      string
+	expiresAt time.Time
+}
+
+// compartmentCache stores the resolved compartment OCIDs keyed by the tenancy OCID and the path. It is shared by all
+// the IdentityServices, since a new service is created in every reconcile.
+var compartmentCache = struct {
+	sync.Mutex
+	compartments map[string]cachedCompartment
+}{compartments: map[string]cachedCompartment{}}
+
+type IdentityService interface {
+	GetCompartmentOCID(ctx context.Context, path string) (string, error)
+	ListAvailabilityDomains(ctx context.Context) ([]string, error)
+}
+
+// identityLister is the part of the identity.IdentityClient used by the identityService
+type identityLister interface {
+	ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error)
+	ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error)
+}
+
+type identityService struct {
+	logger         logr.Logger
+	identityClient identityLister
+	tenancyOCID    string
+}
+
+func NewIdentityService(
+	logger logr.Logger,
+	provider common.ConfigurationProvider) (IdentityService, error) {
+
+	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	logRequests(logger, &identityClient.BaseClient)
+
+	tenancyOCID, err := provider.TenancyOCID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &identityService{
+		logger:         logger.WithName("identityService"),
+		identityClient: identityClient,
+		tenancyOCID:    tenancyOCID,
+	}, nil
+}
+
+// GetCompartmentOCID resolves the name or the path of a compartment to its OCID. A single name is searched in the
+// whole tenancy, and it's an error if more than one compartment has the name. In a path like parent/child, the first
+// name is searched in the whole tenancy, and each following name is a direct child of the previous compartment.
+func (i *identityService) GetCompartmentOCID(ctx context.Context, path string) (string, error) {
+	cacheKey := i.tenancyOCID + "/" + path
+
+	compartmentCache.Lock()
+	cached, ok := compartmentCache.compartments[cacheKey]
+	compartmentCache.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.ocid, nil
+	}
+
+	names := strings.Split(strings.Trim(path, "/"), "/")
+	parentOCID := i.tenancyOCID
+	for depth, name := range names {
+		if name == "" {
+			return "", fmt.Errorf("invalid compartment path %q", path)
+		}
+
+		compartments, err := i.listCompartments(ctx, parentOCID, name, depth == 0)
+		if err != nil {
+			return "", err
+		}
+
+		switch len(compartments) {
+		case 0:
+			return "", fmt.Errorf("compartment %q is not found in the compartment path %q", name, path)
+		case 1:
+			parentOCID = *compartments[0].Id
+		default:
+			ocids := make([]string, len(compartments))
+			for j, compartment := range compartments {
+				ocids[j] = *compartment.Id
+			}
+			return "", fmt.Errorf("compartment name %q is ambiguous, use the path from the root compartment instead; matched compartments: %s",
+				name, strings.Join(ocids, ", "))
+		}
+	}
+
+	i.logger.Info(fmt.Sprintf("Compartment %s is resolved to %s", path, parentOCID))
+
+	compartmentCache.Lock()
+	compartmentCache.compartments[cacheKey] = cachedCompartment{ocid: parentOCID, expiresAt: time.Now().Add(compartmentCacheTTL)}
+	compartmentCache.Unlock()
+
+	return parentOCID, nil
+}
+
+// ListAvailabilityDomains returns the names of the availability domains in the region of the client
+func (i *identityService) ListAvailabilityDomains(ctx context.Context) ([]string, error) {
+	resp, err := i.identityClient.ListAvailabilityDomains(ctx, identity.ListAvailabilityDomainsRequest{
+		CompartmentId: common.String(i.tenancyOCID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(resp.Items))
+	for _, ad := range resp.Items {
+		if ad.Name != nil {
+			names = append(names, *ad.Name)
+		}
+	}
+	return names, nil
+}
+
+// listCompartments returns the ACTIVE compartments with the name under the parent. If inSubtree is true, the
+// compartments at any depth under the parent are returned, otherwise only the direct children.
+func (i *identityService) listCompartments(ctx context.Context, parentOCID string, name string, inSubtree bool) ([]identity.Compartment, error) {
+	request := identity.ListCompartmentsRequest{
+		CompartmentId:          common.String(parentOCID),
+		Name:                   common.String(name),
+		LifecycleState:         identity.CompartmentLifecycleStateActive,
+		CompartmentIdInSubtree: common.Bool(inSubtree),
+	}
+	if inSubtree {
+		request.AccessLevel = identity.ListCompartmentsAccessLevelAny
+	}
+
+	var compartments []identity.Compartment
+	for {
+		resp, err := i.identityClient.ListCompartments(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		compartments = append(compartments, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			return compartments, nil
+		}
+		request.Page = resp.OpcNextPage
+	}
+}
@@ -0,0 +1,175 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/identity"
+)
+
+// fakeIdentityClient lists the compartments and the availability domains in memory. The compartments are returned
+// one per page.
+type fakeIdentityClient struct {
+	compartments        []identity.Compartment
+	availabilityDomains []identity.AvailabilityDomain
+	listCalls           int
+}
+
+func (c *fakeIdentityClient) ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error) {
+	return identity.ListAvailabilityDomainsResponse{Items: c.availabilityDomains}, nil
+}
+
+func (c *fakeIdentityClient) ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	c.listCalls++
+
+	var matched []identity.Compartment
+	for _, compartment := range c.compartments {
+		if *compartment.Name != *request.Name {
+			continue
+		}
+		if *request.CompartmentIdInSubtree || *compartment.CompartmentId == *request.CompartmentId {
+			matched = append(matched, compartment)
+		}
+	}
+
+	start := 0
+	if request.Page != nil {
+		start = len(*request.Page)
+	}
+	if start >= len(matched) {
+		return identity.ListCompartmentsResponse{}, nil
+	}
+
+	resp := identity.ListCompartmentsResponse{Items: matched[start : start+1]}
+	if start+1 < len(matched) {
+		resp.OpcNextPage = common.String(strings.Repeat("x", start+1))
+	}
+	return resp, nil
+}
+
+func fakeCompartment(ocid string, name string, parentOCID string) identity.Compartment {
+	return identity.Compartment{
+		Id:             common.String(ocid),
+		Name:           common.String(name),
+		CompartmentId:  common.String(parentOCID),
+		LifecycleState: identity.CompartmentLifecycleStateActive,
+	}
+}
+
+var _ = Describe("Identity", func() {
+	const tenancyOCID = "ocid1.tenancy.oc1..fake"
+
+	var (
+		identityClient *fakeIdentityClient
+		service        *identityService
+	)
+
+	BeforeEach(func() {
+		compartmentCache.Lock()
+		compartmentCache.compartments = map[string]cachedCompartment{}
+		compartmentCache.Unlock()
+
+		identityClient = &fakeIdentityClient{
+			compartments: []identity.Compartment{
+				fakeCompartment("ocid1.compartment.oc1..prod", "prod", tenancyOCID),
+				fakeCompartment("ocid1.compartment.oc1..dev", "dev", tenancyOCID),
+				fakeCompartment("ocid1.compartment.oc1..prod-db", "db", "ocid1.compartment.oc1..prod"),
+				fakeCompartment("ocid1.compartment.oc1..dev-db", "db", "ocid1.compartment.oc1..dev"),
+				fakeCompartment("ocid1.compartment.oc1..prod-apps", "apps", "ocid1.compartment.oc1..prod"),
+			},
+			availabilityDomains: []identity.AvailabilityDomain{
+				{Name: common.String("Uocm:PHX-AD-1")},
+				{Name: common.String("Uocm:PHX-AD-2")},
+			},
+		}
+		service = &identityService{
+			logger:         logr.Discard(),
+			identityClient: identityClient,
+			tenancyOCID:    tenancyOCID,
+		}
+	})
+
+	Describe("GetCompartmentOCID", func() {
+		It("should resolve a unique name in the tenancy", func() {
+			Expect(service.GetCompartmentOCID(context.TODO(), "apps")).To(Equal("ocid1.compartment.oc1..prod-apps"))
+		})
+
+		It("should resolve a nested path", func() {
+			Expect(service.GetCompartmentOCID(context.TODO(), "dev/db")).To(Equal("ocid1.compartment.oc1..dev-db"))
+			Expect(service.GetCompartmentOCID(context.TODO(), "/prod/db")).To(Equal("ocid1.compartment.oc1..prod-db"))
+		})
+
+		It("should fail if the name is ambiguous", func() {
+			_, err := service.GetCompartmentOCID(context.TODO(), "db")
+			Expect(err).To(MatchError(ContainSubstring(`compartment name "db" is ambiguous`)))
+			Expect(err.Error()).To(ContainSubstring("ocid1.compartment.oc1..prod-db"))
+			Expect(err.Error()).To(ContainSubstring("ocid1.compartment.oc1..dev-db"))
+		})
+
+		It("should fail if a compartment in the path is not found", func() {
+			_, err := service.GetCompartmentOCID(context.TODO(), "prod/web")
+			Expect(err).To(MatchError(ContainSubstring(`compartment "web" is not found`)))
+		})
+
+		It("should fail if the path has an empty name", func() {
+			_, err := service.GetCompartmentOCID(context.TODO(), "prod//db")
+			Expect(err).To(MatchError(ContainSubstring("invalid compartment path")))
+		})
+
+		It("should cache the resolved OCID", func() {
+			Expect(service.GetCompartmentOCID(context.TODO(), "prod/db")).To(Equal("ocid1.compartment.oc1..prod-db"))
+			listCalls := identityClient.listCalls
+
+			Expect(service.GetCompartmentOCID(context.TODO(), "prod/db")).To(Equal("ocid1.compartment.oc1..prod-db"))
+			Expect(identityClient.listCalls).To(Equal(listCalls))
+		})
+	})
+
+	Describe("ListAvailabilityDomains", func() {
+		It("should list the names of the availability domains", func() {
+			Expect(service.ListAvailabilityDomains(context.TODO())).To(Equal([]string{"Uocm:PHX-AD-1", "Uocm:PHX-AD-2"}))
+		})
+	})
+})
@@ -0,0 +1,141 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"github.com/oracle/oci-go-sdk/v64/limits"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// databaseLimitsService is the name of the service of the Autonomous Database limits in the OCI Limits service
+const databaseLimitsService = "database"
+
+// The limits which the provisioning of a serverless database consumes, by the workload type
+var adbOCPULimitNames = map[database.AutonomousDatabaseDbWorkloadEnum]string{
+	database.AutonomousDatabaseDbWorkloadOltp: "atp-ocpu-count",
+	database.AutonomousDatabaseDbWorkloadDw:   "adw-ocpu-count",
+	database.AutonomousDatabaseDbWorkloadAjd:  "ajd-ocpu-count",
+	database.AutonomousDatabaseDbWorkloadApex: "apex-ocpu-count",
+}
+
+// adbFreeLimitName is the limit of the number of the Always Free databases
+const adbFreeLimitName = "adb-free-count"
+
+type LimitsService interface {
+	GetDatabaseAvailability(ctx context.Context, compartmentOCID string, limitName string) (float32, error)
+}
+
+// availabilityGetter is the part of the limits.LimitsClient used by the limitsService
+type availabilityGetter interface {
+	GetResourceAvailability(ctx context.Context, request limits.GetResourceAvailabilityRequest) (limits.GetResourceAvailabilityResponse, error)
+}
+
+type limitsService struct {
+	logger       logr.Logger
+	limitsClient availabilityGetter
+}
+
+func NewLimitsService(
+	logger logr.Logger,
+	provider common.ConfigurationProvider) (LimitsService, error) {
+
+	limitsClient, err := limits.NewLimitsClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	logRequests(logger, &limitsClient.BaseClient)
+
+	return &limitsService{
+		logger:       logger.WithName("limitsService"),
+		limitsClient: limitsClient,
+	}, nil
+}
+
+// GetDatabaseAvailability returns the available count of a limit of the database service in the compartment, which
+// takes both the service limit of the tenancy and the quotas of the compartment into account
+func (l *limitsService) GetDatabaseAvailability(ctx context.Context, compartmentOCID string, limitName string) (float32, error) {
+	resp, err := l.limitsClient.GetResourceAvailability(ctx, limits.GetResourceAvailabilityRequest{
+		ServiceName:   common.String(databaseLimitsService),
+		LimitName:     common.String(limitName),
+		CompartmentId: common.String(compartmentOCID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.FractionalAvailability != nil {
+		return *resp.FractionalAvailability, nil
+	}
+	if resp.Available != nil {
+		return float32(*resp.Available), nil
+	}
+	return 0, nil
+}
+
+// GetADBLimit returns the name of the limit which the provisioning of the database consumes, and the amount it
+// consumes. An Always Free database consumes one of the Always Free databases, and the others consume the OCPUs of
+// their workload type. An empty name is returned for a dedicated database, which consumes the capacity of its
+// Autonomous Container Database instead.
+func GetADBLimit(adb *dbv1alpha1.AutonomousDatabase) (limitName string, required float32) {
+	details := adb.Spec.Details
+	if (details.IsDedicated != nil && *details.IsDedicated) ||
+		details.AutonomousContainerDatabase.OCIACD.OCID != nil || details.AutonomousContainerDatabase.K8sACD.Name != nil {
+		return "", 0
+	}
+
+	if details.IsFreeTier != nil && *details.IsFreeTier {
+		return adbFreeLimitName, 1
+	}
+
+	workload := details.DbWorkload
+	if workload == "" {
+		workload = database.AutonomousDatabaseDbWorkloadOltp
+	}
+
+	cpuCoreCount := 1
+	if details.CPUCoreCount != nil {
+		cpuCoreCount = *details.CPUCoreCount
+	}
+	return adbOCPULimitNames[workload], float32(cpuCoreCount)
+}
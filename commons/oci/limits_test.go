@@ -0,0 +1,110 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"github.com/oracle/oci-go-sdk/v64/limits"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// fakeLimitsClient returns the availability of the limits by their names, and records the last request
+type fakeLimitsClient struct {
+	availability map[string]limits.ResourceAvailability
+	lastRequest  limits.GetResourceAvailabilityRequest
+}
+
+func (c *fakeLimitsClient) GetResourceAvailability(ctx context.Context, request limits.GetResourceAvailabilityRequest) (limits.GetResourceAvailabilityResponse, error) {
+	c.lastRequest = request
+	return limits.GetResourceAvailabilityResponse{ResourceAvailability: c.availability[*request.LimitName]}, nil
+}
+
+var _ = Describe("Limits", func() {
+	var (
+		client  *fakeLimitsClient
+		service *limitsService
+	)
+
+	BeforeEach(func() {
+		client = &fakeLimitsClient{
+			availability: map[string]limits.ResourceAvailability{
+				"atp-ocpu-count": {Available: common.Int64(2), FractionalAvailability: common.Float32(2.5)},
+				"adw-ocpu-count": {Available: common.Int64(0)},
+			},
+		}
+		service = &limitsService{logger: logr.Discard(), limitsClient: client}
+	})
+
+	It("should return the fractional availability of the limit in the compartment", func() {
+		available, err := service.GetDatabaseAvailability(context.TODO(), "ocid1.compartment.oc1..fake", "atp-ocpu-count")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(BeNumerically("==", 2.5))
+		Expect(*client.lastRequest.ServiceName).To(Equal("database"))
+		Expect(*client.lastRequest.CompartmentId).To(Equal("ocid1.compartment.oc1..fake"))
+	})
+
+	It("should fall back to the rounded availability", func() {
+		available, err := service.GetDatabaseAvailability(context.TODO(), "ocid1.compartment.oc1..fake", "adw-ocpu-count")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(BeZero())
+	})
+
+	DescribeTable("GetADBLimit",
+		func(details dbv1alpha1.AutonomousDatabaseDetails, limitName string, required float32) {
+			name, amount := GetADBLimit(&dbv1alpha1.AutonomousDatabase{Spec: dbv1alpha1.AutonomousDatabaseSpec{Details: details}})
+			Expect(name).To(Equal(limitName))
+			Expect(amount).To(Equal(required))
+		},
+		Entry("the OCPUs of the default workload", dbv1alpha1.AutonomousDatabaseDetails{CPUCoreCount: common.Int(2)}, "atp-ocpu-count", float32(2)),
+		Entry("the OCPUs of a data warehouse", dbv1alpha1.AutonomousDatabaseDetails{
+			CPUCoreCount: common.Int(4),
+			DbWorkload:   database.AutonomousDatabaseDbWorkloadDw,
+		}, "adw-ocpu-count", float32(4)),
+		Entry("the number of the Always Free databases", dbv1alpha1.AutonomousDatabaseDetails{IsFreeTier: common.Bool(true)}, "adb-free-count", float32(1)),
+		Entry("no limit of a dedicated database", dbv1alpha1.AutonomousDatabaseDetails{
+			AutonomousContainerDatabase: dbv1alpha1.ACDSpec{OCIACD: dbv1alpha1.OCIACDSpec{OCID: common.String("ocid1.autonomouscontainerdatabase.oc1..fake")}},
+		}, "", float32(0)),
+	)
+})
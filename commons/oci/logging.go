@@ -0,0 +1,76 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
+)
+
+// requestLogger dispatches the requests of an OCI client, and logs each of them with the opc-request-id of the
+// response, so that a request can be traced in OCI from the logs. The logger carries the values of the resource.
+type requestLogger struct {
+	logger     logr.Logger
+	dispatcher common.HTTPRequestDispatcher
+}
+
+func (l *requestLogger) Do(req *http.Request) (*http.Response, error) {
+	resp, err := l.dispatcher.Do(req)
+
+	keysAndValues := []interface{}{"operation", req.Method + " " + req.URL.Path}
+	if resp != nil {
+		keysAndValues = append(keysAndValues, "opc-request-id", resp.Header.Get("opc-request-id"), "statusCode", resp.StatusCode)
+	}
+
+	if err != nil {
+		l.logger.Error(err, "OCI request failed", keysAndValues...)
+	} else {
+		l.logger.Info("OCI request completed", keysAndValues...)
+	}
+	return resp, err
+}
+
+// logRequests logs every request sent by the OCI client
+func logRequests(logger logr.Logger, client *common.BaseClient) {
+	client.HTTPClient = &requestLogger{
+		logger:     logger.WithName("ociRequest"),
+		dispatcher: client.HTTPClient,
+	}
+}
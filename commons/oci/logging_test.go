@@ -0,0 +1,114 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+)
+
+// fakeDispatcher returns the response or the error without sending the request
+type fakeDispatcher struct {
+	resp *http.Response
+	err  error
+}
+
+func (d *fakeDispatcher) Do(req *http.Request) (*http.Response, error) {
+	return d.resp, d.err
+}
+
+var _ = Describe("Request logging", func() {
+	var (
+		entries    []map[string]interface{}
+		client     *common.BaseClient
+		dispatcher *fakeDispatcher
+		request    *http.Request
+	)
+
+	BeforeEach(func() {
+		entries = nil
+		logger := funcr.NewJSON(func(obj string) {
+			entry := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(obj), &entry)).To(Succeed())
+			entries = append(entries, entry)
+		}, funcr.Options{})
+
+		dispatcher = &fakeDispatcher{}
+		client = &common.BaseClient{HTTPClient: dispatcher}
+		logRequests(logger.WithValues("namespace", "default", "name", "adb"), client)
+
+		request = &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Path: "/20160918/autonomousDatabases/ocid1.autonomousdatabase.oc1..fake"},
+		}
+	})
+
+	It("should log the request with the opc-request-id of the response and the values of the logger", func() {
+		dispatcher.resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Opc-Request-Id": []string{"fake-request-id"}}}
+
+		resp, err := client.HTTPClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(BeIdenticalTo(dispatcher.resp))
+
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0]).To(HaveKeyWithValue("namespace", "default"))
+		Expect(entries[0]).To(HaveKeyWithValue("name", "adb"))
+		Expect(entries[0]).To(HaveKeyWithValue("operation", "GET /20160918/autonomousDatabases/ocid1.autonomousdatabase.oc1..fake"))
+		Expect(entries[0]).To(HaveKeyWithValue("opc-request-id", "fake-request-id"))
+		Expect(entries[0]).To(HaveKeyWithValue("statusCode", BeNumerically("==", http.StatusOK)))
+	})
+
+	It("should log the request which fails without a response", func() {
+		dispatcher.err = errors.New("connection refused")
+
+		_, err := client.HTTPClient.Do(request)
+		Expect(err).To(MatchError("connection refused"))
+
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0]).To(HaveKeyWithValue("msg", "OCI request failed"))
+		Expect(entries[0]).To(HaveKeyWithValue("error", "connection refused"))
+		Expect(entries[0]).ToNot(HaveKey("opc-request-id"))
+	})
+})
@@ -0,0 +1,140 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/core"
+)
+
+type NetworkService interface {
+	GetNsgOCIDs(ctx context.Context, subnetOCID string, names []string) ([]string, error)
+}
+
+// nsgLister is the part of the core.VirtualNetworkClient used by the networkService
+type nsgLister interface {
+	GetSubnet(ctx context.Context, request core.GetSubnetRequest) (core.GetSubnetResponse, error)
+	ListNetworkSecurityGroups(ctx context.Context, request core.ListNetworkSecurityGroupsRequest) (core.ListNetworkSecurityGroupsResponse, error)
+}
+
+type networkService struct {
+	logger        logr.Logger
+	networkClient nsgLister
+}
+
+func NewNetworkService(
+	logger logr.Logger,
+	provider common.ConfigurationProvider) (NetworkService, error) {
+
+	networkClient, err := core.NewVirtualNetworkClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	logRequests(logger, &networkClient.BaseClient)
+
+	return &networkService{
+		logger:        logger.WithName("networkService"),
+		networkClient: networkClient,
+	}, nil
+}
+
+// GetNsgOCIDs resolves the display names of the network security groups to their OCIDs. The groups are searched in
+// the VCN of the subnet, and it's an error if a name matches no group or more than one group. The OCIDs are returned
+// in the order of the names.
+func (n *networkService) GetNsgOCIDs(ctx context.Context, subnetOCID string, names []string) ([]string, error) {
+	subnetResp, err := n.networkClient.GetSubnet(ctx, core.GetSubnetRequest{SubnetId: common.String(subnetOCID)})
+	if err != nil {
+		return nil, err
+	}
+	vcnOCID := *subnetResp.VcnId
+
+	ocids := make([]string, len(names))
+	for i, name := range names {
+		nsgs, err := n.listNetworkSecurityGroups(ctx, vcnOCID, name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(nsgs) {
+		case 0:
+			return nil, fmt.Errorf("network security group %q is not found in the VCN %s", name, vcnOCID)
+		case 1:
+			ocids[i] = *nsgs[0].Id
+		default:
+			matched := make([]string, len(nsgs))
+			for j, nsg := range nsgs {
+				matched[j] = *nsg.Id
+			}
+			return nil, fmt.Errorf("network security group name %q is ambiguous, use the nsgOCIDs instead; matched network security groups: %s",
+				name, strings.Join(matched, ", "))
+		}
+	}
+
+	n.logger.Info(fmt.Sprintf("Network security groups %s are resolved to %s", strings.Join(names, ", "), strings.Join(ocids, ", ")))
+
+	return ocids, nil
+}
+
+// listNetworkSecurityGroups returns the AVAILABLE network security groups with the display name in the VCN
+func (n *networkService) listNetworkSecurityGroups(ctx context.Context, vcnOCID string, name string) ([]core.NetworkSecurityGroup, error) {
+	request := core.ListNetworkSecurityGroupsRequest{
+		VcnId:          common.String(vcnOCID),
+		DisplayName:    common.String(name),
+		LifecycleState: core.NetworkSecurityGroupLifecycleStateAvailable,
+	}
+
+	var nsgs []core.NetworkSecurityGroup
+	for {
+		resp, err := n.networkClient.ListNetworkSecurityGroups(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		nsgs = append(nsgs, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			return nsgs, nil
+		}
+		request.Page = resp.OpcNextPage
+	}
+}
@@ -0,0 +1,158 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/core"
+)
+
+// fakeNetworkClient stores the subnets and the network security groups in memory. The network security groups are
+// returned one per page.
+type fakeNetworkClient struct {
+	subnets []core.Subnet
+	nsgs    []core.NetworkSecurityGroup
+}
+
+func (c *fakeNetworkClient) GetSubnet(ctx context.Context, request core.GetSubnetRequest) (core.GetSubnetResponse, error) {
+	for _, subnet := range c.subnets {
+		if *subnet.Id == *request.SubnetId {
+			return core.GetSubnetResponse{Subnet: subnet}, nil
+		}
+	}
+	return core.GetSubnetResponse{}, fakeNotFoundError{}
+}
+
+func (c *fakeNetworkClient) ListNetworkSecurityGroups(ctx context.Context, request core.ListNetworkSecurityGroupsRequest) (core.ListNetworkSecurityGroupsResponse, error) {
+	var matched []core.NetworkSecurityGroup
+	for _, nsg := range c.nsgs {
+		if *nsg.VcnId == *request.VcnId && *nsg.DisplayName == *request.DisplayName && nsg.LifecycleState == request.LifecycleState {
+			matched = append(matched, nsg)
+		}
+	}
+
+	start := 0
+	if request.Page != nil {
+		start = len(*request.Page)
+	}
+	if start >= len(matched) {
+		return core.ListNetworkSecurityGroupsResponse{}, nil
+	}
+
+	resp := core.ListNetworkSecurityGroupsResponse{Items: matched[start : start+1]}
+	if start+1 < len(matched) {
+		resp.OpcNextPage = common.String(strings.Repeat("x", start+1))
+	}
+	return resp, nil
+}
+
+// fakeNotFoundError is the error returned for a missing resource
+type fakeNotFoundError struct{}
+
+func (fakeNotFoundError) Error() string { return "NotAuthorizedOrNotFound" }
+
+func fakeNsg(ocid string, name string, vcnOCID string) core.NetworkSecurityGroup {
+	return core.NetworkSecurityGroup{
+		Id:             common.String(ocid),
+		DisplayName:    common.String(name),
+		VcnId:          common.String(vcnOCID),
+		LifecycleState: core.NetworkSecurityGroupLifecycleStateAvailable,
+	}
+}
+
+var _ = Describe("Network", func() {
+	const (
+		subnetOCID = "ocid1.subnet.oc1..fake"
+		vcnOCID    = "ocid1.vcn.oc1..fake"
+	)
+
+	var service *networkService
+
+	BeforeEach(func() {
+		terminated := fakeNsg("ocid1.networksecuritygroup.oc1..old-db", "db", vcnOCID)
+		terminated.LifecycleState = core.NetworkSecurityGroupLifecycleStateTerminated
+
+		service = &networkService{
+			logger: logr.Discard(),
+			networkClient: &fakeNetworkClient{
+				subnets: []core.Subnet{{Id: common.String(subnetOCID), VcnId: common.String(vcnOCID)}},
+				nsgs: []core.NetworkSecurityGroup{
+					fakeNsg("ocid1.networksecuritygroup.oc1..db", "db", vcnOCID),
+					fakeNsg("ocid1.networksecuritygroup.oc1..apps", "apps", vcnOCID),
+					fakeNsg("ocid1.networksecuritygroup.oc1..web-1", "web", vcnOCID),
+					fakeNsg("ocid1.networksecuritygroup.oc1..web-2", "web", vcnOCID),
+					fakeNsg("ocid1.networksecuritygroup.oc1..other-db", "db", "ocid1.vcn.oc1..other"),
+					terminated,
+				},
+			},
+		}
+	})
+
+	Describe("GetNsgOCIDs", func() {
+		It("should resolve the names in the VCN of the subnet in order", func() {
+			Expect(service.GetNsgOCIDs(context.TODO(), subnetOCID, []string{"apps", "db"})).To(Equal([]string{
+				"ocid1.networksecuritygroup.oc1..apps",
+				"ocid1.networksecuritygroup.oc1..db",
+			}))
+		})
+
+		It("should fail if the name is ambiguous", func() {
+			_, err := service.GetNsgOCIDs(context.TODO(), subnetOCID, []string{"db", "web"})
+			Expect(err).To(MatchError(ContainSubstring(`network security group name "web" is ambiguous`)))
+			Expect(err.Error()).To(ContainSubstring("ocid1.networksecuritygroup.oc1..web-1"))
+			Expect(err.Error()).To(ContainSubstring("ocid1.networksecuritygroup.oc1..web-2"))
+		})
+
+		It("should fail if the name is not found", func() {
+			_, err := service.GetNsgOCIDs(context.TODO(), subnetOCID, []string{"cache"})
+			Expect(err).To(MatchError(ContainSubstring(`network security group "cache" is not found`)))
+		})
+
+		It("should fail if the subnet is not found", func() {
+			_, err := service.GetNsgOCIDs(context.TODO(), "ocid1.subnet.oc1..missing", []string{"db"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
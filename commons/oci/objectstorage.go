@@ -0,0 +1,101 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/objectstorage"
+)
+
+type ObjectStorageService interface {
+	PutObject(ctx context.Context, namespace string, bucket string, objectName string, content []byte) error
+	GetObjectURL(namespace string, bucket string, objectName string) string
+}
+
+type objectStorageService struct {
+	logger   logr.Logger
+	osClient objectstorage.ObjectStorageClient
+}
+
+func NewObjectStorageService(
+	logger logr.Logger,
+	provider common.ConfigurationProvider) (ObjectStorageService, error) {
+
+	osClient, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	logRequests(logger, &osClient.BaseClient)
+
+	return &objectStorageService{
+		logger:   logger.WithName("objectStorageService"),
+		osClient: osClient,
+	}, nil
+}
+
+// PutObject uploads the content to the bucket. The existing object with the same name is overwritten.
+func (o *objectStorageService) PutObject(ctx context.Context, namespace string, bucket string, objectName string, content []byte) error {
+	request := objectstorage.PutObjectRequest{
+		NamespaceName: common.String(namespace),
+		BucketName:    common.String(bucket),
+		ObjectName:    common.String(objectName),
+		ContentLength: common.Int64(int64(len(content))),
+		PutObjectBody: ioutil.NopCloser(bytes.NewReader(content)),
+	}
+
+	_, err := o.osClient.PutObject(ctx, request)
+	return err
+}
+
+// GetObjectURL returns the URL of the object in the region of the client
+func (o *objectStorageService) GetObjectURL(namespace string, bucket string, objectName string) string {
+	return objectURL(o.osClient.Endpoint(), namespace, bucket, objectName)
+}
+
+func objectURL(endpoint string, namespace string, bucket string, objectName string) string {
+	return fmt.Sprintf("%s/n/%s/b/%s/o/%s", endpoint,
+		url.PathEscape(namespace), url.PathEscape(bucket), url.PathEscape(objectName))
+}
@@ -40,10 +40,13 @@ package oci
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/common/auth"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/oracle/oracle-database-operator/commons/k8s"
@@ -83,6 +86,13 @@ func GetOCIProvider(kubeClient client.Client, authData APIKeyAuth) (common.Confi
 func getProviderWithAPIKey(kubeClient client.Client, authData APIKeyAuth) (common.ConfigurationProvider, error) {
 	var region, fingerprint, user, tenancy, passphrase, privatekeyValue string
 
+	if err := checkLocalReference("ConfigMap", *authData.ConfigMapName); err != nil {
+		return nil, err
+	}
+	if err := checkLocalReference("Secret", *authData.SecretName); err != nil {
+		return nil, err
+	}
+
 	// Prepare ConfigMap
 	ociConfigMap, err := k8s.FetchConfigMap(kubeClient, authData.Namespace, *authData.ConfigMapName)
 	if err != nil {
@@ -113,3 +123,16 @@ func getProviderWithAPIKey(kubeClient client.Client, authData APIKeyAuth) (commo
 
 	return common.NewRawConfigurationProvider(tenancy, user, region, fingerprint, privatekeyValue, &passphrase), nil
 }
+
+// checkLocalReference returns an error if the name is not the name of an object, e.g. a namespace-qualified name. The
+// credentials are only read from the namespace of the resource, so that a resource cannot read the credentials of
+// another namespace even if the webhook is bypassed.
+func checkLocalReference(kind string, name string) error {
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("the %s %q is in another namespace; cross-namespace references are not allowed", kind, name)
+	}
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return fmt.Errorf("invalid %s name %q: %s", kind, name, strings.Join(msgs, "; "))
+	}
+	return nil
+}
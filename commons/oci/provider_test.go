@@ -0,0 +1,107 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GetOCIProvider", func() {
+	var kubeClient client.Client
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		// The same credentials exist in both namespaces
+		objects := []client.Object{}
+		for _, namespace := range []string{"tenant-a", "tenant-b"} {
+			objects = append(objects,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "oci-cred", Namespace: namespace},
+					Data: map[string]string{
+						"tenancy":     "ocid1.tenancy.oc1..fake",
+						"user":        "ocid1.user.oc1..fake",
+						"fingerprint": "fake-fingerprint",
+						"region":      "us-phoenix-1",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "oci-privatekey", Namespace: namespace},
+					Data:       map[string][]byte{"privatekey": []byte("fake-privatekey")},
+				})
+		}
+		kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	})
+
+	It("should read the credentials in the namespace of the resource", func() {
+		provider, err := GetOCIProvider(kubeClient, APIKeyAuth{
+			ConfigMapName: common.String("oci-cred"),
+			SecretName:    common.String("oci-privatekey"),
+			Namespace:     "tenant-a",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(provider.TenancyOCID()).To(Equal("ocid1.tenancy.oc1..fake"))
+	})
+
+	It("should reject the Secret in another namespace", func() {
+		_, err := GetOCIProvider(kubeClient, APIKeyAuth{
+			ConfigMapName: common.String("oci-cred"),
+			SecretName:    common.String("tenant-b/oci-privatekey"),
+			Namespace:     "tenant-a",
+		})
+		Expect(err).To(MatchError(ContainSubstring("cross-namespace references are not allowed")))
+	})
+
+	It("should reject the ConfigMap in another namespace", func() {
+		_, err := GetOCIProvider(kubeClient, APIKeyAuth{
+			ConfigMapName: common.String("tenant-b/oci-cred"),
+			SecretName:    common.String("oci-privatekey"),
+			Namespace:     "tenant-a",
+		})
+		Expect(err).To(MatchError(ContainSubstring("cross-namespace references are not allowed")))
+	})
+})
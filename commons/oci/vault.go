@@ -48,7 +48,7 @@ import (
 )
 
 type VaultService interface {
-	GetSecretValue(vaultSecretOCID string) (string, error)
+	GetSecretValue(ctx context.Context, vaultSecretOCID string) (string, error)
 }
 
 type vaultService struct {
@@ -64,6 +64,7 @@ func NewVaultService(
 	if err != nil {
 		return nil, err
 	}
+	logRequests(logger, &secretClient.BaseClient)
 
 	return &vaultService{
 		logger:       logger.WithName("vaultService"),
@@ -71,12 +72,12 @@ func NewVaultService(
 	}, nil
 }
 
-func (v *vaultService) GetSecretValue(vaultSecretOCID string) (string, error) {
+func (v *vaultService) GetSecretValue(ctx context.Context, vaultSecretOCID string) (string, error) {
 	request := secrets.GetSecretBundleRequest{
 		SecretId: common.String(vaultSecretOCID),
 	}
 
-	response, err := v.secretClient.GetSecretBundle(context.TODO(), request)
+	response, err := v.secretClient.GetSecretBundle(ctx, request)
 	if err != nil {
 		return "", err
 	}
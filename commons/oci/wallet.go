@@ -40,63 +40,430 @@ package oci
 
 import (
 	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 )
 
+const (
+	sqlnetOraFileName   = "sqlnet.ora"
+	tnsnamesOraFileName = "tnsnames.ora"
+	cwalletSsoFileName  = "cwallet.sso"
+	ewalletPemFileName  = "ewallet.pem"
+
+	// TNSAliasKey is the key of the TNS alias in the Secret of a single connection profile
+	TNSAliasKey = "tns_alias"
+)
+
+// The files which have to be in the wallet zip
+var requiredWalletFiles = []string{tnsnamesOraFileName, cwalletSsoFileName}
+
+// Cipher suites that are allowed when a minimum TLS version is enforced. Weak ciphers,
+// e.g. CBC, RC4, 3DES, or the ones without forward secrecy, are stripped from the sqlnet.ora.
+var strongCipherSuites = map[string][]string{
+	"1.2": {
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_AES_256_GCM_SHA384",
+		"TLS_AES_128_GCM_SHA256",
+		"TLS_CHACHA20_POLY1305_SHA256",
+	},
+	"1.3": {
+		"TLS_AES_256_GCM_SHA384",
+		"TLS_AES_128_GCM_SHA256",
+		"TLS_CHACHA20_POLY1305_SHA256",
+	},
+}
+
+// The value of SSL_VERSION which allows the given version and the versions above it
+var sslVersions = map[string]string{
+	"1.2": "1.2 or 1.3",
+	"1.3": "1.3",
+}
+
 // ExtractWallet extracts the wallet and returns a map object which holds the byte values of the unzipped files.
+// An error is returned if the zip is corrupt or any of the required files is missing.
 func ExtractWallet(content io.ReadCloser) (map[string][]byte, error) {
-	path, err := saveWalletZip(content)
-	if err != nil {
-		return nil, err
-	}
+	defer content.Close()
 
-	data, err := unzipWallet(path)
+	zipContent, err := ioutil.ReadAll(content)
 	if err != nil {
 		return nil, err
 	}
-	return data, nil
+
+	return UnzipWallet(zipContent)
 }
 
-func saveWalletZip(content io.ReadCloser) (string, error) {
-	// Create a temp file wallet*.zip
-	const walletFileName = "wallet*.zip"
-	outZip, err := ioutil.TempFile("", walletFileName)
+// UnzipWallet unzips the wallet and returns a map object which holds the byte values of the files. An error is
+// returned if the zip is corrupt or any of the required files is missing, e.g. the zip is truncated during the download.
+func UnzipWallet(zipContent []byte) (map[string][]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("invalid wallet zip: %w", err)
+	}
+
+	data := map[string][]byte{}
+	for _, file := range reader.File {
+		fileReader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet zip: %w", err)
+		}
+
+		// The checksum of the file is verified when the reader reaches EOF
+		content, err := ioutil.ReadAll(fileReader)
+		fileReader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet zip: %s: %w", file.Name, err)
+		}
+
+		data[file.Name] = content
 	}
-	defer outZip.Close()
 
-	// Save the wallet in wallet*.zip
-	if _, err := io.Copy(outZip, content); err != nil {
-		return "", err
+	for _, fileName := range requiredWalletFiles {
+		if _, ok := data[fileName]; !ok {
+			return nil, fmt.Errorf("invalid wallet zip: %s not found in the wallet", fileName)
+		}
 	}
 
-	return outZip.Name(), nil
+	return data, nil
 }
 
-func unzipWallet(path string) (map[string][]byte, error) {
-	data := map[string][]byte{}
+// WalletChecksum returns the hex-encoded SHA-256 checksum of the wallet files. The files are hashed in the order of
+// their names, each as the name, a NUL byte, the content and another NUL byte, so that the checksum doesn't depend on
+// the order of the map.
+func WalletChecksum(data map[string][]byte) string {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	reader, err := zip.OpenReader(path)
-	if err != nil {
-		return data, err
+	hash := sha256.New()
+	for _, name := range names {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write(data[name])
+		hash.Write([]byte{0})
 	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-	defer reader.Close()
-	for _, file := range reader.File {
-		reader, err := file.Open()
+// ErrNoWalletCertificate is returned by WalletExpiry if the wallet has no certificate in the ewallet.pem, e.g. the
+// wallet is downloaded by an old version of OCI which only provides the cwallet.sso
+var ErrNoWalletCertificate = errors.New("no certificate found in the " + ewalletPemFileName)
+
+// WalletExpiry returns the earliest expiry of the certificates in the ewallet.pem of the wallet. The private key in the
+// file is encrypted with the wallet password, but the certificates are not, so the password is not required.
+func WalletExpiry(data map[string][]byte) (time.Time, error) {
+	var expiry time.Time
+
+	rest := data[ewalletPemFileName]
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
 		if err != nil {
-			return data, err
+			return time.Time{}, fmt.Errorf("invalid certificate in the %s: %w", ewalletPemFileName, err)
+		}
+		if expiry.IsZero() || cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+
+	if expiry.IsZero() {
+		return time.Time{}, ErrNoWalletCertificate
+	}
+	return expiry, nil
+}
+
+// WalletCertPool returns the pool of the CA certificates in the ewallet.pem of the wallet, which verify the certificate
+// of the database listener
+func WalletCertPool(data map[string][]byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	found := false
+
+	rest := data[ewalletPemFileName]
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
 		}
 
-		content, err := ioutil.ReadAll(reader)
+		cert, err := x509.ParseCertificate(block.Bytes)
 		if err != nil {
-			return data, err
+			return nil, fmt.Errorf("invalid certificate in the %s: %w", ewalletPemFileName, err)
+		}
+		if cert.IsCA {
+			pool.AddCert(cert)
+			found = true
 		}
+	}
 
-		data[file.Name] = content
+	if !found {
+		return nil, fmt.Errorf("no CA certificate found in the %s", ewalletPemFileName)
 	}
+	return pool, nil
+}
 
-	return data, nil
+// WalletConnectDescriptor returns the connect descriptor of the alias in the tnsnames.ora of the wallet. The alias is
+// case-insensitive. The first descriptor is returned if the alias is not found, e.g. the wallet is split by profile.
+func WalletConnectDescriptor(data map[string][]byte, alias string) (string, error) {
+	tnsnamesOra, ok := data[tnsnamesOraFileName]
+	if !ok {
+		return "", fmt.Errorf("%s not found in the wallet", tnsnamesOraFileName)
+	}
+
+	entries := parseTnsnamesOra(string(tnsnamesOra))
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no connect descriptor found in the %s", tnsnamesOraFileName)
+	}
+
+	for _, entry := range entries {
+		if strings.EqualFold(entry.alias, alias) {
+			return entry.value, nil
+		}
+	}
+	return entries[0].value, nil
+}
+
+// EnforceMinTLSVersion rewrites the sqlnet.ora in the wallet so that the client only negotiates the TLS version
+// minVersion or above, and only uses the strong cipher suites. Returns true if the sqlnet.ora is changed.
+func EnforceMinTLSVersion(data map[string][]byte, minVersion string) (bool, error) {
+	cipherSuites, ok := strongCipherSuites[minVersion]
+	if !ok {
+		return false, fmt.Errorf("unsupported TLS version %s", minVersion)
+	}
+
+	sqlnetOra, ok := data[sqlnetOraFileName]
+	if !ok {
+		return false, fmt.Errorf("%s not found in the wallet", sqlnetOraFileName)
+	}
+
+	params, remaining := removeSqlnetParameters(string(sqlnetOra), "SSL_VERSION", "SSL_CIPHER_SUITES")
+
+	// Keep the cipher suites in the original file if they are strong enough
+	if existing, ok := params["SSL_CIPHER_SUITES"]; ok {
+		var filtered []string
+		for _, cipher := range parseCipherSuites(existing) {
+			if containsString(cipherSuites, cipher) {
+				filtered = append(filtered, cipher)
+			}
+		}
+		if len(filtered) > 0 {
+			cipherSuites = filtered
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.TrimRight(remaining, "\n"))
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("SSL_VERSION = %s\n", sslVersions[minVersion]))
+	builder.WriteString(fmt.Sprintf("SSL_CIPHER_SUITES = (%s)\n", strings.Join(cipherSuites, ", ")))
+
+	newSqlnetOra := builder.String()
+	if newSqlnetOra == string(sqlnetOra) {
+		return false, nil
+	}
+
+	data[sqlnetOraFileName] = []byte(newSqlnetOra)
+	return true, nil
+}
+
+// The host of an address in the tnsnames.ora, e.g. (host=adb.us-ashburn-1.oraclecloud.com)
+var tnsHostPattern = regexp.MustCompile(`(?i)(\(\s*host\s*=\s*)([^)\s]+)(\s*\))`)
+
+// RewriteWalletHosts replaces the domain of the hosts in the tnsnames.ora of the wallet with baseDomain. The first
+// label of each host is kept, so that the rewriting is idempotent. Returns true if the tnsnames.ora is changed.
+func RewriteWalletHosts(data map[string][]byte, baseDomain string) (bool, error) {
+	tnsnamesOra, ok := data[tnsnamesOraFileName]
+	if !ok {
+		return false, fmt.Errorf("%s not found in the wallet", tnsnamesOraFileName)
+	}
+
+	baseDomain = strings.Trim(baseDomain, ".")
+	if baseDomain == "" {
+		return false, errors.New("the base domain is empty")
+	}
+
+	newTnsnamesOra := tnsHostPattern.ReplaceAllStringFunc(string(tnsnamesOra), func(address string) string {
+		groups := tnsHostPattern.FindStringSubmatch(address)
+		label := strings.SplitN(groups[2], ".", 2)[0]
+		return groups[1] + label + "." + baseDomain + groups[3]
+	})
+
+	if newTnsnamesOra == string(tnsnamesOra) {
+		return false, nil
+	}
+
+	data[tnsnamesOraFileName] = []byte(newTnsnamesOra)
+	return true, nil
+}
+
+// SplitWalletProfiles splits the wallet by the connection profiles in the tnsnames.ora. The returned map is keyed by
+// the profile name, e.g. "high" of the alias "mydb_high". Each wallet has the same files except that the tnsnames.ora
+// only contains the alias of the profile, which is also stored under the TNSAliasKey.
+func SplitWalletProfiles(data map[string][]byte) (map[string]map[string][]byte, error) {
+	tnsnamesOra, ok := data[tnsnamesOraFileName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the wallet", tnsnamesOraFileName)
+	}
+
+	profiles := map[string]map[string][]byte{}
+	for _, entry := range parseTnsnamesOra(string(tnsnamesOra)) {
+		profileData := map[string][]byte{}
+		for key, val := range data {
+			profileData[key] = val
+		}
+		profileData[tnsnamesOraFileName] = []byte(entry.alias + " = " + entry.value + "\n")
+		profileData[TNSAliasKey] = []byte(entry.alias)
+
+		profiles[profileName(entry.alias)] = profileData
+	}
+
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no connection profile found in the %s", tnsnamesOraFileName)
+	}
+	return profiles, nil
+}
+
+type tnsEntry struct {
+	alias string
+	value string
+}
+
+// parseTnsnamesOra returns the entries of a tnsnames.ora in order. An entry can span multiple lines if it's in parentheses.
+func parseTnsnamesOra(content string) []tnsEntry {
+	var entries []tnsEntry
+
+	var current *tnsEntry
+	var value strings.Builder
+	depth := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		if depth == 0 {
+			trimmed := strings.TrimSpace(line)
+			i := strings.Index(trimmed, "=")
+			if i <= 0 || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+
+			current = &tnsEntry{alias: strings.TrimSpace(trimmed[:i])}
+			value.Reset()
+			line = trimmed[i+1:]
+		}
+
+		depth += strings.Count(line, "(") - strings.Count(line, ")")
+		if depth < 0 {
+			depth = 0
+		}
+
+		value.WriteString(strings.TrimSpace(line))
+		if depth == 0 && current != nil {
+			current.value = value.String()
+			entries = append(entries, *current)
+			current = nil
+		}
+	}
+
+	return entries
+}
+
+// profileName returns the part after the database name of the alias, which is valid in a Secret name,
+// e.g. "tpurgent" of "mydb_tpurgent"
+func profileName(alias string) string {
+	name := strings.ToLower(alias)
+	if i := strings.Index(name, "_"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ReplaceAll(name, "_", "-")
+}
+
+// removeSqlnetParameters removes the given parameters from the content of a sqlnet.ora, and returns the values of
+// the removed parameters and the remaining content. A parameter value can span multiple lines if it's in parentheses.
+func removeSqlnetParameters(content string, keys ...string) (map[string]string, string) {
+	params := map[string]string{}
+	var kept []string
+
+	var currentKey string
+	var currentValue strings.Builder
+	depth := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		if depth == 0 {
+			currentKey = ""
+			if i := strings.Index(line, "="); i >= 0 {
+				key := strings.ToUpper(strings.TrimSpace(line[:i]))
+				for _, k := range keys {
+					if key == k {
+						currentKey = k
+						currentValue.Reset()
+						line = line[i+1:]
+						break
+					}
+				}
+			}
+		}
+
+		depth += strings.Count(line, "(") - strings.Count(line, ")")
+		if depth < 0 {
+			depth = 0
+		}
+
+		if currentKey == "" {
+			kept = append(kept, line)
+			continue
+		}
+
+		currentValue.WriteString(line)
+		if depth == 0 {
+			params[currentKey] = strings.TrimSpace(currentValue.String())
+		}
+	}
+
+	return params, strings.Join(kept, "\n")
+}
+
+func parseCipherSuites(value string) []string {
+	value = strings.Trim(strings.TrimSpace(value), "()")
+
+	var ciphers []string
+	for _, cipher := range strings.Split(value, ",") {
+		cipher = strings.ToUpper(strings.TrimSpace(cipher))
+		if cipher != "" {
+			ciphers = append(ciphers, cipher)
+		}
+	}
+	return ciphers
+}
+
+func containsString(list []string, s string) bool {
+	for _, val := range list {
+		if val == s {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,362 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const sampleSqlnetOra = `WALLET_LOCATION = (SOURCE = (METHOD = file) (METHOD_DATA = (DIRECTORY="?/network/admin")))
+SSL_SERVER_DN_MATCH=yes
+SSL_VERSION = 1.1
+SSL_CIPHER_SUITES = (TLS_RSA_WITH_AES_256_CBC_SHA,
+  TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384)
+`
+
+const sampleTnsnamesOra = `mydb_high = (description= (retry_count=20)(retry_delay=3)
+  (address=(protocol=tcps)(port=1522)(host=adb.us-phoenix-1.oraclecloud.com))
+  (connect_data=(service_name=mydb_high.adb.oraclecloud.com)))
+
+mydb_low = (description= (address=(protocol=tcps)(port=1522)(host=adb.us-phoenix-1.oraclecloud.com))(connect_data=(service_name=mydb_low.adb.oraclecloud.com)))
+mydb_tpurgent = (description= (address=(protocol=tcps)(port=1522)(host=adb.us-phoenix-1.oraclecloud.com))(connect_data=(service_name=mydb_tpurgent.adb.oraclecloud.com)))
+`
+
+var _ = Describe("Wallet", func() {
+	Describe("EnforceMinTLSVersion", func() {
+		It("should enforce the minimum TLS version and strip the weak ciphers", func() {
+			data := map[string][]byte{sqlnetOraFileName: []byte(sampleSqlnetOra)}
+
+			changed, err := EnforceMinTLSVersion(data, "1.2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			sqlnetOra := string(data[sqlnetOraFileName])
+			Expect(sqlnetOra).To(ContainSubstring("SSL_VERSION = 1.2 or 1.3\n"))
+			Expect(sqlnetOra).To(ContainSubstring("SSL_CIPHER_SUITES = (TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384)\n"))
+			Expect(sqlnetOra).ToNot(ContainSubstring("SSL_VERSION = 1.1"))
+			Expect(sqlnetOra).ToNot(ContainSubstring("CBC"))
+			Expect(sqlnetOra).To(ContainSubstring(`WALLET_LOCATION = (SOURCE = (METHOD = file) (METHOD_DATA = (DIRECTORY="?/network/admin")))`))
+			Expect(sqlnetOra).To(ContainSubstring("SSL_SERVER_DN_MATCH=yes"))
+		})
+
+		It("should add the strong cipher suites if none is specified", func() {
+			data := map[string][]byte{sqlnetOraFileName: []byte("SSL_SERVER_DN_MATCH=yes\n")}
+
+			_, err := EnforceMinTLSVersion(data, "1.3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data[sqlnetOraFileName])).To(Equal("SSL_SERVER_DN_MATCH=yes\n" +
+				"SSL_VERSION = 1.3\n" +
+				"SSL_CIPHER_SUITES = (TLS_AES_256_GCM_SHA384, TLS_AES_128_GCM_SHA256, TLS_CHACHA20_POLY1305_SHA256)\n"))
+		})
+
+		It("should not change the sqlnet.ora if the version is already enforced", func() {
+			data := map[string][]byte{sqlnetOraFileName: []byte(sampleSqlnetOra)}
+
+			_, err := EnforceMinTLSVersion(data, "1.2")
+			Expect(err).ToNot(HaveOccurred())
+			enforced := string(data[sqlnetOraFileName])
+
+			changed, err := EnforceMinTLSVersion(data, "1.2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(string(data[sqlnetOraFileName])).To(Equal(enforced))
+		})
+
+		It("should return an error if the version is not supported", func() {
+			data := map[string][]byte{sqlnetOraFileName: []byte(sampleSqlnetOra)}
+
+			_, err := EnforceMinTLSVersion(data, "1.0")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error if the sqlnet.ora is missing", func() {
+			_, err := EnforceMinTLSVersion(map[string][]byte{}, "1.2")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("RewriteWalletHosts", func() {
+		It("should replace the domain of the hosts and keep the service names", func() {
+			data := map[string][]byte{tnsnamesOraFileName: []byte(sampleTnsnamesOra)}
+
+			changed, err := RewriteWalletHosts(data, "db.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			tnsnamesOra := string(data[tnsnamesOraFileName])
+			Expect(tnsnamesOra).To(ContainSubstring("(host=adb.db.example.com)"))
+			Expect(tnsnamesOra).ToNot(ContainSubstring("us-phoenix-1"))
+			Expect(tnsnamesOra).To(ContainSubstring("(service_name=mydb_high.adb.oraclecloud.com)"))
+		})
+
+		It("should not change the tnsnames.ora if the hosts are already rewritten", func() {
+			data := map[string][]byte{tnsnamesOraFileName: []byte(sampleTnsnamesOra)}
+
+			_, err := RewriteWalletHosts(data, "db.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			rewritten := string(data[tnsnamesOraFileName])
+
+			changed, err := RewriteWalletHosts(data, "db.example.com.")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(string(data[tnsnamesOraFileName])).To(Equal(rewritten))
+		})
+
+		It("should return an error if the tnsnames.ora is missing", func() {
+			_, err := RewriteWalletHosts(map[string][]byte{}, "db.example.com")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UnzipWallet", func() {
+		newZip := func(files map[string]string) []byte {
+			buf := new(bytes.Buffer)
+			writer := zip.NewWriter(buf)
+			for name, content := range files {
+				fileWriter, err := writer.Create(name)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = fileWriter.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(writer.Close()).To(Succeed())
+			return buf.Bytes()
+		}
+
+		It("should unzip a valid wallet", func() {
+			data, err := UnzipWallet(newZip(map[string]string{
+				tnsnamesOraFileName: sampleTnsnamesOra,
+				cwalletSsoFileName:  "fake-cwallet-sso",
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(HaveKeyWithValue(tnsnamesOraFileName, []byte(sampleTnsnamesOra)))
+		})
+
+		It("should reject a truncated zip", func() {
+			content := newZip(map[string]string{
+				tnsnamesOraFileName: sampleTnsnamesOra,
+				cwalletSsoFileName:  "fake-cwallet-sso",
+			})
+
+			_, err := UnzipWallet(content[:len(content)/2])
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a wallet without the cwallet.sso", func() {
+			_, err := UnzipWallet(newZip(map[string]string{tnsnamesOraFileName: sampleTnsnamesOra}))
+			Expect(err).To(MatchError(ContainSubstring("cwallet.sso not found in the wallet")))
+		})
+	})
+
+	Describe("SplitWalletProfiles", func() {
+		It("should create a wallet per connection profile", func() {
+			data := map[string][]byte{
+				tnsnamesOraFileName: []byte(sampleTnsnamesOra),
+				sqlnetOraFileName:   []byte(sampleSqlnetOra),
+				"cwallet.sso":       []byte("fake-sso"),
+			}
+
+			profiles, err := SplitWalletProfiles(data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profiles).To(HaveLen(3))
+			Expect(profiles).To(HaveKey("high"))
+			Expect(profiles).To(HaveKey("low"))
+			Expect(profiles).To(HaveKey("tpurgent"))
+
+			high := profiles["high"]
+			Expect(string(high[TNSAliasKey])).To(Equal("mydb_high"))
+			Expect(string(high[tnsnamesOraFileName])).To(HavePrefix("mydb_high = (description="))
+			Expect(string(high[tnsnamesOraFileName])).To(ContainSubstring("service_name=mydb_high.adb.oraclecloud.com"))
+			Expect(string(high[tnsnamesOraFileName])).ToNot(ContainSubstring("mydb_low"))
+			Expect(high["cwallet.sso"]).To(Equal([]byte("fake-sso")))
+			Expect(high[sqlnetOraFileName]).To(Equal([]byte(sampleSqlnetOra)))
+
+			// The original wallet is not changed
+			Expect(data[tnsnamesOraFileName]).To(Equal([]byte(sampleTnsnamesOra)))
+		})
+
+		It("should return an error if the tnsnames.ora is missing", func() {
+			_, err := SplitWalletProfiles(map[string][]byte{sqlnetOraFileName: []byte(sampleSqlnetOra)})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("WalletChecksum", func() {
+		It("should only change if the files change", func() {
+			data := map[string][]byte{
+				tnsnamesOraFileName: []byte(sampleTnsnamesOra),
+				sqlnetOraFileName:   []byte(sampleSqlnetOra),
+			}
+			checksum := WalletChecksum(data)
+			Expect(checksum).To(HaveLen(64))
+
+			Expect(WalletChecksum(map[string][]byte{
+				sqlnetOraFileName:   []byte(sampleSqlnetOra),
+				tnsnamesOraFileName: []byte(sampleTnsnamesOra),
+			})).To(Equal(checksum))
+
+			data[sqlnetOraFileName] = append([]byte(sampleSqlnetOra), '\n')
+			Expect(WalletChecksum(data)).NotTo(Equal(checksum))
+		})
+
+		It("should not mix up the names and the contents", func() {
+			Expect(WalletChecksum(map[string][]byte{"ab": []byte("c")})).
+				NotTo(Equal(WalletChecksum(map[string][]byte{"a": []byte("bc")})))
+		})
+	})
+
+	Describe("WalletExpiry", func() {
+		// newCertificatePEM returns a self-signed certificate in PEM which expires at notAfter
+		newCertificatePEM := func(notAfter time.Time) []byte {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "adb.fake.oraclecloud.com"},
+				NotBefore:    notAfter.AddDate(-1, 0, 0),
+				NotAfter:     notAfter,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+
+			return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+
+		It("should return the earliest expiry of the certificates", func() {
+			earliest := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			var ewalletPem []byte
+			ewalletPem = append(ewalletPem, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("fake")})...)
+			ewalletPem = append(ewalletPem, newCertificatePEM(earliest.AddDate(5, 0, 0))...)
+			ewalletPem = append(ewalletPem, newCertificatePEM(earliest)...)
+
+			expiry, err := WalletExpiry(map[string][]byte{ewalletPemFileName: ewalletPem})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expiry.Equal(earliest)).To(BeTrue())
+		})
+
+		It("should return ErrNoWalletCertificate if the wallet has no certificate", func() {
+			_, err := WalletExpiry(map[string][]byte{tnsnamesOraFileName: []byte(sampleTnsnamesOra)})
+			Expect(err).To(MatchError(ErrNoWalletCertificate))
+		})
+
+		It("should return an error if a certificate is invalid", func() {
+			_, err := WalletExpiry(map[string][]byte{
+				ewalletPemFileName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("fake")}),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err).ToNot(MatchError(ErrNoWalletCertificate))
+		})
+	})
+
+	Describe("WalletCertPool", func() {
+		newCertificateDER := func(isCA bool) []byte {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber:          big.NewInt(1),
+				Subject:               pkix.Name{CommonName: "adb.fake.oraclecloud.com"},
+				NotBefore:             time.Now().Add(-time.Hour),
+				NotAfter:              time.Now().Add(time.Hour),
+				IsCA:                  isCA,
+				BasicConstraintsValid: true,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			return der
+		}
+
+		It("should only add the CA certificates", func() {
+			caDER := newCertificateDER(true)
+
+			var ewalletPem []byte
+			ewalletPem = append(ewalletPem, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("fake")})...)
+			ewalletPem = append(ewalletPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newCertificateDER(false)})...)
+			ewalletPem = append(ewalletPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+
+			pool, err := WalletCertPool(map[string][]byte{ewalletPemFileName: ewalletPem})
+			Expect(err).ToNot(HaveOccurred())
+
+			caCert, err := x509.ParseCertificate(caDER)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pool.Subjects()).To(Equal([][]byte{caCert.RawSubject}))
+		})
+
+		It("should return an error if the wallet has no CA certificate", func() {
+			_, err := WalletCertPool(map[string][]byte{
+				ewalletPemFileName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newCertificateDER(false)}),
+			})
+			Expect(err).To(MatchError(ContainSubstring("no CA certificate")))
+		})
+	})
+
+	Describe("WalletConnectDescriptor", func() {
+		data := map[string][]byte{tnsnamesOraFileName: []byte(sampleTnsnamesOra)}
+
+		It("should return the descriptor of the alias regardless of the case", func() {
+			descriptor, err := WalletConnectDescriptor(data, "MYDB_LOW")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(descriptor).To(ContainSubstring("service_name=mydb_low.adb.oraclecloud.com"))
+		})
+
+		It("should return the first descriptor if the alias is not found", func() {
+			descriptor, err := WalletConnectDescriptor(data, "mydb_medium")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(descriptor).To(ContainSubstring("service_name=mydb_high.adb.oraclecloud.com"))
+		})
+
+		It("should return an error if the tnsnames.ora is missing", func() {
+			_, err := WalletConnectDescriptor(map[string][]byte{}, "mydb_high")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
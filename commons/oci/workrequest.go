@@ -49,8 +49,8 @@ import (
 )
 
 type WorkRequestService interface {
-	Get(opcWorkRequestID string) (workrequests.GetWorkRequestResponse, error)
-	List(compartmentID string, resourceID string) (workrequests.ListWorkRequestsResponse, error)
+	Get(ctx context.Context, opcWorkRequestID string) (workrequests.GetWorkRequestResponse, error)
+	List(ctx context.Context, compartmentID string, resourceID string) (workrequests.ListWorkRequestsResponse, error)
 }
 
 type workRequestService struct {
@@ -67,6 +67,7 @@ func NewWorkRequestService(
 	if err != nil {
 		return nil, err
 	}
+	logRequests(logger, &workClient.BaseClient)
 
 	return &workRequestService{
 		logger:     logger.WithName("workRequestService"),
@@ -74,12 +75,12 @@ func NewWorkRequestService(
 	}, nil
 }
 
-func (w *workRequestService) Get(opcWorkRequestID string) (workrequests.GetWorkRequestResponse, error) {
+func (w *workRequestService) Get(ctx context.Context, opcWorkRequestID string) (workrequests.GetWorkRequestResponse, error) {
 	workRequest := workrequests.GetWorkRequestRequest{
 		WorkRequestId: common.String(opcWorkRequestID),
 	}
 
-	resp, err := w.workClient.GetWorkRequest(context.TODO(), workRequest)
+	resp, err := w.workClient.GetWorkRequest(ctx, workRequest)
 	if err != nil {
 		return resp, err
 	}
@@ -87,13 +88,13 @@ func (w *workRequestService) Get(opcWorkRequestID string) (workrequests.GetWorkR
 	return resp, nil
 }
 
-func (w *workRequestService) List(compartmentID string, resourceID string) (workrequests.ListWorkRequestsResponse, error) {
+func (w *workRequestService) List(ctx context.Context, compartmentID string, resourceID string) (workrequests.ListWorkRequestsResponse, error) {
 	req := workrequests.ListWorkRequestsRequest{
 		CompartmentId: common.String(compartmentID),
 		ResourceId:    common.String(resourceID),
 	}
 
-	resp, err := w.workClient.ListWorkRequests(context.TODO(), req)
+	resp, err := w.workClient.ListWorkRequests(ctx, req)
 	if err != nil {
 		return resp, err
 	}
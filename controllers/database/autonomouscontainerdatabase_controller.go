@@ -45,6 +45,7 @@ import (
 	"reflect"
 
 	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 
 	corev1 "k8s.io/api/core/v1"
@@ -71,6 +72,10 @@ type AutonomousContainerDatabaseReconciler struct {
 	Scheme     *runtime.Scheme
 	Recorder   record.EventRecorder
 
+	// MaxConcurrentReconciles is the max number of the resources reconciled at the same time. Zero uses the
+	// DefaultACDMaxConcurrentReconciles.
+	MaxConcurrentReconciles int
+
 	dbService oci.DatabaseService
 }
 
@@ -79,10 +84,15 @@ func (r *AutonomousContainerDatabaseReconciler) SetupWithManager(mgr ctrl.Manage
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousContainerDatabase{}).
 		WithEventFilter(r.eventFilterPredicate()).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 5}).
+		WithOptions(r.controllerOptions()).
 		Complete(r)
 }
 
+// controllerOptions returns the options of the controller
+func (r *AutonomousContainerDatabaseReconciler) controllerOptions() controller.Options {
+	return concurrencyOptions(r.MaxConcurrentReconciles, DefaultACDMaxConcurrentReconciles)
+}
+
 func (r *AutonomousContainerDatabaseReconciler) eventFilterPredicate() predicate.Predicate {
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -144,7 +154,7 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	if err := r.setupOCIClients(logger, acd); err != nil {
 		logger.Error(err, "Fail to setup OCI clients")
 
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 
 	logger.Info("OCI clients configured succesfully")
@@ -154,9 +164,9 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	******************************************************************/
 
 	if acd.Spec.AutonomousContainerDatabaseOCID != nil {
-		resp, err := r.dbService.GetAutonomousContainerDatabase(*acd.Spec.AutonomousContainerDatabaseOCID)
+		resp, err := r.dbService.GetAutonomousContainerDatabase(ctx, *acd.Spec.AutonomousContainerDatabaseOCID)
 		if err != nil {
-			return r.manageError(logger, acd, err)
+			return r.manageError(ctx, logger, acd, err)
 		}
 
 		ociACD = &dbv1alpha1.AutonomousContainerDatabase{}
@@ -170,7 +180,7 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	******************************************************************/
 	needsRequeue, err := r.validateLifecycleState(logger, acd, ociACD)
 	if err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 
 	if needsRequeue {
@@ -186,7 +196,7 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	******************************************************************/
 	exitReconcile, err := r.validateCleanup(logger, acd)
 	if err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 
 	if exitReconcile {
@@ -197,25 +207,32 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	* Register/unregister the finalizer
 	******************************************************************/
 	if err := r.validateFinalizer(acd); err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 
 	/******************************************************************
 	* Validate operations
 	******************************************************************/
-	exitReconcile, result, err := r.validateOperation(logger, acd, ociACD)
+	exitReconcile, result, err := r.validateOperation(ctx, logger, acd, ociACD)
 	if err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 	if exitReconcile {
 		return result, nil
 	}
 
+	/******************************************************************
+	* Enable or disable the automatic failover
+	******************************************************************/
+	if err := r.validateAutomaticFailover(ctx, logger, acd); err != nil {
+		return r.manageError(ctx, logger, acd, err)
+	}
+
 	/******************************************************************
 	*	Update the status and requeue if it's in an intermediate state
 	******************************************************************/
 	if err := r.KubeClient.Status().Update(context.TODO(), acd); err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
 	}
 
 	if dbv1alpha1.IsACDIntermediateState(acd.Status.LifecycleState) {
@@ -224,7 +241,13 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	}
 
 	if err := r.patchLastSuccessfulSpec(acd); err != nil {
-		return r.manageError(logger, acd, err)
+		return r.manageError(ctx, logger, acd, err)
+	}
+
+	// Wait until the standby is healthy to enable the automatic failover
+	if acd.IsAutomaticFailoverPending() {
+		logger.Info("The automatic failover is waiting for the standby; reconcile queued")
+		return requeueResult, nil
 	}
 
 	logger.Info("AutonomousContainerDatabase reconciles successfully")
@@ -246,7 +269,7 @@ func (r *AutonomousContainerDatabaseReconciler) setupOCIClients(logger logr.Logg
 		return err
 	}
 
-	r.dbService, err = oci.NewDatabaseService(logger, r.KubeClient, provider)
+	r.dbService, err = oci.NewDatabaseService(logger, r.KubeClient, provider, acd.Spec.OCIConfig.EndpointOverride)
 	if err != nil {
 		return err
 	}
@@ -254,7 +277,7 @@ func (r *AutonomousContainerDatabaseReconciler) setupOCIClients(logger logr.Logg
 	return nil
 }
 
-func (r *AutonomousContainerDatabaseReconciler) manageError(logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase, issue error) (ctrl.Result, error) {
+func (r *AutonomousContainerDatabaseReconciler) manageError(ctx context.Context, logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase, issue error) (ctrl.Result, error) {
 	l := logger.WithName("manageError")
 
 	// Has synced at least once
@@ -265,7 +288,7 @@ func (r *AutonomousContainerDatabaseReconciler) manageError(logger logr.Logger,
 		var finalIssue = issue
 
 		// Roll back
-		specChanged, err := r.getACD(logger, acd)
+		specChanged, err := r.getACD(ctx, logger, acd)
 		if err != nil {
 			finalIssue = k8s.CombineErrors(finalIssue, err)
 		}
@@ -307,6 +330,9 @@ func (r *AutonomousContainerDatabaseReconciler) validateLifecycleState(logger lo
 		return true, nil
 	}
 
+	// The armed state of the automatic failover is missing from the OCI object, and is refreshed by
+	// validateAutomaticFailover
+	ociACD.Status.IsAutomaticFailoverEnabled = acd.Status.IsAutomaticFailoverEnabled
 	acd.Status = ociACD.Status
 
 	if err := r.KubeClient.Status().Update(context.TODO(), acd); err != nil {
@@ -394,6 +420,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateFinalizer(acd *dbv1alpha
 }
 
 func (r *AutonomousContainerDatabaseReconciler) validateOperation(
+	ctx context.Context,
 	logger logr.Logger,
 	acd *dbv1alpha1.AutonomousContainerDatabase,
 	ociACD *dbv1alpha1.AutonomousContainerDatabase) (exitReconcile bool, result ctrl.Result, err error) {
@@ -410,7 +437,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateOperation(
 		if acd.Spec.AutonomousContainerDatabaseOCID == nil {
 			l.Info("Create operation")
 
-			err := r.createACD(logger, acd)
+			err := r.createACD(ctx, logger, acd)
 			if err != nil {
 				return false, emptyResult, err
 			}
@@ -425,7 +452,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateOperation(
 		} else {
 			l.Info("Bind operation")
 
-			_, err := r.getACD(logger, acd)
+			_, err := r.getACD(ctx, logger, acd)
 			if err != nil {
 				return false, emptyResult, err
 			}
@@ -461,7 +488,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateOperation(
 		}
 
 		if ociDetailsChanged {
-			ociReqSent, specChanged, err := r.updateACD(logger, acd, difACD)
+			ociReqSent, specChanged, err := r.updateACD(ctx, logger, acd, difACD)
 			if err != nil {
 				return false, emptyResult, err
 			}
@@ -494,7 +521,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateOperation(
 		l.Info("No operation specified; sync the resource")
 
 		// The user doesn't change the spec and the controller should pull the spec from the OCI.
-		specChanged, err := r.getACD(logger, acd)
+		specChanged, err := r.getACD(ctx, logger, acd)
 		if err != nil {
 			return false, emptyResult, err
 		}
@@ -511,6 +538,73 @@ func (r *AutonomousContainerDatabaseReconciler) validateOperation(
 	}
 }
 
+// validateAutomaticFailover enables or disables the automatic failover of the Autonomous Data Guard once the
+// Autonomous Container Database is AVAILABLE. The armed state is refreshed from the Data Guard association first. The
+// automatic failover is enabled only when the standby is healthy, otherwise the change is deferred.
+func (r *AutonomousContainerDatabaseReconciler) validateAutomaticFailover(ctx context.Context, logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase) error {
+	if acd.Spec.IsAutomaticFailoverEnabled == nil && acd.Status.IsAutomaticFailoverEnabled == nil {
+		return nil
+	}
+
+	if acd.Spec.AutonomousContainerDatabaseOCID == nil ||
+		acd.Status.LifecycleState != database.AutonomousContainerDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateAutomaticFailover")
+
+	associations, err := r.dbService.ListAutonomousContainerDatabaseDataguardAssociations(ctx, *acd.Spec.AutonomousContainerDatabaseOCID)
+	if err != nil {
+		return err
+	}
+
+	if len(associations) == 0 {
+		acd.Status.IsAutomaticFailoverEnabled = nil
+		if acd.Spec.IsAutomaticFailoverEnabled != nil && *acd.Spec.IsAutomaticFailoverEnabled {
+			r.Recorder.Event(acd, corev1.EventTypeWarning, "AutomaticFailoverUnavailable",
+				"The automatic failover requires an Autonomous Container Database with Autonomous Data Guard")
+		}
+		return nil
+	}
+
+	association := associations[0]
+	armed := association.IsAutomaticFailoverEnabled != nil && *association.IsAutomaticFailoverEnabled
+	acd.Status.IsAutomaticFailoverEnabled = common.Bool(armed)
+
+	desired := acd.Spec.IsAutomaticFailoverEnabled
+	if desired == nil || *desired == armed {
+		return nil
+	}
+
+	if *desired && !isStandbyHealthy(association) {
+		l.Info("The standby is not healthy; the automatic failover is deferred",
+			"LifecycleState", association.LifecycleState, "PeerLifecycleState", association.PeerLifecycleState)
+		return nil
+	}
+
+	l.Info("Sending UpdateAutonomousContainerDatabaseDataguardAssociation request to OCI", "IsAutomaticFailoverEnabled", *desired)
+	if _, err := r.dbService.UpdateAutomaticFailover(ctx, *acd.Spec.AutonomousContainerDatabaseOCID, *association.Id, *desired); err != nil {
+		return err
+	}
+
+	acd.Status.IsAutomaticFailoverEnabled = common.Bool(*desired)
+	if *desired {
+		r.Recorder.Event(acd, corev1.EventTypeNormal, "AutomaticFailoverEnabled", "Enabled the automatic failover")
+	} else {
+		r.Recorder.Event(acd, corev1.EventTypeNormal, "AutomaticFailoverDisabled", "Disabled the automatic failover")
+	}
+	return nil
+}
+
+// isStandbyHealthy returns true if the Data Guard association and its peer are AVAILABLE, and either of them is the
+// standby
+func isStandbyHealthy(association database.AutonomousContainerDatabaseDataguardAssociation) bool {
+	return association.LifecycleState == database.AutonomousContainerDatabaseDataguardAssociationLifecycleStateAvailable &&
+		association.PeerLifecycleState == database.AutonomousContainerDatabaseDataguardAssociationPeerLifecycleStateAvailable &&
+		(association.Role == database.AutonomousContainerDatabaseDataguardAssociationRoleStandby ||
+			association.PeerRole == database.AutonomousContainerDatabaseDataguardAssociationPeerRoleStandby)
+}
+
 func (r *AutonomousContainerDatabaseReconciler) updateCR(acd *dbv1alpha1.AutonomousContainerDatabase) error {
 	// Update the lastSucSpec
 	if err := acd.UpdateLastSuccessfulSpec(); err != nil {
@@ -538,10 +632,10 @@ func (r *AutonomousContainerDatabaseReconciler) patchLastSuccessfulSpec(acd *dbv
 	return nil
 }
 
-func (r *AutonomousContainerDatabaseReconciler) createACD(logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase) error {
+func (r *AutonomousContainerDatabaseReconciler) createACD(ctx context.Context, logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase) error {
 	logger.WithName("createACD").Info("Sending CreateAutonomousContainerDatabase request to OCI")
 
-	resp, err := r.dbService.CreateAutonomousContainerDatabase(acd)
+	resp, err := r.dbService.CreateAutonomousContainerDatabase(ctx, acd)
 	if err != nil {
 		return err
 	}
@@ -551,7 +645,7 @@ func (r *AutonomousContainerDatabaseReconciler) createACD(logger logr.Logger, ac
 	return nil
 }
 
-func (r *AutonomousContainerDatabaseReconciler) getACD(logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase) (bool, error) {
+func (r *AutonomousContainerDatabaseReconciler) getACD(ctx context.Context, logger logr.Logger, acd *dbv1alpha1.AutonomousContainerDatabase) (bool, error) {
 	if acd == nil {
 		return false, errors.New("AutonomousContainerDatabase OCID is missing")
 	}
@@ -559,7 +653,7 @@ func (r *AutonomousContainerDatabaseReconciler) getACD(logger logr.Logger, acd *
 	logger.WithName("getACD").Info("Sending GetAutonomousContainerDatabase request to OCI")
 
 	// Get the information from OCI
-	resp, err := r.dbService.GetAutonomousContainerDatabase(*acd.Spec.AutonomousContainerDatabaseOCID)
+	resp, err := r.dbService.GetAutonomousContainerDatabase(ctx, *acd.Spec.AutonomousContainerDatabaseOCID)
 	if err != nil {
 		return false, err
 	}
@@ -572,17 +666,18 @@ func (r *AutonomousContainerDatabaseReconciler) getACD(logger logr.Logger, acd *
 // updateACD returns true if an OCI request is sent.
 // The AutonomousContainerDatabase is updated with the returned object from the OCI requests.
 func (r *AutonomousContainerDatabaseReconciler) updateACD(
+	ctx context.Context,
 	logger logr.Logger,
 	acd *dbv1alpha1.AutonomousContainerDatabase,
 	difACD *dbv1alpha1.AutonomousContainerDatabase) (ociReqSent bool, specChanged bool, err error) {
 
-	validations := []func(logr.Logger, *dbv1alpha1.AutonomousContainerDatabase, *dbv1alpha1.AutonomousContainerDatabase) (bool, bool, error){
+	validations := []func(context.Context, logr.Logger, *dbv1alpha1.AutonomousContainerDatabase, *dbv1alpha1.AutonomousContainerDatabase) (bool, bool, error){
 		r.validateGeneralFields,
 		r.validateDesiredLifecycleState,
 	}
 
 	for _, op := range validations {
-		ociReqSent, specChanged, err := op(logger, acd, difACD)
+		ociReqSent, specChanged, err := op(ctx, logger, acd, difACD)
 		if err != nil {
 			return false, false, err
 		}
@@ -596,6 +691,7 @@ func (r *AutonomousContainerDatabaseReconciler) updateACD(
 }
 
 func (r *AutonomousContainerDatabaseReconciler) validateGeneralFields(
+	ctx context.Context,
 	logger logr.Logger,
 	acd *dbv1alpha1.AutonomousContainerDatabase,
 	difACD *dbv1alpha1.AutonomousContainerDatabase) (sent bool, requeue bool, err error) {
@@ -608,7 +704,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateGeneralFields(
 
 	logger.WithName("validateGeneralFields").Info("Sending UpdateAutonomousDatabase request to OCI")
 
-	resp, err := r.dbService.UpdateAutonomousContainerDatabase(*acd.Spec.AutonomousContainerDatabaseOCID, difACD)
+	resp, err := r.dbService.UpdateAutonomousContainerDatabase(ctx, *acd.Spec.AutonomousContainerDatabaseOCID, difACD)
 	if err != nil {
 		return false, false, err
 	}
@@ -619,6 +715,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateGeneralFields(
 }
 
 func (r *AutonomousContainerDatabaseReconciler) validateDesiredLifecycleState(
+	ctx context.Context,
 	logger logr.Logger,
 	acd *dbv1alpha1.AutonomousContainerDatabase,
 	difACD *dbv1alpha1.AutonomousContainerDatabase) (sent bool, specChanged bool, err error) {
@@ -633,7 +730,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateDesiredLifecycleState(
 	case dbv1alpha1.AcdActionRestart:
 		l.Info("Sending RestartAutonomousContainerDatabase request to OCI")
 
-		resp, err := r.dbService.RestartAutonomousContainerDatabase(*acd.Spec.AutonomousContainerDatabaseOCID)
+		resp, err := r.dbService.RestartAutonomousContainerDatabase(ctx, *acd.Spec.AutonomousContainerDatabaseOCID)
 		if err != nil {
 			return false, false, err
 		}
@@ -642,7 +739,7 @@ func (r *AutonomousContainerDatabaseReconciler) validateDesiredLifecycleState(
 	case dbv1alpha1.AcdActionTerminate:
 		l.Info("Sending TerminateAutonomousContainerDatabase request to OCI")
 
-		_, err := r.dbService.TerminateAutonomousContainerDatabase(*acd.Spec.AutonomousContainerDatabaseOCID)
+		_, err := r.dbService.TerminateAutonomousContainerDatabase(ctx, *acd.Spec.AutonomousContainerDatabaseOCID)
 		if err != nil {
 			return false, false, err
 		}
@@ -0,0 +1,157 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("AutonomousContainerDatabase automatic failover", func() {
+	const acdOCID = "ocid1.autonomouscontainerdatabase.oc1..fake"
+
+	var (
+		reconciler *AutonomousContainerDatabaseReconciler
+		dbService  *fakeDatabaseService
+		acd        *dbv1alpha1.AutonomousContainerDatabase
+	)
+
+	BeforeEach(func() {
+		acd = &dbv1alpha1.AutonomousContainerDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "acd",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousContainerDatabaseSpec{
+				AutonomousContainerDatabaseOCID: common.String(acdOCID),
+			},
+			Status: dbv1alpha1.AutonomousContainerDatabaseStatus{
+				LifecycleState: database.AutonomousContainerDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			dgAssociations: []database.AutonomousContainerDatabaseDataguardAssociation{{
+				Id:                            common.String("ocid1.autonomouscontainerdatabasedataguardassociation.oc1..fake"),
+				AutonomousContainerDatabaseId: common.String(acdOCID),
+				Role:                          database.AutonomousContainerDatabaseDataguardAssociationRolePrimary,
+				LifecycleState:                database.AutonomousContainerDatabaseDataguardAssociationLifecycleStateAvailable,
+				PeerRole:                      database.AutonomousContainerDatabaseDataguardAssociationPeerRoleStandby,
+				PeerLifecycleState:            database.AutonomousContainerDatabaseDataguardAssociationPeerLifecycleStateAvailable,
+				IsAutomaticFailoverEnabled:    common.Bool(false),
+			}},
+		}
+		reconciler = &AutonomousContainerDatabaseReconciler{
+			Log:       logr.Discard(),
+			Recorder:  record.NewFakeRecorder(10),
+			dbService: dbService,
+		}
+	})
+
+	It("should enable the automatic failover once the standby is healthy", func() {
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(true)
+		dbService.dgAssociations[0].PeerLifecycleState = database.AutonomousContainerDatabaseDataguardAssociationPeerLifecycleStateProvisioning
+
+		By("Deferring the change while the standby is provisioning")
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(BeZero())
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(Equal(common.Bool(false)))
+		Expect(acd.IsAutomaticFailoverPending()).To(BeTrue())
+
+		By("Enabling the automatic failover once the standby is AVAILABLE")
+		dbService.dgAssociations[0].PeerLifecycleState = database.AutonomousContainerDatabaseDataguardAssociationPeerLifecycleStateAvailable
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(Equal(1))
+		Expect(dbService.dgAssociations[0].IsAutomaticFailoverEnabled).To(Equal(common.Bool(true)))
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(Equal(common.Bool(true)))
+		Expect(acd.IsAutomaticFailoverPending()).To(BeFalse())
+
+		By("Sending no request once the automatic failover is armed")
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(Equal(1))
+	})
+
+	It("should enable the automatic failover from the standby Autonomous Container Database", func() {
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(true)
+		dbService.dgAssociations[0].Role = database.AutonomousContainerDatabaseDataguardAssociationRoleStandby
+		dbService.dgAssociations[0].PeerRole = database.AutonomousContainerDatabaseDataguardAssociationPeerRolePrimary
+
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(Equal(1))
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(Equal(common.Bool(true)))
+	})
+
+	It("should disable the automatic failover regardless of the standby", func() {
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(false)
+		dbService.dgAssociations[0].IsAutomaticFailoverEnabled = common.Bool(true)
+		dbService.dgAssociations[0].PeerLifecycleState = database.AutonomousContainerDatabaseDataguardAssociationPeerLifecycleStateUnavailable
+
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(Equal(1))
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(Equal(common.Bool(false)))
+	})
+
+	It("should leave the status empty if the Autonomous Container Database has no Data Guard association", func() {
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(true)
+		dbService.dgAssociations = nil
+
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(BeZero())
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(BeNil())
+		Expect(acd.IsAutomaticFailoverPending()).To(BeFalse())
+	})
+
+	It("should not send any request before the Autonomous Container Database is bound", func() {
+		acd.Spec.AutonomousContainerDatabaseOCID = nil
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(true)
+
+		Expect(reconciler.validateAutomaticFailover(context.TODO(), reconciler.Log, acd)).To(Succeed())
+		Expect(dbService.failoverCalls).To(BeZero())
+		Expect(acd.Status.IsAutomaticFailoverEnabled).To(BeNil())
+	})
+})
@@ -0,0 +1,101 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// AutonomousDatabaseAutoScalingPath is the path where the auto scaling advisor is served
+const AutonomousDatabaseAutoScalingPath = "/validate-database-oracle-com-v1alpha1-autonomousdatabase-autoscaling"
+
+// dwWithoutAutoScalingWarning is returned when a DW database is provisioned without auto scaling
+const dwWithoutAutoScalingWarning = "the DW workload is provisioned with isAutoScalingEnabled set to false; " +
+	"data warehouses usually benefit from auto scaling during the peak loads"
+
+//+kubebuilder:webhook:verbs=create,path=/validate-database-oracle-com-v1alpha1-autonomousdatabase-autoscaling,mutating=false,failurePolicy=ignore,sideEffects=None,groups=database.oracle.com,resources=autonomousdatabases,versions=v1alpha1,name=vautonomousdatabaseautoscaling.kb.io,admissionReviewVersions={v1}
+
+// AutonomousDatabaseAutoScalingAdvisor returns a warning when a DW database is provisioned without auto scaling.
+// The request is never denied.
+type AutonomousDatabaseAutoScalingAdvisor struct {
+	Log logr.Logger
+
+	// Disabled turns off the warning
+	Disabled bool
+
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &AutonomousDatabaseAutoScalingAdvisor{}
+
+// InjectDecoder implements admission.DecoderInjector
+func (a *AutonomousDatabaseAutoScalingAdvisor) InjectDecoder(d *admission.Decoder) error {
+	a.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler
+func (a *AutonomousDatabaseAutoScalingAdvisor) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if a.Disabled || req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	adb := &dbv1alpha1.AutonomousDatabase{}
+	if err := a.decoder.Decode(req, adb); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// The bind operation doesn't provision a database, so the settings of the existing database are kept
+	if adb.Spec.Details.AutonomousDatabaseOCID != nil ||
+		adb.Spec.Details.DbWorkload != database.AutonomousDatabaseDbWorkloadDw ||
+		(adb.Spec.Details.IsAutoScalingEnabled != nil && *adb.Spec.Details.IsAutoScalingEnabled) {
+		return admission.Allowed("")
+	}
+
+	a.Log.Info("DW workload provisioned without auto scaling", "Namespace", adb.GetNamespace(), "Name", adb.GetName())
+	return admission.Allowed("").WithWarnings(dwWithoutAutoScalingWarning)
+}
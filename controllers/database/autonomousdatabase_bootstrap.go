@@ -0,0 +1,278 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
+)
+
+const (
+	// defaultBootstrapImage provides sqlplus to the bootstrap Job
+	defaultBootstrapImage = "ghcr.io/oracle/oraclelinux8-instantclient:21"
+	// defaultBootstrapBackoffLimit is the number of retries of the bootstrap Job
+	defaultBootstrapBackoffLimit int32 = 3
+	// bootstrapConfigMapAnnotation records the ConfigMap whose scripts are run by the bootstrap Job
+	bootstrapConfigMapAnnotation = "database.oracle.com/bootstrap-configmap"
+)
+
+// bootstrapScript runs the scripts in the order of the file names. The wallet is copied to a writable directory, so
+// that the sqlnet.ora can point to it. Each script stops at the first error.
+const bootstrapScript = `set -e
+cp -rL /wallet /tmp/wallet
+sed -i 's|?/network/admin|/tmp/wallet|' /tmp/wallet/sqlnet.ora
+export TNS_ADMIN=/tmp/wallet
+for f in $(ls /bootstrap | sort); do
+  echo "Running $f"
+  { echo "WHENEVER SQLERROR EXIT FAILURE"; echo "WHENEVER OSERROR EXIT FAILURE"; cat "/bootstrap/$f"; echo; echo "EXIT"; } |
+    sqlplus -S -L "$ADMIN_USER/\"$ADMIN_PASSWORD\"@$TNS_ALIAS"
+done
+`
+
+// isBootstrapPending returns true if the bootstrap SQL in the ConfigMap of the spec has not been completed
+func isBootstrapPending(adb *dbv1alpha1.AutonomousDatabase) bool {
+	configMapName := adb.Spec.Bootstrap.ConfigMapName
+	return configMapName != nil &&
+		(adb.Status.Bootstrap.ConfigMapName != *configMapName || adb.Status.Bootstrap.CompletionTime == nil)
+}
+
+func bootstrapJobName(adb *dbv1alpha1.AutonomousDatabase) string {
+	return adb.GetName() + "-bootstrap"
+}
+
+// validateBootstrap runs the bootstrap SQL once the database is AVAILABLE and the wallet Secret is ready. The scripts
+// are run by a Job, which is created once per ConfigMap; the completion is recorded in status.bootstrap, so that the
+// scripts are not run again. The Job retries the failed scripts up to the backoffLimit, after which the
+// BootstrapFailed condition is set. Deleting the failed Job retries the bootstrap. Returns true if the Job is still
+// running or has not been created yet.
+func (r *AutonomousDatabaseReconciler) validateBootstrap(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (pending bool, err error) {
+	if !isBootstrapPending(adb) {
+		return false, nil
+	}
+
+	l := logger.WithName("validateBootstrap")
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		l.Info("The database is not AVAILABLE; the bootstrap is postponed")
+		return true, nil
+	}
+
+	walletNamespace, walletName := walletLocation(adb)
+	if _, err := k8s.FetchSecret(r.KubeClient, walletNamespace, walletName); err != nil {
+		if apiErrors.IsNotFound(err) {
+			l.Info("The wallet is not ready; the bootstrap is postponed")
+			return true, nil
+		}
+		return false, err
+	}
+
+	job := &batchv1.Job{}
+	err = r.KubeClient.Get(ctx, client.ObjectKey{Namespace: adb.GetNamespace(), Name: bootstrapJobName(adb)}, job)
+	if apiErrors.IsNotFound(err) {
+		return true, r.createBootstrapJob(ctx, l, adb)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// The Job of another ConfigMap is replaced
+	if job.Annotations[bootstrapConfigMapAnnotation] != *adb.Spec.Bootstrap.ConfigMapName {
+		l.Info("The ConfigMap is changed; delete the bootstrap Job of the previous ConfigMap")
+		if err := r.KubeClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apiErrors.IsNotFound(err) {
+			return false, err
+		}
+		return true, nil
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch cond.Type {
+		case batchv1.JobComplete:
+			completionTime := metav1.Now()
+			if job.Status.CompletionTime != nil {
+				completionTime = *job.Status.CompletionTime
+			}
+			adb.Status.Bootstrap = dbv1alpha1.BootstrapStatus{
+				ConfigMapName:  *adb.Spec.Bootstrap.ConfigMapName,
+				CompletionTime: &completionTime,
+			}
+			meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionBootstrapFailed)
+
+			l.Info("The bootstrap SQL is completed")
+			r.Recorder.Event(adb, corev1.EventTypeNormal, "Bootstrapped",
+				fmt.Sprintf("The bootstrap SQL in the ConfigMap %s is completed", *adb.Spec.Bootstrap.ConfigMapName))
+			return false, nil
+
+		case batchv1.JobFailed:
+			msg := fmt.Sprintf("The bootstrap Job %s has failed: %s; delete the Job to retry", job.GetName(), cond.Message)
+			if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionBootstrapFailed) {
+				r.Recorder.Event(adb, corev1.EventTypeWarning, "BootstrapFailed", msg)
+			}
+			meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+				Type:               dbv1alpha1.ADBConditionBootstrapFailed,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: adb.GetGeneration(),
+				Reason:             cond.Reason,
+				Message:            msg,
+			})
+			return false, nil
+		}
+	}
+
+	l.Info("The bootstrap Job is running")
+	return true, nil
+}
+
+// createBootstrapJob creates the Job which runs the scripts of the ConfigMap as the admin user
+func (r *AutonomousDatabaseReconciler) createBootstrapJob(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	_, walletName := walletLocation(adb)
+
+	image := defaultBootstrapImage
+	if adb.Spec.Bootstrap.Image != nil {
+		image = *adb.Spec.Bootstrap.Image
+	}
+
+	tnsAlias := strings.ToLower(adb.Status.DbName) + "_low"
+	if adb.Spec.Bootstrap.TNSAlias != nil {
+		tnsAlias = *adb.Spec.Bootstrap.TNSAlias
+	}
+
+	backoffLimit := defaultBootstrapBackoffLimit
+	if adb.Spec.Bootstrap.BackoffLimit != nil {
+		backoffLimit = *adb.Spec.Bootstrap.BackoffLimit
+	}
+
+	adminUsername := dbv1alpha1.DefaultAdminUsername
+	if adb.Spec.Details.AdminUsername != nil {
+		adminUsername = *adb.Spec.Details.AdminUsername
+	}
+
+	if adb.Spec.Details.AdminPassword.K8sSecret.Name == nil {
+		return fmt.Errorf("the bootstrap requires the admin password in a Secret")
+	}
+	passwordSecret := *adb.Spec.Details.AdminPassword.K8sSecret.Name
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        bootstrapJobName(adb),
+			Namespace:   adb.GetNamespace(),
+			Labels:      map[string]string{"app": adb.GetName()},
+			Annotations: map[string]string{bootstrapConfigMapAnnotation: *adb.Spec.Bootstrap.ConfigMapName},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": adb.GetName()},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "bootstrap",
+							Image:   image,
+							Command: []string{"/bin/sh", "-c", bootstrapScript},
+							Env: []corev1.EnvVar{
+								{Name: "ADMIN_USER", Value: adminUsername},
+								{
+									Name: "ADMIN_PASSWORD",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: passwordSecret},
+											Key:                  passwordSecret,
+										},
+									},
+								},
+								{Name: "TNS_ALIAS", Value: tnsAlias},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "wallet", MountPath: "/wallet", ReadOnly: true},
+								{Name: "bootstrap", MountPath: "/bootstrap", ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "wallet",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: walletName},
+							},
+						},
+						{
+							Name: "bootstrap",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: *adb.Spec.Bootstrap.ConfigMapName},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(adb, job, r.KubeClient.Scheme()); err != nil {
+		return err
+	}
+
+	if err := r.KubeClient.Create(ctx, job); err != nil && !apiErrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	logger.Info("Created the bootstrap Job " + job.GetName())
+	return nil
+}
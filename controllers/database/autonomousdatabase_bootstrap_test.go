@@ -0,0 +1,206 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("AutonomousDatabase bootstrap", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		adb        *dbv1alpha1.AutonomousDatabase
+		jobKey     types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+				UID:       "fake-uid",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AdminPassword: dbv1alpha1.PasswordSpec{
+						K8sSecret: dbv1alpha1.K8sSecretSpec{Name: common.String("admin-password")},
+					},
+				},
+				Bootstrap: dbv1alpha1.BootstrapSpec{ConfigMapName: common.String("bootstrap-sql")},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				DbName:         "FAKEDB",
+			},
+		}
+		wallet := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "adb-instance-wallet", Namespace: "default"},
+		}
+
+		reconciler = &AutonomousDatabaseReconciler{
+			KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(adb, wallet).Build(),
+			Recorder:   record.NewFakeRecorder(10),
+		}
+		jobKey = types.NamespacedName{Name: "adb-bootstrap", Namespace: "default"}
+	})
+
+	setJobCondition := func(conditionType batchv1.JobConditionType) {
+		job := &batchv1.Job{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, job)).To(Succeed())
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:    conditionType,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Fake",
+			Message: "fake message",
+		})
+		Expect(reconciler.KubeClient.Status().Update(context.TODO(), job)).To(Succeed())
+	}
+
+	It("should create the Job once the database is AVAILABLE and the wallet is ready", func() {
+		pending, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeTrue())
+
+		job := &batchv1.Job{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, job)).To(Succeed())
+		Expect(job.GetAnnotations()).To(HaveKeyWithValue(bootstrapConfigMapAnnotation, "bootstrap-sql"))
+		Expect(*job.Spec.BackoffLimit).To(Equal(defaultBootstrapBackoffLimit))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TNS_ALIAS", Value: "fakedb_low"}))
+		Expect(job.Spec.Template.Spec.Volumes[0].Secret.SecretName).To(Equal("adb-instance-wallet"))
+		Expect(job.Spec.Template.Spec.Volumes[1].ConfigMap.Name).To(Equal("bootstrap-sql"))
+		Expect(metav1.IsControlledBy(job, adb)).To(BeTrue())
+	})
+
+	It("should postpone the bootstrap until the wallet is ready", func() {
+		adb.Spec.Details.Wallet.Name = common.String("missing-wallet")
+
+		pending, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeTrue())
+		Expect(apiErrors.IsNotFound(reconciler.KubeClient.Get(context.TODO(), jobKey, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should record the completion and not run the scripts again", func() {
+		_, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		setJobCondition(batchv1.JobComplete)
+
+		pending, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeFalse())
+		Expect(adb.Status.Bootstrap.ConfigMapName).To(Equal("bootstrap-sql"))
+		Expect(adb.Status.Bootstrap.CompletionTime).ToNot(BeNil())
+		Expect(isBootstrapPending(adb)).To(BeFalse())
+
+		// The Job is not re-created once the bootstrap is completed
+		job := &batchv1.Job{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, job)).To(Succeed())
+		Expect(reconciler.KubeClient.Delete(context.TODO(), job)).To(Succeed())
+
+		pending, err = reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeFalse())
+		Expect(apiErrors.IsNotFound(reconciler.KubeClient.Get(context.TODO(), jobKey, &batchv1.Job{}))).To(BeTrue())
+	})
+
+	It("should set the BootstrapFailed condition if the Job has failed", func() {
+		_, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		setJobCondition(batchv1.JobFailed)
+
+		pending, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeFalse())
+		Expect(adb.Status.Bootstrap.CompletionTime).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionBootstrapFailed)).To(BeTrue())
+
+		// Deleting the failed Job retries the bootstrap
+		job := &batchv1.Job{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, job)).To(Succeed())
+		Expect(reconciler.KubeClient.Delete(context.TODO(), job)).To(Succeed())
+
+		pending, err = reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeTrue())
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, &batchv1.Job{})).To(Succeed())
+	})
+
+	It("should replace the Job if the ConfigMap is changed", func() {
+		_, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		setJobCondition(batchv1.JobComplete)
+		_, err = reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+
+		adb.Spec.Bootstrap.ConfigMapName = common.String("bootstrap-sql-v2")
+		Expect(isBootstrapPending(adb)).To(BeTrue())
+
+		pending, err := reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeTrue())
+		Expect(apiErrors.IsNotFound(reconciler.KubeClient.Get(context.TODO(), jobKey, &batchv1.Job{}))).To(BeTrue())
+
+		_, err = reconciler.validateBootstrap(context.TODO(), logr.Discard(), adb)
+		Expect(err).ToNot(HaveOccurred())
+		job := &batchv1.Job{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), jobKey, job)).To(Succeed())
+		Expect(job.GetAnnotations()).To(HaveKeyWithValue(bootstrapConfigMapAnnotation, "bootstrap-sql-v2"))
+	})
+})
@@ -43,40 +43,87 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/adb_family"
 	"github.com/oracle/oracle-database-operator/commons/annotations"
 	"github.com/oracle/oracle-database-operator/commons/k8s"
+	"github.com/oracle/oracle-database-operator/commons/lock"
 	"github.com/oracle/oracle-database-operator/commons/oci"
 )
 
 var requeueResult ctrl.Result = ctrl.Result{Requeue: true, RequeueAfter: 15 * time.Second}
 var emptyResult ctrl.Result = ctrl.Result{}
 
+const (
+	defaultHealthCheckInterval = 60 * time.Second
+	defaultHealthCheckTimeout  = 10 * time.Second
+
+	minTerminationRequeue = 15 * time.Second
+	maxTerminationRequeue = 5 * time.Minute
+
+	// The failed update is retried after the interval. Only the fields which are still different from the database
+	// in OCI are sent, since the spec is compared with the database again in the next reconcile.
+	failedUpdateRequeue = 1 * time.Minute
+	// The requeue interval after an error which is not expected to succeed until the spec or the OCI config is fixed,
+	// e.g. an invalid request or a missing permission
+	fatalErrorRequeue = 10 * time.Minute
+
+	// The requeue interval once the provisioning is stalled, so that the recovery is still observed
+	stalledProvisionRequeue = 10 * time.Minute
+
+	// The number of attempts to download a valid wallet in a reconcile
+	walletDownloadAttempts = 3
+	// The requeue interval after OCI responds to the wallet generation with a conflict, e.g. the database has just
+	// become AVAILABLE. The interval doubles after each retry, up to the interval of requeueResult.
+	walletGenerationBackoff = 2 * time.Second
+)
+
+// adbMutations serializes the OCI requests on the same database from the AutonomousDatabase, the backup and the restore
+// controllers, e.g. a manual backup and the scaling of the same database. The controllers have separate work queues,
+// so the reconciles of the same database can run concurrently otherwise.
+var adbMutations = lock.NewKeyedMutex()
+
+// errWalletPending is returned if the wallet cannot be generated yet
+var errWalletPending = errors.New("the wallet cannot be generated yet")
+
+// errWalletFailed is returned if the wallet cannot be generated after the retries in the spec.details.wallet.downloadRetries
+var errWalletFailed = errors.New("the wallet cannot be generated after the retries")
+
 // *AutonomousDatabaseReconciler reconciles a AutonomousDatabase object
 type AutonomousDatabaseReconciler struct {
 	KubeClient client.Client
@@ -84,13 +131,79 @@ type AutonomousDatabaseReconciler struct {
 	Scheme     *runtime.Scheme
 	Recorder   record.EventRecorder
 
+	// SkipTerminationWait removes the finalizer once the terminate request is accepted,
+	// instead of waiting for the database to be TERMINATED in OCI
+	SkipTerminationWait bool
+	// TerminationMaxWait is the max time to wait for the database to be TERMINATED before removing the finalizer.
+	// Zero means no limit.
+	TerminationMaxWait time.Duration
+	// ProvisionMaxWait is the max time for the database to be PROVISIONING before the Stalled condition is set and
+	// the requeue slows down. Zero means no limit.
+	ProvisionMaxWait time.Duration
+	// ResyncPeriod is the max time between two syncs with OCI if the generation doesn't change.
+	// The OCI diffing is skipped until then. Zero means the diffing runs in every reconcile.
+	ResyncPeriod time.Duration
+	// ResyncJitter is the max fraction of the ResyncPeriod randomly added to each resync, so that the resources
+	// created together don't reach OCI at the same time. Zero disables the jitter.
+	ResyncJitter float64
+	// AllowedOperations is the set of the OCI operations which may be sent. The requests of the other operations are
+	// refused with the OperationDenied condition. Nil allows all the operations.
+	AllowedOperations map[OCIOperation]bool
+	// QuotaPrecheck checks the available quota in the compartment with the OCI Limits service before a database is
+	// provisioned, so that the provisioning fails with the QuotaExceeded condition before any request is sent.
+	QuotaPrecheck bool
+	// MaxConcurrentReconciles is the max number of the resources reconciled at the same time. Zero uses the
+	// DefaultADBMaxConcurrentReconciles.
+	MaxConcurrentReconciles int
+	// WalletNamespaces is the set of the namespaces where the wallet Secrets of the resources in the other namespaces
+	// may be stored. Nil only allows the namespace of the resource.
+	WalletNamespaces map[string]bool
+	// ShutdownGracePeriod is how long the reconciles in flight may still send the OCI requests and update the
+	// resources after the manager is stopped. Zero cancels them right away, and a negative value never cancels them.
+	ShutdownGracePeriod time.Duration
+
 	dbService oci.DatabaseService
+	// newDBService builds the dbService from the OCI config of the resource. Only overridden in the tests.
+	newDBService func(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error)
+
+	osService oci.ObjectStorageService
+	// newOSService builds the osService from the OCI config of the resource. Only overridden in the tests.
+	newOSService func(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (oci.ObjectStorageService, error)
+
+	idService oci.IdentityService
+	// newIDService builds the idService from the OCI config of the resource. Only overridden in the tests.
+	newIDService func(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (oci.IdentityService, error)
+
+	netService oci.NetworkService
+	// newNetService builds the netService from the OCI config of the resource. Only overridden in the tests.
+	newNetService func(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (oci.NetworkService, error)
+
+	limService oci.LimitsService
+	// newLimService builds the limService from the OCI config of the resource. Only overridden in the tests.
+	newLimService func(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (oci.LimitsService, error)
+
+	// The reconciles in flight, which are awaited when the manager is stopped. No reconcile starts once stopping is
+	// set, so that the WaitGroup is never incremented while it's awaited.
+	shutdownLock sync.Mutex
+	stopping     bool
+	inFlight     sync.WaitGroup
 }
 
 // SetupWithManager function
 func (r *AutonomousDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Export the fleet metrics from the informer cache
+	if err := metrics.Registry.Register(NewAutonomousDatabaseCollector(mgr.GetClient(), r.Log)); err != nil {
+		return err
+	}
+
+	// Let the in-flight reconciles persist the status before the manager exits
+	if err := mgr.Add(manager.RunnableFunc(r.waitForInFlightReconciles)); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabase{}).
+		Owns(&batchv1.Job{}).
 		Watches(
 			&source.Kind{Type: &dbv1alpha1.AutonomousDatabaseBackup{}},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueMapFn()),
@@ -100,10 +213,76 @@ func (r *AutonomousDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error
 			handler.EnqueueRequestsFromMapFunc(r.enqueueMapFn()),
 		).
 		WithEventFilter(predicate.And(r.eventFilterPredicate(), r.watchPredicate())).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 50}). // ReconcileHandler is never invoked concurrently with the same object.
+		WithOptions(r.controllerOptions()). // ReconcileHandler is never invoked concurrently with the same object.
 		Complete(r)
 }
 
+// controllerOptions returns the options of the controller
+func (r *AutonomousDatabaseReconciler) controllerOptions() controller.Options {
+	return concurrencyOptions(r.MaxConcurrentReconciles, DefaultADBMaxConcurrentReconciles)
+}
+
+// startReconcile registers a reconcile in flight, or returns false if the manager is being stopped. The caller must
+// call r.inFlight.Done() once the reconcile is finished if true is returned.
+func (r *AutonomousDatabaseReconciler) startReconcile(ctx context.Context) bool {
+	r.shutdownLock.Lock()
+	defer r.shutdownLock.Unlock()
+
+	if r.stopping || ctx.Err() != nil {
+		return false
+	}
+	r.inFlight.Add(1)
+	return true
+}
+
+// waitForInFlightReconciles blocks until the manager is stopped, and then waits for the reconciles in flight. The
+// OCI requests and the status updates use the context of reconcileContext, which outlives the manager by the
+// ShutdownGracePeriod, so a reconcile in flight, e.g. the one which has just provisioned a database, still persists
+// the status. The wait is bounded by the graceful shutdown timeout of the manager.
+func (r *AutonomousDatabaseReconciler) waitForInFlightReconciles(ctx context.Context) error {
+	<-ctx.Done()
+
+	r.shutdownLock.Lock()
+	r.stopping = true
+	r.shutdownLock.Unlock()
+
+	r.Log.Info("Waiting for the in-flight reconciles to finish")
+	r.inFlight.Wait()
+	r.Log.Info("All the in-flight reconciles are finished")
+
+	return nil
+}
+
+// reconcileContext returns the context of the OCI requests and the Kubernetes requests of a reconcile. The ctx of
+// Reconcile is canceled as soon as the manager is stopped, while the returned context is only canceled once the
+// ShutdownGracePeriod has passed since then, or once the returned CancelFunc is called.
+func (r *AutonomousDatabaseReconciler) reconcileContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	reconcileCtx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-reconcileCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+
+		if r.ShutdownGracePeriod < 0 {
+			return
+		}
+
+		timer := time.NewTimer(r.ShutdownGracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-reconcileCtx.Done():
+		case <-timer.C:
+			cancel()
+		}
+	}()
+
+	return reconcileCtx, cancel
+}
+
 func (r *AutonomousDatabaseReconciler) enqueueMapFn() handler.MapFunc {
 	return func(o client.Object) []reconcile.Request {
 		reqs := make([]reconcile.Request, len(o.GetOwnerReferences()))
@@ -183,19 +362,30 @@ func (r *AutonomousDatabaseReconciler) eventFilterPredicate() predicate.Predicat
 // +kubebuilder:rbac:groups=database.oracle.com,resources=autonomouscontainerdatabases,verbs=get;list
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;get;list;update
 // +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile is the funtion that the operator calls every time when the reconciliation loop is triggered.
 // It go to the beggining of the reconcile if an error is returned. We won't return a error if it is related
 // to OCI, because the issues cannot be solved by re-run the reconcile.
 func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := r.Log.WithValues("Namespace/Name", req.NamespacedName)
+	logger := reconcileLogger(r.Log, req.NamespacedName, nil)
+
+	// No reconcile starts once the manager is being stopped. The resource is reconciled again after the restart.
+	if !r.startReconcile(ctx) {
+		logger.Info("The manager is being stopped; skip the reconcile")
+		return emptyResult, nil
+	}
+	defer r.inFlight.Done()
+
+	// The reconcile in flight may finish within the grace period once the manager is stopped
+	ctx, cancel := r.reconcileContext(ctx)
+	defer cancel()
 
 	var err error
-	var ociADB *dbv1alpha1.AutonomousDatabase
 
 	// Get the autonomousdatabase instance from the cluster
 	desiredADB := &dbv1alpha1.AutonomousDatabase{}
-	if err := r.KubeClient.Get(context.TODO(), req.NamespacedName, desiredADB); err != nil {
+	if err := r.KubeClient.Get(ctx, req.NamespacedName, desiredADB); err != nil {
 		// Ignore not-found errors, since they can't be fixed by an immediate requeue.
 		// No need to change the since we don't know if we obtain the object.
 		if apiErrors.IsNotFound(err) {
@@ -204,6 +394,27 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		// Failed to get ADB, so we don't need to update the status
 		return emptyResult, err
 	}
+	logger = reconcileLogger(r.Log, req.NamespacedName, desiredADB)
+
+	/******************************************************************
+	* Skip all the OCI operations if the reconciliation is paused
+	******************************************************************/
+	paused, err := r.validatePause(ctx, logger, desiredADB)
+	if err != nil {
+		return emptyResult, err
+	}
+	if paused {
+		return emptyResult, nil
+	}
+
+	/******************************************************************
+	* Skip the OCI diffing if the generation has been synced and the
+	* periodic resync is not due yet
+	******************************************************************/
+	if resyncAfter, synced := r.isSynced(desiredADB); synced {
+		logger.Info("The generation has been synced; next resync queued", "RequeueAfter", resyncAfter.String())
+		return ctrl.Result{RequeueAfter: resyncAfter}, nil
+	}
 
 	/******************************************************************
 	* Get OCI database client
@@ -211,11 +422,20 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if err := r.setupOCIClients(logger, desiredADB); err != nil {
 		logger.Error(err, "Fail to setup OCI clients")
 
-		return r.manageError(logger.WithName("setupOCIClients"), desiredADB, err)
+		return r.manageError(ctx, logger.WithName("setupOCIClients"), desiredADB, err)
 	}
 
 	logger.Info("OCI clients configured succesfully")
 
+	/******************************************************************
+	* Wait until the backup and the restore controllers finish sending
+	* the requests on the same database
+	******************************************************************/
+	if adbOCID := desiredADB.GetAutonomousDatabaseOCID(); adbOCID != nil {
+		unlock := adbMutations.Lock(*adbOCID)
+		defer unlock()
+	}
+
 	/******************************************************************
 	* Cleanup the resource if the resource is to be deleted.
 	* Deletion timestamp will be added to a object before it is deleted.
@@ -223,13 +443,13 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 	* all the finalizers are removed from the object metadata.
 	* Refer to this page for more details of using finalizers: https://kubernetes.io/blog/2022/05/14/using-finalizers-to-control-deletion/
 	******************************************************************/
-	exitReconcile, err := r.validateCleanup(logger, desiredADB)
+	exitReconcile, result, err := r.validateCleanup(ctx, logger, desiredADB)
 	if err != nil {
-		return r.manageError(logger.WithName("validateCleanup"), desiredADB, err)
+		return r.manageError(ctx, logger.WithName("validateCleanup"), desiredADB, err)
 	}
 
 	if exitReconcile {
-		return emptyResult, nil
+		return result, nil
 	}
 
 	/******************************************************************
@@ -237,62 +457,211 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 	******************************************************************/
 	exit, err := r.validateFinalizer(logger, desiredADB)
 	if err != nil {
-		return r.manageError(logger.WithName("validateFinalizer"), desiredADB, err)
+		return r.manageError(ctx, logger.WithName("validateFinalizer"), desiredADB, err)
 	}
 
 	if exit {
 		return emptyResult, nil
 	}
 
+	/******************************************************************
+	* Validate the admin password file. Wait until the file is ready
+	* because the password could be required by the operations.
+	******************************************************************/
+	if err := r.validateAdminPasswordFile(desiredADB); err != nil {
+		logger.Info(err.Error() + "; reconcile queued")
+		r.Recorder.Event(desiredADB, corev1.EventTypeWarning, "AdminPasswordNotReady", err.Error())
+
+		if err := r.updateStatus(ctx, desiredADB); err != nil {
+			return r.manageError(ctx, logger.WithName("validateAdminPasswordFile"), desiredADB, err)
+		}
+		return requeueResult, nil
+	}
+
 	/******************************************************************
 	* Validate operations
 	******************************************************************/
 	modifiedADB := desiredADB.DeepCopy() // the ADB which stores the changes
-	exitReconcile, result, err := r.validateOperation(logger, modifiedADB, ociADB)
+	exitReconcile, result, err = r.validateOperation(ctx, logger, modifiedADB)
 	if err != nil {
-		return r.manageError(logger.WithName("validateOperation"), modifiedADB, err)
+		return r.manageError(ctx, logger.WithName("validateOperation"), modifiedADB, err)
 	}
 	if exitReconcile {
 		return result, nil
 	}
+	// The result is requeueResult if an OCI request is sent
+	requestSent := result.Requeue
 
 	/*****************************************************
 	*	Sync AutonomousDatabase Backups from OCI
 	*****************************************************/
-	if err := r.syncBackupResources(logger, modifiedADB); err != nil {
-		return r.manageError(logger.WithName("syncBackupResources"), modifiedADB, err)
+	if err := r.syncBackupResources(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("syncBackupResources"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Run the one-shot action if requested
+	*****************************************************/
+	resetLastAction(modifiedADB)
+
+	/*****************************************************
+	*	Rotate the wallet if requested
+	*****************************************************/
+	if err := r.validateWalletRotation(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateWalletRotation"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Rotate the encryption key if requested
+	*****************************************************/
+	if err := r.validateEncryptionKeyRotation(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateEncryptionKeyRotation"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Refresh the connection URLs if requested
+	*****************************************************/
+	if err := r.validateConnectionURLsRefresh(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateConnectionURLsRefresh"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Shrink the storage if requested
+	*****************************************************/
+	if err := r.validateShrink(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateShrink"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Detach the refreshable clone if requested
+	*****************************************************/
+	if err := r.validateCloneDetach(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateCloneDetach"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Create or terminate the cross-region standby peers
+	*****************************************************/
+	if err := r.validateDisasterRecoveryPeers(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateDisasterRecoveryPeers"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Regenerate the wallet if the mTLS setting changes
+	*****************************************************/
+	if err := r.validateWalletMTLS(ctx, logger, modifiedADB); err != nil && !errors.Is(err, errWalletPending) && !errors.Is(err, errWalletFailed) {
+		return r.manageError(ctx, logger.WithName("validateWalletMTLS"), modifiedADB, err)
 	}
 
 	/*****************************************************
 	*	Validate Wallet
 	*****************************************************/
-	if err := r.validateWallet(logger, modifiedADB); err != nil {
-		return r.manageError(logger.WithName("validateWallet"), modifiedADB, err)
+	// The database is still reported as it is in OCI if the wallet cannot be generated yet
+	walletErr := r.validateWallet(ctx, logger, modifiedADB)
+	if walletErr != nil && !errors.Is(walletErr, errWalletPending) && !errors.Is(walletErr, errWalletFailed) {
+		return r.manageError(ctx, logger.WithName("validateWallet"), modifiedADB, walletErr)
+	}
+
+	/*****************************************************
+	*	Report whether the database is ready
+	*****************************************************/
+	setReadyCondition(modifiedADB, walletErr)
+
+	/*****************************************************
+	*	Run the bootstrap SQL once the wallet is ready
+	*****************************************************/
+	bootstrapPending, err := r.validateBootstrap(ctx, logger, modifiedADB)
+	if err != nil {
+		return r.manageError(ctx, logger.WithName("validateBootstrap"), modifiedADB, err)
 	}
 
+	/*****************************************************
+	*	Validate conditions
+	*****************************************************/
+	r.validateConnectivity(ctx, logger, modifiedADB)
+	r.validateDbWorkloadCondition(modifiedADB)
+	r.validateAdminPasswordRotation(modifiedADB)
+
 	/******************************************************************
 	*	Requeue if it's in an intermediate state. Update the status right before
 	* exiting the reconcile, otherwise the modifiedADB will be overwritten
 	* by the object returned from the cluster.
 	******************************************************************/
+	stalled := r.validateProvisionStalled(logger, modifiedADB)
+
 	if dbv1alpha1.IsADBIntermediateState(modifiedADB.Status.LifecycleState) {
-		logger.WithName("IsADBIntermediateState").Info("LifecycleState is " + string(modifiedADB.Status.LifecycleState) + "; reconcile queued")
+		result := requeueResult
+		if stalled {
+			result = ctrl.Result{RequeueAfter: stalledProvisionRequeue}
+		}
 
-		if err := r.KubeClient.Status().Update(context.TODO(), modifiedADB); err != nil {
-			return r.manageError(logger.WithName("IsADBIntermediateState"), modifiedADB, err)
+		logger.WithName("IsADBIntermediateState").Info("LifecycleState is "+string(modifiedADB.Status.LifecycleState)+"; reconcile queued",
+			"RequeueAfter", result.RequeueAfter.String())
+
+		if err := r.updateStatus(ctx, modifiedADB); err != nil {
+			return r.manageError(ctx, logger.WithName("IsADBIntermediateState"), modifiedADB, err)
 		}
 
-		return requeueResult, nil
+		return result, nil
 	}
 
 	/******************************************************************
 	* Update the lastSucSpec and the status, and then finish the reconcile.
-	*	Requeue if it's in an intermediate state or the modifiedADB
-	* doesn't match the desiredADB.
+	* The spec is never updated by the controller. Requeue if an OCI
+	* request is sent, so that the result is observed in the status.
 	******************************************************************/
-	// Do the comparison before updating the status to avoid being overwritten
-	var requeue bool = false
-	if !reflect.DeepEqual(modifiedADB.Spec, desiredADB.Spec) {
+	var requeue bool = requestSent
+
+	// Retry the failed upload of the wallet
+	if meta.IsStatusConditionFalse(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded) {
+		logger.Info("The wallet is not uploaded to the Object Storage bucket; reconcile queued")
+		requeue = true
+	}
+
+	// Wait for the wallet rotation
+	if meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating) {
+		logger.Info("The wallet is being rotated; reconcile queued")
+		requeue = true
+	}
+
+	// Wait until Database Management and Operations Insights are enabled or disabled
+	if dbv1alpha1.IsEnablementTransient(string(modifiedADB.Status.DatabaseManagementStatus)) {
+		logger.Info("Database Management is " + string(modifiedADB.Status.DatabaseManagementStatus) + "; reconcile queued")
+		requeue = true
+	}
+	if dbv1alpha1.IsEnablementTransient(string(modifiedADB.Status.OperationsInsightsStatus)) {
+		logger.Info("Operations Insights is " + string(modifiedADB.Status.OperationsInsightsStatus) + "; reconcile queued")
+		requeue = true
+	}
+
+	// Wait until the cross-region standby peers are created or terminated
+	if modifiedADB.IsDisasterRecoveryPeerTransient() {
+		logger.Info("A disaster recovery peer is being created or terminated; reconcile queued")
+		requeue = true
+	}
+
+	// Wait until the new admin password is confirmed
+	if meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating) {
+		logger.Info("The admin password is being rotated; reconcile queued")
+		requeue = true
+	}
+
+	// Wait for the encryption key rotation
+	if meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating) {
+		logger.Info("The encryption key is being rotated; reconcile queued")
+		requeue = true
+	}
+
+	// Retry the wallet generation
+	var walletRetryAfter time.Duration
+	if meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionWalletPending) {
+		logger.Info("The wallet cannot be generated yet; reconcile queued")
+		walletRetryAfter = walletRetryInterval(modifiedADB)
+	}
+
+	// Wait for the bootstrap SQL
+	if bootstrapPending {
+		logger.Info("The bootstrap SQL is not completed; reconcile queued")
 		requeue = true
 	}
 
@@ -303,203 +672,505 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		requeue = true
 	}
 
-	if err := r.patchLastSuccessfulSpec(modifiedADB); err != nil {
-		return r.manageError(logger.WithName("patchLastSuccessfulSpec"), modifiedADB, err)
+	// Record the spec only if it has been applied to the database
+	if !requestSent {
+		if err := r.patchLastSuccessfulSpec(modifiedADB); err != nil {
+			return r.manageError(ctx, logger.WithName("patchLastSuccessfulSpec"), modifiedADB, err)
+		}
+
+		now := metav1.Now()
+		modifiedADB.Status.ObservedGeneration = modifiedADB.GetGeneration()
+		modifiedADB.Status.LastSyncTime = &now
+		setLastError(modifiedADB, nil)
+	}
+
+	if err := r.updateStatus(ctx, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("Status().Update"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Annotate the console URL
+	*****************************************************/
+	if err := r.patchConsoleURL(modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("patchConsoleURL"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Export the manifest if requested
+	*****************************************************/
+	if err := r.validateExportManifest(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateExportManifest"), modifiedADB, err)
+	}
+
+	/*****************************************************
+	*	Adopt the databases in the compartment if requested
+	*****************************************************/
+	if err := r.validateAdoptCompartment(ctx, logger, modifiedADB); err != nil {
+		return r.manageError(ctx, logger.WithName("validateAdoptCompartment"), modifiedADB, err)
 	}
 
-	if err := r.KubeClient.Status().Update(context.TODO(), modifiedADB); err != nil {
-		return r.manageError(logger.WithName("Status().Update"), modifiedADB, err)
+	// The refresh, the export and the adoption are one-shot, so remove the annotations once the resource is synced
+	if err := annotations.RemoveAnnotations(r.KubeClient, modifiedADB,
+		dbv1alpha1.ForceRefreshAnnotation, dbv1alpha1.ExportManifestAnnotation, dbv1alpha1.AdoptCompartmentAnnotation); err != nil {
+		return r.manageError(ctx, logger.WithName("RemoveAnnotations"), modifiedADB, err)
 	}
 
 	if requeue {
 		logger.Info("Reconcile queued")
 		return requeueResult, nil
 
+	} else if walletRetryAfter > 0 {
+		logger.Info("Reconcile queued", "after", walletRetryAfter.String())
+		return ctrl.Result{RequeueAfter: walletRetryAfter}, nil
+
+	} else if isHealthCheckEnabled(modifiedADB) {
+		logger.Info("AutonomousDatabase reconciles successfully; next connectivity check queued")
+		return ctrl.Result{RequeueAfter: getHealthCheckDuration(modifiedADB.Spec.HealthCheck.IntervalSeconds, defaultHealthCheckInterval)}, nil
+
+	} else if r.ResyncPeriod > 0 {
+		logger.Info("AutonomousDatabase reconciles successfully; next resync queued")
+		return ctrl.Result{RequeueAfter: r.jitterResync(r.ResyncPeriod)}, nil
+
 	} else {
 		logger.Info("AutonomousDatabase reconciles successfully")
 		return emptyResult, nil
 	}
 }
 
-func (r *AutonomousDatabaseReconciler) setupOCIClients(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
-	var err error
-
-	authData := oci.APIKeyAuth{
-		ConfigMapName: adb.Spec.OCIConfig.ConfigMapName,
-		SecretName:    adb.Spec.OCIConfig.SecretName,
-		Namespace:     adb.GetNamespace(),
+// isSynced returns true if the current generation has been applied to the database, and the periodic resync is not
+// due yet. The returned duration is the remaining time to the next resync. The reconcile is never skipped if a
+// refresh is forced by the annotation, the resource is to be deleted, or the health check is enabled, since the
+// connectivity is checked in every reconcile.
+func (r *AutonomousDatabaseReconciler) isSynced(adb *dbv1alpha1.AutonomousDatabase) (time.Duration, bool) {
+	if r.ResyncPeriod <= 0 ||
+		adb.IsForceRefreshRequested() ||
+		adb.IsExportManifestRequested() ||
+		adb.IsAdoptCompartmentRequested() ||
+		adb.PendingAction() != "" ||
+		adb.GetDeletionTimestamp() != nil ||
+		isHealthCheckEnabled(adb) ||
+		isBootstrapPending(adb) ||
+		meta.IsStatusConditionFalse(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded) ||
+		meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending) ||
+		adb.Status.LastSyncTime == nil ||
+		adb.Status.ObservedGeneration != adb.GetGeneration() {
+		return 0, false
 	}
 
-	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
-	if err != nil {
-		return err
+	resyncAfter := r.ResyncPeriod - time.Since(adb.Status.LastSyncTime.Time)
+	if resyncAfter <= 0 {
+		return 0, false
 	}
 
-	r.dbService, err = oci.NewDatabaseService(logger, r.KubeClient, provider)
-	if err != nil {
-		return err
-	}
+	return r.jitterResync(resyncAfter), true
+}
 
-	return nil
+// jitterResync adds a random duration of up to ResyncJitter times the ResyncPeriod to the duration.
+func (r *AutonomousDatabaseReconciler) jitterResync(d time.Duration) time.Duration {
+	if r.ResyncJitter <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Float64()*r.ResyncJitter*float64(r.ResyncPeriod))
 }
 
-func (r *AutonomousDatabaseReconciler) manageError(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, issue error) (ctrl.Result, error) {
-	l := logger.WithName("manageError")
-	// Has synced at least once
-	if adb.Status.LifecycleState != "" {
-		// Send event
-		r.Recorder.Event(adb, corev1.EventTypeWarning, "UpdateFailed", issue.Error())
+// validateExportManifest writes the manifest of the live OCI config to the ConfigMap <name>-manifest if the
+// ExportManifestAnnotation exists. The ConfigMap is owned by the resource.
+func (r *AutonomousDatabaseReconciler) validateExportManifest(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if !adb.IsExportManifestRequested() {
+		return nil
+	}
 
-		var finalIssue = issue
+	adbOCID := adb.GetAutonomousDatabaseOCID()
+	if adbOCID == nil {
+		logger.Info("The database is not provisioned yet; skip exporting the manifest")
+		return nil
+	}
 
-		// Roll back
-		ociADB := adb.DeepCopy()
-		specChanged, err := r.getADB(l, ociADB)
-		if err != nil {
-			finalIssue = k8s.CombineErrors(finalIssue, err)
-		}
+	resp, err := r.dbService.GetAutonomousDatabase(ctx, *adbOCID)
+	if err != nil {
+		return err
+	}
 
-		// Will exit the Reconcile anyway after the manageError is called.
-		if specChanged {
-			// Clear the lifecycleState first to avoid the webhook error when update during an intermediate state
-			adb.Status.LifecycleState = ""
-			if err := r.KubeClient.Status().Update(context.TODO(), adb); err != nil {
-				finalIssue = k8s.CombineErrors(finalIssue, err)
-			}
+	manifest, err := adbfamily.ExportManifest(adb, resp.AutonomousDatabase)
+	if err != nil {
+		return err
+	}
 
-			adb.Spec = ociADB.Spec
+	name := adb.GetName() + "-manifest"
+	data := map[string]string{"autonomousdatabase.yaml": string(manifest)}
+	if err := k8s.CreateOrUpdateConfigMap(r.KubeClient, adb.GetNamespace(), name, data, adb); err != nil {
+		return err
+	}
 
-			if err := r.KubeClient.Update(context.TODO(), adb); err != nil {
-				finalIssue = k8s.CombineErrors(finalIssue, err)
-			}
-		}
+	logger.Info("Manifest exported", "ConfigMap", name)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "ManifestExported", "The manifest is exported to the ConfigMap "+name)
 
-		l.Error(finalIssue, "UpdateFailed")
+	return nil
+}
 
-		return emptyResult, nil
-	} else {
-		// Send event
-		r.Recorder.Event(adb, corev1.EventTypeWarning, "CreateFailed", issue.Error())
+// validateAdoptCompartment creates a resource bound to each database in the compartment if the
+// AdoptCompartmentAnnotation exists. The resources are named after the dbName, and use the same ociConfig. The
+// databases which already have a resource in the namespace, and the terminated ones, are skipped.
+func (r *AutonomousDatabaseReconciler) validateAdoptCompartment(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if !adb.IsAdoptCompartmentRequested() {
+		return nil
+	}
 
-		return emptyResult, issue
+	if adb.Status.CompartmentOCID == "" {
+		logger.Info("The database is not provisioned yet; skip adopting the compartment")
+		return nil
 	}
-}
 
-func (r *AutonomousDatabaseReconciler) validateOperation(
-	logger logr.Logger,
-	adb *dbv1alpha1.AutonomousDatabase,
-	ociADB *dbv1alpha1.AutonomousDatabase) (exit bool, result ctrl.Result, err error) {
+	l := logger.WithName("validateAdoptCompartment")
 
-	lastSpec, err := adb.GetLastSuccessfulSpec()
+	summaries, err := r.dbService.ListAutonomousDatabases(ctx, adb.Status.CompartmentOCID)
 	if err != nil {
-		return false, emptyResult, err
+		return err
 	}
 
-	l := logger.WithName("validateOperation")
-
-	// If lastSucSpec is nil, then it's CREATE or BIND opertaion
-	if lastSpec == nil {
-		if adb.Spec.Details.AutonomousDatabaseOCID == nil {
-			l.Info("Create operation")
-			err := r.createADB(logger, adb)
-			if err != nil {
-				return false, emptyResult, err
-			}
+	var adopted []string
+	for _, summary := range summaries {
+		if summary.Id == nil || summary.DbName == nil ||
+			summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminating ||
+			summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminated {
+			continue
+		}
 
-			// Update the ADB OCID
-			if err := r.updateCR(adb); err != nil {
-				return false, emptyResult, err
-			}
+		existing, err := k8s.FetchAutonomousDatabaseWithOCID(r.KubeClient, adb.GetNamespace(), *summary.Id)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
 
-			l.Info("AutonomousDatabaseOCID updated; exit reconcile")
-			return true, emptyResult, nil
-		} else {
-			l.Info("Bind operation")
-			_, err := r.getADB(logger, adb)
-			if err != nil {
-				return false, emptyResult, err
-			}
+		boundADB := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      strings.ToLower(*summary.DbName),
+				Namespace: adb.GetNamespace(),
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String(*summary.Id),
+				},
+				OCIConfig: adb.Spec.OCIConfig,
+				HardLink:  common.Bool(false),
+			},
+		}
 
-			if err := r.updateCR(adb); err != nil {
-				return false, emptyResult, err
+		if err := r.KubeClient.Create(ctx, boundADB); err != nil {
+			if apiErrors.IsAlreadyExists(err) {
+				l.Info("A resource with the same name exists; skip the database", "Name", boundADB.Name, "OCID", *summary.Id)
+				continue
 			}
-
-			l.Info("spec updated; exit reconcile")
-			return true, emptyResult, nil
+			return err
 		}
-	}
 
-	// If it's not CREATE or BIND opertaion, then it's UPDATE or SYNC operation.
-	// In most of the case the user changes the spec, and we update the oci ADB, but when the user updates on
-	// the Cloud Console, the controller cannot tell the direction and how to update the resource.
-	// Thus we compare the current spec with the lastSucSpec. If the details are different, it means that
-	// the user updates the spec (UPDATE operation), otherwise it's a SYNC operation.
-	lastDifADB := adb.DeepCopy()
-
-	lastDetailsChanged, err := lastDifADB.RemoveUnchangedDetails(*lastSpec)
-	if err != nil {
-		return false, emptyResult, err
+		l.Info("Resource created to bind the database", "Name", boundADB.Name, "OCID", *summary.Id)
+		adopted = append(adopted, boundADB.Name)
 	}
 
-	if lastDetailsChanged {
-		// Double check if the user input spec is actually different from the spec in OCI. If so, then update the resource.
-		// When the update completes and the status changes from UPDATING to AVAILABLE, the lastSucSpec is not updated yet,
-		// so we compare with the oci ADB again to make sure that the updates are completed.
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "CompartmentAdopted",
+		fmt.Sprintf("%d database(s) adopted from the compartment: %s", len(adopted), strings.Join(adopted, ", ")))
 
-		l.Info("Update operation")
+	return nil
+}
 
-		exit, err := r.updateADB(logger, adb)
-		if err != nil {
-			return false, emptyResult, err
+// validatePause reports the Paused condition, and returns true if the reconciliation is paused by the annotation.
+// Resuming the reconciliation sets the condition to False, which is updated along with the other status fields.
+func (r *AutonomousDatabaseReconciler) validatePause(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (paused bool, err error) {
+	if !adb.IsReconcilePaused() {
+		if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionPaused) {
+			logger.Info("Reconciliation resumed")
+			meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+				Type:               dbv1alpha1.ADBConditionPaused,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: adb.GetGeneration(),
+				Reason:             "ReconcileResumed",
+				Message:            "reconciliation is resumed",
+			})
 		}
+		return false, nil
+	}
 
-		return exit, emptyResult, nil
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionPaused) {
+		return true, nil
+	}
 
-	} else {
-		l.Info("No operation specified; sync the resource")
+	msg := fmt.Sprintf("reconciliation is paused by the annotation %s: \"false\"", dbv1alpha1.ReconcileAnnotation)
+	logger.Info(msg)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "Paused", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "ReconcilePaused",
+		Message:            msg,
+	})
+	if err := r.updateStatus(ctx, adb); err != nil {
+		return true, err
+	}
 
-		testOldADB := adb.DeepCopy()
+	return true, nil
+}
 
-		// The user doesn't change the spec and the controller should pull the spec from the OCI.
-		specChanged, err := r.getADB(logger, adb)
-		if err != nil {
-			return false, emptyResult, err
+// reconcileLogger returns the logger of a reconcile with the namespace and the name of the resource, and the OCID of
+// the database once it's known. The OCI services are built with the logger, so the OCI requests carry them as well.
+func reconcileLogger(logger logr.Logger, key types.NamespacedName, adb *dbv1alpha1.AutonomousDatabase) logr.Logger {
+	logger = logger.WithValues("namespace", key.Namespace, "name", key.Name)
+	if adb != nil {
+		if adbOCID := adb.GetAutonomousDatabaseOCID(); adbOCID != nil {
+			logger = logger.WithValues("adbOCID", *adbOCID)
 		}
+	}
+	return logger
+}
+
+func (r *AutonomousDatabaseReconciler) setupOCIClients(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	var err error
 
-		if specChanged {
-			l.Info("The local spec doesn't match the oci's spec; update the CR")
+	if r.newDBService != nil {
+		if r.dbService, err = r.newDBService(logger, adb); err != nil {
+			return err
+		}
+		if r.newOSService != nil {
+			if r.osService, err = r.newOSService(logger, adb); err != nil {
+				return err
+			}
+		}
+		if r.newIDService != nil {
+			if r.idService, err = r.newIDService(logger, adb); err != nil {
+				return err
+			}
+		}
+		if r.newNetService != nil {
+			if r.netService, err = r.newNetService(logger, adb); err != nil {
+				return err
+			}
+		}
+		if r.newLimService != nil {
+			if r.limService, err = r.newLimService(logger, adb); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-			// Erase the status.lifecycleState temporarily to avoid the webhook error.
-			oldADB := adb.DeepCopy()
-			adb.Status.LifecycleState = ""
-			r.KubeClient.Status().Update(context.TODO(), adb)
-			adb.Spec = oldADB.Spec
+	authData := oci.APIKeyAuth{
+		ConfigMapName: adb.Spec.OCIConfig.ConfigMapName,
+		SecretName:    adb.Spec.OCIConfig.SecretName,
+		Namespace:     adb.GetNamespace(),
+	}
 
-			adb.DeepCopy().RemoveUnchangedDetails(testOldADB.Spec)
+	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
+	if err != nil {
+		return err
+	}
 
-			if err := r.updateCR(adb); err != nil {
-				return false, emptyResult, err
+	r.dbService, err = oci.NewDatabaseService(logger, r.KubeClient, provider, adb.Spec.OCIConfig.EndpointOverride)
+	if err != nil {
+		return err
+	}
+
+	r.osService, err = oci.NewObjectStorageService(logger, provider)
+	if err != nil {
+		return err
+	}
+
+	r.idService, err = oci.NewIdentityService(logger, provider)
+	if err != nil {
+		return err
+	}
+
+	r.netService, err = oci.NewNetworkService(logger, provider)
+	if err != nil {
+		return err
+	}
+
+	r.limService, err = oci.NewLimitsService(logger, provider)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AutonomousDatabaseReconciler) manageError(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, issue error) (ctrl.Result, error) {
+	l := logger.WithName("manageError")
+
+	// The errors which are not retriable are retried at a slower pace
+	retriable, _ := classifyOCIError(issue)
+
+	// Has synced at least once
+	if adb.Status.LifecycleState != "" {
+		// Send event
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "UpdateFailed", issue.Error())
+
+		var finalIssue = issue
+
+		// The spec is left as is. Refresh the observed state so that the status reflects the database in OCI.
+		if _, err := r.getADB(ctx, l, adb); err != nil {
+			finalIssue = k8s.CombineErrors(finalIssue, err)
+		} else {
+			setLastError(adb, issue)
+			if err := r.updateStatus(ctx, adb); err != nil {
+				finalIssue = k8s.CombineErrors(finalIssue, err)
 			}
+		}
+
+		l.Error(finalIssue, "UpdateFailed")
+
+		// Some of the fields might have been applied before the failure. The spec is not recorded as the
+		// lastSuccessfulSpec, so the remaining fields are retried until the database converges with the spec.
+		if !retriable {
+			return ctrl.Result{RequeueAfter: fatalErrorRequeue}, nil
+		}
+		return ctrl.Result{RequeueAfter: failedUpdateRequeue}, nil
+	} else {
+		// Send event
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "CreateFailed", issue.Error())
+
+		setLastError(adb, issue)
+		if err := r.updateStatus(ctx, adb); err != nil {
+			return emptyResult, k8s.CombineErrors(issue, err)
+		}
+
+		if !retriable {
+			l.Error(issue, "CreateFailed")
+			return ctrl.Result{RequeueAfter: fatalErrorRequeue}, nil
+		}
+		return emptyResult, issue
+	}
+}
+
+// setLastError stores the details of the failed OCI request in the status, and sets the OCIRequestFailed condition.
+// The code and the request id are only available from an OCI service error. A nil err clears the details.
+func setLastError(adb *dbv1alpha1.AutonomousDatabase, err error) {
+	adb.Status.LastErrorCode = ""
+	adb.Status.LastError = ""
+	adb.Status.LastRequestId = ""
+	setOCIRequestFailed(adb, err)
+
+	if err == nil {
+		return
+	}
+
+	var serviceErr common.ServiceError
+	if errors.As(err, &serviceErr) {
+		adb.Status.LastErrorCode = serviceErr.GetCode()
+		adb.Status.LastError = serviceErr.GetMessage()
+		adb.Status.LastRequestId = serviceErr.GetOpcRequestID()
+		return
+	}
+	adb.Status.LastError = err.Error()
+}
 
+// validateOperation provisions the database if it doesn't have an OCID yet. Otherwise the spec is compared with
+// the database in OCI and the differences are applied. The spec is the desired state and is never overwritten;
+// the observed attributes are stored in the status.
+// The returned result is requeueResult if an OCI request is sent without exiting the reconcile.
+func (r *AutonomousDatabaseReconciler) validateOperation(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase) (exit bool, result ctrl.Result, err error) {
+
+	l := logger.WithName("validateOperation")
+
+	// The condition is set again below if the operation is still denied
+	meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)
+
+	// The terminated database is not polled until the spec is changed to provision or to bind another database
+	if terminated := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionTerminated); terminated != nil {
+		specOCID := adb.Spec.Details.AutonomousDatabaseOCID
+		if terminated.ObservedGeneration == adb.GetGeneration() ||
+			(specOCID != nil && *specOCID == adb.Status.AutonomousDatabaseOCID) {
+			l.Info("The database is TERMINATED in OCI; reconcile skipped")
 			return true, emptyResult, nil
 		}
-		return false, emptyResult, nil
+
+		l.Info("The spec is changed after the database is TERMINATED; release the terminated database",
+			"AutonomousDatabaseOCID", adb.Status.AutonomousDatabaseOCID)
+		adb.Status.AutonomousDatabaseOCID = ""
+		adb.Status.LifecycleState = ""
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionTerminated)
+	}
+
+	if adb.GetAutonomousDatabaseOCID() == nil && isCreateIfMissing(adb) {
+		if !r.isOperationAllowed(OCIOperationGet) {
+			return true, emptyResult, r.denyOperation(ctx, l, adb, OCIOperationGet)
+		}
+
+		bound, err := r.bindExistingADB(ctx, l, adb)
+		if err != nil {
+			return false, emptyResult, err
+		}
+
+		if bound {
+			if err := r.updateStatus(ctx, adb); err != nil {
+				return false, emptyResult, err
+			}
+
+			l.Info("AutonomousDatabaseOCID of the existing database updated in the status; reconcile queued")
+			return true, requeueResult, nil
+		}
+	}
+
+	if adb.GetAutonomousDatabaseOCID() == nil {
+		if !r.isOperationAllowed(OCIOperationCreate) {
+			return true, emptyResult, r.denyOperation(ctx, l, adb, OCIOperationCreate)
+		}
+
+		l.Info("Create operation")
+		err := r.createADB(ctx, logger, adb)
+		if err != nil {
+			return false, emptyResult, err
+		}
+
+		// Update the status first, which stores the ADB OCID
+		if err := r.updateStatus(ctx, adb); err != nil {
+			return false, emptyResult, err
+		}
+
+		if err := r.patchLastSuccessfulSpec(adb); err != nil {
+			return false, emptyResult, err
+		}
+
+		l.Info("AutonomousDatabaseOCID updated in the status; reconcile queued")
+		return true, requeueResult, nil
+	}
+
+	if !r.isOperationAllowed(OCIOperationGet) {
+		return true, emptyResult, r.denyOperation(ctx, l, adb, OCIOperationGet)
+	}
+
+	sent, exit, err := r.updateADB(ctx, logger, adb)
+	if err != nil {
+		return false, emptyResult, err
 	}
+	if sent {
+		return exit, requeueResult, nil
+	}
+	return exit, emptyResult, nil
 }
 
-func (r *AutonomousDatabaseReconciler) validateCleanup(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exitReconcile bool, err error) {
+func (r *AutonomousDatabaseReconciler) validateCleanup(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exitReconcile bool, result ctrl.Result, err error) {
 	l := logger.WithName("validateCleanup")
 
 	isADBToBeDeleted := adb.GetDeletionTimestamp() != nil
 
 	if !isADBToBeDeleted {
-		return false, nil
+		return false, emptyResult, nil
+	}
+
+	if err := r.deleteSplitWallets(ctx, l, adb); err != nil {
+		return false, emptyResult, err
 	}
 
 	if controllerutil.ContainsFinalizer(adb, dbv1alpha1.ADBFinalizer) {
 		if adb.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminating {
-			// Delete in progress, continue with the reconcile logic
-			return false, nil
+			// Delete in progress, wait until the database is TERMINATED in OCI
+			return r.waitForTermination(ctx, l, adb)
 		}
 
 		if adb.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
@@ -507,38 +1178,143 @@ func (r *AutonomousDatabaseReconciler) validateCleanup(logger logr.Logger, adb *
 			// Once all finalizers have been removed, the object will be deleted.
 			l.Info("Resource is in TERMINATED state; remove the finalizer")
 			if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
-				return false, err
+				return false, emptyResult, err
 			}
-			return true, nil
+			return true, emptyResult, nil
 		}
 
-		if adb.Spec.Details.AutonomousDatabaseOCID == nil {
+		if adb.GetAutonomousDatabaseOCID() == nil {
 			l.Info("Missing AutonomousDatabaseOCID to terminate Autonomous Database; remove the finalizer anyway", "Name", adb.Name, "Namespace", adb.Namespace)
 			// Remove finalizer anyway.
 			if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
-				return false, err
+				return false, emptyResult, err
 			}
-			return true, nil
+			return true, emptyResult, nil
 		}
 
-		if adb.Spec.Details.LifecycleState != database.AutonomousDatabaseLifecycleStateTerminated {
-			// Run finalization logic for finalizer. If the finalization logic fails, don't remove the finalizer so
-			// that we can retry during the next reconciliation.
-			l.Info("Terminating Autonomous Database")
-			adb.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateTerminated
-			if err := r.KubeClient.Update(context.TODO(), adb); err != nil {
-				return false, err
+		// The database is kept in OCI if the terminate operation is not allowed
+		if !r.isOperationAllowed(OCIOperationTerminate) {
+			msg := "The terminate operation is not allowed by --allowed-operations; the database is kept in OCI and the finalizer is removed"
+			l.Info(msg)
+			r.Recorder.Event(adb, corev1.EventTypeWarning, "OperationDenied", msg)
+			if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
+				return false, emptyResult, err
 			}
-			// Exit the reconcile since we have updated the spec
-			return true, nil
+			return true, emptyResult, nil
 		}
 
-		// Continue with the reconcile logic
-		return false, nil
+		// Run finalization logic for finalizer. If the finalization logic fails, don't remove the finalizer so
+		// that we can retry during the next reconciliation.
+		// OCI only allows terminate operation when the ADB is in an valid state, otherwise requeue the reconcile.
+		if !dbv1alpha1.ValidADBTerminateState(adb.Status.LifecycleState) {
+			l.Info("LifecycleState is " + string(adb.Status.LifecycleState) + "; wait to terminate the Autonomous Database")
+			return true, requeueResult, nil
+		}
+
+		l.Info("Sending DeleteAutonomousDatabase request to OCI")
+		if _, err := r.dbService.DeleteAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID()); err != nil {
+			return false, emptyResult, err
+		}
+
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateTerminating
+		if err := r.updateStatus(ctx, adb); err != nil {
+			return false, emptyResult, err
+		}
+		return true, requeueResult, nil
 	}
 
 	// Exit the Reconcile since the to-be-deleted resource doesn't has a finalizer
-	return true, nil
+	return true, emptyResult, nil
+}
+
+// validateProvisionStalled sets the Stalled condition if the database has been PROVISIONING for longer than the
+// ProvisionMaxWait since the resource was created, and removes the condition once the database leaves the PROVISIONING
+// state. Returns true if the provisioning is stalled.
+func (r *AutonomousDatabaseReconciler) validateProvisionStalled(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) bool {
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateProvisioning {
+		if meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionStalled) != nil {
+			logger.Info("Database is " + string(adb.Status.LifecycleState) + "; the provisioning is no longer stalled")
+			meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionStalled)
+		}
+		return false
+	}
+
+	if r.ProvisionMaxWait <= 0 || time.Since(adb.GetCreationTimestamp().Time) <= r.ProvisionMaxWait {
+		return false
+	}
+
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionStalled) {
+		msg := fmt.Sprintf("Database is still PROVISIONING after %s; check the work requests of the database in OCI", r.ProvisionMaxWait)
+		logger.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "ProvisionStalled", msg)
+
+		meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+			Type:               dbv1alpha1.ADBConditionStalled,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: adb.GetGeneration(),
+			Reason:             "ProvisionTimeout",
+			Message:            msg,
+		})
+	}
+
+	return true
+}
+
+// waitForTermination removes the finalizer when the database is TERMINATED or not found in OCI. Otherwise the
+// reconcile is requeued with an increasing interval, until the TerminationMaxWait is exceeded.
+func (r *AutonomousDatabaseReconciler) waitForTermination(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exitReconcile bool, result ctrl.Result, err error) {
+	if r.SkipTerminationWait {
+		logger.Info("Resource is in TERMINATING state; remove the finalizer without waiting for the termination")
+		if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
+			return false, emptyResult, err
+		}
+		return true, emptyResult, nil
+	}
+
+	resp, err := r.dbService.GetAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		if _, reason := classifyOCIError(err); reason != ociErrorNotFound {
+			return false, emptyResult, err
+		}
+
+		// The database is already removed from OCI
+		logger.Info("Database not found in OCI; remove the finalizer")
+		if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
+			return false, emptyResult, err
+		}
+		return true, emptyResult, nil
+	}
+
+	if resp.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
+		logger.Info("Database is TERMINATED in OCI; remove the finalizer")
+		if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
+			return false, emptyResult, err
+		}
+		return true, emptyResult, nil
+	}
+
+	elapsed := time.Since(adb.GetDeletionTimestamp().Time)
+	if r.TerminationMaxWait > 0 && elapsed > r.TerminationMaxWait {
+		msg := fmt.Sprintf("Database is still in %s state after %s; remove the finalizer anyway", resp.LifecycleState, r.TerminationMaxWait)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "TerminationTimeout", msg)
+		logger.Info(msg)
+
+		if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, adb, dbv1alpha1.ADBFinalizer); err != nil {
+			return false, emptyResult, err
+		}
+		return true, emptyResult, nil
+	}
+
+	// Back off as the termination takes longer. The interval is about the time elapsed, so it doubles every time.
+	requeueAfter := elapsed
+	if requeueAfter < minTerminationRequeue {
+		requeueAfter = minTerminationRequeue
+	} else if requeueAfter > maxTerminationRequeue {
+		requeueAfter = maxTerminationRequeue
+	}
+
+	logger.Info("Database is in "+string(resp.LifecycleState)+" state; wait for the termination", "RequeueAfter", requeueAfter.String())
+	return true, ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *AutonomousDatabaseReconciler) validateFinalizer(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exit bool, err error) {
@@ -577,23 +1353,64 @@ func (r *AutonomousDatabaseReconciler) validateFinalizer(logger logr.Logger, adb
 	return false, nil
 }
 
-// updateCR updates the lastSucSpec and the CR
-func (r *AutonomousDatabaseReconciler) updateCR(adb *dbv1alpha1.AutonomousDatabase) error {
-	// Update the lastSucSpec
-	if err := adb.UpdateLastSuccessfulSpec(); err != nil {
+// updateStatus updates the status of the resource. The resource might be modified by others during the reconcile,
+// so on a conflict the latest resource is fetched and the status is written to it again. The resourceVersion of the
+// adb is updated so that the subsequent patches are based on the latest resource. A change of the lifecycleState is
+// recorded in the lifecycleHistory.
+func (r *AutonomousDatabaseReconciler) updateStatus(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) error {
+	adb.RecordLifecycleState(metav1.Now())
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.KubeClient.Status().Update(ctx, adb)
+		if !apiErrors.IsConflict(err) {
+			return err
+		}
+
+		latestADB := &dbv1alpha1.AutonomousDatabase{}
+		if getErr := r.KubeClient.Get(ctx, client.ObjectKeyFromObject(adb), latestADB); getErr != nil {
+			return getErr
+		}
+		adb.SetResourceVersion(latestADB.GetResourceVersion())
+
+		return err
+	})
+}
+
+// patchLastSuccessfulSpec records the spec in the lastSucSpec annotation. The admin password is kept as is
+// because it's recorded only after it's applied, see patchLastAdminPassword.
+func (r *AutonomousDatabaseReconciler) patchLastSuccessfulSpec(adb *dbv1alpha1.AutonomousDatabase) error {
+	lastSpec, err := adb.GetLastSuccessfulSpec()
+	if err != nil {
 		return err
 	}
 
-	if err := r.KubeClient.Update(context.TODO(), adb); err != nil {
+	spec := adb.Spec.DeepCopy()
+	if lastSpec != nil {
+		spec.Details.AdminPassword = lastSpec.Details.AdminPassword
+	}
+
+	return r.patchLastSpec(adb, spec)
+}
+
+// patchLastAdminPassword records the admin password in the lastSucSpec annotation after it's applied
+func (r *AutonomousDatabaseReconciler) patchLastAdminPassword(adb *dbv1alpha1.AutonomousDatabase) error {
+	lastSpec, err := adb.GetLastSuccessfulSpec()
+	if err != nil {
 		return err
 	}
-	return nil
+
+	if lastSpec == nil {
+		lastSpec = adb.Spec.DeepCopy()
+	}
+	lastSpec.Details.AdminPassword = adb.Spec.Details.AdminPassword
+
+	return r.patchLastSpec(adb, lastSpec)
 }
 
-func (r *AutonomousDatabaseReconciler) patchLastSuccessfulSpec(adb *dbv1alpha1.AutonomousDatabase) error {
+func (r *AutonomousDatabaseReconciler) patchLastSpec(adb *dbv1alpha1.AutonomousDatabase, spec *dbv1alpha1.AutonomousDatabaseSpec) error {
 	copyADB := adb.DeepCopy()
 
-	specBytes, err := json.Marshal(adb.Spec)
+	specBytes, err := json.Marshal(spec)
 	if err != nil {
 		return err
 	}
@@ -602,129 +1419,636 @@ func (r *AutonomousDatabaseReconciler) patchLastSuccessfulSpec(adb *dbv1alpha1.A
 		dbv1alpha1.LastSuccessfulSpec: string(specBytes),
 	}
 
-	annotations.PatchAnnotations(r.KubeClient, adb, anns)
+	err = annotations.PatchAnnotations(r.KubeClient, adb, anns)
 
 	adb.Spec = copyADB.Spec
 	adb.Status = copyADB.Status
 
-	return nil
+	return err
 }
 
-func (r *AutonomousDatabaseReconciler) createADB(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
-	logger.WithName("createADB").Info("Sending CreateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.CreateAutonomousDatabase(adb)
-	if err != nil {
-		return err
+// patchConsoleURL sets the ConsoleURLAnnotation once the OCID is known. The annotation is patched only if the URL
+// changes, e.g. after the binding of a database in another region.
+func (r *AutonomousDatabaseReconciler) patchConsoleURL(adb *dbv1alpha1.AutonomousDatabase) error {
+	url := adb.ConsoleURL()
+	if url == "" || adb.GetAnnotations()[dbv1alpha1.ConsoleURLAnnotation] == url {
+		return nil
 	}
 
-	// Restore the admin password after updating from OCI ADB
-	adminPass := adb.Spec.Details.AdminPassword
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
-	adb.Spec.Details.AdminPassword = adminPass
+	copyADB := adb.DeepCopy()
+
+	err := annotations.PatchAnnotations(r.KubeClient, adb, map[string]string{
+		dbv1alpha1.ConsoleURLAnnotation: url,
+	})
 
-	return nil
+	adb.Spec = copyADB.Spec
+	adb.Status = copyADB.Status
+
+	return err
+}
+
+// isCreateIfMissing returns true if the resource is bound to the existing database with the displayName, and the
+// database is only provisioned if there is none
+func isCreateIfMissing(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.Details.CreateIfMissing != nil && *adb.Spec.Details.CreateIfMissing
 }
 
-// getADB gets the information from OCI and overwrites the spec and the status, but not update the CR in the cluster
-func (r *AutonomousDatabaseReconciler) getADB(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (bool, error) {
-	if adb == nil {
-		return false, errors.New("AutonomousDatabase OCID is missing")
+// bindExistingADB looks up the database with the displayName in the compartment. If a database which is not
+// terminated has the displayName, its OCID is set in the status, so that the resource is bound to it instead of
+// provisioning a new one. Returns an error if more than one database has the displayName.
+func (r *AutonomousDatabaseReconciler) bindExistingADB(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (bool, error) {
+	l := logger.WithName("bindExistingADB")
+
+	compartmentOCID := adb.Spec.Details.CompartmentOCID
+	if compartmentOCID == nil && adb.Spec.Details.CompartmentName != nil {
+		resolved, err := r.idService.GetCompartmentOCID(ctx, *adb.Spec.Details.CompartmentName)
+		if err != nil {
+			r.Recorder.Event(adb, corev1.EventTypeWarning, "CompartmentNotResolved", err.Error())
+			return false, err
+		}
+		compartmentOCID = common.String(resolved)
 	}
 
-	l := logger.WithName("getADB")
+	if compartmentOCID == nil || adb.Spec.Details.DisplayName == nil {
+		return false, errors.New("the displayName and the compartment are required to look up the existing database")
+	}
 
-	// Get the information from OCI
-	l.Info("Sending GetAutonomousDatabase request to OCI")
-	resp, err := r.dbService.GetAutonomousDatabase(*adb.Spec.Details.AutonomousDatabaseOCID)
+	ocid, err := r.findADBByDisplayName(ctx, *compartmentOCID, *adb.Spec.Details.DisplayName)
 	if err != nil {
 		return false, err
 	}
 
-	specChanged := adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	if ocid == "" {
+		l.Info("No database has the displayName; provision a new one", "displayName", *adb.Spec.Details.DisplayName)
+		return false, nil
+	}
 
-	return specChanged, nil
+	adb.Status.AutonomousDatabaseOCID = ocid
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "BoundToExisting",
+		fmt.Sprintf("Bound to the existing database %s with the displayName %s", ocid, *adb.Spec.Details.DisplayName))
+	return true, nil
 }
 
-// updateADB returns true if an OCI request is sent.
-// The AutonomousDatabase is updated with the returned object from the OCI requests.
-func (r *AutonomousDatabaseReconciler) updateADB(
-	logger logr.Logger,
-	adb *dbv1alpha1.AutonomousDatabase) (exit bool, err error) {
+// findADBByDisplayName returns the OCID of the database with the displayName in the compartment, or an empty string
+// if there is none. The terminated databases are ignored, and more than one database with the displayName is an error.
+func (r *AutonomousDatabaseReconciler) findADBByDisplayName(ctx context.Context, compartmentOCID string, displayName string) (string, error) {
+	resp, err := r.dbService.ListAutonomousDatabasesByDisplayName(ctx, compartmentOCID, displayName)
+	if err != nil {
+		return "", err
+	}
 
-	l := logger.WithName("updateADB")
+	var ocids []string
+	for _, summary := range resp.Items {
+		if summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminated ||
+			summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminating {
+			continue
+		}
+		ocids = append(ocids, *summary.Id)
+	}
 
-	// Get OCI AutonomousDatabase and update the lifecycleState of the CR,
-	// so that the validatexx functions know when the state changes back to AVAILABLE
-	ociADB := adb.DeepCopy()
-	_, err = r.getADB(logger, ociADB)
-	if err != nil {
-		return false, err
+	switch len(ocids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ocids[0], nil
+	default:
+		return "", fmt.Errorf("the displayName %s is used by more than one database: %s",
+			displayName, strings.Join(ocids, ", "))
 	}
+}
 
-	adb.Status.LifecycleState = ociADB.Status.LifecycleState
+// isRebindOnMissing returns true if the resource is bound to another database with the same displayName once the
+// database is not found in OCI
+func isRebindOnMissing(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.Details.RebindOnMissing != nil && *adb.Spec.Details.RebindOnMissing
+}
+
+// rebindMissingADB binds the resource to the database with the same displayName in the compartment, after the
+// database of the stored OCID is not found in OCI, e.g. it's terminated out-of-band. The displayName and the
+// compartment observed in the status are used if they're not in the spec. The OCID in the spec is patched if it's
+// set, and the new OCID is stored in the status. Returns the notFoundErr if no database has the displayName.
+func (r *AutonomousDatabaseReconciler) rebindMissingADB(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	notFoundErr error) (sent bool, exit bool, err error) {
+
+	l := logger.WithName("rebindMissingADB")
+
+	oldOCID := *adb.GetAutonomousDatabaseOCID()
+
+	displayName := adb.Status.DisplayName
+	if adb.Spec.Details.DisplayName != nil {
+		displayName = *adb.Spec.Details.DisplayName
+	}
+	compartmentOCID := adb.Status.CompartmentOCID
+	if adb.Spec.Details.CompartmentOCID != nil {
+		compartmentOCID = *adb.Spec.Details.CompartmentOCID
+	}
+	if displayName == "" || compartmentOCID == "" {
+		l.Info("The displayName or the compartment is unknown; skip the re-bind", "AutonomousDatabaseOCID", oldOCID)
+		return false, false, notFoundErr
+	}
+
+	l.Info("Database not found in OCI; look up the database with the displayName",
+		"AutonomousDatabaseOCID", oldOCID, "displayName", displayName)
+	newOCID, err := r.findADBByDisplayName(ctx, compartmentOCID, displayName)
+	if err != nil {
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "RebindFailed", err.Error())
+		return false, false, err
+	}
+	if newOCID == "" {
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "RebindFailed",
+			fmt.Sprintf("The database %s is not found, and no database has the displayName %s", oldOCID, displayName))
+		return false, false, notFoundErr
+	}
+
+	// The spec is patched first, since the patch overwrites the status in the object
+	if adb.Spec.Details.AutonomousDatabaseOCID != nil {
+		copyADB := adb.DeepCopy()
+		if err := k8s.Patch(r.KubeClient, adb, "/spec/details/autonomousDatabaseOCID", newOCID); err != nil {
+			return false, false, err
+		}
+		adb.Status = copyADB.Status
+	}
+
+	adb.Status.AutonomousDatabaseOCID = newOCID
+	adb.Status.LifecycleState = ""
+	if err := r.updateStatus(ctx, adb); err != nil {
+		return false, false, err
+	}
+
+	l.Info("Re-bound to the database with the displayName", "AutonomousDatabaseOCID", newOCID)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "Rebound",
+		fmt.Sprintf("The database %s is not found; re-bound to the database %s with the displayName %s",
+			oldOCID, newOCID, displayName))
+
+	return true, true, nil
+}
+
+// createADB provisions the database. The compartmentName is resolved to the OCID if the compartmentOCID is not set,
+// and so are the nsgNames if the nsgOCIDs are not set. The resolved OCIDs are only sent in the request, and the spec
+// is not changed.
+func (r *AutonomousDatabaseReconciler) createADB(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	l := logger.WithName("createADB")
+
+	createADB := adb.DeepCopy()
+
+	// The suffix is kept if it's generated in an earlier attempt, so that the names don't change between the retries
+	if adb.Spec.Details.GenerateNameSuffix != nil && *adb.Spec.Details.GenerateNameSuffix {
+		if adb.Status.NameSuffix == "" {
+			adb.Status.NameSuffix = generateNameSuffix()
+		}
+		createADB.Status.NameSuffix = adb.Status.NameSuffix
+		createADB.ApplyNameSuffix(&createADB.Spec.Details)
+	}
+
+	if adb.Spec.Details.CompartmentOCID == nil && adb.Spec.Details.CompartmentName != nil {
+		compartmentOCID, err := r.idService.GetCompartmentOCID(ctx, *adb.Spec.Details.CompartmentName)
+		if err != nil {
+			r.Recorder.Event(adb, corev1.EventTypeWarning, "CompartmentNotResolved", err.Error())
+			return err
+		}
+
+		createADB.Spec.Details.CompartmentOCID = common.String(compartmentOCID)
+	}
+
+	if err := r.resolveNsgNames(ctx, adb, createADB, createADB.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID); err != nil {
+		return err
+	}
+
+	if err := r.validateAvailabilityDomain(ctx, adb); err != nil {
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAvailabilityDomain", err.Error())
+		return err
+	}
+
+	if err := r.validateQuota(ctx, l, adb, createADB); err != nil {
+		return err
+	}
+
+	l.Info("Sending CreateAutonomousDatabase request to OCI")
+	resp, err := r.dbService.CreateAutonomousDatabase(ctx, createADB)
+	if err != nil {
+		return err
+	}
+
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	return nil
+}
+
+// generateNameSuffix returns a random suffix of lowercase letters and digits, which is valid in both the displayName
+// and the dbName
+func generateNameSuffix() string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	suffix := make([]byte, dbv1alpha1.NameSuffixLength)
+	for i := range suffix {
+		suffix[i] = chars[rand.Intn(len(chars))]
+	}
+	return string(suffix)
+}
+
+// validateAvailabilityDomain returns an error if the availabilityDomain is not one of the availability domains in
+// the region
+func (r *AutonomousDatabaseReconciler) validateAvailabilityDomain(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Spec.Details.AvailabilityDomain == nil {
+		return nil
+	}
+
+	availabilityDomains, err := r.idService.ListAvailabilityDomains(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range availabilityDomains {
+		if strings.EqualFold(name, *adb.Spec.Details.AvailabilityDomain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the availabilityDomain %s is not in the region; the availability domains are %s",
+		*adb.Spec.Details.AvailabilityDomain, strings.Join(availabilityDomains, ", "))
+}
+
+// updatePlacement sets the availability domain, the VM cluster and the Exadata infrastructure of a dedicated database
+// in the status. The database is placed on the ones of its Autonomous Container Database, which are only looked up
+// once.
+func (r *AutonomousDatabaseReconciler) updatePlacement(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) {
+	if adb.Status.AutonomousContainerDatabaseOCID == "" ||
+		(adb.Status.AvailabilityDomain != "" && adb.Status.CloudExadataInfrastructureOCID != "") {
+		return
+	}
+
+	resp, err := r.dbService.GetAutonomousContainerDatabase(ctx, adb.Status.AutonomousContainerDatabaseOCID)
+	if err != nil {
+		logger.Error(err, "Fail to get the placement of the AutonomousContainerDatabase")
+		return
+	}
+
+	if resp.AutonomousContainerDatabase.AvailabilityDomain != nil {
+		adb.Status.AvailabilityDomain = *resp.AutonomousContainerDatabase.AvailabilityDomain
+	}
+
+	vmClusterOCID, infrastructureOCID, err := r.dbService.GetContainerDatabasePlacement(ctx, resp.AutonomousContainerDatabase)
+	if err != nil {
+		logger.Error(err, "Fail to get the Exadata infrastructure of the AutonomousContainerDatabase")
+		return
+	}
+	adb.Status.AutonomousVMClusterOCID = vmClusterOCID
+	adb.Status.CloudExadataInfrastructureOCID = infrastructureOCID
+}
+
+// updatePatching sets the patch model and the maintenance runs of a dedicated database in the status. They are
+// the ones of its Autonomous Container Database, which is patched with the database, so they're looked up in every
+// sync. The status is left as is if the lookup fails.
+func (r *AutonomousDatabaseReconciler) updatePatching(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) {
+	if adb.Status.AutonomousContainerDatabaseOCID == "" {
+		return
+	}
+
+	resp, err := r.dbService.GetAutonomousContainerDatabase(ctx, adb.Status.AutonomousContainerDatabaseOCID)
+	if err != nil {
+		logger.Error(err, "Fail to get the patching of the AutonomousContainerDatabase")
+		return
+	}
+
+	acd := resp.AutonomousContainerDatabase
+	adb.Status.Maintenance.PatchModel = acd.PatchModel
+	adb.Status.Maintenance.NextMaintenanceRunOCID = ""
+	if acd.NextMaintenanceRunId != nil {
+		adb.Status.Maintenance.NextMaintenanceRunOCID = *acd.NextMaintenanceRunId
+	}
+	adb.Status.Maintenance.LastMaintenanceRunOCID = ""
+	if acd.LastMaintenanceRunId != nil {
+		adb.Status.Maintenance.LastMaintenanceRunOCID = *acd.LastMaintenanceRunId
+	}
+	adb.Status.Maintenance.LastPatchOCID = ""
+	if acd.PatchId != nil {
+		adb.Status.Maintenance.LastPatchOCID = *acd.PatchId
+	}
+}
+
+// resolveNsgNames sets the nsgOCIDs of the target to the OCIDs of the nsgNames, if the nsgNames are specified
+// without the nsgOCIDs. The groups are looked up in the VCN of the subnet. The events are sent to the adb.
+func (r *AutonomousDatabaseReconciler) resolveNsgNames(
+	ctx context.Context,
+	adb *dbv1alpha1.AutonomousDatabase,
+	target *dbv1alpha1.AutonomousDatabase,
+	subnetOCID *string) error {
+
+	privateEndpoint := &target.Spec.Details.NetworkAccess.PrivateEndpoint
+	if privateEndpoint.NsgNames == nil || privateEndpoint.NsgOCIDs != nil || subnetOCID == nil {
+		return nil
+	}
+
+	nsgOCIDs, err := r.netService.GetNsgOCIDs(ctx, *subnetOCID, privateEndpoint.NsgNames)
+	if err != nil {
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "NsgNotResolved", err.Error())
+		return err
+	}
+
+	privateEndpoint.NsgOCIDs = nsgOCIDs
+	return nil
+}
+
+// getADB gets the information from OCI and updates the status, but not update the CR in the cluster.
+// The returned object is a copy of the adb whose spec is overwritten by the OCI attributes, which is only used
+// to compare with the desired spec.
+func (r *AutonomousDatabaseReconciler) getADB(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (*dbv1alpha1.AutonomousDatabase, error) {
+	if adb == nil || adb.GetAutonomousDatabaseOCID() == nil {
+		return nil, errors.New("AutonomousDatabase OCID is missing")
+	}
+
+	l := logger.WithName("getADB")
+
+	// Get the information from OCI
+	l.Info("Sending GetAutonomousDatabase request to OCI")
+	resp, err := r.dbService.GetAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return nil, err
+	}
+
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	ociADB := adb.DeepCopy()
+	ociADB.UpdateFromOCIADB(resp.AutonomousDatabase)
+
+	return ociADB, nil
+}
+
+// updateADB returns true if an OCI request is sent.
+// The status of the AutonomousDatabase is updated with the returned object from the OCI requests.
+func (r *AutonomousDatabaseReconciler) updateADB(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase) (sent bool, exit bool, err error) {
+
+	l := logger.WithName("updateADB")
+
+	// Get OCI AutonomousDatabase and update the status of the CR,
+	// so that the validatexx functions know when the state changes back to AVAILABLE
+	ociADB, err := r.getADB(ctx, logger, adb)
+	if err != nil {
+		if _, reason := classifyOCIError(err); reason == ociErrorNotFound && isRebindOnMissing(adb) {
+			return r.rebindMissingADB(ctx, l, adb, err)
+		}
+		return false, false, err
+	}
+
+	// Stop polling the database once it's TERMINATED in OCI
+	if ociADB.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
+		return false, true, r.setTerminated(ctx, l, adb)
+	}
+
+	// An Always Free database stopped by OCI is left STOPPED
+	r.validateAutoStopped(l, adb, ociADB)
+
+	r.updatePlacement(ctx, l, adb)
+	r.updatePatching(ctx, l, adb)
+
+	// Special case: the database is STOPPED, e.g. on a schedule, and OCI rejects the updates until it's started.
+	// Only the lifecycleState is reconciled; the other fields are compared once the database is started again.
+	if isStateChangeOnly(adb, ociADB) {
+		return r.updateLifecycleStateOnly(ctx, logger, adb, ociADB)
+	}
 
 	// Start update
 	difADB := adb.DeepCopy()
 
+	// The nsgNames are compared with the OCI database by their OCIDs
+	subnetOCID := difADB.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID
+	if subnetOCID == nil {
+		subnetOCID = ociADB.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID
+	}
+	if err := r.resolveNsgNames(ctx, adb, difADB, subnetOCID); err != nil {
+		return false, false, err
+	}
+
+	// The freeformTags are merged into the tags in OCI if the removeTags are specified
+	difADB.Spec.Details.FreeformTags = difADB.Spec.Details.MergedFreeformTags(ociADB.Spec.Details.FreeformTags)
+
+	// The database in OCI has the names with the generated suffix
+	adb.ApplyNameSuffix(&difADB.Spec.Details)
+
 	ociDetailsChanged, err := difADB.RemoveUnchangedDetails(ociADB.Spec)
 	if err != nil {
-		return false, err
+		return false, false, err
+	}
+
+	// Report the fields changed out of band before they are reconciled
+	if ociDetailsChanged {
+		r.reportDrift(logger, adb, ociADB)
+	}
+
+	// Special case: the database is stopped for scaling, which has to be done before the desired lifecycleState.
+	// The database has to be started again even if the spec has no difference from the OCI ADB.
+	sent, err = r.validateStoppedForScaling(ctx, logger, adb, difADB, ociADB)
+	if err != nil {
+		return false, false, err
+	}
+	if sent {
+		return true, false, nil
 	}
 
 	// Do the update request only if the current ADB is actually different from the OCI ADB
 	if ociDetailsChanged {
-		// Special case: if the oci ADB is terminating, then update the spec and exit the reconcile.
+		// Special case: if the oci ADB is terminating, then exit the reconcile.
 		// This happens when the lifecycleState changes to TERMINATED during an intermediate state,
-		// whatever is in progress should be abandonded and the desired spec should the same as oci ADB.
+		// whatever is in progress should be abandonded.
 		if ociADB.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminating {
-			l.Info("OCI ADB is in TERMINATING state; update the spec and exit the reconcile")
+			l.Info("OCI ADB is in TERMINATING state; update the status and exit the reconcile")
 
-			adb.Status.LifecycleState = ""
-			if err := r.KubeClient.Status().Update(context.TODO(), adb); err != nil {
-				return false, err
+			if err := r.updateStatus(ctx, adb); err != nil {
+				return false, false, err
 			}
+			return false, true, nil
+		}
 
-			adb.Spec = ociADB.Spec
-			if err := r.KubeClient.Update(context.TODO(), adb); err != nil {
-				return false, err
-			}
-			return true, nil
+		// Refuse the update or the terminate operation before any request is sent
+		op := OCIOperationUpdate
+		if difADB.Spec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
+			op = OCIOperationTerminate
+		}
+		if !r.isOperationAllowed(op) {
+			return false, true, r.denyOperation(ctx, l, adb, op)
 		}
 
 		// Special case: if the lifecycleState is changed, it might have to exit the reconcile in some cases.
-		sent, exit, err := r.validateDesiredLifecycleState(logger, adb, difADB, ociADB)
+		sent, exit, err := r.validateDesiredLifecycleState(ctx, logger, adb, difADB, ociADB)
 		if err != nil {
-			return false, err
+			return false, false, err
 		}
 		if sent {
-			return exit, nil
+			return true, exit, nil
+		}
+
+		// OCI rejects the update requests unless the database is AVAILABLE. Wait until the transient state, e.g.
+		// SCALE_IN_PROGRESS, is over. The reconcile is requeued as if a request was sent, so that the spec is not
+		// recorded as applied in the meantime.
+		if dbv1alpha1.IsADBTransientState(ociADB.Status.LifecycleState) {
+			l.Info("OCI ADB is in " + string(ociADB.Status.LifecycleState) + " state; the update is deferred")
+			return true, false, nil
 		}
 
-		validations := []func(logr.Logger, *dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase) (bool, error){
+		validations := []func(context.Context, logr.Logger, *dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase) (bool, error){
 			r.validateGeneralFields,
 			r.validateAdminPassword,
 			r.validateDbWorkload,
 			r.validateLicenseModel,
 			r.validateScalingFields,
+			r.validateAutoScalingFields,
+			r.validateBackupRetention,
+			r.validateDisasterRecoveryType,
+			r.validateDatabaseManagement,
+			r.validateOperationsInsights,
 			r.validateGeneralNetworkAccess,
 		}
 
 		for _, op := range validations {
-			sent, err := op(logger, adb, difADB, ociADB)
+			sent, err := op(ctx, logger, adb, difADB, ociADB)
 			if err != nil {
-				return false, err
+				return false, false, err
 			}
 
 			if sent {
-				return false, nil
+				return true, false, nil
 			}
 		}
 	}
 
-	return false, nil
+	return false, false, nil
+}
+
+// isStateChangeOnly returns true if the database is STOPPED or STOPPING in OCI, unless the operator has stopped it
+// for scaling, in which case the scaling fields are still to be applied.
+func isStateChangeOnly(adb *dbv1alpha1.AutonomousDatabase, ociADB *dbv1alpha1.AutonomousDatabase) bool {
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionStoppedForScaling) {
+		return false
+	}
+	return ociADB.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateStopped ||
+		ociADB.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateStopping
+}
+
+// updateLifecycleStateOnly starts or terminates a stopped database if the lifecycleState in the spec differs from
+// the one in OCI, without diffing the CPU, the storage, the tags or any other field.
+func (r *AutonomousDatabaseReconciler) updateLifecycleStateOnly(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, exit bool, err error) {
+
+	desiredState := adb.Spec.Details.LifecycleState
+	if desiredState == "" || desiredState == ociADB.Spec.Details.LifecycleState {
+		logger.WithName("updateLifecycleStateOnly").Info("OCI ADB is " + string(ociADB.Status.LifecycleState) +
+			"; the other fields are not compared until the database is started")
+		return false, false, nil
+	}
+
+	op := OCIOperationUpdate
+	if desiredState == database.AutonomousDatabaseLifecycleStateTerminated {
+		op = OCIOperationTerminate
+	}
+	if !r.isOperationAllowed(op) {
+		return false, true, r.denyOperation(ctx, logger.WithName("updateLifecycleStateOnly"), adb, op)
+	}
+
+	difADB := &dbv1alpha1.AutonomousDatabase{}
+	difADB.Spec.Details.LifecycleState = desiredState
+	return r.validateDesiredLifecycleState(ctx, logger, adb, difADB, ociADB)
+}
+
+// isAutoStopped returns true if the database is an Always Free database which OCI has stopped after inactivity, while
+// the spec still has the lifecycleState AVAILABLE which has been applied before. The database is started again only
+// if the lifecycleState changes in the spec, e.g. from STOPPED to AVAILABLE.
+func isAutoStopped(adb *dbv1alpha1.AutonomousDatabase, ociADB *dbv1alpha1.AutonomousDatabase) bool {
+	if !ociADB.Status.IsFreeTier ||
+		ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateStopped ||
+		adb.Spec.Details.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return false
+	}
+
+	lastSpec, err := adb.GetLastSuccessfulSpec()
+	if err != nil || lastSpec == nil {
+		return false
+	}
+	return lastSpec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateAvailable
+}
+
+// validateAutoStopped sets the AutoStopped condition if the Always Free database has been stopped by OCI after
+// inactivity, and reports an event once. The desired lifecycleState is treated as applied, so that the database is not
+// started again, which would defeat the inactivity rule of the Always Free tier. The condition is removed otherwise.
+func (r *AutonomousDatabaseReconciler) validateAutoStopped(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, ociADB *dbv1alpha1.AutonomousDatabase) {
+	if !isAutoStopped(adb, ociADB) {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionAutoStopped)
+		return
+	}
+
+	ociADB.Spec.Details.LifecycleState = adb.Spec.Details.LifecycleState
+
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionAutoStopped) {
+		return
+	}
+
+	msg := "The Always Free database has been stopped by OCI after inactivity; set the lifecycleState to STOPPED and then to AVAILABLE to start it"
+	logger.Info(msg)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "AutoStopped", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionAutoStopped,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "StoppedForInactivity",
+		Message:            msg,
+	})
+}
+
+// setTerminated sets the Terminated condition and updates the status. The reconcile is skipped afterwards until the
+// spec is changed without the OCID of the terminated database, or the resource is deleted.
+func (r *AutonomousDatabaseReconciler) setTerminated(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	msg := "The database is TERMINATED in OCI; change the spec to provision a new database, or delete the resource"
+	if adb.Spec.Details.AutonomousDatabaseOCID != nil {
+		msg = "The database is TERMINATED in OCI; change the autonomousDatabaseOCID to bind another database, remove it to provision a new database, or delete the resource"
+	}
+
+	// The termination requested in the spec is expected
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionTerminated) &&
+		adb.Spec.Details.LifecycleState != database.AutonomousDatabaseLifecycleStateTerminated {
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "Terminated", msg)
+	}
+
+	logger.Info(msg)
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionTerminated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "TerminatedInOCI",
+		Message:            msg,
+	})
+
+	return r.updateStatus(ctx, adb)
+}
+
+// reportDrift sends a Warning event which lists the fields of the OCI database that diverge from the spec while the
+// spec is unchanged, so that the out-of-band changes are recorded before they are reverted by the reconcile.
+func (r *AutonomousDatabaseReconciler) reportDrift(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, ociADB *dbv1alpha1.AutonomousDatabase) {
+	l := logger.WithName("reportDrift")
+
+	drifted, err := adb.GetDriftedDetails(ociADB.Spec)
+	if err != nil {
+		l.Error(err, "Fail to compare the spec with the OCI database")
+		return
+	}
+	if len(drifted) == 0 {
+		return
+	}
+
+	l.Info("The OCI database diverges from the spec", "fields", drifted)
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "DriftDetected",
+		"The fields changed out of band are reverted to the spec: "+strings.Join(drifted, ", "))
 }
 
 func (r *AutonomousDatabaseReconciler) validateGeneralFields(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -733,7 +2057,9 @@ func (r *AutonomousDatabaseReconciler) validateGeneralFields(
 	if difADB.Spec.Details.DisplayName == nil &&
 		difADB.Spec.Details.DbName == nil &&
 		difADB.Spec.Details.DbVersion == nil &&
-		difADB.Spec.Details.FreeformTags == nil {
+		difADB.Spec.Details.FreeformTags == nil &&
+		difADB.Spec.Details.DefinedTags == nil &&
+		difADB.Spec.Details.CustomerContacts == nil {
 		return false, nil
 	}
 
@@ -743,26 +2069,37 @@ func (r *AutonomousDatabaseReconciler) validateGeneralFields(
 
 	l := logger.WithName("validateGeneralFields")
 
+	// OCI replaces the definedTags as a whole, so the tags in the ignored namespaces are sent as they are in OCI
+	if difADB.Spec.Details.DefinedTags != nil {
+		for namespace, tags := range ociADB.Status.DefinedTags {
+			if adb.IsIgnoredTagNamespace(namespace) {
+				difADB.Spec.Details.DefinedTags[namespace] = tags
+			}
+		}
+	}
+
 	l.Info("Sending UpdateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.UpdateAutonomousDatabaseGeneralFields(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateAutonomousDatabaseGeneralFields(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return true, nil
 }
 
 // Special case: compare with lastSpec but not ociSpec
 func (r *AutonomousDatabaseReconciler) validateAdminPassword(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
 	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
 
 	if difADB.Spec.Details.AdminPassword.K8sSecret.Name == nil &&
-		difADB.Spec.Details.AdminPassword.OCISecret.OCID == nil {
+		difADB.Spec.Details.AdminPassword.OCISecret.OCID == nil &&
+		difADB.Spec.Details.AdminPassword.VolumePath == nil {
 		return false, nil
 	}
 
@@ -773,19 +2110,69 @@ func (r *AutonomousDatabaseReconciler) validateAdminPassword(
 	l := logger.WithName("validateAdminPassword")
 
 	l.Info("Sending UpdateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.UpdateAutonomousDatabaseAdminPassword(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateAutonomousDatabaseAdminPassword(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
-	// Update the admin password fields because they are missing in the ociADB
-	adb.Spec.Details.AdminPassword = difADB.Spec.Details.AdminPassword
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	// The password is write-only in OCI. Record it in the lastSucSpec, so that it's not applied again.
+	if err := r.patchLastAdminPassword(adb); err != nil {
+		return false, err
+	}
+
+	// OCI doesn't keep the previous password valid, so the rotation is signaled until the new password is confirmed
+	msg := "The admin password is being changed; the previous password is no longer accepted once the update completes"
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "AdminPasswordRotating", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionAdminPasswordRotating,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "Updating",
+		Message:            msg,
+	})
 
 	return true, nil
 }
 
+// validateAdminPasswordRotation marks the rotation of the admin password as completed once the database is AVAILABLE
+// again. If the health check is enabled, the rotation is confirmed only after the connectivity check succeeds, so the
+// AdminPasswordRotating condition stays true while the database doesn't accept connections.
+func (r *AutonomousDatabaseReconciler) validateAdminPasswordRotation(adb *dbv1alpha1.AutonomousDatabase) {
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating) ||
+		adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionAdminPasswordRotating,
+		ObservedGeneration: adb.GetGeneration(),
+	}
+
+	if !isHealthCheckEnabled(adb) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Updated"
+		condition.Message = "The new admin password is applied; enable the health check to confirm the connectivity"
+	} else if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Confirmed"
+		condition.Message = "The new admin password is applied and the database accepts connections"
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "WaitingForConnectivity"
+		condition.Message = "The new admin password is applied, but the database doesn't accept connections yet"
+		meta.SetStatusCondition(&adb.Status.Conditions, condition)
+		return
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "AdminPasswordRotated", condition.Message)
+}
+
 func (r *AutonomousDatabaseReconciler) validateDbWorkload(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -801,18 +2188,76 @@ func (r *AutonomousDatabaseReconciler) validateDbWorkload(
 
 	l := logger.WithName("validateDbWorkload")
 
+	// The webhook might be disabled, so double check the transition here
+	if !dbv1alpha1.IsDbWorkloadTransitionAllowed(ociADB.Spec.Details.DbWorkload, difADB.Spec.Details.DbWorkload) {
+		return false, fmt.Errorf("cannot change dbWorkload from %s to %s", ociADB.Spec.Details.DbWorkload, difADB.Spec.Details.DbWorkload)
+	}
+
 	l.Info("Sending UpdateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.UpdateAutonomousDatabaseDBWorkload(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateAutonomousDatabaseDBWorkload(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionDbWorkloadUpdating,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "TransitionInProgress",
+		Message:            fmt.Sprintf("Changing dbWorkload from %s to %s", ociADB.Spec.Details.DbWorkload, difADB.Spec.Details.DbWorkload),
+	})
 
 	return true, nil
 }
 
+// validateAdminPasswordFile checks the file in the adminPassword.volumePath, and reports the result in the AdminPasswordReady condition
+func (r *AutonomousDatabaseReconciler) validateAdminPasswordFile(adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Spec.Details.AdminPassword.VolumePath == nil {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordReady)
+		return nil
+	}
+
+	if _, err := oci.ReadPasswordFile(*adb.Spec.Details.AdminPassword.VolumePath); err != nil {
+		meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+			Type:               dbv1alpha1.ADBConditionAdminPasswordReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: adb.GetGeneration(),
+			Reason:             "PasswordFileInvalid",
+			Message:            err.Error(),
+		})
+		return err
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionAdminPasswordReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "PasswordFileFound",
+		Message:            fmt.Sprintf("password file %s is ready", *adb.Spec.Details.AdminPassword.VolumePath),
+	})
+	return nil
+}
+
+// validateDbWorkloadCondition marks the dbWorkload transition as completed when the database is back to a stable state
+func (r *AutonomousDatabaseReconciler) validateDbWorkloadCondition(adb *dbv1alpha1.AutonomousDatabase) {
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionDbWorkloadUpdating) ||
+		dbv1alpha1.IsADBIntermediateState(adb.Status.LifecycleState) {
+		return
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionDbWorkloadUpdating,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "TransitionCompleted",
+		Message:            fmt.Sprintf("dbWorkload is %s", adb.Status.DbWorkload),
+	})
+}
+
 func (r *AutonomousDatabaseReconciler) validateLicenseModel(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -829,62 +2274,464 @@ func (r *AutonomousDatabaseReconciler) validateLicenseModel(
 	l := logger.WithName("validateLicenseModel")
 
 	l.Info("Sending UpdateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.UpdateAutonomousDatabaseLicenseModel(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateAutonomousDatabaseLicenseModel(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return true, nil
 }
 
 func (r *AutonomousDatabaseReconciler) validateScalingFields(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
 	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
 
 	if difADB.Spec.Details.DataStorageSizeInTBs == nil &&
-		difADB.Spec.Details.CPUCoreCount == nil &&
-		difADB.Spec.Details.IsAutoScalingEnabled == nil {
+		difADB.Spec.Details.DataStorageSizeInGBs == nil &&
+		difADB.Spec.Details.CPUCoreCount == nil {
 		return false, nil
 	}
 
-	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+	l := logger.WithName("validateScalingFields")
+
+	if isStopBeforeScalingEnabled(adb) {
+		switch ociADB.Status.LifecycleState {
+		case database.AutonomousDatabaseLifecycleStateAvailable:
+			// Stop the database first. The scaling is sent once the database is STOPPED.
+			l.Info("Sending StopAutonomousDatabase request to OCI before scaling")
+			resp, err := r.dbService.StopAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+			if err != nil {
+				return false, err
+			}
+
+			adb.Status.LifecycleState = resp.LifecycleState
+			meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+				Type:               dbv1alpha1.ADBConditionStoppedForScaling,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: adb.GetGeneration(),
+				Reason:             "Stopping",
+				Message:            "The database is stopped to be scaled",
+			})
+			r.Recorder.Event(adb, corev1.EventTypeNormal, "StoppingForScaling", "Stopping the database before scaling")
+			return true, nil
+		case database.AutonomousDatabaseLifecycleStateStopped:
+			r.Recorder.Event(adb, corev1.EventTypeNormal, "Scaling", "Scaling the stopped database")
+		default:
+			return false, nil
+		}
+	} else if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
 		return false, nil
 	}
 
-	l := logger.WithName("validateScalingFields")
-
 	l.Info("Sending UpdateAutonomousDatabase request to OCI")
-	resp, err := r.dbService.UpdateAutonomousDatabaseScalingFields(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateAutonomousDatabaseScalingFields(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+	// The desired CPU core count is the new baseline. The actual value can be raised by the auto scaling later.
+	if difADB.Spec.Details.CPUCoreCount != nil {
+		adb.Status.BaselineCPUCoreCount = *difADB.Spec.Details.CPUCoreCount
+	}
 
 	return true, nil
 }
 
-func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
+// validateStoppedForScaling drives the database which is stopped by the operator to be scaled. The database is
+// scaled while it's STOPPED, and then started again unless the desired lifecycleState is STOPPED. The scaling is
+// applied before the desired lifecycleState, otherwise the database would be started before it's scaled.
+func (r *AutonomousDatabaseReconciler) validateStoppedForScaling(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
-	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, exit bool, err error) {
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
 
-	if difADB.Spec.Details.LifecycleState == "" {
-		return false, false, nil
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionStoppedForScaling) {
+		return false, nil
 	}
 
-	if difADB.Spec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
-		// OCI only allows terminate operation when the ADB is in an valid state, otherwise requeue the reconcile.
-		if !dbv1alpha1.ValidADBTerminateState(adb.Status.LifecycleState) {
-			return false, false, nil
-		}
-	} else if dbv1alpha1.IsADBIntermediateState(ociADB.Status.LifecycleState) {
-		// Other lifecycle management operation; requeue the reconcile if it's in an intermediate state
+	switch ociADB.Status.LifecycleState {
+	case database.AutonomousDatabaseLifecycleStateStopped:
+	case database.AutonomousDatabaseLifecycleStateStopping, database.AutonomousDatabaseLifecycleStateScaleInProgress:
+		// Wait until the database is STOPPED
+		return false, nil
+	default:
+		// The database is started or changed by others; leave it as is
+		r.setStoppedForScalingCompleted(adb, "Interrupted", "The database is "+string(ociADB.Status.LifecycleState))
+		return false, nil
+	}
+
+	if difADB.Spec.Details.DataStorageSizeInTBs != nil || difADB.Spec.Details.DataStorageSizeInGBs != nil ||
+		difADB.Spec.Details.CPUCoreCount != nil {
+		return r.validateScalingFields(ctx, logger, adb, difADB, ociADB)
+	}
+
+	r.setStoppedForScalingCompleted(adb, "Scaled", "The database is scaled")
+
+	if adb.Spec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateStopped {
+		return false, nil
+	}
+
+	logger.WithName("validateStoppedForScaling").Info("Sending StartAutonomousDatabase request to OCI after scaling")
+	resp, err := r.dbService.StartAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return false, err
+	}
+
+	adb.Status.LifecycleState = resp.LifecycleState
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "StartingAfterScaling", "Starting the database after scaling")
+	return true, nil
+}
+
+func (r *AutonomousDatabaseReconciler) setStoppedForScalingCompleted(adb *dbv1alpha1.AutonomousDatabase, reason string, message string) {
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionStoppedForScaling,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func isStopBeforeScalingEnabled(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.Details.StopBeforeScaling != nil && *adb.Spec.Details.StopBeforeScaling
+}
+
+// validateAutoScalingFields toggles the CPU and storage auto scaling separately from the scaling fields,
+// so that flipping only the auto scaling flags doesn't send the CPU or storage size to OCI.
+func (r *AutonomousDatabaseReconciler) validateAutoScalingFields(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
+
+	if difADB.Spec.Details.IsAutoScalingEnabled == nil &&
+		difADB.Spec.Details.IsAutoScalingForStorageEnabled == nil {
+		return false, nil
+	}
+
+	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return false, nil
+	}
+
+	l := logger.WithName("validateAutoScalingFields")
+
+	l.Info("Sending UpdateAutonomousDatabase request to OCI")
+	resp, err := r.dbService.UpdateAutonomousDatabaseAutoScalingFields(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
+	if err != nil {
+		return false, err
+	}
+
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	return true, nil
+}
+
+// validateBackupRetention applies the retention period of the automatic backups. OCI doesn't return the value,
+// so the applied value is stored in the status and compared with the spec.
+func (r *AutonomousDatabaseReconciler) validateBackupRetention(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
+
+	if difADB.Spec.Details.BackupRetentionPeriodInDays == nil {
+		return false, nil
+	}
+
+	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return false, nil
+	}
+
+	l := logger.WithName("validateBackupRetention")
+
+	l.Info("Sending UpdateAutonomousDatabase request to OCI")
+	resp, err := r.dbService.UpdateAutonomousDatabaseBackupRetention(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
+	if err != nil {
+		return false, err
+	}
+
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+	adb.Status.BackupRetentionPeriodInDays = *difADB.Spec.Details.BackupRetentionPeriodInDays
+
+	return true, nil
+}
+
+func (r *AutonomousDatabaseReconciler) validateDisasterRecoveryType(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
+
+	if difADB.Spec.Details.DisasterRecoveryType == "" {
+		return false, nil
+	}
+
+	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return false, nil
+	}
+
+	l := logger.WithName("validateDisasterRecoveryType")
+
+	l.Info("Sending ChangeDisasterRecoveryConfiguration request to OCI")
+	resp, err := r.dbService.ChangeDisasterRecoveryConfiguration(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
+	if err != nil {
+		return false, err
+	}
+
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+	adb.Status.DisasterRecovery.Type = difADB.Spec.Details.DisasterRecoveryType
+
+	return true, nil
+}
+
+// featureToggle is a feature of the database which is enabled and disabled by the dedicated OCI operations, and
+// whose status is one of ENABLING, ENABLED, DISABLING, NOT_ENABLED, FAILED_ENABLING and FAILED_DISABLING
+type featureToggle struct {
+	name      string
+	enabled   *bool
+	ociStatus string
+	enable    func(adbOCID string) error
+	disable   func(adbOCID string) error
+	// setStatus sets the transient status in the resource, since the operations return no database
+	setStatus func(status string)
+}
+
+// validateFeatureToggle enables or disables the feature once the database is AVAILABLE. The change is deferred
+// while the previous transition is in progress.
+func (r *AutonomousDatabaseReconciler) validateFeatureToggle(
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase,
+	feature featureToggle) (sent bool, err error) {
+
+	if feature.enabled == nil {
+		return false, nil
+	}
+
+	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return false, nil
+	}
+
+	// Wait until the previous transition completes. The reconcile is requeued as if a request was sent, so that the
+	// spec is not recorded as applied in the meantime.
+	if dbv1alpha1.IsEnablementTransient(feature.ociStatus) {
+		logger.Info(feature.name + " is " + feature.ociStatus + "; the change is deferred")
+		return true, nil
+	}
+
+	if *feature.enabled {
+		logger.Info("Sending the request to OCI to enable " + feature.name)
+		if err := feature.enable(*adb.GetAutonomousDatabaseOCID()); err != nil {
+			return false, err
+		}
+		feature.setStatus(dbv1alpha1.EnablementStatusEnabling)
+	} else {
+		logger.Info("Sending the request to OCI to disable " + feature.name)
+		if err := feature.disable(*adb.GetAutonomousDatabaseOCID()); err != nil {
+			return false, err
+		}
+		feature.setStatus(dbv1alpha1.EnablementStatusDisabling)
+	}
+
+	return true, nil
+}
+
+func (r *AutonomousDatabaseReconciler) validateDatabaseManagement(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
+
+	return r.validateFeatureToggle(logger.WithName("validateDatabaseManagement"), adb, ociADB, featureToggle{
+		name:      "Database Management",
+		enabled:   difADB.Spec.Details.IsDatabaseManagementEnabled,
+		ociStatus: string(ociADB.Status.DatabaseManagementStatus),
+		enable: func(adbOCID string) error {
+			_, err := r.dbService.EnableDatabaseManagement(ctx, adbOCID)
+			return err
+		},
+		disable: func(adbOCID string) error {
+			_, err := r.dbService.DisableDatabaseManagement(ctx, adbOCID)
+			return err
+		},
+		setStatus: func(status string) {
+			adb.Status.DatabaseManagementStatus = database.AutonomousDatabaseDatabaseManagementStatusEnum(status)
+		},
+	})
+}
+
+func (r *AutonomousDatabaseReconciler) validateOperationsInsights(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, err error) {
+
+	return r.validateFeatureToggle(logger.WithName("validateOperationsInsights"), adb, ociADB, featureToggle{
+		name:      "Operations Insights",
+		enabled:   difADB.Spec.Details.IsOperationsInsightsEnabled,
+		ociStatus: string(ociADB.Status.OperationsInsightsStatus),
+		enable: func(adbOCID string) error {
+			_, err := r.dbService.EnableOperationsInsights(ctx, adbOCID)
+			return err
+		},
+		disable: func(adbOCID string) error {
+			_, err := r.dbService.DisableOperationsInsights(ctx, adbOCID)
+			return err
+		},
+		setStatus: func(status string) {
+			adb.Status.OperationsInsightsStatus = database.AutonomousDatabaseOperationsInsightsStatusEnum(status)
+		},
+	})
+}
+
+// validateDisasterRecoveryPeers reconciles the cross-region standby peers of spec.details.disasterRecoveryPeers and
+// the deprecated spec.details.disasterRecoveryPeer once the database is AVAILABLE. The peer of the deprecated
+// status.disasterRecovery.peer is migrated into the peers, and the role and the lifecycleState of the peers are
+// refreshed from their regions first. Then a peer whose region is removed from the spec is terminated, or a peer is
+// created in a region added to the spec. One request is sent per reconcile, and the reconcile is requeued until the
+// peer settles. A peer is removed from the status once it's TERMINATED.
+func (r *AutonomousDatabaseReconciler) validateDisasterRecoveryPeers(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Status.DisasterRecovery.Peer.Region != "" {
+		if !adb.HasDisasterRecoveryPeerIn(adb.Status.DisasterRecovery.Peer.Region) {
+			adb.Status.DisasterRecovery.Peers = append(adb.Status.DisasterRecovery.Peers, adb.Status.DisasterRecovery.Peer)
+		}
+		adb.Status.DisasterRecovery.Peer = dbv1alpha1.DisasterRecoveryPeerStatus{}
+	}
+
+	desiredPeers := adb.GetDisasterRecoveryPeers()
+	if len(desiredPeers) == 0 && len(adb.Status.DisasterRecovery.Peers) == 0 {
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateDisasterRecoveryPeers")
+
+	// Refresh the peers, and forget the terminated ones. A peer which is not found is only terminated if it was being
+	// terminated; otherwise, e.g. a peer which was just created, it's not visible in its region yet and is kept as is.
+	var peers []dbv1alpha1.DisasterRecoveryPeerStatus
+	for _, peer := range adb.Status.DisasterRecovery.Peers {
+		resp, err := r.dbService.GetAutonomousDatabaseInRegion(ctx, peer.AutonomousDatabaseOCID, peer.Region)
+		if err != nil {
+			if _, reason := classifyOCIError(err); reason != ociErrorNotFound {
+				return err
+			}
+			if peer.LifecycleState != database.AutonomousDatabaseLifecycleStateTerminating {
+				l.Info("The disaster recovery peer is not found in its region yet", "Region", peer.Region)
+				peers = append(peers, peer)
+				continue
+			}
+			resp.LifecycleState = database.AutonomousDatabaseLifecycleStateTerminated
+		}
+
+		if resp.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
+			l.Info("The disaster recovery peer is terminated", "Region", peer.Region)
+			continue
+		}
+		peer.LifecycleState = resp.LifecycleState
+		peer.Role = resp.Role
+		peers = append(peers, peer)
+	}
+	adb.Status.DisasterRecovery.Peers = peers
+
+	desired := make(map[string]bool)
+	for _, peer := range desiredPeers {
+		if peer.Region != nil {
+			desired[strings.ToLower(*peer.Region)] = true
+		}
+	}
+
+	// Terminate a peer whose region is removed from the spec
+	for i, peer := range adb.Status.DisasterRecovery.Peers {
+		if desired[strings.ToLower(peer.Region)] || peer.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminating {
+			continue
+		}
+
+		if !r.isOperationAllowed(OCIOperationTerminate) {
+			return r.denyOperation(ctx, l, adb, OCIOperationTerminate)
+		}
+
+		l.Info("Sending DeleteAutonomousDatabase request to OCI to terminate the disaster recovery peer", "Region", peer.Region)
+		if _, err := r.dbService.DeleteAutonomousDatabaseInRegion(ctx, peer.AutonomousDatabaseOCID, peer.Region); err != nil {
+			return err
+		}
+		adb.Status.DisasterRecovery.Peers[i].LifecycleState = database.AutonomousDatabaseLifecycleStateTerminating
+		r.Recorder.Event(adb, corev1.EventTypeNormal, "DisasterRecoveryPeerTerminating",
+			"Terminating the disaster recovery peer in "+peer.Region)
+		return nil
+	}
+
+	// Create a peer in a region added to the spec
+	for _, peer := range desiredPeers {
+		if peer.Region == nil || adb.HasDisasterRecoveryPeerIn(*peer.Region) {
+			continue
+		}
+
+		if !r.isOperationAllowed(OCIOperationCreate) {
+			return r.denyOperation(ctx, l, adb, OCIOperationCreate)
+		}
+
+		if peer.DisasterRecoveryType == "" {
+			peer.DisasterRecoveryType = dbv1alpha1.DisasterRecoveryTypeADG
+		}
+
+		l.Info("Sending CreateAutonomousDatabase request to OCI to create the disaster recovery peer", "Region", *peer.Region)
+		resp, err := r.dbService.CreateDisasterRecoveryPeer(ctx, adb, peer)
+		if err != nil {
+			return err
+		}
+
+		created := dbv1alpha1.DisasterRecoveryPeerStatus{
+			Region:               *peer.Region,
+			DisasterRecoveryType: peer.DisasterRecoveryType,
+			Role:                 resp.Role,
+			LifecycleState:       resp.LifecycleState,
+		}
+		if resp.Id != nil {
+			created.AutonomousDatabaseOCID = *resp.Id
+		}
+		adb.Status.DisasterRecovery.Peers = append(adb.Status.DisasterRecovery.Peers, created)
+		r.Recorder.Event(adb, corev1.EventTypeNormal, "DisasterRecoveryPeerCreated",
+			"Creating the disaster recovery peer in "+*peer.Region)
+		return nil
+	}
+
+	return nil
+}
+
+func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	difADB *dbv1alpha1.AutonomousDatabase,
+	ociADB *dbv1alpha1.AutonomousDatabase) (sent bool, exit bool, err error) {
+
+	if difADB.Spec.Details.LifecycleState == "" {
+		return false, false, nil
+	}
+
+	if difADB.Spec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateTerminated {
+		// OCI only allows terminate operation when the ADB is in an valid state, otherwise requeue the reconcile.
+		if !dbv1alpha1.ValidADBTerminateState(adb.Status.LifecycleState) {
+			return false, false, nil
+		}
+	} else if dbv1alpha1.IsADBIntermediateState(ociADB.Status.LifecycleState) {
+		// Other lifecycle management operation; requeue the reconcile if it's in an intermediate state
 		return false, false, nil
 	}
 
@@ -894,7 +2741,7 @@ func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
 	case database.AutonomousDatabaseLifecycleStateAvailable:
 		l.Info("Sending StartAutonomousDatabase request to OCI")
 
-		resp, err := r.dbService.StartAutonomousDatabase(*adb.Spec.Details.AutonomousDatabaseOCID)
+		resp, err := r.dbService.StartAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
 		if err != nil {
 			return false, false, err
 		}
@@ -903,7 +2750,7 @@ func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
 	case database.AutonomousDatabaseLifecycleStateStopped:
 		l.Info("Sending StopAutonomousDatabase request to OCI")
 
-		resp, err := r.dbService.StopAutonomousDatabase(*adb.Spec.Details.AutonomousDatabaseOCID)
+		resp, err := r.dbService.StopAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
 		if err != nil {
 			return false, false, err
 		}
@@ -912,7 +2759,7 @@ func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
 	case database.AutonomousDatabaseLifecycleStateTerminated:
 		l.Info("Sending DeleteAutonomousDatabase request to OCI")
 
-		_, err := r.dbService.DeleteAutonomousDatabase(*adb.Spec.Details.AutonomousDatabaseOCID)
+		_, err := r.dbService.DeleteAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
 		if err != nil {
 			return false, false, err
 		}
@@ -950,6 +2797,7 @@ func (r *AutonomousDatabaseReconciler) validateDesiredLifecycleState(
 // 2. Dedicated databases:
 //   Apply the configs directly
 func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -971,7 +2819,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 
 	l := logger.WithName("validateGeneralNetworkAccess")
 
-	if !*adb.Spec.Details.IsDedicated {
+	if !*ociADB.Spec.Details.IsDedicated {
 		var lastAccessType = ociADB.Spec.Details.NetworkAccess.AccessType
 		var difAccessType = difADB.Spec.Details.NetworkAccess.AccessType
 
@@ -981,13 +2829,13 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 				l.Info("Configuring network access type to PUBLIC")
 				// OCI validation requires IsMTLSConnectionRequired to be enabled before changing the network access type to PUBLIC
 				if !*ociADB.Spec.Details.NetworkAccess.IsMTLSConnectionRequired {
-					if err := r.setMTLSRequired(logger, adb); err != nil {
+					if err := r.setMTLSRequired(ctx, logger, adb); err != nil {
 						return false, err
 					}
 					return true, nil
 				}
 
-				if err := r.setNetworkAccessPublic(logger, ociADB.Spec.Details.NetworkAccess.AccessType, adb); err != nil {
+				if err := r.setNetworkAccessPublic(ctx, logger, ociADB.Spec.Details.NetworkAccess.AccessType, adb); err != nil {
 					return false, err
 				}
 				return true, nil
@@ -998,19 +2846,19 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 				// PRIVATE to PUBLIC, so the steps are PRIVATE->(requeue)->PUBLIC->(requeue)->RESTRICTED.
 				if lastAccessType == dbv1alpha1.NetworkAccessTypePrivate {
 					if !*ociADB.Spec.Details.NetworkAccess.IsMTLSConnectionRequired {
-						if err := r.setMTLSRequired(logger, adb); err != nil {
+						if err := r.setMTLSRequired(ctx, logger, adb); err != nil {
 							return false, err
 						}
 						return true, nil
 					}
 
-					if err := r.setNetworkAccessPublic(logger, ociADB.Spec.Details.NetworkAccess.AccessType, adb); err != nil {
+					if err := r.setNetworkAccessPublic(ctx, logger, ociADB.Spec.Details.NetworkAccess.AccessType, adb); err != nil {
 						return false, err
 					}
 					return true, nil
 				}
 
-				sent, err := r.validateNetworkAccess(logger, adb, difADB, ociADB)
+				sent, err := r.validateNetworkAccess(ctx, logger, adb, difADB, ociADB)
 				if err != nil {
 					return false, err
 				}
@@ -1018,7 +2866,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 					return true, nil
 				}
 
-				sent, err = r.validateMTLS(logger, adb, difADB, ociADB)
+				sent, err = r.validateMTLS(ctx, logger, adb, difADB, ociADB)
 				if err != nil {
 					return false, err
 				}
@@ -1028,7 +2876,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 			case dbv1alpha1.NetworkAccessTypePrivate:
 				l.Info("Configuring network access type to PRIVATE")
 
-				sent, err := r.validateNetworkAccess(logger, adb, difADB, ociADB)
+				sent, err := r.validateNetworkAccess(ctx, logger, adb, difADB, ociADB)
 				if err != nil {
 					return false, err
 				}
@@ -1036,7 +2884,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 					return true, nil
 				}
 
-				sent, err = r.validateMTLS(logger, adb, difADB, ociADB)
+				sent, err = r.validateMTLS(ctx, logger, adb, difADB, ociADB)
 				if err != nil {
 					return false, err
 				}
@@ -1046,7 +2894,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 			}
 		} else {
 			// Access type doesn't change
-			sent, err := r.validateNetworkAccess(logger, adb, difADB, ociADB)
+			sent, err := r.validateNetworkAccess(ctx, logger, adb, difADB, ociADB)
 			if err != nil {
 				return false, err
 			}
@@ -1054,7 +2902,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 				return true, nil
 			}
 
-			sent, err = r.validateMTLS(logger, adb, difADB, ociADB)
+			sent, err = r.validateMTLS(ctx, logger, adb, difADB, ociADB)
 			if err != nil {
 				return false, err
 			}
@@ -1064,7 +2912,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 		}
 	} else {
 		// Dedicated database
-		sent, err := r.validateNetworkAccess(logger, adb, difADB, ociADB)
+		sent, err := r.validateNetworkAccess(ctx, logger, adb, difADB, ociADB)
 		if err != nil {
 			return false, err
 		}
@@ -1077,24 +2925,23 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 }
 
 // Set the mTLS to true but not changing the spec
-func (r *AutonomousDatabaseReconciler) setMTLSRequired(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+func (r *AutonomousDatabaseReconciler) setMTLSRequired(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
 	l := logger.WithName("setMTLSRequired")
 
 	l.Info("Sending request to OCI to set IsMtlsConnectionRequired to true")
 
-	adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(true)
-
-	resp, err := r.dbService.UpdateNetworkAccessMTLSRequired(*adb.Spec.Details.AutonomousDatabaseOCID)
+	resp, err := r.dbService.UpdateNetworkAccessMTLSRequired(ctx, *adb.GetAutonomousDatabaseOCID())
 	if err != nil {
 		return err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return nil
 }
 
 func (r *AutonomousDatabaseReconciler) validateMTLS(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -1108,38 +2955,33 @@ func (r *AutonomousDatabaseReconciler) validateMTLS(
 
 	l.Info("Sending request to OCI to configure IsMtlsConnectionRequired")
 
-	resp, err := r.dbService.UpdateNetworkAccessMTLS(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateNetworkAccessMTLS(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return true, nil
 }
 
-func (r *AutonomousDatabaseReconciler) setNetworkAccessPublic(logger logr.Logger, lastAcessType dbv1alpha1.NetworkAccessTypeEnum, adb *dbv1alpha1.AutonomousDatabase) error {
-	adb.Spec.Details.NetworkAccess.AccessType = dbv1alpha1.NetworkAccessTypePublic
-	adb.Spec.Details.NetworkAccess.AccessControlList = nil
-	adb.Spec.Details.NetworkAccess.PrivateEndpoint.HostnamePrefix = common.String("")
-	adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = nil
-	adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = nil
-
+func (r *AutonomousDatabaseReconciler) setNetworkAccessPublic(ctx context.Context, logger logr.Logger, lastAcessType dbv1alpha1.NetworkAccessTypeEnum, adb *dbv1alpha1.AutonomousDatabase) error {
 	l := logger.WithName("setNetworkAccessPublic")
 
 	l.Info("Sending request to OCI to configure network access options to PUBLIC")
 
-	resp, err := r.dbService.UpdateNetworkAccessPublic(lastAcessType, *adb.Spec.Details.AutonomousDatabaseOCID)
+	resp, err := r.dbService.UpdateNetworkAccessPublic(ctx, lastAcessType, *adb.GetAutonomousDatabaseOCID())
 	if err != nil {
 		return err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return nil
 }
 
 func (r *AutonomousDatabaseReconciler) validateNetworkAccess(
+	ctx context.Context,
 	logger logr.Logger,
 	adb *dbv1alpha1.AutonomousDatabase,
 	difADB *dbv1alpha1.AutonomousDatabase,
@@ -1158,20 +3000,24 @@ func (r *AutonomousDatabaseReconciler) validateNetworkAccess(
 
 	l.Info("Sending request to OCI to configure network access options")
 
-	resp, err := r.dbService.UpdateNetworkAccess(*adb.Spec.Details.AutonomousDatabaseOCID, difADB)
+	resp, err := r.dbService.UpdateNetworkAccess(ctx, *adb.GetAutonomousDatabaseOCID(), difADB)
 	if err != nil {
 		return false, err
 	}
 
-	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
 
 	return true, nil
 }
 
-func (r *AutonomousDatabaseReconciler) validateWallet(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
-	if adb.Spec.Details.Wallet.Name == nil &&
-		adb.Spec.Details.Wallet.Password.K8sSecret.Name == nil &&
-		adb.Spec.Details.Wallet.Password.OCISecret.OCID == nil {
+func (r *AutonomousDatabaseReconciler) validateWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Spec.Details.Wallet.ObjectStorage.Bucket == nil {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded)
+		adb.Status.WalletObjectURL = ""
+	}
+
+	if !isWalletRequested(adb) {
+		adb.Status.WalletExpiryTime = nil
 		return nil
 	}
 
@@ -1179,54 +3025,1031 @@ func (r *AutonomousDatabaseReconciler) validateWallet(logger logr.Logger, adb *d
 		return nil
 	}
 
+	// The wallet is managed by the user
+	if adb.Spec.Details.Wallet.Regenerate == dbv1alpha1.WalletRegenerateNever {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)
+		return nil
+	}
+
 	l := logger.WithName("validateWallet")
 
-	// lastSucSpec may be nil if this is the first time entering the reconciliation loop
-	var walletName string
+	// The generation is not retried after the retries are exhausted, until the spec changes
+	if cond := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletFailed); cond != nil {
+		if cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == adb.GetGeneration() {
+			return nil
+		}
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletFailed)
+		adb.Status.WalletDownloadRetries = 0
+	}
 
-	if adb.Spec.Details.Wallet.Name == nil {
-		walletName = adb.GetName() + "-instance-wallet"
-	} else {
-		walletName = *adb.Spec.Details.Wallet.Name
+	walletNamespace, walletName := walletLocation(adb)
+
+	if adb.Spec.Details.Wallet.ObjectStorage.Bucket != nil {
+		return r.uploadWallet(ctx, l, adb, walletName)
 	}
 
-	secret, err := k8s.FetchSecret(r.KubeClient, adb.GetNamespace(), walletName)
+	if err := r.validateWalletNamespace(adb, walletNamespace); err != nil {
+		return err
+	}
+
+	// Cross-namespace owner references are not allowed
+	var owner client.Object
+	if walletNamespace == adb.GetNamespace() {
+		owner = adb
+	}
+
+	secret, err := k8s.FetchSecret(r.KubeClient, walletNamespace, walletName)
 	if err == nil {
-		val, ok := secret.Labels["app"]
-		if !ok || val != adb.Name {
-			// Overwrite if the fetched secret has a different label
-			l.Info("wallet existed but has a different label; skip the download")
+		if !isWalletOwner(adb, secret) {
+			// Never overwrite a Secret which belongs to something else
+			l.Info("wallet existed but is not owned by the resource; skip the download")
+			r.refuseWallet(adb, secret)
+			return nil
 		}
-		// No-op if Wallet is already downloaded
-		return nil
+
+		// The Secrets created by the earlier versions have no controller, so they are not garbage-collected with the resource
+		if owner != nil && metav1.GetControllerOf(secret) == nil {
+			if err := controllerutil.SetControllerReference(owner, secret, r.KubeClient.Scheme()); err != nil {
+				return err
+			}
+			if err := r.KubeClient.Update(ctx, secret); err != nil {
+				return err
+			}
+			l.Info(fmt.Sprintf("The owner reference is set on the Secret %s/%s", walletNamespace, walletName))
+		}
+
+		if adb.Spec.Details.Wallet.Regenerate == dbv1alpha1.WalletRegenerateAlways {
+			return r.regenerateWallet(ctx, l, adb, secret)
+		}
+
+		// No-op if Wallet is already downloaded, except that the minTlsVersion, the hostRewrite, the checksum and the
+		// splitProfiles have to be applied
+		changed, err := applyWalletSettings(adb, secret.Data)
+		if err != nil {
+			return err
+		}
+		if changed {
+			setWalletChecksum(adb, secret)
+			if err := r.KubeClient.Update(ctx, secret); err != nil {
+				return err
+			}
+			l.Info(fmt.Sprintf("The wallet settings are applied to the Secret %s/%s", walletNamespace, walletName))
+		}
+
+		if setWalletChecksum(adb, secret) {
+			if err := r.KubeClient.Update(ctx, secret); err != nil {
+				return err
+			}
+			l.Info(fmt.Sprintf("The checksum annotation of the Secret %s/%s is updated", walletNamespace, walletName))
+		}
+
+		setWalletExpiry(l, adb, secret.Data)
+
+		return r.validateSplitWallet(ctx, l, adb, walletNamespace, walletName, secret.Data)
 	} else if !apiErrors.IsNotFound(err) {
 		return err
 	}
 
-	resp, err := r.dbService.DownloadWallet(adb)
+	_, data, err := r.downloadWallet(ctx, l, adb)
 	if err != nil {
 		return err
 	}
 
-	data, err := oci.ExtractWallet(resp.Content)
-	if err != nil {
+	if _, err := applyWalletSettings(adb, data); err != nil {
 		return err
 	}
 
 	label := map[string]string{"app": adb.GetName()}
 
-	if err := k8s.CreateSecret(r.KubeClient, adb.Namespace, walletName, data, adb, label); err != nil {
+	annotations := walletAnnotations(adb, walletNamespace)
+	if isWalletChecksumEnabled(adb) {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[dbv1alpha1.WalletChecksumAnnotation] = oci.WalletChecksum(data)
+	}
+
+	if err := k8s.CreateSecret(r.KubeClient, walletNamespace, walletName, data, owner, label, annotations); err != nil {
 		return err
 	}
 
-	l.Info(fmt.Sprintf("Wallet is stored in the Secret %s", walletName))
+	l.Info(fmt.Sprintf("Wallet is stored in the Secret %s/%s", walletNamespace, walletName))
+	setWalletExpiry(l, adb, data)
 
-	return nil
+	return r.validateSplitWallet(ctx, l, adb, walletNamespace, walletName, data)
+}
+
+// isWalletRequested returns true if the wallet is to be downloaded. The wallet is never downloaded if manageWallet is
+// false.
+func isWalletRequested(adb *dbv1alpha1.AutonomousDatabase) bool {
+	if adb.Spec.Details.ManageWallet != nil && !*adb.Spec.Details.ManageWallet {
+		return false
+	}
+	return adb.Spec.Details.Wallet.Name != nil ||
+		adb.Spec.Details.Wallet.Password.K8sSecret.Name != nil ||
+		adb.Spec.Details.Wallet.Password.OCISecret.OCID != nil ||
+		adb.Spec.Details.Wallet.Password.VolumePath != nil
+}
+
+// isReadyRequiresWallet returns true if the Ready condition waits for the wallet, which is the default
+func isReadyRequiresWallet(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.Details.ReadyRequiresWallet == nil || *adb.Spec.Details.ReadyRequiresWallet
+}
+
+// isWalletStored returns true if the wallet is stored in the Secret or uploaded to the bucket, given the walletErr
+// returned by the validateWallet. The wallet which is not requested or is left to the user is regarded as stored.
+func isWalletStored(adb *dbv1alpha1.AutonomousDatabase, walletErr error) bool {
+	if !isWalletRequested(adb) || adb.Spec.Details.Wallet.Regenerate == dbv1alpha1.WalletRegenerateNever {
+		return true
+	}
+	if walletErr != nil {
+		return false
+	}
+	if adb.Spec.Details.Wallet.ObjectStorage.Bucket != nil {
+		return meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded)
+	}
+	return true
+}
+
+// setReadyCondition sets the Ready condition, which is true once the database is AVAILABLE and the wallet is stored.
+// The wallet is not waited for if spec.details.readyRequiresWallet is false.
+func setReadyCondition(adb *dbv1alpha1.AutonomousDatabase, walletErr error) {
+	condition := metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionReady,
+		ObservedGeneration: adb.GetGeneration(),
+	}
+
+	switch {
+	case adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NotAvailable"
+		condition.Message = fmt.Sprintf("The database is %s", adb.Status.LifecycleState)
+	case isReadyRequiresWallet(adb) && !isWalletStored(adb, walletErr):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "WalletNotStored"
+		condition.Message = "The database is AVAILABLE but the wallet is not stored yet"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Ready"
+		condition.Message = "The database is AVAILABLE"
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+}
+
+// applyWalletSettings applies the minTlsVersion and the hostRewrite to the wallet files. Returns true if the files are
+// changed.
+func applyWalletSettings(adb *dbv1alpha1.AutonomousDatabase, data map[string][]byte) (bool, error) {
+	var changed bool
+
+	if adb.Spec.Details.Wallet.MinTLSVersion != nil {
+		enforced, err := oci.EnforceMinTLSVersion(data, *adb.Spec.Details.Wallet.MinTLSVersion)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || enforced
+	}
+
+	if adb.Spec.Details.Wallet.HostRewrite != nil {
+		rewritten, err := oci.RewriteWalletHosts(data, *adb.Spec.Details.Wallet.HostRewrite)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || rewritten
+	}
+
+	return changed, nil
+}
+
+// isWalletChecksumEnabled returns true if the wallet Secret is to be annotated with the checksum of the files
+func isWalletChecksumEnabled(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.Details.Wallet.Checksum != nil && *adb.Spec.Details.Wallet.Checksum
+}
+
+// setWalletChecksum sets the checksum annotation of the wallet Secret to the checksum of its data, or removes the
+// annotation if the checksum is not enabled. Returns true if the annotations are changed.
+func setWalletChecksum(adb *dbv1alpha1.AutonomousDatabase, secret *corev1.Secret) bool {
+	current, ok := secret.Annotations[dbv1alpha1.WalletChecksumAnnotation]
+
+	if !isWalletChecksumEnabled(adb) {
+		if !ok {
+			return false
+		}
+		delete(secret.Annotations, dbv1alpha1.WalletChecksumAnnotation)
+		return true
+	}
+
+	checksum := oci.WalletChecksum(secret.Data)
+	if ok && current == checksum {
+		return false
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[dbv1alpha1.WalletChecksumAnnotation] = checksum
+	return true
+}
+
+// setWalletExpiry records the earliest expiry of the certificates in the stored wallet, which is exported by the
+// adb_wallet_expiry_seconds metric. The expiry is cleared if the wallet has no certificate.
+func setWalletExpiry(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, data map[string][]byte) {
+	expiry, err := oci.WalletExpiry(data)
+	if err != nil {
+		if !errors.Is(err, oci.ErrNoWalletCertificate) {
+			logger.Info("Cannot read the expiry of the wallet", "error", err.Error())
+		}
+		adb.Status.WalletExpiryTime = nil
+		return
+	}
+
+	expiryTime := metav1.NewTime(expiry)
+	adb.Status.WalletExpiryTime = &expiryTime
+}
+
+// walletLocation returns the namespace and the name of the wallet Secret. The name is also used for the object in
+// the Object Storage bucket.
+func walletLocation(adb *dbv1alpha1.AutonomousDatabase) (namespace string, name string) {
+	name = adb.GetName() + "-instance-wallet"
+	if adb.Spec.Details.Wallet.Name != nil {
+		name = *adb.Spec.Details.Wallet.Name
+	}
+
+	namespace = adb.GetNamespace()
+	if adb.Spec.Details.Wallet.Namespace != nil && *adb.Spec.Details.Wallet.Namespace != "" {
+		namespace = *adb.Spec.Details.Wallet.Namespace
+	}
+
+	return namespace, name
+}
+
+// validateWalletRotation rotates the wallet if the rotateWallet action is requested. The rotation invalidates all the
+// wallets downloaded before, so a new wallet is stored once the rotation completes, and then the action is recorded
+// in status.lastAction. The WalletRotating condition is true until then.
+func (r *AutonomousDatabaseReconciler) validateWalletRotation(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.PendingAction() != dbv1alpha1.ADBActionRotateWallet {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating)
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateWalletRotation")
+
+	if !meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating) {
+		if !r.isOperationAllowed(OCIOperationUpdate) {
+			return r.denyOperation(ctx, l, adb, OCIOperationUpdate)
+		}
+
+		l.Info("Sending UpdateAutonomousDatabaseWallet request to OCI to rotate the wallet")
+		if _, err := r.dbService.RotateWallet(ctx, *adb.GetAutonomousDatabaseOCID()); err != nil {
+			return err
+		}
+
+		msg := "The wallet is being rotated; all the wallets downloaded before are invalidated, and the applications have to fetch the new wallet"
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletRotating", msg)
+
+		meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+			Type:               dbv1alpha1.ADBConditionWalletRotating,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: adb.GetGeneration(),
+			Reason:             "RotationRequested",
+			Message:            msg,
+		})
+		return nil
+	}
+
+	resp, err := r.dbService.GetWallet(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return err
+	}
+
+	if resp.LifecycleState != database.AutonomousDatabaseWalletLifecycleStateActive {
+		l.Info("The wallet is " + string(resp.LifecycleState) + "; wait for the rotation")
+		return nil
+	}
+
+	if err := r.refreshWallet(ctx, l, adb); err != nil {
+		return err
+	}
+
+	// The action is one-shot. The lastAction and the condition are updated in the same status update, so that the
+	// wallet is not rotated again if the status update fails.
+	completeAction(adb)
+
+	meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "WalletRotated", "The wallet is rotated and the new wallet is stored")
+
+	return nil
+}
+
+// completeAction records the one-shot action in the status once it's done, so that it's not run again. The spec is
+// left as is since it's owned by the user.
+func completeAction(adb *dbv1alpha1.AutonomousDatabase) {
+	adb.Status.LastAction = adb.Spec.Action
+	adb.Status.LastActionGeneration = adb.GetGeneration()
+}
+
+// resetLastAction clears the lastAction once the action is removed from the spec, so that the same action can be
+// requested again
+func resetLastAction(adb *dbv1alpha1.AutonomousDatabase) {
+	if adb.Spec.Action == "" {
+		adb.Status.LastAction = ""
+		adb.Status.LastActionGeneration = 0
+	}
+}
+
+// validateEncryptionKeyRotation rotates the encryption key if the rotateEncryptionKey action is requested. OCI
+// re-encrypts the database with the latest version of the KMS key while the database is UPDATING, and then the action
+// is recorded in status.lastAction. The EncryptionKeyRotating condition is true until then. The action is ignored with
+// a warning event if the database doesn't use a customer-managed key.
+func (r *AutonomousDatabaseReconciler) validateEncryptionKeyRotation(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.PendingAction() != dbv1alpha1.ADBActionRotateEncryptionKey {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating)
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateEncryptionKeyRotation")
+
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating) {
+		// The database is AVAILABLE again after the rotation
+		completeAction(adb)
+
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating)
+		r.Recorder.Event(adb, corev1.EventTypeNormal, "EncryptionKeyRotated",
+			"The database is encrypted with the key version "+adb.Status.EncryptionKey.KmsKeyVersionOCID)
+		return nil
+	}
+
+	if !adb.Status.EncryptionKey.IsCustomerManaged() {
+		msg := "The database doesn't use a customer-managed key; the rotateEncryptionKey action is ignored"
+		l.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAction", msg)
+		completeAction(adb)
+		return nil
+	}
+
+	if !r.isOperationAllowed(OCIOperationUpdate) {
+		return r.denyOperation(ctx, l, adb, OCIOperationUpdate)
+	}
+
+	l.Info("Sending RotateAutonomousDatabaseEncryptionKey request to OCI")
+	resp, err := r.dbService.RotateEncryptionKey(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return err
+	}
+	adb.Status.LifecycleState = resp.LifecycleState
+
+	msg := "The database is being re-encrypted with the latest version of the key " + adb.Status.EncryptionKey.KmsKeyOCID
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "EncryptionKeyRotating", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionEncryptionKeyRotating,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "RotationRequested",
+		Message:            msg,
+	})
+	return nil
+}
+
+// validateShrink reclaims the unused storage if the shrink action is requested. The Shrinking condition is true while
+// the database is UPDATING. Once the database is AVAILABLE again, the reclaimed storage is recorded in the status and
+// the action is recorded in status.lastAction. The action is ignored with a warning event if the database doesn't
+// support the shrink.
+func (r *AutonomousDatabaseReconciler) validateShrink(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.PendingAction() != dbv1alpha1.ADBActionShrink {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionShrinking)
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateShrink")
+
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionShrinking) {
+		// The database is AVAILABLE again after the shrink
+		completeAction(adb)
+
+		reclaimed := adb.Status.Shrink.AllocatedStorageBeforeInGBs - adb.Status.AllocatedStorageSizeInGBs
+		if reclaimed < 0 {
+			reclaimed = 0
+		}
+		adb.Status.Shrink.ReclaimedStorageSizeInGBs = reclaimed
+		adb.Status.Shrink.LastShrinkTime = metav1.Now().UTC().Format(time.RFC3339)
+
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionShrinking)
+		r.Recorder.Event(adb, corev1.EventTypeNormal, "StorageShrunk",
+			fmt.Sprintf("The storage is shrunk; %d GB is reclaimed", reclaimed))
+		return nil
+	}
+
+	if !adb.IsShrinkAllowed() {
+		msg := "The database doesn't support the shrink; the shrink action is ignored"
+		l.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAction", msg)
+		completeAction(adb)
+		return nil
+	}
+
+	if !r.isOperationAllowed(OCIOperationUpdate) {
+		return r.denyOperation(ctx, l, adb, OCIOperationUpdate)
+	}
+
+	allocatedBefore := adb.Status.AllocatedStorageSizeInGBs
+
+	l.Info("Sending ShrinkAutonomousDatabase request to OCI")
+	resp, err := r.dbService.ShrinkAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return err
+	}
+	adb.Status.LifecycleState = resp.LifecycleState
+	adb.Status.Shrink = dbv1alpha1.ShrinkStatus{AllocatedStorageBeforeInGBs: allocatedBefore}
+
+	msg := fmt.Sprintf("The storage is being shrunk from the allocated %d GB", allocatedBefore)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "StorageShrinking", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionShrinking,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "ShrinkRequested",
+		Message:            msg,
+	})
+	return nil
+}
+
+// validateCloneDetach detaches the refreshable clone from its source database if the detachClone action is requested
+// and confirmed by the ConfirmDetachAnnotation. The CloneDetaching condition is true while the database is UPDATING.
+// Once the database is AVAILABLE again and no longer a refreshable clone, the action is recorded in status.lastAction.
+// The action is ignored with a warning event if the database is not a refreshable clone or the detach is not
+// confirmed.
+func (r *AutonomousDatabaseReconciler) validateCloneDetach(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.PendingAction() != dbv1alpha1.ADBActionDetachClone {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionCloneDetaching)
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateCloneDetach")
+
+	if meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionCloneDetaching) {
+		if adb.Status.IsRefreshableClone {
+			// OCI hasn't reported the detach yet
+			return nil
+		}
+
+		// The database is AVAILABLE again after the detach
+		completeAction(adb)
+
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionCloneDetaching)
+		r.Recorder.Event(adb, corev1.EventTypeNormal, "CloneDetached",
+			"The refreshable clone is detached from its source database and is now a standalone database")
+		return nil
+	}
+
+	if !adb.Status.IsRefreshableClone {
+		msg := "The database is not a refreshable clone; the detachClone action is ignored"
+		l.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAction", msg)
+		completeAction(adb)
+		return nil
+	}
+
+	if !adb.IsConfirmed(dbv1alpha1.ConfirmDetachAnnotation) {
+		msg := fmt.Sprintf("The annotation %s doesn't match the dbName; the detachClone action is ignored",
+			dbv1alpha1.ConfirmDetachAnnotation)
+		l.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAction", msg)
+		completeAction(adb)
+		return nil
+	}
+
+	if !r.isOperationAllowed(OCIOperationUpdate) {
+		return r.denyOperation(ctx, l, adb, OCIOperationUpdate)
+	}
+
+	l.Info("Sending UpdateAutonomousDatabase request to OCI to detach the refreshable clone")
+	resp, err := r.dbService.DetachRefreshableClone(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return err
+	}
+	adb.Status.LifecycleState = resp.LifecycleState
+
+	msg := "The refreshable clone is being detached from its source database"
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "CloneDetaching", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionCloneDetaching,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "DetachRequested",
+		Message:            msg,
+	})
+	return nil
+}
+
+// validateConnectionURLsRefresh fetches the database again if the refreshConnectionURLs action is requested, so that
+// the ORDS and APEX URLs in the status reflect the changes made in OCI, e.g. the access is enabled or disabled, and
+// then the action is recorded in status.lastAction. The action is ignored with a warning event if the database is not
+// an APEX or AJD database.
+func (r *AutonomousDatabaseReconciler) validateConnectionURLsRefresh(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.PendingAction() != dbv1alpha1.ADBActionRefreshConnectionURLs {
+		return nil
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateConnectionURLsRefresh")
+
+	if !dbv1alpha1.IsConnectionURLsRefreshAllowed(adb.Status.DbWorkload) {
+		msg := fmt.Sprintf("The dbWorkload %s doesn't serve the ORDS and APEX URLs; the refreshConnectionURLs action is ignored", adb.Status.DbWorkload)
+		l.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidAction", msg)
+		completeAction(adb)
+		return nil
+	}
+
+	l.Info("Sending GetAutonomousDatabase request to OCI to refresh the connection URLs")
+	resp, err := r.dbService.GetAutonomousDatabase(ctx, *adb.GetAutonomousDatabaseOCID())
+	if err != nil {
+		return err
+	}
+	adb.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+
+	completeAction(adb)
+
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "ConnectionURLsRefreshed", "The connection URLs are refreshed from OCI")
+	return nil
+}
+
+// validateWalletMTLS replaces the stored wallet once the isMtlsConnectionRequired of the database changes, so that the
+// stored connection artifacts match the connections the database accepts. The setting under which the wallet was
+// generated is recorded in status.walletMtlsConnectionRequired. The wallet is replaced once the update of the setting
+// completes, i.e. the database is AVAILABLE again.
+func (r *AutonomousDatabaseReconciler) validateWalletMTLS(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if !isWalletRequested(adb) {
+		adb.Status.WalletMTLSConnectionRequired = nil
+		return nil
+	}
+
+	isMTLSRequired := adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired
+	if isMTLSRequired == nil {
+		return nil
+	}
+
+	// The wallets stored before the setting is recorded are assumed to match the database
+	if adb.Status.WalletMTLSConnectionRequired == nil {
+		adb.Status.WalletMTLSConnectionRequired = common.Bool(*isMTLSRequired)
+		return nil
+	}
+
+	if *adb.Status.WalletMTLSConnectionRequired == *isMTLSRequired ||
+		adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateWalletMTLS")
+
+	l.Info(fmt.Sprintf("isMtlsConnectionRequired is changed to %t; regenerate the wallet", *isMTLSRequired))
+	if err := r.refreshWallet(ctx, l, adb); err != nil {
+		return err
+	}
+
+	adb.Status.WalletMTLSConnectionRequired = common.Bool(*isMTLSRequired)
+	r.Recorder.Event(adb, corev1.EventTypeNormal, "WalletRegenerated",
+		fmt.Sprintf("The wallet is regenerated since isMtlsConnectionRequired is changed to %t", *isMTLSRequired))
+
+	return nil
+}
+
+// refreshWallet replaces the stored wallet with a newly generated one, unless the wallet is managed by the user. A
+// missing Secret is left to the validateWallet.
+func (r *AutonomousDatabaseReconciler) refreshWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if !isWalletRequested(adb) || adb.Spec.Details.Wallet.Regenerate == dbv1alpha1.WalletRegenerateNever {
+		return nil
+	}
+
+	walletNamespace, walletName := walletLocation(adb)
+
+	if adb.Spec.Details.Wallet.ObjectStorage.Bucket != nil {
+		// Upload the wallet again even if it's uploaded to the same object
+		adb.Status.WalletObjectURL = ""
+		return r.uploadWallet(ctx, logger, adb, walletName)
+	}
+
+	if err := r.validateWalletNamespace(adb, walletNamespace); err != nil {
+		return err
+	}
+
+	secret, err := k8s.FetchSecret(r.KubeClient, walletNamespace, walletName)
+	if apiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !isWalletOwner(adb, secret) {
+		logger.Info("wallet existed but is not owned by the resource; skip the refresh")
+		r.refuseWallet(adb, secret)
+		return nil
+	}
+
+	return r.regenerateWallet(ctx, logger, adb, secret)
+}
+
+// regenerateWallet replaces the data of the existing wallet Secret with a newly generated wallet
+func (r *AutonomousDatabaseReconciler) regenerateWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, secret *corev1.Secret) error {
+	_, data, err := r.downloadWallet(ctx, logger, adb)
+	if err != nil {
+		return err
+	}
+
+	if _, err := applyWalletSettings(adb, data); err != nil {
+		return err
+	}
+
+	secret.Data = data
+	secret.StringData = nil
+	setWalletChecksum(adb, secret)
+	if err := r.KubeClient.Update(ctx, secret); err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Wallet is regenerated in the Secret %s/%s", secret.GetNamespace(), secret.GetName()))
+	setWalletExpiry(logger, adb, data)
+
+	return r.validateSplitWallet(ctx, logger, adb, secret.GetNamespace(), secret.GetName(), data)
+}
+
+// downloadWallet downloads the wallet zip and returns the zip and the unzipped files. The download is retried if the
+// zip is corrupt or misses the required files, so that an invalid wallet is never persisted.
+func (r *AutonomousDatabaseReconciler) downloadWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) ([]byte, map[string][]byte, error) {
+	var lastErr error
+
+	for attempt := 1; attempt <= walletDownloadAttempts; attempt++ {
+		resp, err := r.generateWallet(ctx, logger, adb)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err := ioutil.ReadAll(resp.Content)
+		resp.Content.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, err := oci.UnzipWallet(content)
+		if err == nil {
+			return content, data, nil
+		}
+
+		logger.Info("The downloaded wallet is invalid; retry the download", "attempt", attempt, "error", err.Error())
+		lastErr = err
+	}
+
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "InvalidWallet", lastErr.Error())
+	return nil, nil, lastErr
+}
+
+// generateWallet requests the wallet from OCI. If OCI responds with a conflict, which happens if the internal services
+// are not ready right after the database becomes AVAILABLE, the WalletPending condition is set and errWalletPending is
+// returned, so that the generation is retried in a requeued reconcile without failing the whole reconcile. The
+// reconcile doesn't wait for the retry, so the lock on the database is not held meanwhile. Once the retries in the
+// spec.details.wallet.downloadRetries are exhausted, the WalletFailed condition is set and errWalletFailed is returned.
+func (r *AutonomousDatabaseReconciler) generateWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (database.GenerateAutonomousDatabaseWalletResponse, error) {
+	resp, err := r.dbService.DownloadWallet(ctx, adb)
+	if err == nil {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)
+		adb.Status.WalletDownloadRetries = 0
+		return resp, nil
+	}
+
+	if _, reason := classifyOCIError(err); reason != ociErrorConflict {
+		return resp, err
+	}
+
+	if retries := adb.Spec.Details.Wallet.DownloadRetries; retries != nil && adb.Status.WalletDownloadRetries >= *retries {
+		msg := fmt.Sprintf("The wallet cannot be generated after %d retries: %s", adb.Status.WalletDownloadRetries, err.Error())
+		logger.Info(msg)
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletFailed", msg)
+
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)
+		meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+			Type:               dbv1alpha1.ADBConditionWalletFailed,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: adb.GetGeneration(),
+			Reason:             "RetriesExhausted",
+			Message:            msg,
+		})
+		return resp, errWalletFailed
+	}
+	adb.Status.WalletDownloadRetries++
+
+	logger.Info("The wallet cannot be generated yet; retry in a requeued reconcile", "error", err.Error())
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletPending", err.Error())
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionWalletPending,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "GenerationConflict",
+		Message:            err.Error(),
+	})
+	return resp, errWalletPending
+}
+
+// walletRetryInterval returns the interval to requeue the reconcile which retries the wallet generation. It's the
+// spec.details.wallet.downloadInterval if set. Otherwise it starts from walletGenerationBackoff and doubles after each
+// retry, up to the interval of requeueResult.
+func walletRetryInterval(adb *dbv1alpha1.AutonomousDatabase) time.Duration {
+	if interval := adb.Spec.Details.Wallet.DownloadInterval; interval != nil {
+		return interval.Duration
+	}
+
+	interval := walletGenerationBackoff
+	for i := 1; i < adb.Status.WalletDownloadRetries && interval < requeueResult.RequeueAfter; i++ {
+		interval *= 2
+	}
+	if interval > requeueResult.RequeueAfter {
+		interval = requeueResult.RequeueAfter
+	}
+	return interval
+}
+
+// uploadWallet uploads the wallet zip to the Object Storage bucket instead of storing it in a Secret. The upload is
+// skipped if the wallet is already uploaded to the same object, unless the wallet is regenerated always. A failed
+// upload is reported in the WalletUploaded condition and retried in the next reconcile.
+func (r *AutonomousDatabaseReconciler) uploadWallet(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, walletName string) error {
+	objectStorage := adb.Spec.Details.Wallet.ObjectStorage
+
+	objectName := walletName + ".zip"
+	if objectStorage.Prefix != nil {
+		objectName = *objectStorage.Prefix + objectName
+	}
+
+	objectURL := r.osService.GetObjectURL(*objectStorage.Namespace, *objectStorage.Bucket, objectName)
+	if adb.Spec.Details.Wallet.Regenerate != dbv1alpha1.WalletRegenerateAlways &&
+		adb.Status.WalletObjectURL == objectURL &&
+		meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded) {
+		return nil
+	}
+
+	content, data, err := r.downloadWallet(ctx, logger, adb)
+	if err != nil {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionWalletUploaded,
+		ObservedGeneration: adb.GetGeneration(),
+	}
+
+	if err := r.osService.PutObject(ctx, *objectStorage.Namespace, *objectStorage.Bucket, objectName, content); err != nil {
+		logger.Info("Failed to upload the wallet; retry in the next reconcile", "error", err.Error())
+		r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletUploadFailed", err.Error())
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UploadFailed"
+		condition.Message = err.Error()
+	} else {
+		logger.Info("Wallet is uploaded to " + objectURL)
+
+		adb.Status.WalletObjectURL = objectURL
+		setWalletExpiry(logger, adb, data)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UploadSucceeded"
+		condition.Message = "The wallet is uploaded to " + objectURL
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+	return nil
+}
+
+// validateSplitWallet creates a Secret per connection profile if the splitProfiles is enabled. The Secret is named
+// after the wallet Secret and the profile, e.g. <walletName>-high. The existing Secrets are updated if the wallet changes.
+func (r *AutonomousDatabaseReconciler) validateSplitWallet(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	walletNamespace string,
+	walletName string,
+	data map[string][]byte) error {
+
+	if adb.Spec.Details.Wallet.SplitProfiles == nil || !*adb.Spec.Details.Wallet.SplitProfiles {
+		return nil
+	}
+
+	profiles, err := oci.SplitWalletProfiles(data)
+	if err != nil {
+		return err
+	}
+
+	// Cross-namespace owner references are not allowed
+	var owner client.Object
+	if walletNamespace == adb.GetNamespace() {
+		owner = adb
+	}
+
+	for profile, profileData := range profiles {
+		secretName := walletName + "-" + profile
+
+		secret, err := k8s.FetchSecret(r.KubeClient, walletNamespace, secretName)
+		if err == nil {
+			// Never overwrite a Secret of the same name which belongs to something else
+			if !isWalletOwner(adb, secret) {
+				r.refuseWallet(adb, secret)
+				return fmt.Errorf("the Secret %s/%s of the connection profile %s is not owned by the resource", walletNamespace, secretName, profile)
+			}
+
+			if reflect.DeepEqual(secret.Data, profileData) {
+				continue
+			}
+
+			secret.Data = profileData
+			if err := r.KubeClient.Update(ctx, secret); err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("Connection profile %s is updated in the Secret %s/%s", profile, walletNamespace, secretName))
+			continue
+		} else if !apiErrors.IsNotFound(err) {
+			return err
+		}
+
+		label := map[string]string{
+			"app":                         adb.GetName(),
+			dbv1alpha1.WalletProfileLabel: profile,
+		}
+
+		if err := k8s.CreateSecret(r.KubeClient, walletNamespace, secretName, profileData, owner, label, walletAnnotations(adb, walletNamespace)); err != nil {
+			return err
+		}
+		logger.Info(fmt.Sprintf("Connection profile %s is stored in the Secret %s/%s", profile, walletNamespace, secretName))
+	}
+
+	return nil
+}
+
+// deleteSplitWallets deletes the Secrets of the connection profiles. The Secrets in the same namespace are removed
+// along with the resource by the owner reference, but the ones in another namespace have to be deleted explicitly.
+func (r *AutonomousDatabaseReconciler) deleteSplitWallets(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Spec.Details.Wallet.SplitProfiles == nil || !*adb.Spec.Details.Wallet.SplitProfiles {
+		return nil
+	}
+
+	walletNamespace := adb.GetNamespace()
+	if adb.Spec.Details.Wallet.Namespace != nil && *adb.Spec.Details.Wallet.Namespace != "" {
+		walletNamespace = *adb.Spec.Details.Wallet.Namespace
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := r.KubeClient.List(ctx, secretList,
+		client.InNamespace(walletNamespace),
+		client.MatchingLabels{"app": adb.GetName()},
+		client.HasLabels{dbv1alpha1.WalletProfileLabel}); err != nil {
+		return err
+	}
+
+	for i := range secretList.Items {
+		// The app label alone doesn't prove the Secret is written by the resource
+		if !isWalletOwner(adb, &secretList.Items[i]) {
+			continue
+		}
+		if err := r.KubeClient.Delete(ctx, &secretList.Items[i]); err != nil && !apiErrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info(fmt.Sprintf("Secret %s/%s is deleted", walletNamespace, secretList.Items[i].Name))
+	}
+
+	return nil
+}
+
+func isHealthCheckEnabled(adb *dbv1alpha1.AutonomousDatabase) bool {
+	return adb.Spec.HealthCheck.Enabled != nil && *adb.Spec.HealthCheck.Enabled
+}
+
+func getHealthCheckDuration(seconds *int, defaultDuration time.Duration) time.Duration {
+	if seconds == nil || *seconds <= 0 {
+		return defaultDuration
+	}
+	return time.Duration(*seconds) * time.Second
+}
+
+// validateConnectivity checks if the database listener accepts connections and sets the Connected condition.
+// The result is only reported in the condition, so a failed check never changes the lifecycleState.
+func (r *AutonomousDatabaseReconciler) validateConnectivity(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) {
+	if !isHealthCheckEnabled(adb) {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)
+		return
+	}
+
+	l := logger.WithName("validateConnectivity")
+
+	condition := metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionConnected,
+		ObservedGeneration: adb.GetGeneration(),
+	}
+
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "DatabaseNotAvailable"
+		condition.Message = "The database is in " + string(adb.Status.LifecycleState) + " state"
+	} else {
+		address, verified, err := r.checkListener(ctx, adb, getHealthCheckDuration(adb.Spec.HealthCheck.TimeoutSeconds, defaultHealthCheckTimeout))
+
+		if err != nil {
+			l.Info("Connectivity check failed", "error", err.Error())
+
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "ConnectionFailed"
+			if errors.Is(err, adbfamily.ErrHandshakeFailed) {
+				condition.Reason = "HandshakeFailed"
+			}
+			condition.Message = err.Error()
+		} else if verified {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "ConnectionSucceeded"
+			condition.Message = "The database listener at " + address + " accepts TLS connections verified by the wallet"
+		} else {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "ConnectionSucceeded"
+			condition.Message = "The database listener at " + address + " accepts connections"
+		}
+	}
+
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+}
+
+// checkListener checks the listener of the first connection string in the status. If the wallet Secret is stored by
+// the resource, the listener of the same TNS name in the tnsnames.ora of the wallet is verified by a TLS handshake
+// with the CA certificates of the wallet, and verified is true. The certificate is verified for the host reported by
+// OCI, since the hosts in the tnsnames.ora can be rewritten by the hostRewrite. Otherwise, e.g. the wallet is uploaded
+// to Object Storage, only a TCP connection is opened to the listener.
+func (r *AutonomousDatabaseReconciler) checkListener(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase, timeout time.Duration) (address string, verified bool, err error) {
+	address, err = adbfamily.GetProbeAddress(adb)
+	if err != nil {
+		return address, false, err
+	}
+
+	walletData := r.getStoredWallet(ctx, adb)
+	if walletData == nil {
+		return address, false, adbfamily.PingListener(address, timeout)
+	}
+
+	descriptor, err := oci.WalletConnectDescriptor(walletData, adbfamily.GetProbeTNSName(adb))
+	if err != nil {
+		return address, false, err
+	}
+	walletAddress, err := adbfamily.GetListenerAddress(descriptor)
+	if err != nil {
+		return address, false, err
+	}
+	rootCAs, err := oci.WalletCertPool(walletData)
+	if err != nil {
+		return walletAddress, false, err
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return walletAddress, false, err
+	}
+	return walletAddress, true, adbfamily.HandshakeListener(walletAddress, host, rootCAs, timeout)
+}
+
+// getStoredWallet returns the data of the wallet Secret if it's stored by the resource, otherwise nil
+func (r *AutonomousDatabaseReconciler) getStoredWallet(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) map[string][]byte {
+	if !isWalletRequested(adb) || adb.Spec.Details.Wallet.ObjectStorage.Bucket != nil {
+		return nil
+	}
+
+	namespace, name := walletLocation(adb)
+	secret := &corev1.Secret{}
+	if err := r.KubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil
+	}
+	if !isWalletOwner(adb, secret) {
+		return nil
+	}
+
+	return secret.Data
 }
 
 // updateBackupResources get the list of AutonomousDatabasBackups and
 // create a backup object if it's not found in the same namespace
-func (r *AutonomousDatabaseReconciler) syncBackupResources(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+func (r *AutonomousDatabaseReconciler) syncBackupResources(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
 	l := logger.WithName("syncBackupResources")
 
 	// Get the list of AutonomousDatabaseBackupOCID in the same namespace
@@ -1248,7 +4071,7 @@ func (r *AutonomousDatabaseReconciler) syncBackupResources(logger logr.Logger, a
 		}
 	}
 
-	resp, err := r.dbService.ListAutonomousDatabaseBackups(*adb.Spec.Details.AutonomousDatabaseOCID)
+	resp, err := r.dbService.ListAutonomousDatabaseBackups(ctx, *adb.GetAutonomousDatabaseOCID())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,4962 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+// fakeDatabaseService overrides the DatabaseService methods used by the specs.
+// Calling the methods that are not overridden panics.
+type fakeDatabaseService struct {
+	oci.DatabaseService
+
+	// The attributes of the database in OCI. The Id and the LifecycleState are overwritten.
+	ociADB      database.AutonomousDatabase
+	getADBState database.AutonomousDatabaseLifecycleStateEnum
+	// The OCIDs of the databases which are not found by GetAutonomousDatabase
+	missingADBs map[string]bool
+	getADBCalls int
+	scaleCalls  int
+	stopCalls   int
+	startCalls  int
+	drTypeCalls int
+	drPeerCalls int
+	walletCalls int
+	rotateCalls int
+	deleteCalls int
+	keyCalls    int
+	pwdCalls    int
+	dbmCalls    int
+	opsiCalls   int
+	shrinkCalls int
+	detachCalls int
+	// The lifecycleState of the wallet returned by GetWallet. Defaults to ACTIVE.
+	walletState database.AutonomousDatabaseWalletLifecycleStateEnum
+	// The error returned by the next UpdateAutonomousDatabaseScalingFields request, which is cleared once returned
+	scaleErr error
+	// The difADB of the last UpdateAutonomousDatabaseScalingFields request
+	scaleDifADB *dbv1alpha1.AutonomousDatabase
+	// The difADB of the last UpdateAutonomousDatabaseGeneralFields request
+	generalFieldsDifADB *dbv1alpha1.AutonomousDatabase
+	// The difADB of the last UpdateNetworkAccess request
+	networkAccessDifADB *dbv1alpha1.AutonomousDatabase
+	// The adb of the last CreateAutonomousDatabase request
+	createdADB *dbv1alpha1.AutonomousDatabase
+	// The wallet zips returned by DownloadWallet in order
+	walletZips [][]byte
+	// The errors returned by DownloadWallet in order before any wallet is returned
+	walletErrs []error
+	// The databases returned by the list operations
+	adbSummaries []database.AutonomousDatabaseSummary
+	// The cross-region peers returned by GetAutonomousDatabaseInRegion by their OCIDs. A missing peer is not found.
+	peerADBs        map[string]database.AutonomousDatabase
+	peerDeleteCalls int
+	// The Data Guard associations returned by ListAutonomousContainerDatabaseDataguardAssociations
+	dgAssociations []database.AutonomousContainerDatabaseDataguardAssociation
+	failoverCalls  int
+	// The availability domain, the VM cluster and the Exadata infrastructure of the Autonomous Container Database
+	acdAvailabilityDomain *string
+	acdVMCluster          string
+	acdInfrastructure     string
+	getACDCalls           int
+	// The attributes of the Autonomous Container Database. The Id and the AvailabilityDomain are overwritten.
+	ociACD database.AutonomousContainerDatabase
+	// The backup returned by GetAutonomousDatabaseBackup. The Id is overwritten.
+	ociBackup database.AutonomousDatabaseBackup
+	// The OCIDs of the backups deleted by DeleteAutonomousDatabaseBackup in order
+	deletedBackups []string
+}
+
+func (s *fakeDatabaseService) newOCIADB(adbOCID string, state database.AutonomousDatabaseLifecycleStateEnum) database.AutonomousDatabase {
+	ociADB := s.ociADB
+	ociADB.Id = common.String(adbOCID)
+	ociADB.LifecycleState = state
+	if ociADB.IsDedicated == nil {
+		ociADB.IsDedicated = common.Bool(false)
+	}
+	if ociADB.ConnectionStrings == nil {
+		ociADB.ConnectionStrings = &database.AutonomousDatabaseConnectionStrings{}
+	}
+	return ociADB
+}
+
+func (s *fakeDatabaseService) GetAutonomousDatabase(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseResponse, error) {
+	s.getADBCalls++
+	if s.missingADBs[adbOCID] {
+		return database.GetAutonomousDatabaseResponse{}, fakeServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound"}
+	}
+	return database.GetAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, s.getADBState),
+	}, nil
+}
+
+func (s *fakeDatabaseService) GetContainerDatabasePlacement(ctx context.Context, acd database.AutonomousContainerDatabase) (string, string, error) {
+	return s.acdVMCluster, s.acdInfrastructure, nil
+}
+
+func (s *fakeDatabaseService) GetAutonomousContainerDatabase(ctx context.Context, acdOCID string) (database.GetAutonomousContainerDatabaseResponse, error) {
+	s.getACDCalls++
+	acd := s.ociACD
+	acd.Id = common.String(acdOCID)
+	acd.AvailabilityDomain = s.acdAvailabilityDomain
+	return database.GetAutonomousContainerDatabaseResponse{AutonomousContainerDatabase: acd}, nil
+}
+
+func (s *fakeDatabaseService) ListAutonomousDatabasesByDisplayName(ctx context.Context, compartmentOCID string, displayName string) (database.ListAutonomousDatabasesResponse, error) {
+	var items []database.AutonomousDatabaseSummary
+	for _, summary := range s.adbSummaries {
+		if *summary.CompartmentId == compartmentOCID && *summary.DisplayName == displayName {
+			items = append(items, summary)
+		}
+	}
+	return database.ListAutonomousDatabasesResponse{Items: items}, nil
+}
+
+func (s *fakeDatabaseService) ListAutonomousDatabases(ctx context.Context, compartmentOCID string) ([]database.AutonomousDatabaseSummary, error) {
+	var items []database.AutonomousDatabaseSummary
+	for _, summary := range s.adbSummaries {
+		if *summary.CompartmentId == compartmentOCID {
+			items = append(items, summary)
+		}
+	}
+	return items, nil
+}
+
+func (s *fakeDatabaseService) UpdateAutonomousDatabaseScalingFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.scaleCalls++
+	s.scaleDifADB = difADB.DeepCopy()
+	if err := s.scaleErr; err != nil {
+		s.scaleErr = nil
+		return database.UpdateAutonomousDatabaseResponse{}, err
+	}
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateScaleInProgress),
+	}, nil
+}
+
+func (s *fakeDatabaseService) UpdateAutonomousDatabaseGeneralFields(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.generalFieldsDifADB = difADB.DeepCopy()
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) UpdateAutonomousDatabaseAdminPassword(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.pwdCalls++
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) EnableDatabaseManagement(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseManagementResponse, error) {
+	s.dbmCalls++
+	s.ociADB.DatabaseManagementStatus = database.AutonomousDatabaseDatabaseManagementStatusEnabling
+	return database.EnableAutonomousDatabaseManagementResponse{}, nil
+}
+
+func (s *fakeDatabaseService) DisableDatabaseManagement(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseManagementResponse, error) {
+	s.dbmCalls++
+	s.ociADB.DatabaseManagementStatus = database.AutonomousDatabaseDatabaseManagementStatusDisabling
+	return database.DisableAutonomousDatabaseManagementResponse{}, nil
+}
+
+func (s *fakeDatabaseService) EnableOperationsInsights(ctx context.Context, adbOCID string) (database.EnableAutonomousDatabaseOperationsInsightsResponse, error) {
+	s.opsiCalls++
+	return database.EnableAutonomousDatabaseOperationsInsightsResponse{}, nil
+}
+
+func (s *fakeDatabaseService) DisableOperationsInsights(ctx context.Context, adbOCID string) (database.DisableAutonomousDatabaseOperationsInsightsResponse, error) {
+	s.opsiCalls++
+	return database.DisableAutonomousDatabaseOperationsInsightsResponse{}, nil
+}
+
+func (s *fakeDatabaseService) UpdateNetworkAccess(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.networkAccessDifADB = difADB.DeepCopy()
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) StopAutonomousDatabase(ctx context.Context, adbOCID string) (database.StopAutonomousDatabaseResponse, error) {
+	s.stopCalls++
+	return database.StopAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateStopping),
+	}, nil
+}
+
+func (s *fakeDatabaseService) StartAutonomousDatabase(ctx context.Context, adbOCID string) (database.StartAutonomousDatabaseResponse, error) {
+	s.startCalls++
+	return database.StartAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateStarting),
+	}, nil
+}
+
+func (s *fakeDatabaseService) DeleteAutonomousDatabase(ctx context.Context, adbOCID string) (database.DeleteAutonomousDatabaseResponse, error) {
+	s.deleteCalls++
+	return database.DeleteAutonomousDatabaseResponse{}, nil
+}
+
+func (s *fakeDatabaseService) ChangeDisasterRecoveryConfiguration(ctx context.Context, adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.drTypeCalls++
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) CreateAutonomousDatabase(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (database.CreateAutonomousDatabaseResponse, error) {
+	s.createdADB = adb
+	return database.CreateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB("ocid1.autonomousdatabase.oc1..created", database.AutonomousDatabaseLifecycleStateProvisioning),
+	}, nil
+}
+
+func (s *fakeDatabaseService) CreateDisasterRecoveryPeer(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase, peer dbv1alpha1.DisasterRecoveryPeerSpec) (database.CreateAutonomousDatabaseResponse, error) {
+	s.drPeerCalls++
+	return database.CreateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB("ocid1.autonomousdatabase.oc1.phx.peer", database.AutonomousDatabaseLifecycleStateProvisioning),
+	}, nil
+}
+
+func (s *fakeDatabaseService) GetAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.GetAutonomousDatabaseResponse, error) {
+	peer, ok := s.peerADBs[adbOCID]
+	if !ok {
+		return database.GetAutonomousDatabaseResponse{}, fakeServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound"}
+	}
+	return database.GetAutonomousDatabaseResponse{AutonomousDatabase: peer}, nil
+}
+
+func (s *fakeDatabaseService) DeleteAutonomousDatabaseInRegion(ctx context.Context, adbOCID string, region string) (database.DeleteAutonomousDatabaseResponse, error) {
+	s.peerDeleteCalls++
+	return database.DeleteAutonomousDatabaseResponse{}, nil
+}
+
+func (s *fakeDatabaseService) ListAutonomousContainerDatabaseDataguardAssociations(ctx context.Context, acdOCID string) ([]database.AutonomousContainerDatabaseDataguardAssociation, error) {
+	return s.dgAssociations, nil
+}
+
+func (s *fakeDatabaseService) UpdateAutomaticFailover(ctx context.Context, acdOCID string, associationOCID string, enabled bool) (database.UpdateAutonomousContainerDatabaseDataguardAssociationResponse, error) {
+	s.failoverCalls++
+	for i := range s.dgAssociations {
+		if *s.dgAssociations[i].Id == associationOCID {
+			s.dgAssociations[i].IsAutomaticFailoverEnabled = common.Bool(enabled)
+		}
+	}
+	return database.UpdateAutonomousContainerDatabaseDataguardAssociationResponse{}, nil
+}
+
+func (s *fakeDatabaseService) GetAutonomousDatabaseBackup(ctx context.Context, backupOCID string) (database.GetAutonomousDatabaseBackupResponse, error) {
+	ociBackup := s.ociBackup
+	ociBackup.Id = common.String(backupOCID)
+	return database.GetAutonomousDatabaseBackupResponse{AutonomousDatabaseBackup: ociBackup}, nil
+}
+
+func (s *fakeDatabaseService) DeleteAutonomousDatabaseBackup(ctx context.Context, backupOCID string) error {
+	s.deletedBackups = append(s.deletedBackups, backupOCID)
+	return nil
+}
+
+func (s *fakeDatabaseService) ListAutonomousDatabaseBackups(ctx context.Context, adbOCID string) (database.ListAutonomousDatabaseBackupsResponse, error) {
+	return database.ListAutonomousDatabaseBackupsResponse{}, nil
+}
+
+// DownloadWallet returns the walletErrs and the walletZips in order, and then the wallet in testdata/wallet.zip
+func (s *fakeDatabaseService) DownloadWallet(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (database.GenerateAutonomousDatabaseWalletResponse, error) {
+	s.walletCalls++
+
+	if len(s.walletErrs) > 0 {
+		var err error
+		err, s.walletErrs = s.walletErrs[0], s.walletErrs[1:]
+		return database.GenerateAutonomousDatabaseWalletResponse{}, err
+	}
+
+	var content []byte
+	if len(s.walletZips) > 0 {
+		content, s.walletZips = s.walletZips[0], s.walletZips[1:]
+	} else {
+		content = readTestdata("wallet.zip")
+	}
+
+	return database.GenerateAutonomousDatabaseWalletResponse{
+		Content: ioutil.NopCloser(bytes.NewReader(content)),
+	}, nil
+}
+
+// fakeServiceError is an error response from OCI
+type fakeServiceError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+func (e fakeServiceError) Error() string {
+	return fmt.Sprintf("Error returned by Database Service. Http Status Code: %d. Error Code: %s. Message: %s", e.statusCode, e.code, e.message)
+}
+func (e fakeServiceError) GetHTTPStatusCode() int  { return e.statusCode }
+func (e fakeServiceError) GetMessage() string      { return e.message }
+func (e fakeServiceError) GetCode() string         { return e.code }
+func (e fakeServiceError) GetOpcRequestID() string { return "fake-request-id" }
+
+func (s *fakeDatabaseService) RotateWallet(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseWalletResponse, error) {
+	s.rotateCalls++
+	s.walletState = database.AutonomousDatabaseWalletLifecycleStateUpdating
+	return database.UpdateAutonomousDatabaseWalletResponse{}, nil
+}
+
+func (s *fakeDatabaseService) ShrinkAutonomousDatabase(ctx context.Context, adbOCID string) (database.ShrinkAutonomousDatabaseResponse, error) {
+	s.shrinkCalls++
+	return database.ShrinkAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) DetachRefreshableClone(ctx context.Context, adbOCID string) (database.UpdateAutonomousDatabaseResponse, error) {
+	s.detachCalls++
+	return database.UpdateAutonomousDatabaseResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) RotateEncryptionKey(ctx context.Context, adbOCID string) (database.RotateAutonomousDatabaseEncryptionKeyResponse, error) {
+	s.keyCalls++
+	return database.RotateAutonomousDatabaseEncryptionKeyResponse{
+		AutonomousDatabase: s.newOCIADB(adbOCID, database.AutonomousDatabaseLifecycleStateUpdating),
+	}, nil
+}
+
+func (s *fakeDatabaseService) GetWallet(ctx context.Context, adbOCID string) (database.GetAutonomousDatabaseWalletResponse, error) {
+	state := s.walletState
+	if state == "" {
+		state = database.AutonomousDatabaseWalletLifecycleStateActive
+	}
+	return database.GetAutonomousDatabaseWalletResponse{
+		AutonomousDatabaseWallet: database.AutonomousDatabaseWallet{LifecycleState: state},
+	}, nil
+}
+
+// testWalletExpiry is the expiry of the certificate in the ewallet.pem of testdata/wallet.zip
+var testWalletExpiry = time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestWallet returns the wallet in testdata/wallet.zip with the cwallet.sso replaced
+func newTestWallet(cwalletSso string) []byte {
+	files, err := oci.UnzipWallet(readTestdata("wallet.zip"))
+	Expect(err).ToNot(HaveOccurred())
+	files["cwallet.sso"] = []byte(cwalletSso)
+
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for name, content := range files {
+		fileWriter, err := writer.Create(name)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fileWriter.Write(content)
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(writer.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func readTestdata(fileName string) []byte {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", fileName))
+	Expect(err).ToNot(HaveOccurred())
+	return content
+}
+
+// conflictOnceClient fails the first status update with a Conflict, as if the resource was modified by others
+type conflictOnceClient struct {
+	client.Client
+	statusUpdates int
+}
+
+func (c *conflictOnceClient) Status() client.StatusWriter {
+	return &conflictOnceStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type conflictOnceStatusWriter struct {
+	client.StatusWriter
+	client *conflictOnceClient
+}
+
+func (w *conflictOnceStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	w.client.statusUpdates++
+	if w.client.statusUpdates == 1 {
+		return apiErrors.NewConflict(dbv1alpha1.GroupVersion.WithResource("autonomousdatabases").GroupResource(),
+			obj.GetName(), errors.New("the object has been modified"))
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+// fakeObjectStorageService stores the uploaded objects in memory, or fails the upload if the putErr is set.
+type fakeObjectStorageService struct {
+	objects map[string][]byte
+	putErr  error
+}
+
+func (s *fakeObjectStorageService) PutObject(ctx context.Context, namespace string, bucket string, objectName string, content []byte) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	s.objects[s.GetObjectURL(namespace, bucket, objectName)] = content
+	return nil
+}
+
+func (s *fakeObjectStorageService) GetObjectURL(namespace string, bucket string, objectName string) string {
+	return "https://objectstorage.fake/n/" + namespace + "/b/" + bucket + "/o/" + objectName
+}
+
+// fakeIdentityService resolves the compartment paths in the map, or fails if the path is not in the map
+type fakeIdentityService struct {
+	compartments        map[string]string
+	availabilityDomains []string
+	resolveCalls        int
+}
+
+func (s *fakeIdentityService) GetCompartmentOCID(ctx context.Context, path string) (string, error) {
+	s.resolveCalls++
+	ocid, ok := s.compartments[path]
+	if !ok {
+		return "", fmt.Errorf("compartment name %q is ambiguous", path)
+	}
+	return ocid, nil
+}
+
+func (s *fakeIdentityService) ListAvailabilityDomains(ctx context.Context) ([]string, error) {
+	return s.availabilityDomains, nil
+}
+
+// fakeNetworkService resolves the names of the network security groups in the map, or fails if a name is not in the map
+type fakeNetworkService struct {
+	nsgs         map[string]string
+	resolveCalls int
+}
+
+func (s *fakeNetworkService) GetNsgOCIDs(ctx context.Context, subnetOCID string, names []string) ([]string, error) {
+	s.resolveCalls++
+	var ocids []string
+	for _, name := range names {
+		ocid, ok := s.nsgs[name]
+		if !ok {
+			return nil, fmt.Errorf("network security group name %q is ambiguous", name)
+		}
+		ocids = append(ocids, ocid)
+	}
+	return ocids, nil
+}
+
+// newTestReconciler returns a reconciler with a fake Kubernetes client which has the objects. The OCI services are
+// set by the tests.
+func newTestReconciler(objs ...client.Object) *AutonomousDatabaseReconciler {
+	scheme := runtime.NewScheme()
+	Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	return &AutonomousDatabaseReconciler{
+		KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Log:        logr.Discard(),
+		Recorder:   record.NewFakeRecorder(10),
+	}
+}
+
+var _ = Describe("AutonomousDatabase compartment name", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		idService  *fakeIdentityService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentName: common.String("prod/db"),
+					DbName:          common.String("adb"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		idService = &fakeIdentityService{compartments: map[string]string{"prod/db": "ocid1.compartment.oc1..prod-db"}}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+		reconciler.idService = idService
+	})
+
+	It("should provision the database in the resolved compartment", func() {
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		Expect(*dbService.createdADB.Spec.Details.CompartmentOCID).To(Equal("ocid1.compartment.oc1..prod-db"))
+		// The spec is not changed
+		Expect(adb.Spec.Details.CompartmentOCID).To(BeNil())
+		Expect(*adb.GetAutonomousDatabaseOCID()).To(Equal("ocid1.autonomousdatabase.oc1..created"))
+	})
+
+	It("should use the compartmentOCID if it's set", func() {
+		adb.Spec.Details.CompartmentOCID = common.String("ocid1.compartment.oc1..explicit")
+
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		Expect(idService.resolveCalls).To(Equal(0))
+		Expect(*dbService.createdADB.Spec.Details.CompartmentOCID).To(Equal("ocid1.compartment.oc1..explicit"))
+	})
+
+	It("should not provision the database if the compartment cannot be resolved", func() {
+		adb.Spec.Details.CompartmentName = common.String("db")
+
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(MatchError(ContainSubstring("ambiguous")))
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("CompartmentNotResolved")))
+	})
+})
+
+var _ = Describe("AutonomousDatabase createIfMissing", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID: common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:     common.String("adb"),
+					DbName:          common.String("adb"),
+					CreateIfMissing: common.Bool(true),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			adbSummaries: []database.AutonomousDatabaseSummary{
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..terminated"),
+					CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:    common.String("adb"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateTerminated,
+				},
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..other"),
+					CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:    common.String("other"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+				},
+			},
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should provision the database if none has the displayName", func() {
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(result).To(Equal(requeueResult))
+
+		Expect(dbService.createdADB).ToNot(BeNil())
+		Expect(adb.Status.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..created"))
+	})
+
+	It("should bind to the existing database with the displayName", func() {
+		dbService.adbSummaries = append(dbService.adbSummaries, database.AutonomousDatabaseSummary{
+			Id:             common.String("ocid1.autonomousdatabase.oc1..existing"),
+			CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+			DisplayName:    common.String("adb"),
+			LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateStopped,
+		})
+
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(result).To(Equal(requeueResult))
+
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(adb.Status.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..existing"))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("BoundToExisting")))
+	})
+
+	It("should neither bind nor provision if more than one database has the displayName", func() {
+		for _, ocid := range []string{"ocid1.autonomousdatabase.oc1..one", "ocid1.autonomousdatabase.oc1..two"} {
+			dbService.adbSummaries = append(dbService.adbSummaries, database.AutonomousDatabaseSummary{
+				Id:             common.String(ocid),
+				CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+				DisplayName:    common.String("adb"),
+				LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+			})
+		}
+
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).To(MatchError(ContainSubstring("is used by more than one database")))
+
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(adb.Status.AutonomousDatabaseOCID).To(BeEmpty())
+	})
+})
+
+var _ = Describe("AutonomousDatabase rebindOnMissing", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..old"),
+					RebindOnMissing:        common.Bool(true),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				AutonomousDatabaseOCID: "ocid1.autonomousdatabase.oc1..old",
+				CompartmentOCID:        "ocid1.compartment.oc1..fake",
+				DisplayName:            "adb",
+				LifecycleState:         database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+			missingADBs: map[string]bool{"ocid1.autonomousdatabase.oc1..old": true},
+			adbSummaries: []database.AutonomousDatabaseSummary{
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..old"),
+					CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:    common.String("adb"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateTerminated,
+				},
+			},
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should re-bind to the database with the displayName after the database is not found", func() {
+		dbService.adbSummaries = append(dbService.adbSummaries, database.AutonomousDatabaseSummary{
+			Id:             common.String("ocid1.autonomousdatabase.oc1..new"),
+			CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+			DisplayName:    common.String("adb"),
+			LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+		})
+
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(result).To(Equal(requeueResult))
+
+		Expect(*adb.GetAutonomousDatabaseOCID()).To(Equal("ocid1.autonomousdatabase.oc1..new"))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(And(
+			ContainSubstring("Rebound"),
+			ContainSubstring("ocid1.autonomousdatabase.oc1..old"),
+			ContainSubstring("ocid1.autonomousdatabase.oc1..new"))))
+
+		// Both the spec and the status are updated in the cluster
+		updated := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updated)).To(Succeed())
+		Expect(*updated.Spec.Details.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..new"))
+		Expect(updated.Status.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..new"))
+
+		// The next reconcile syncs the new database
+		_, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+	})
+
+	It("should report the not found error if no database has the displayName", func() {
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).To(MatchError(ContainSubstring("NotAuthorizedOrNotFound")))
+
+		Expect(*adb.GetAutonomousDatabaseOCID()).To(Equal("ocid1.autonomousdatabase.oc1..old"))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("RebindFailed")))
+	})
+
+	It("should not re-bind if rebindOnMissing is not set", func() {
+		adb.Spec.Details.RebindOnMissing = nil
+		dbService.adbSummaries = append(dbService.adbSummaries, database.AutonomousDatabaseSummary{
+			Id:             common.String("ocid1.autonomousdatabase.oc1..new"),
+			CompartmentId:  common.String("ocid1.compartment.oc1..fake"),
+			DisplayName:    common.String("adb"),
+			LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+		})
+
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).To(MatchError(ContainSubstring("NotAuthorizedOrNotFound")))
+
+		Expect(*adb.GetAutonomousDatabaseOCID()).To(Equal("ocid1.autonomousdatabase.oc1..old"))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).ToNot(Receive())
+	})
+})
+
+var _ = Describe("AutonomousDatabase generated name suffix", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID:    common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:        common.String("ci-adb"),
+					DbName:             common.String("ciadb"),
+					GenerateNameSuffix: common.Bool(true),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{getADBState: database.AutonomousDatabaseLifecycleStateAvailable}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should provision the database with the generated names without changing the spec", func() {
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		suffix := adb.Status.NameSuffix
+		Expect(suffix).To(MatchRegexp("^[a-z0-9]{5}$"))
+		Expect(*dbService.createdADB.Spec.Details.DisplayName).To(Equal("ci-adb-" + suffix))
+		Expect(*dbService.createdADB.Spec.Details.DbName).To(Equal("ciadb" + suffix))
+		Expect(*adb.Spec.Details.DisplayName).To(Equal("ci-adb"))
+		Expect(*adb.Spec.Details.DbName).To(Equal("ciadb"))
+	})
+
+	It("should keep the generated names across the reconciles", func() {
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		suffix := adb.Status.NameSuffix
+
+		// A retry of the provisioning uses the same suffix
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(adb.Status.NameSuffix).To(Equal(suffix))
+		Expect(*dbService.createdADB.Spec.Details.DisplayName).To(Equal("ci-adb-" + suffix))
+
+		// The database in OCI has the generated names, so they are not renamed to the names in the spec
+		dbService.ociADB.DisplayName = common.String("ci-adb-" + suffix)
+		dbService.ociADB.DbName = common.String("ciadb" + suffix)
+
+		for i := 0; i < 2; i++ {
+			_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+		Expect(adb.Status.NameSuffix).To(Equal(suffix))
+		Expect(adb.Status.DisplayName).To(Equal("ci-adb-" + suffix))
+	})
+})
+
+var _ = Describe("AutonomousDatabase availability domain", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID:    common.String("ocid1.compartment.oc1..fake"),
+					DbName:             common.String("adb"),
+					IsDedicated:        common.Bool(true),
+					AvailabilityDomain: common.String("Uocm:PHX-AD-2"),
+				},
+			},
+		}
+		adb.Spec.Details.AutonomousContainerDatabase.OCIACD.OCID = common.String("ocid1.autonomouscontainerdatabase.oc1..fake")
+
+		dbService = &fakeDatabaseService{acdAvailabilityDomain: common.String("Uocm:PHX-AD-2")}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+		reconciler.idService = &fakeIdentityService{availabilityDomains: []string{"Uocm:PHX-AD-1", "Uocm:PHX-AD-2"}}
+	})
+
+	It("should provision the database in an availability domain of the region", func() {
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(*dbService.createdADB.Spec.Details.AvailabilityDomain).To(Equal("Uocm:PHX-AD-2"))
+	})
+
+	It("should not provision the database if the availability domain is not in the region", func() {
+		adb.Spec.Details.AvailabilityDomain = common.String("Uocm:IAD-AD-1")
+
+		err := reconciler.createADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).To(MatchError(ContainSubstring("the availabilityDomain Uocm:IAD-AD-1 is not in the region")))
+		Expect(err.Error()).To(ContainSubstring("Uocm:PHX-AD-1, Uocm:PHX-AD-2"))
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("InvalidAvailabilityDomain")))
+	})
+
+	It("should set the placement of the container database in the status", func() {
+		adb.Status.AutonomousContainerDatabaseOCID = "ocid1.autonomouscontainerdatabase.oc1..fake"
+		dbService.acdVMCluster = "ocid1.cloudautonomousvmcluster.oc1..fake"
+		dbService.acdInfrastructure = "ocid1.cloudexadatainfrastructure.oc1..fake"
+
+		reconciler.updatePlacement(context.TODO(), reconciler.Log, adb)
+		Expect(adb.Status.AvailabilityDomain).To(Equal("Uocm:PHX-AD-2"))
+		Expect(adb.Status.AutonomousVMClusterOCID).To(Equal("ocid1.cloudautonomousvmcluster.oc1..fake"))
+		Expect(adb.Status.CloudExadataInfrastructureOCID).To(Equal("ocid1.cloudexadatainfrastructure.oc1..fake"))
+
+		// The placement is only looked up once
+		reconciler.updatePlacement(context.TODO(), reconciler.Log, adb)
+		Expect(dbService.getACDCalls).To(Equal(1))
+	})
+
+	It("should not look up the placement of a serverless database", func() {
+		reconciler.updatePlacement(context.TODO(), reconciler.Log, adb)
+
+		Expect(adb.Status.AvailabilityDomain).To(BeEmpty())
+		Expect(adb.Status.CloudExadataInfrastructureOCID).To(BeEmpty())
+		Expect(dbService.getACDCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase maintenance status", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	It("should set the patch level and the maintenance window of a serverless database", func() {
+		dbService.ociADB = database.AutonomousDatabase{
+			AutonomousMaintenanceScheduleType: database.AutonomousDatabaseAutonomousMaintenanceScheduleTypeEarly,
+			TimeMaintenanceBegin:              &common.SDKTime{Time: time.Date(2022, 6, 4, 10, 0, 0, 0, time.UTC)},
+			TimeMaintenanceEnd:                &common.SDKTime{Time: time.Date(2022, 6, 4, 12, 0, 0, 0, time.UTC)},
+			StandbyDb: &database.AutonomousDatabaseStandbySummary{
+				LifecycleState: database.AutonomousDatabaseStandbySummaryLifecycleStateMaintenanceInProgress,
+			},
+		}
+
+		_, err := reconciler.getADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+
+		maintenance := adb.Status.Maintenance
+		Expect(maintenance.ScheduleType).To(Equal(database.AutonomousDatabaseAutonomousMaintenanceScheduleTypeEarly))
+		Expect(maintenance.TimeMaintenanceBegin).To(Equal(dbv1alpha1.FormatSDKTime(dbService.ociADB.TimeMaintenanceBegin)))
+		Expect(maintenance.TimeMaintenanceEnd).To(Equal(dbv1alpha1.FormatSDKTime(dbService.ociADB.TimeMaintenanceEnd)))
+		Expect(maintenance.StandbyLifecycleState).To(Equal(database.AutonomousDatabaseStandbySummaryLifecycleStateMaintenanceInProgress))
+
+		reconciler.updatePatching(context.TODO(), reconciler.Log, adb)
+		Expect(adb.Status.Maintenance.PatchModel).To(BeEmpty())
+		Expect(dbService.getACDCalls).To(BeZero())
+	})
+
+	It("should set the patching of the container database of a dedicated database", func() {
+		dbService.ociADB = database.AutonomousDatabase{
+			IsDedicated:                   common.Bool(true),
+			AutonomousContainerDatabaseId: common.String("ocid1.autonomouscontainerdatabase.oc1..fake"),
+		}
+		dbService.ociACD = database.AutonomousContainerDatabase{
+			PatchModel:           database.AutonomousContainerDatabasePatchModelUpdates,
+			NextMaintenanceRunId: common.String("ocid1.maintenancerun.oc1..next"),
+			LastMaintenanceRunId: common.String("ocid1.maintenancerun.oc1..last"),
+			PatchId:              common.String("ocid1.dbpatch.oc1..last"),
+		}
+
+		_, err := reconciler.getADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		reconciler.updatePatching(context.TODO(), reconciler.Log, adb)
+
+		Expect(adb.Status.Maintenance.PatchModel).To(Equal(database.AutonomousContainerDatabasePatchModelUpdates))
+		Expect(adb.Status.Maintenance.NextMaintenanceRunOCID).To(Equal("ocid1.maintenancerun.oc1..next"))
+		Expect(adb.Status.Maintenance.LastMaintenanceRunOCID).To(Equal("ocid1.maintenancerun.oc1..last"))
+		Expect(adb.Status.Maintenance.LastPatchOCID).To(Equal("ocid1.dbpatch.oc1..last"))
+
+		// The next run is cleared once it's completed
+		dbService.ociACD.NextMaintenanceRunId = nil
+		reconciler.updatePatching(context.TODO(), reconciler.Log, adb)
+		Expect(adb.Status.Maintenance.NextMaintenanceRunOCID).To(BeEmpty())
+		Expect(dbService.getACDCalls).To(Equal(2))
+	})
+})
+
+var _ = Describe("AutonomousDatabase network security group names", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		netService *fakeNetworkService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID: common.String("ocid1.compartment.oc1..fake"),
+					DbName:          common.String("adb"),
+					NetworkAccess: dbv1alpha1.NetworkAccessSpec{
+						AccessType: dbv1alpha1.NetworkAccessTypePrivate,
+						PrivateEndpoint: dbv1alpha1.PrivateEndpointSpec{
+							SubnetOCID: common.String("ocid1.subnet.oc1..fake"),
+							NsgNames:   []string{"db", "apps"},
+						},
+					},
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		netService = &fakeNetworkService{nsgs: map[string]string{
+			"db":   "ocid1.networksecuritygroup.oc1..db",
+			"apps": "ocid1.networksecuritygroup.oc1..apps",
+		}}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+		reconciler.netService = netService
+	})
+
+	It("should provision the database with the resolved network security groups", func() {
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		Expect(dbService.createdADB.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs).To(Equal([]string{
+			"ocid1.networksecuritygroup.oc1..db",
+			"ocid1.networksecuritygroup.oc1..apps",
+		}))
+		// The spec is not changed
+		Expect(adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs).To(BeNil())
+	})
+
+	It("should use the nsgOCIDs if they are set", func() {
+		adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = []string{"ocid1.networksecuritygroup.oc1..explicit"}
+
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		Expect(netService.resolveCalls).To(Equal(0))
+		Expect(dbService.createdADB.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs).To(Equal([]string{
+			"ocid1.networksecuritygroup.oc1..explicit",
+		}))
+	})
+
+	It("should not provision the database if a name cannot be resolved", func() {
+		adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = []string{"db", "web"}
+
+		Expect(reconciler.createADB(context.TODO(), reconciler.Log, adb)).To(MatchError(ContainSubstring("ambiguous")))
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("NsgNotResolved")))
+	})
+
+	Context("the database is provisioned", func() {
+		BeforeEach(func() {
+			adb.Spec.Details.AutonomousDatabaseOCID = common.String("ocid1.autonomousdatabase.oc1..fake")
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.SubnetOCID = nil
+			dbService.getADBState = database.AutonomousDatabaseLifecycleStateAvailable
+			dbService.ociADB = database.AutonomousDatabase{
+				CompartmentId: common.String("ocid1.compartment.oc1..fake"),
+				DbName:        common.String("adb"),
+				SubnetId:      common.String("ocid1.subnet.oc1..fake"),
+				NsgIds:        []string{"ocid1.networksecuritygroup.oc1..db", "ocid1.networksecuritygroup.oc1..apps"},
+			}
+		})
+
+		It("should not update the database if the resolved network security groups are unchanged", func() {
+			sent, exit, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sent).To(BeFalse())
+			Expect(exit).To(BeFalse())
+			// The names are resolved in the subnet of the OCI database
+			Expect(netService.resolveCalls).To(Equal(1))
+			Expect(dbService.networkAccessDifADB).To(BeNil())
+		})
+
+		It("should not update the database if OCI returns the same network security groups in another order", func() {
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = nil
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = []string{
+				"ocid1.networksecuritygroup.oc1..apps",
+				"ocid1.networksecuritygroup.oc1..db",
+			}
+
+			sent, exit, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sent).To(BeFalse())
+			Expect(exit).To(BeFalse())
+			Expect(dbService.networkAccessDifADB).To(BeNil())
+		})
+
+		It("should update the database if a network security group is replaced", func() {
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgNames = nil
+			adb.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs = []string{
+				"ocid1.networksecuritygroup.oc1..web",
+				"ocid1.networksecuritygroup.oc1..db",
+			}
+
+			sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sent).To(BeTrue())
+			Expect(dbService.networkAccessDifADB.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs).To(Equal([]string{
+				"ocid1.networksecuritygroup.oc1..web",
+				"ocid1.networksecuritygroup.oc1..db",
+			}))
+		})
+
+		It("should update the database to the resolved network security groups", func() {
+			netService.nsgs["apps"] = "ocid1.networksecuritygroup.oc1..apps-new"
+
+			sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sent).To(BeTrue())
+			Expect(dbService.networkAccessDifADB.Spec.Details.NetworkAccess.PrivateEndpoint.NsgOCIDs).To(Equal([]string{
+				"ocid1.networksecuritygroup.oc1..db",
+				"ocid1.networksecuritygroup.oc1..apps-new",
+			}))
+		})
+	})
+})
+
+var _ = Describe("AutonomousDatabase access control", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CompartmentOCID:        common.String("ocid1.compartment.oc1..fake"),
+					DbName:                 common.String("adb"),
+					NetworkAccess: dbv1alpha1.NetworkAccessSpec{
+						AccessType:             dbv1alpha1.NetworkAccessTypeRestricted,
+						IsAccessControlEnabled: common.Bool(true),
+						AccessControlList:      []string{"192.168.0.1"},
+					},
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+			ociADB: database.AutonomousDatabase{
+				CompartmentId:            common.String("ocid1.compartment.oc1..fake"),
+				DbName:                   common.String("adb"),
+				IsAccessControlEnabled:   common.Bool(true),
+				WhitelistedIps:           []string{"192.168.0.1"},
+				IsMtlsConnectionRequired: common.Bool(true),
+			},
+		}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	It("should not update the database if the access control is unchanged", func() {
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.networkAccessDifADB).To(BeNil())
+	})
+
+	It("should disable the access control of a serverless database and keep the whitelist", func() {
+		adb.Spec.Details.NetworkAccess.IsAccessControlEnabled = common.Bool(false)
+		adb.Spec.Details.NetworkAccess.AccessControlList = nil
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+
+		networkAccess := dbService.networkAccessDifADB.Spec.Details.NetworkAccess
+		Expect(networkAccess.IsAccessControlEnabled).To(Equal(common.Bool(false)))
+		// The whitelist is left out of the request, so it's kept in OCI
+		Expect(networkAccess.AccessControlList).To(BeNil())
+		Expect(networkAccess.AccessType).To(BeEmpty())
+	})
+
+	It("should enable the access control along with a new whitelist", func() {
+		dbService.ociADB.IsAccessControlEnabled = common.Bool(false)
+		adb.Spec.Details.NetworkAccess.AccessControlList = []string{"192.168.0.2"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+
+		networkAccess := dbService.networkAccessDifADB.Spec.Details.NetworkAccess
+		Expect(networkAccess.IsAccessControlEnabled).To(Equal(common.Bool(true)))
+		Expect(networkAccess.AccessControlList).To(Equal([]string{"192.168.0.2"}))
+	})
+})
+
+var _ = Describe("AutonomousDatabase termination", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+	)
+
+	newTerminatingADB := func(deletedFor time.Duration) *dbv1alpha1.AutonomousDatabase {
+		deletionTimestamp := metav1.NewTime(time.Now().Add(-deletedFor))
+		return &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "adb",
+				Namespace:         "default",
+				DeletionTimestamp: &deletionTimestamp,
+				Finalizers:        []string{dbv1alpha1.ADBFinalizer},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					LifecycleState:         database.AutonomousDatabaseLifecycleStateTerminated,
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateTerminating,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		dbService = &fakeDatabaseService{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler()
+		reconciler.Recorder = recorder
+		reconciler.TerminationMaxWait = time.Hour
+		reconciler.dbService = dbService
+	})
+
+	It("should keep the finalizer while the database is TERMINATING in OCI", func() {
+		adb := newTerminatingADB(time.Minute)
+		Expect(reconciler.KubeClient.Create(context.TODO(), adb)).To(Succeed())
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateTerminating
+
+		exitReconcile, result, err := reconciler.validateCleanup(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exitReconcile).To(BeTrue())
+		Expect(result.RequeueAfter).To(BeNumerically(">=", minTerminationRequeue))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", maxTerminationRequeue))
+		Expect(adb.GetFinalizers()).To(ContainElement(dbv1alpha1.ADBFinalizer))
+	})
+
+	It("should remove the finalizer once the database is TERMINATED in OCI", func() {
+		adb := newTerminatingADB(time.Minute)
+		Expect(reconciler.KubeClient.Create(context.TODO(), adb)).To(Succeed())
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateTerminated
+
+		exitReconcile, result, err := reconciler.validateCleanup(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exitReconcile).To(BeTrue())
+		Expect(result.Requeue).To(BeFalse())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(adb.GetFinalizers()).ToNot(ContainElement(dbv1alpha1.ADBFinalizer))
+	})
+
+	It("should remove the finalizer with a warning event after the max wait", func() {
+		adb := newTerminatingADB(2 * time.Hour)
+		Expect(reconciler.KubeClient.Create(context.TODO(), adb)).To(Succeed())
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateTerminating
+
+		exitReconcile, _, err := reconciler.validateCleanup(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exitReconcile).To(BeTrue())
+		Expect(adb.GetFinalizers()).ToNot(ContainElement(dbv1alpha1.ADBFinalizer))
+		Expect(recorder.Events).To(Receive(ContainSubstring("TerminationTimeout")))
+	})
+})
+
+var _ = Describe("AutonomousDatabase admin password file", func() {
+	var reconciler *AutonomousDatabaseReconciler
+
+	BeforeEach(func() {
+		reconciler = newTestReconciler()
+	})
+
+	It("should report the AdminPasswordReady condition as False if the file doesn't exist", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		adb.Spec.Details.AdminPassword.VolumePath = common.String("/nonexistent/admin-password")
+
+		Expect(reconciler.validateAdminPasswordFile(adb)).ToNot(Succeed())
+		Expect(meta.IsStatusConditionFalse(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordReady)).To(BeTrue())
+	})
+
+	It("should not report the condition if the volumePath is not set", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{}
+
+		Expect(reconciler.validateAdminPasswordFile(adb)).To(Succeed())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordReady)).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase reconcile logger", func() {
+	It("should carry the resource and the OCID of the database", func() {
+		var entry map[string]interface{}
+		logger := funcr.NewJSON(func(obj string) {
+			entry = map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(obj), &entry)).To(Succeed())
+		}, funcr.Options{})
+
+		key := types.NamespacedName{Name: "adb", Namespace: "default"}
+		adb := &dbv1alpha1.AutonomousDatabase{}
+
+		reconcileLogger(logger, key, adb).Info("provisioning")
+		Expect(entry).To(HaveKeyWithValue("namespace", "default"))
+		Expect(entry).To(HaveKeyWithValue("name", "adb"))
+		Expect(entry).ToNot(HaveKey("adbOCID"))
+
+		adb.Status.AutonomousDatabaseOCID = "ocid1.autonomousdatabase.oc1..fake"
+		reconcileLogger(logger, key, adb).Info("provisioned")
+		Expect(entry).To(HaveKeyWithValue("adbOCID", "ocid1.autonomousdatabase.oc1..fake"))
+	})
+})
+
+var _ = Describe("AutonomousDatabase reconcile pause", func() {
+	It("should not make any OCI call while the reconciliation is paused", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.ReconcileAnnotation: "false"},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				// The OCI config doesn't exist, so the reconcile fails if it tries to setup the OCI clients
+				OCIConfig: dbv1alpha1.OCIConfigSpec{
+					ConfigMapName: common.String("oci-cred"),
+					SecretName:    common.String("oci-privatekey"),
+				},
+			},
+		}
+
+		dbService := &fakeDatabaseService{}
+		reconciler := newTestReconciler(adb)
+		reconciler.dbService = dbService
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+
+		Expect(reconciler.dbService).To(BeIdenticalTo(dbService))
+		Expect(dbService.getADBCalls).To(BeZero())
+
+		pausedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, pausedADB)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(pausedADB.Status.Conditions, dbv1alpha1.ADBConditionPaused)).To(BeTrue())
+	})
+})
+
+var _ = Describe("AutonomousDatabase observed state", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+		lookupKey = types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:          common.String("fake-displayName"),
+				DbName:               common.String("fakedb"),
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should store the OCI attributes in the status without changing the spec", func() {
+		modifiedADB := adb.DeepCopy()
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeFalse())
+		Expect(result).To(Equal(emptyResult))
+
+		Expect(modifiedADB.Spec).To(Equal(adb.Spec))
+		Expect(modifiedADB.Status.CPUCoreCount).To(Equal(1))
+		Expect(modifiedADB.Status.DisplayName).To(Equal("fake-displayName"))
+		Expect(modifiedADB.Status.AutonomousDatabaseOCID).To(Equal(*adb.Spec.Details.AutonomousDatabaseOCID))
+
+		clusterADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, clusterADB)).To(Succeed())
+		Expect(clusterADB.Spec).To(Equal(adb.Spec))
+	})
+
+	It("should keep the desired spec after sending the update request", func() {
+		adb.Spec.Details.CPUCoreCount = common.Int(2)
+
+		modifiedADB := adb.DeepCopy()
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeFalse())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.scaleCalls).To(Equal(1))
+
+		Expect(modifiedADB.Spec).To(Equal(adb.Spec))
+		Expect(modifiedADB.Status.CPUCoreCount).To(Equal(1))
+		Expect(modifiedADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateScaleInProgress))
+	})
+})
+
+var _ = Describe("AutonomousDatabase reconcile deduplication", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		lastSyncTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(2),
+				},
+				// The OCI config doesn't exist, so the reconcile fails if it tries to setup the OCI clients
+				OCIConfig: dbv1alpha1.OCIConfigSpec{
+					ConfigMapName: common.String("oci-cred"),
+					SecretName:    common.String("oci-privatekey"),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				ObservedGeneration: 2,
+				LastSyncTime:       &lastSyncTime,
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		reconciler = newTestReconciler(adb)
+		reconciler.ResyncPeriod = 10 * time.Minute
+		reconciler.dbService = dbService
+	})
+
+	It("should not make any OCI call if the generation has been synced", func() {
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 8*time.Minute))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 9*time.Minute))
+
+		Expect(reconciler.dbService).To(BeIdenticalTo(dbService))
+		Expect(dbService.getADBCalls).To(BeZero())
+		Expect(dbService.scaleCalls).To(BeZero())
+	})
+
+	It("should sync if the generation changes or the resync is due", func() {
+		_, synced := reconciler.isSynced(adb)
+		Expect(synced).To(BeTrue())
+
+		changedADB := adb.DeepCopy()
+		changedADB.Generation = 3
+		_, synced = reconciler.isSynced(changedADB)
+		Expect(synced).To(BeFalse())
+
+		staleADB := adb.DeepCopy()
+		lastSyncTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		staleADB.Status.LastSyncTime = &lastSyncTime
+		_, synced = reconciler.isSynced(staleADB)
+		Expect(synced).To(BeFalse())
+
+		reconciler.ResyncPeriod = 0
+		_, synced = reconciler.isSynced(adb)
+		Expect(synced).To(BeFalse())
+	})
+
+	It("should jitter the resync within the bounds", func() {
+		Expect(reconciler.jitterResync(reconciler.ResyncPeriod)).To(Equal(10 * time.Minute))
+
+		reconciler.ResyncJitter = 0.2
+		var min, max time.Duration
+		for i := 0; i < 1000; i++ {
+			resyncAfter := reconciler.jitterResync(reconciler.ResyncPeriod)
+			Expect(resyncAfter).To(BeNumerically(">=", 10*time.Minute))
+			Expect(resyncAfter).To(BeNumerically("<", 12*time.Minute))
+
+			if i == 0 || resyncAfter < min {
+				min = resyncAfter
+			}
+			if resyncAfter > max {
+				max = resyncAfter
+			}
+		}
+		// The resyncs are spread over the jitter window instead of landing on the same time
+		Expect(max - min).To(BeNumerically(">", time.Minute))
+	})
+})
+
+var _ = Describe("AutonomousDatabase CPU auto scaling", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(1),
+					IsAutoScalingEnabled:   common.Bool(true),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				CPUCoreCount:         1,
+				BaselineCPUCoreCount: 1,
+				IsAutoScalingEnabled: true,
+			},
+		}
+
+		// The auto scaling has raised the CPU core count
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(3),
+				DataStorageSizeInTBs: common.Int(1),
+				IsAutoScalingEnabled: common.Bool(true),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should not scale down the CPU raised by the auto scaling", func() {
+		modifiedADB := adb.DeepCopy()
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeFalse())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.scaleCalls).To(BeZero())
+
+		Expect(modifiedADB.Status.CPUCoreCount).To(Equal(3))
+		Expect(modifiedADB.Status.BaselineCPUCoreCount).To(Equal(1))
+	})
+
+	It("should scale the baseline if the desired CPU core count changes", func() {
+		adb.Spec.Details.CPUCoreCount = common.Int(2)
+
+		modifiedADB := adb.DeepCopy()
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.scaleCalls).To(Equal(1))
+
+		Expect(modifiedADB.Status.BaselineCPUCoreCount).To(Equal(2))
+	})
+
+	It("should show the max storage the storage auto scaling can grow to", func() {
+		modifiedADB := adb.DeepCopy()
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(modifiedADB.Status.MaxStorageSizeInTBs).To(Equal(1))
+
+		dbService.ociADB.IsAutoScalingForStorageEnabled = common.Bool(true)
+		adb.Spec.Details.IsAutoScalingForStorageEnabled = common.Bool(true)
+
+		modifiedADB = adb.DeepCopy()
+		_, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(modifiedADB.Status.IsAutoScalingForStorageEnabled).To(BeTrue())
+		Expect(modifiedADB.Status.MaxStorageSizeInTBs).To(Equal(3))
+	})
+})
+
+var _ = Describe("AutonomousDatabase storage size in GB", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		// The manifest is migrated from the dataStorageSizeInTBs to the dataStorageSizeInGBs
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					DataStorageSizeInGBs:   common.Int(1024),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DataStorageSizeInTBs: common.Int(1),
+				DataStorageSizeInGBs: common.Int(1024),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should not resize the database if the size in GB is the same as the size in TB", func() {
+		// OCI only returns the size in TB
+		dbService.ociADB.DataStorageSizeInGBs = nil
+
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.scaleCalls).To(BeZero())
+	})
+
+	It("should not resize the database if the size in TB is the same as the size in GB", func() {
+		adb.Spec.Details.DataStorageSizeInGBs = nil
+		adb.Spec.Details.DataStorageSizeInTBs = common.Int(1)
+		dbService.ociADB.DataStorageSizeInTBs = nil
+
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.scaleCalls).To(BeZero())
+	})
+
+	It("should resize the database in GB if the size changes", func() {
+		adb.Spec.Details.DataStorageSizeInGBs = common.Int(1536)
+
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.scaleCalls).To(Equal(1))
+		Expect(*dbService.scaleDifADB.Spec.Details.DataStorageSizeInGBs).To(Equal(1536))
+		Expect(dbService.scaleDifADB.Spec.Details.DataStorageSizeInTBs).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase update during a transient state", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(2),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	DescribeTable("should defer the update until the database is AVAILABLE",
+		func(state database.AutonomousDatabaseLifecycleStateEnum) {
+			dbService.getADBState = state
+
+			modifiedADB := adb.DeepCopy()
+			exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exit).To(BeFalse())
+			Expect(result).To(Equal(requeueResult))
+			Expect(dbService.scaleCalls).To(BeZero())
+		},
+		Entry("SCALE_IN_PROGRESS", database.AutonomousDatabaseLifecycleStateScaleInProgress),
+		Entry("UPDATING", database.AutonomousDatabaseLifecycleStateUpdating),
+		Entry("PROVISIONING", database.AutonomousDatabaseLifecycleStateProvisioning),
+		Entry("BACKUP_IN_PROGRESS", database.AutonomousDatabaseLifecycleStateBackupInProgress),
+		Entry("MAINTENANCE_IN_PROGRESS", database.AutonomousDatabaseLifecycleStateMaintenanceInProgress),
+		Entry("AVAILABLE_NEEDS_ATTENTION", database.AutonomousDatabaseLifecycleStateAvailableNeedsAttention),
+		Entry("UNAVAILABLE", database.AutonomousDatabaseLifecycleStateUnavailable),
+	)
+
+	It("should send the update once the database is AVAILABLE", func() {
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateAvailable
+
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.scaleCalls).To(Equal(1))
+	})
+
+	It("should not treat the STOPPED state as transient", func() {
+		Expect(dbv1alpha1.IsADBTransientState(database.AutonomousDatabaseLifecycleStateStopped)).To(BeFalse())
+		Expect(dbv1alpha1.IsADBTransientState(database.AutonomousDatabaseLifecycleStateAvailable)).To(BeFalse())
+		Expect(dbv1alpha1.IsADBTransientState(database.AutonomousDatabaseLifecycleStateTerminated)).To(BeFalse())
+	})
+})
+
+var _ = Describe("AutonomousDatabase stopped state", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(2),
+					DataStorageSizeInTBs:   common.Int(2),
+					DisplayName:            common.String("renamed"),
+					FreeformTags:           map[string]string{"env": "prod"},
+					LifecycleState:         database.AutonomousDatabaseLifecycleStateStopped,
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:          common.String("original"),
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	expectNoUpdate := func() {
+		Expect(dbService.scaleCalls).To(BeZero())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+		Expect(dbService.networkAccessDifADB).To(BeNil())
+	}
+
+	It("should not send any update request while driving the database to STOPPED", func() {
+		By("Stopping the AVAILABLE database before the other fields are compared")
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateAvailable
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.stopCalls).To(Equal(1))
+		expectNoUpdate()
+
+		By("Waiting while the database is STOPPING")
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateStopping
+		_, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		expectNoUpdate()
+
+		By("Leaving the other fields as they are while the database is STOPPED")
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateStopped
+		for i := 0; i < 3; i++ {
+			exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exit).To(BeFalse())
+			Expect(result).To(Equal(emptyResult))
+		}
+		Expect(dbService.stopCalls).To(Equal(1))
+		Expect(dbService.startCalls).To(BeZero())
+		expectNoUpdate()
+	})
+
+	It("should only start the STOPPED database, and apply the other fields once it's AVAILABLE", func() {
+		adb.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateStopped
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.startCalls).To(Equal(1))
+		expectNoUpdate()
+
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateAvailable
+		_, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.generalFieldsDifADB).ToNot(BeNil())
+	})
+
+	It("should still scale the database stopped for scaling", func() {
+		adb.Status.Conditions = []metav1.Condition{{
+			Type:   dbv1alpha1.ADBConditionStoppedForScaling,
+			Status: metav1.ConditionTrue,
+			Reason: "Stopped",
+		}}
+		Expect(isStateChangeOnly(adb, &dbv1alpha1.AutonomousDatabase{
+			Status: dbv1alpha1.AutonomousDatabaseStatus{LifecycleState: database.AutonomousDatabaseLifecycleStateStopped},
+		})).To(BeFalse())
+	})
+})
+
+var _ = Describe("AutonomousDatabase Always Free", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+	)
+
+	newFreeADB := func(lastLifecycleState database.AutonomousDatabaseLifecycleStateEnum) *dbv1alpha1.AutonomousDatabase {
+		lastSucSpec := dbv1alpha1.AutonomousDatabaseSpec{
+			Details: dbv1alpha1.AutonomousDatabaseDetails{
+				AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				IsFreeTier:             common.Bool(true),
+				LifecycleState:         lastLifecycleState,
+			},
+		}
+		lastSucSpecBytes, err := json.Marshal(lastSucSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.LastSuccessfulSpec: string(lastSucSpecBytes)},
+			},
+			Spec: *lastSucSpec.DeepCopy(),
+		}
+		adb.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		return adb
+	}
+
+	BeforeEach(func() {
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+				IsFreeTier:           common.Bool(true),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateStopped,
+		}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	It("should not start the database stopped by OCI after inactivity", func() {
+		adb := newFreeADB(database.AutonomousDatabaseLifecycleStateAvailable)
+
+		modifiedADB := adb.DeepCopy()
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeFalse())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.startCalls).To(BeZero())
+		Expect(modifiedADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateStopped))
+		Expect(modifiedADB.Status.IsFreeTier).To(BeTrue())
+	})
+
+	It("should start the database if the lifecycleState changes in the spec", func() {
+		adb := newFreeADB(database.AutonomousDatabaseLifecycleStateStopped)
+
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.startCalls).To(Equal(1))
+	})
+
+	It("should not flap between the reconciles, and start the database only when it's requested again", func() {
+		adb := newFreeADB(database.AutonomousDatabaseLifecycleStateAvailable)
+		Expect(reconciler.KubeClient.Create(context.TODO(), adb)).To(Succeed())
+		recorder := record.NewFakeRecorder(10)
+		reconciler.Recorder = recorder
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		By("Leaving the database STOPPED by OCI")
+		for i := 0; i < 3; i++ {
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+		}
+		Expect(dbService.startCalls).To(BeZero())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(ContainSubstring("AutoStopped")))
+
+		stoppedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, stoppedADB)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(stoppedADB.Status.Conditions, dbv1alpha1.ADBConditionAutoStopped)).To(BeTrue())
+		Expect(stoppedADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateStopped))
+
+		By("Starting the database once the lifecycleState changes from STOPPED to AVAILABLE")
+		stoppedADB.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+		Expect(reconciler.KubeClient.Update(context.TODO(), stoppedADB)).To(Succeed())
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.startCalls).To(BeZero())
+
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, stoppedADB)).To(Succeed())
+		Expect(meta.FindStatusCondition(stoppedADB.Status.Conditions, dbv1alpha1.ADBConditionAutoStopped)).To(BeNil())
+		stoppedADB.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		Expect(reconciler.KubeClient.Update(context.TODO(), stoppedADB)).To(Succeed())
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.startCalls).To(Equal(1))
+	})
+
+	It("should start a paid database stopped out of band", func() {
+		adb := newFreeADB(database.AutonomousDatabaseLifecycleStateAvailable)
+		adb.Spec.Details.IsFreeTier = nil
+		dbService.ociADB.IsFreeTier = common.Bool(false)
+
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.startCalls).To(Equal(1))
+	})
+})
+
+var _ = Describe("AutonomousDatabase stop before scaling", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(2),
+					StopBeforeScaling:      common.Bool(true),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+				IsDedicated:          common.Bool(true),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should stop the database, scale it, and start it again", func() {
+		modifiedADB := adb.DeepCopy()
+
+		By("Stopping the database")
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.stopCalls).To(Equal(1))
+		Expect(dbService.scaleCalls).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionStoppedForScaling)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("StoppingForScaling")))
+
+		By("Scaling the database once it's STOPPED")
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateStopped
+		_, result, err = reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.scaleCalls).To(Equal(1))
+		Expect(dbService.startCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Scaling")))
+
+		By("Starting the database once it's scaled")
+		dbService.ociADB.CpuCoreCount = common.Int(2)
+		_, result, err = reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.startCalls).To(Equal(1))
+		Expect(dbService.scaleCalls).To(Equal(1))
+		Expect(meta.IsStatusConditionFalse(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionStoppedForScaling)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("StartingAfterScaling")))
+	})
+
+	It("should scale the database directly if it's not enabled", func() {
+		adb.Spec.Details.StopBeforeScaling = nil
+
+		modifiedADB := adb.DeepCopy()
+		_, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.stopCalls).To(BeZero())
+		Expect(dbService.scaleCalls).To(Equal(1))
+	})
+})
+
+var _ = Describe("AutonomousDatabase force refresh", func() {
+	It("should get the database from OCI even if the generation has been synced", func() {
+		lastSyncTime := metav1.Now()
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Generation:  1,
+				Annotations: map[string]string{dbv1alpha1.ForceRefreshAnnotation: "true"},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState:     database.AutonomousDatabaseLifecycleStateAvailable,
+				ObservedGeneration: 1,
+				LastSyncTime:       &lastSyncTime,
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.ResyncPeriod = 10 * time.Minute
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.getADBCalls).ToNot(BeZero())
+		Expect(dbService.scaleCalls).To(BeZero())
+
+		// The annotation is one-shot
+		refreshedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, refreshedADB)).To(Succeed())
+		Expect(refreshedADB.GetAnnotations()).ToNot(HaveKey(dbv1alpha1.ForceRefreshAnnotation))
+
+		// The next reconcile is skipped since the generation has been synced
+		getADBCalls := dbService.getADBCalls
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.getADBCalls).To(Equal(getADBCalls))
+	})
+})
+
+var _ = Describe("AutonomousDatabase lifecycle history", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		adb        *dbv1alpha1.AutonomousDatabase
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+		lookupKey = types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		reconciler = newTestReconciler(adb)
+	})
+
+	historyStates := func() []database.AutonomousDatabaseLifecycleStateEnum {
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, updatedADB)).To(Succeed())
+
+		states := []database.AutonomousDatabaseLifecycleStateEnum{}
+		for _, transition := range updatedADB.Status.LifecycleHistory {
+			Expect(transition.Time.IsZero()).To(BeFalse())
+			states = append(states, transition.LifecycleState)
+		}
+		return states
+	}
+
+	It("should append the transitions of the lifecycleState", func() {
+		Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopping
+		Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+		Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+
+		// The state doesn't change
+		Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+
+		Expect(historyStates()).To(Equal([]database.AutonomousDatabaseLifecycleStateEnum{
+			database.AutonomousDatabaseLifecycleStateAvailable,
+			database.AutonomousDatabaseLifecycleStateStopping,
+			database.AutonomousDatabaseLifecycleStateStopped,
+			database.AutonomousDatabaseLifecycleStateAvailable,
+		}))
+	})
+
+	It("should keep only the last transitions", func() {
+		states := []database.AutonomousDatabaseLifecycleStateEnum{
+			database.AutonomousDatabaseLifecycleStateStopped,
+			database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		for i := 0; i < dbv1alpha1.MaxLifecycleHistory+2; i++ {
+			adb.Status.LifecycleState = states[i%2]
+			Expect(reconciler.updateStatus(context.TODO(), adb)).To(Succeed())
+		}
+
+		history := historyStates()
+		Expect(history).To(HaveLen(dbv1alpha1.MaxLifecycleHistory))
+		Expect(history[0]).To(Equal(database.AutonomousDatabaseLifecycleStateStopped))
+		Expect(history[len(history)-1]).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+	})
+})
+
+var _ = Describe("AutonomousDatabase console URL", func() {
+	It("should annotate the resource with the console URL of the database", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1.phx.fake"),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		annotatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, annotatedADB)).To(Succeed())
+		Expect(annotatedADB.GetAnnotations()).To(HaveKeyWithValue(dbv1alpha1.ConsoleURLAnnotation,
+			"https://cloud.oracle.com/db/adbs/ocid1.autonomousdatabase.oc1.phx.fake?region=us-phoenix-1"))
+		Expect(annotatedADB.Status.ObservedGeneration).To(Equal(int64(1)))
+	})
+})
+
+var _ = Describe("AutonomousDatabase concurrent mutations", func() {
+	It("should not scale the database while another controller sends a request on the same database", func() {
+		const adbOCID = "ocid1.autonomousdatabase.oc1..concurrent"
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String(adbOCID),
+					CPUCoreCount:           common.Int(2),
+				},
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		// The lock is held as if a backup of the database is being created
+		unlock := adbMutations.Lock(adbOCID)
+
+		done := make(chan error)
+		go func() {
+			_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(adb)})
+			done <- err
+		}()
+
+		Consistently(done, 200*time.Millisecond).ShouldNot(Receive())
+
+		unlock()
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.scaleCalls).To(Equal(1))
+	})
+})
+
+var _ = Describe("AutonomousDatabase freeform tags", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				FreeformTags: map[string]string{"env": "dev", "team": "db", "owner": "ops"},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	It("should replace the tags if the removeTags are not specified", func() {
+		adb.Spec.Details.FreeformTags = map[string]string{"env": "prod"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).To(Equal(map[string]string{"env": "prod"}))
+	})
+
+	It("should add a tag and keep the others", func() {
+		adb.Spec.Details.FreeformTags = map[string]string{"cost-center": "42"}
+		adb.Spec.Details.RemoveTags = []string{}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).To(Equal(map[string]string{
+			"env": "dev", "team": "db", "owner": "ops", "cost-center": "42",
+		}))
+	})
+
+	It("should update a tag and keep the others", func() {
+		adb.Spec.Details.FreeformTags = map[string]string{"env": "prod"}
+		adb.Spec.Details.RemoveTags = []string{}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).To(Equal(map[string]string{
+			"env": "prod", "team": "db", "owner": "ops",
+		}))
+	})
+
+	It("should delete a tag and keep the others", func() {
+		adb.Spec.Details.RemoveTags = []string{"owner", "missing"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).To(Equal(map[string]string{
+			"env": "dev", "team": "db",
+		}))
+	})
+
+	It("should send an empty map to delete the last tags", func() {
+		adb.Spec.Details.RemoveTags = []string{"env", "team", "owner"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).ToNot(BeNil())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.FreeformTags).To(BeEmpty())
+	})
+
+	It("should not update the database if the tags are already merged", func() {
+		adb.Spec.Details.FreeformTags = map[string]string{"env": "dev"}
+		adb.Spec.Details.RemoveTags = []string{"cost-center"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase customer contacts", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CustomerContacts: []database.CustomerContact{
+					{Email: common.String("ops@example.com")},
+					{Email: common.String("dba@example.com")},
+				},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	It("should not update the database if only the order of the contacts is different", func() {
+		adb.Spec.Details.CustomerContacts = []string{"dba@example.com", "ops@example.com"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+	})
+
+	It("should send an empty list to remove the contacts", func() {
+		adb.Spec.Details.CustomerContacts = []string{}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.CustomerContacts).ToNot(BeNil())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.CustomerContacts).To(BeEmpty())
+	})
+
+	It("should leave the contacts as is if the field is not set", func() {
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase idempotent apply", func() {
+	It("should not send any update request when the same spec is applied again", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					DisplayName:            common.String("adb"),
+					DbName:                 common.String("adb"),
+					CPUCoreCount:           common.Int(2),
+					DataStorageSizeInTBs:   common.Int(1),
+					LifecycleState:         database.AutonomousDatabaseLifecycleStateAvailable,
+					FreeformTags:           map[string]string{"env": "prod"},
+					NetworkAccess: dbv1alpha1.NetworkAccessSpec{
+						AccessType:               dbv1alpha1.NetworkAccessTypeRestricted,
+						AccessControlList:        []string{"10.0.0.0/16"},
+						IsMTLSConnectionRequired: common.Bool(true),
+					},
+				},
+			},
+		}
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:              common.String("adb"),
+				DbName:                   common.String("adb"),
+				CpuCoreCount:             common.Int(2),
+				DataStorageSizeInTBs:     common.Int(1),
+				FreeformTags:             map[string]string{"env": "prod"},
+				WhitelistedIps:           []string{"10.0.0.0/16"},
+				IsMtlsConnectionRequired: common.Bool(true),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		for i := 0; i < 2; i++ {
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(emptyResult))
+		}
+
+		Expect(dbService.getADBCalls).To(Equal(2))
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+		Expect(dbService.networkAccessDifADB).To(BeNil())
+		Expect(dbService.scaleCalls).To(BeZero())
+		Expect(dbService.startCalls).To(BeZero())
+		Expect(dbService.stopCalls).To(BeZero())
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).ToNot(Receive())
+
+		syncedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, syncedADB)).To(Succeed())
+		Expect(syncedADB.Status.ObservedGeneration).To(Equal(int64(1)))
+	})
+})
+
+var _ = Describe("AutonomousDatabase terminated in OCI", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..terminated"),
+					LifecycleState:         database.AutonomousDatabaseLifecycleStateAvailable,
+				},
+			},
+		}
+		lookupKey = types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateTerminated,
+		}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.ResyncPeriod = 10 * time.Minute
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+	})
+
+	It("should report the termination and stop polling the database", func() {
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Terminated")))
+		// The database is not started again
+		Expect(dbService.startCalls).To(Equal(0))
+
+		terminatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, terminatedADB)).To(Succeed())
+		Expect(terminatedADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateTerminated))
+		Expect(meta.IsStatusConditionTrue(terminatedADB.Status.Conditions, dbv1alpha1.ADBConditionTerminated)).To(BeTrue())
+
+		By("Skipping the reconcile without polling OCI")
+		getADBCalls := dbService.getADBCalls
+		result, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.getADBCalls).To(Equal(getADBCalls))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should keep skipping the reconcile while the spec still refers to the terminated database", func() {
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		terminatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, terminatedADB)).To(Succeed())
+		terminatedADB.Generation = 2
+		terminatedADB.Spec.Details.DisplayName = common.String("renamed")
+		Expect(reconciler.KubeClient.Update(context.TODO(), terminatedADB)).To(Succeed())
+
+		getADBCalls := dbService.getADBCalls
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.getADBCalls).To(Equal(getADBCalls))
+	})
+
+	It("should provision a new database once the OCID is removed from the spec", func() {
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		terminatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, terminatedADB)).To(Succeed())
+		terminatedADB.Generation = 2
+		terminatedADB.Spec.Details.AutonomousDatabaseOCID = nil
+		Expect(reconciler.KubeClient.Update(context.TODO(), terminatedADB)).To(Succeed())
+
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.createdADB).ToNot(BeNil())
+
+		provisionedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, provisionedADB)).To(Succeed())
+		Expect(provisionedADB.Status.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..created"))
+		Expect(meta.FindStatusCondition(provisionedADB.Status.Conditions, dbv1alpha1.ADBConditionTerminated)).To(BeNil())
+	})
+})
+
+// blockingDatabaseService blocks the CreateAutonomousDatabase request until it's released, or until its ctx is
+// canceled, like the OCI client does
+type blockingDatabaseService struct {
+	*fakeDatabaseService
+
+	created  chan struct{}
+	released chan struct{}
+}
+
+func (s *blockingDatabaseService) CreateAutonomousDatabase(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) (database.CreateAutonomousDatabaseResponse, error) {
+	close(s.created)
+	select {
+	case <-s.released:
+	case <-ctx.Done():
+		return database.CreateAutonomousDatabaseResponse{}, ctx.Err()
+	}
+	return s.fakeDatabaseService.CreateAutonomousDatabase(ctx, adb)
+}
+
+var _ = Describe("AutonomousDatabase graceful shutdown", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *blockingDatabaseService
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID: common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:     common.String("adb"),
+					DbName:          common.String("adb"),
+				},
+			},
+		}
+		lookupKey = types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		dbService = &blockingDatabaseService{
+			fakeDatabaseService: &fakeDatabaseService{},
+			created:             make(chan struct{}),
+			released:            make(chan struct{}),
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.ShutdownGracePeriod = time.Minute
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+	})
+
+	It("should persist the status of the reconcile in flight before stopping", func() {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		reconciled := make(chan error, 1)
+		go func() {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: lookupKey})
+			reconciled <- err
+		}()
+		Eventually(dbService.created).Should(BeClosed())
+
+		By("Stopping the manager while the database is being provisioned")
+		cancel()
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- reconciler.waitForInFlightReconciles(ctx)
+		}()
+		Consistently(stopped).ShouldNot(Receive())
+
+		close(dbService.released)
+		Eventually(reconciled).Should(Receive(BeNil()))
+		Eventually(stopped).Should(Receive(BeNil()))
+
+		provisionedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, provisionedADB)).To(Succeed())
+		Expect(provisionedADB.Status.AutonomousDatabaseOCID).To(Equal("ocid1.autonomousdatabase.oc1..created"))
+	})
+
+	It("should cancel the OCI request in flight once the grace period has passed", func() {
+		reconciler.ShutdownGracePeriod = 0
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		reconciled := make(chan error, 1)
+		go func() {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: lookupKey})
+			reconciled <- err
+		}()
+		Eventually(dbService.created).Should(BeClosed())
+
+		By("Stopping the manager while the database is being provisioned")
+		cancel()
+		Eventually(reconciled).Should(Receive())
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(reconciler.waitForInFlightReconciles(ctx)).To(Succeed())
+	})
+
+	It("should not start a reconcile once the manager is stopped", func() {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		Expect(reconciler.waitForInFlightReconciles(ctx)).To(Succeed())
+
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.createdADB).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase stalled provisioning", func() {
+	It("should slow down the requeue once the provisioning is stalled, and recover when it's AVAILABLE", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "adb",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateProvisioning,
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateProvisioning,
+		}
+		recorder := record.NewFakeRecorder(10)
+		reconciler := newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.ProvisionMaxWait = 15 * time.Minute
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{RequeueAfter: stalledProvisionRequeue}))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ProvisionStalled")))
+
+		stalledADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, stalledADB)).To(Succeed())
+		Expect(stalledADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateProvisioning))
+		Expect(meta.IsStatusConditionTrue(stalledADB.Status.Conditions, dbv1alpha1.ADBConditionStalled)).To(BeTrue())
+
+		// The event is only reported once
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).ToNot(Receive(ContainSubstring("ProvisionStalled")))
+
+		By("Recovering once the database is AVAILABLE")
+		dbService.getADBState = database.AutonomousDatabaseLifecycleStateAvailable
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		recoveredADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, recoveredADB)).To(Succeed())
+		Expect(recoveredADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		Expect(meta.FindStatusCondition(recoveredADB.Status.Conditions, dbv1alpha1.ADBConditionStalled)).To(BeNil())
+	})
+
+	It("should requeue as usual before the ProvisionMaxWait", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateProvisioning,
+			},
+		}
+		reconciler := newTestReconciler()
+		reconciler.ProvisionMaxWait = 15 * time.Minute
+
+		Expect(reconciler.validateProvisionStalled(logr.Discard(), adb)).To(BeFalse())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionStalled)).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase manifest export", func() {
+	It("should export the manifest to a ConfigMap and remove the annotation", func() {
+		lastSyncTime := metav1.Now()
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Generation:  1,
+				Annotations: map[string]string{dbv1alpha1.ExportManifestAnnotation: ""},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					AdminPassword: dbv1alpha1.PasswordSpec{
+						K8sSecret: dbv1alpha1.K8sSecretSpec{Name: common.String("admin-password")},
+					},
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState:     database.AutonomousDatabaseLifecycleStateAvailable,
+				ObservedGeneration: 1,
+				LastSyncTime:       &lastSyncTime,
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:          common.String("exported"),
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.ResyncPeriod = 10 * time.Minute
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "adb-manifest", Namespace: "default"}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKey("autonomousdatabase.yaml"))
+		Expect(configMap.Data["autonomousdatabase.yaml"]).To(ContainSubstring("displayName: exported"))
+		Expect(configMap.Data["autonomousdatabase.yaml"]).ToNot(ContainSubstring("admin-password"))
+
+		// The annotation is one-shot
+		exportedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, exportedADB)).To(Succeed())
+		Expect(exportedADB.GetAnnotations()).ToNot(HaveKey(dbv1alpha1.ExportManifestAnnotation))
+	})
+})
+
+var _ = Describe("AutonomousDatabase connection format", func() {
+	const (
+		longConn  = "(description=(address=(protocol=tcps)(port=1522)(host=fake.host))(connect_data=(service_name=fakedb_high.adb.oraclecloud.com)))"
+		shortConn = "fake.host:1522/fakedb_high.adb.oraclecloud.com"
+	)
+
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		profile := func(syntaxFormat database.DatabaseConnectionStringProfileSyntaxFormatEnum, value string) database.DatabaseConnectionStringProfile {
+			return database.DatabaseConnectionStringProfile{
+				DisplayName:       common.String("fakedb_high"),
+				Value:             common.String(value),
+				TlsAuthentication: database.DatabaseConnectionStringProfileTlsAuthenticationMutual,
+				SyntaxFormat:      syntaxFormat,
+			}
+		}
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				ConnectionStrings: &database.AutonomousDatabaseConnectionStrings{
+					Profiles: []database.DatabaseConnectionStringProfile{
+						profile(database.DatabaseConnectionStringProfileSyntaxFormatLong, longConn),
+						profile(database.DatabaseConnectionStringProfileSyntaxFormatEzconnect, shortConn),
+					},
+				},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	DescribeTable("should show the connection strings of the selected format",
+		func(format dbv1alpha1.ConnectionFormatEnum, expected string) {
+			adb.Spec.Details.Wallet.ConnectionFormat = format
+
+			_, err := reconciler.getADB(context.TODO(), logr.Discard(), adb)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(adb.Status.AllConnectionStrings).To(HaveLen(1))
+			Expect(adb.Status.AllConnectionStrings[0].ConnectionStrings).To(Equal([]dbv1alpha1.ConnectionStringSpec{
+				{TNSName: "fakedb_high", ConnectionString: expected},
+			}))
+		},
+		Entry("the default format", dbv1alpha1.ConnectionFormatEnum(""), longConn),
+		Entry("the long format", dbv1alpha1.ConnectionFormatLong, longConn),
+		Entry("the short format", dbv1alpha1.ConnectionFormatShort, shortConn),
+	)
+})
+
+var _ = Describe("AutonomousDatabase split wallet", func() {
+	const tnsnamesOra = `fakedb_high = (description=(address=(protocol=tcps)(port=1522)(host=fake.host))(connect_data=(service_name=fakedb_high.adb.oraclecloud.com)))
+fakedb_low = (description=(address=(protocol=tcps)(port=1522)(host=fake.host))(connect_data=(service_name=fakedb_low.adb.oraclecloud.com)))
+`
+
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					Wallet: dbv1alpha1.WalletSpec{
+						Name:          common.String("adb-wallet"),
+						SplitProfiles: common.Bool(true),
+					},
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		// The wallet has been downloaded
+		wallet := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb-wallet",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "adb"},
+			},
+			Data: map[string][]byte{
+				"tnsnames.ora": []byte(tnsnamesOra),
+				"cwallet.sso":  []byte("fake-sso"),
+			},
+		}
+
+		reconciler = newTestReconciler(adb, wallet)
+	})
+
+	It("should create a Secret per connection profile and delete them along with the resource", func() {
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		high := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "adb-wallet-high", Namespace: "default"}, high)).To(Succeed())
+		// The fake client doesn't convert the stringData to the data
+		Expect(high.StringData["tns_alias"]).To(Equal("fakedb_high"))
+		Expect(high.StringData["tnsnames.ora"]).ToNot(ContainSubstring("fakedb_low"))
+		Expect(high.Labels).To(HaveKeyWithValue(dbv1alpha1.WalletProfileLabel, "high"))
+
+		secrets := &corev1.SecretList{}
+		Expect(reconciler.KubeClient.List(context.TODO(), secrets, client.HasLabels{dbv1alpha1.WalletProfileLabel})).To(Succeed())
+		Expect(secrets.Items).To(HaveLen(2))
+
+		Expect(reconciler.deleteSplitWallets(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(reconciler.KubeClient.List(context.TODO(), secrets, client.HasLabels{dbv1alpha1.WalletProfileLabel})).To(Succeed())
+		Expect(secrets.Items).To(BeEmpty())
+
+		// The wallet Secret is kept
+		wallet := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "adb-wallet", Namespace: "default"}, wallet)).To(Succeed())
+	})
+
+	It("should not overwrite a Secret of the connection profile which belongs to something else", func() {
+		unrelated := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb-wallet-high",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "other-app"},
+			},
+			Data: map[string][]byte{"password": []byte("unrelated")},
+		}
+		Expect(reconciler.KubeClient.Create(context.TODO(), unrelated)).To(Succeed())
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(ContainSubstring("is not owned by the resource")))
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("WalletNotOwned")))
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "adb-wallet-high", Namespace: "default"}, secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(map[string][]byte{"password": []byte("unrelated")}))
+	})
+
+	It("should set the owner reference on the wallet Secret which has no controller", func() {
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		wallet := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "adb-wallet", Namespace: "default"}, wallet)).To(Succeed())
+		Expect(metav1.GetControllerOf(wallet)).ToNot(BeNil())
+		Expect(metav1.GetControllerOf(wallet).Name).To(Equal("adb"))
+	})
+})
+
+var _ = Describe("AutonomousDatabase wallet in Object Storage", func() {
+	const objectURL = "https://objectstorage.fake/n/fake-namespace/b/wallets/o/ci/adb-wallet.zip"
+
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		osService  *fakeObjectStorageService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					Wallet: dbv1alpha1.WalletSpec{
+						Name: common.String("adb-wallet"),
+						ObjectStorage: dbv1alpha1.WalletObjectStorageSpec{
+							Bucket:    common.String("wallets"),
+							Namespace: common.String("fake-namespace"),
+							Prefix:    common.String("ci/"),
+						},
+					},
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		osService = &fakeObjectStorageService{objects: map[string][]byte{}}
+
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = &fakeDatabaseService{}
+		reconciler.osService = osService
+	})
+
+	It("should upload the wallet zip instead of creating a Secret", func() {
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		Expect(osService.objects).To(HaveKeyWithValue(objectURL, readTestdata("wallet.zip")))
+		Expect(adb.Status.WalletObjectURL).To(Equal(objectURL))
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded)).To(BeTrue())
+
+		secrets := &corev1.SecretList{}
+		Expect(reconciler.KubeClient.List(context.TODO(), secrets)).To(Succeed())
+		Expect(secrets.Items).To(BeEmpty())
+	})
+
+	It("should report a retriable condition if the upload fails", func() {
+		osService.putErr = errors.New("service unavailable")
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("UploadFailed"))
+		Expect(adb.Status.WalletObjectURL).To(BeEmpty())
+
+		// The upload is retried and the condition is cleared
+		osService.putErr = nil
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletUploaded)).To(BeTrue())
+	})
+
+	It("should upload the wallet only once unless it's regenerated always", func() {
+		dbService := reconciler.dbService.(*fakeDatabaseService)
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.walletCalls).To(Equal(1))
+
+		adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateAlways
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.walletCalls).To(Equal(2))
+	})
+})
+
+var _ = Describe("AutonomousDatabase disaster recovery", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CompartmentId: common.String("ocid1.compartment.oc1..fake"),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should change the disaster recovery type", func() {
+		adb.Spec.Details.DisasterRecoveryType = dbv1alpha1.DisasterRecoveryTypeBackupBased
+
+		modifiedADB := adb.DeepCopy()
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+		Expect(dbService.drTypeCalls).To(Equal(1))
+		Expect(dbService.drPeerCalls).To(BeZero())
+
+		Expect(modifiedADB.Status.DisasterRecovery.Type).To(Equal(dbv1alpha1.DisasterRecoveryTypeBackupBased))
+	})
+
+	It("should reconcile the deprecated disasterRecoveryPeer as an entry of the list", func() {
+		peerOCID := "ocid1.autonomousdatabase.oc1.phx.peer"
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		adb.Spec.Details.DisasterRecoveryPeer = dbv1alpha1.DisasterRecoveryPeerSpec{
+			Region:               common.String("us-phoenix-1"),
+			DisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeBackupBased,
+		}
+
+		By("Creating the peer")
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers).To(Equal([]dbv1alpha1.DisasterRecoveryPeerStatus{{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeBackupBased,
+			AutonomousDatabaseOCID: peerOCID,
+			LifecycleState:         database.AutonomousDatabaseLifecycleStateProvisioning,
+		}}))
+
+		By("Refreshing the role and the lifecycleState of the peer without creating it again")
+		dbService.peerADBs = map[string]database.AutonomousDatabase{
+			peerOCID: {
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				Role:           database.AutonomousDatabaseRoleStandby,
+			},
+		}
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers[0].Role).To(Equal(database.AutonomousDatabaseRoleStandby))
+
+		By("Terminating the peer once it's removed from the spec")
+		adb.Spec.Details.DisasterRecoveryPeer = dbv1alpha1.DisasterRecoveryPeerSpec{}
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.peerDeleteCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers[0].LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateTerminating))
+	})
+
+	It("should migrate the deprecated status.disasterRecovery.peer into the peers", func() {
+		peerOCID := "ocid1.autonomousdatabase.oc1.phx.peer"
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		adb.Spec.Details.DisasterRecoveryPeer = dbv1alpha1.DisasterRecoveryPeerSpec{
+			Region:               common.String("us-phoenix-1"),
+			DisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeADG,
+		}
+		adb.Status.DisasterRecovery.Peer = dbv1alpha1.DisasterRecoveryPeerStatus{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeADG,
+			AutonomousDatabaseOCID: peerOCID,
+		}
+		dbService.peerADBs = map[string]database.AutonomousDatabase{
+			peerOCID: {
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				Role:           database.AutonomousDatabaseRoleStandby,
+			},
+		}
+
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(BeZero())
+		Expect(dbService.peerDeleteCalls).To(BeZero())
+		Expect(adb.Status.DisasterRecovery.Peer).To(Equal(dbv1alpha1.DisasterRecoveryPeerStatus{}))
+		Expect(adb.Status.DisasterRecovery.Peers).To(Equal([]dbv1alpha1.DisasterRecoveryPeerStatus{{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeADG,
+			AutonomousDatabaseOCID: peerOCID,
+			Role:                   database.AutonomousDatabaseRoleStandby,
+			LifecycleState:         database.AutonomousDatabaseLifecycleStateAvailable,
+		}}))
+	})
+
+	It("should create a standby peer added to the list, and terminate it once removed from the list", func() {
+		peerOCID := "ocid1.autonomousdatabase.oc1.phx.peer"
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		adb.Spec.Details.DisasterRecoveryPeers = []dbv1alpha1.DisasterRecoveryPeerSpec{
+			{Region: common.String("us-phoenix-1")},
+		}
+
+		By("Creating the peer with the default type ADG")
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers).To(Equal([]dbv1alpha1.DisasterRecoveryPeerStatus{{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeADG,
+			AutonomousDatabaseOCID: peerOCID,
+			LifecycleState:         database.AutonomousDatabaseLifecycleStateProvisioning,
+		}}))
+		Expect(adb.IsDisasterRecoveryPeerTransient()).To(BeTrue())
+
+		By("Refreshing the role and the lifecycleState of the peer without creating it again")
+		dbService.peerADBs = map[string]database.AutonomousDatabase{
+			peerOCID: {
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				Role:           database.AutonomousDatabaseRoleStandby,
+			},
+		}
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers[0].Role).To(Equal(database.AutonomousDatabaseRoleStandby))
+		Expect(adb.Status.DisasterRecovery.Peers[0].LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		Expect(adb.IsDisasterRecoveryPeerTransient()).To(BeFalse())
+
+		By("Terminating the peer removed from the list")
+		adb.Spec.Details.DisasterRecoveryPeers = nil
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.peerDeleteCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers[0].LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateTerminating))
+
+		By("Forgetting the peer once it's terminated")
+		delete(dbService.peerADBs, peerOCID)
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.peerDeleteCalls).To(Equal(1))
+		Expect(dbService.drPeerCalls).To(Equal(1))
+		Expect(adb.Status.DisasterRecovery.Peers).To(BeEmpty())
+	})
+
+	It("should keep a peer which is not found in its region yet without creating it again", func() {
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		adb.Spec.Details.DisasterRecoveryPeers = []dbv1alpha1.DisasterRecoveryPeerSpec{
+			{Region: common.String("us-phoenix-1"), DisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeADG},
+		}
+		adb.Status.DisasterRecovery.Peers = []dbv1alpha1.DisasterRecoveryPeerStatus{{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeADG,
+			AutonomousDatabaseOCID: "ocid1.autonomousdatabase.oc1.phx.peer",
+			LifecycleState:         database.AutonomousDatabaseLifecycleStateProvisioning,
+		}}
+
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.drPeerCalls).To(BeZero())
+		Expect(adb.Status.DisasterRecovery.Peers).To(HaveLen(1))
+		Expect(adb.Status.DisasterRecovery.Peers[0].LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateProvisioning))
+	})
+
+	It("should not terminate a removed peer if the terminate operation is not allowed", func() {
+		reconciler.AllowedOperations = map[OCIOperation]bool{OCIOperationGet: true, OCIOperationUpdate: true}
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		adb.Status.DisasterRecovery.Peers = []dbv1alpha1.DisasterRecoveryPeerStatus{{
+			Region:                 "us-phoenix-1",
+			DisasterRecoveryType:   dbv1alpha1.DisasterRecoveryTypeADG,
+			AutonomousDatabaseOCID: "ocid1.autonomousdatabase.oc1.phx.peer",
+		}}
+		dbService.peerADBs = map[string]database.AutonomousDatabase{
+			"ocid1.autonomousdatabase.oc1.phx.peer": {LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable},
+		}
+
+		Expect(reconciler.validateDisasterRecoveryPeers(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.peerDeleteCalls).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)).To(BeTrue())
+	})
+})
+
+var _ = Describe("AutonomousDatabase status update", func() {
+	It("should retry the status update on a conflict", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+		}
+
+		reconciler := newTestReconciler(adb)
+		kubeClient := &conflictOnceClient{Client: reconciler.KubeClient}
+		reconciler.KubeClient = kubeClient
+
+		modifiedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(kubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), modifiedADB)).To(Succeed())
+		modifiedADB.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+
+		Expect(reconciler.updateStatus(context.TODO(), modifiedADB)).To(Succeed())
+		Expect(kubeClient.statusUpdates).To(Equal(2))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(kubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+	})
+})
+
+var _ = Describe("AutonomousDatabase wallet validation", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					Wallet: dbv1alpha1.WalletSpec{
+						Name: common.String("adb-wallet"),
+					},
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should retry the download if the wallet zip is corrupt", func() {
+		dbService.walletZips = [][]byte{readTestdata("corrupt_wallet.zip")}
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.walletCalls).To(Equal(2))
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+		// The fake client doesn't convert the stringData to the data
+		Expect(secret.StringData).To(HaveKey("tnsnames.ora"))
+		Expect(secret.StringData).To(HaveKey("cwallet.sso"))
+	})
+
+	It("should record the expiry of the wallet certificates", func() {
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(adb.Status.WalletExpiryTime).ToNot(BeNil())
+		Expect(adb.Status.WalletExpiryTime.Time.Equal(testWalletExpiry)).To(BeTrue())
+
+		// The expiry is cleared once the wallet is not requested
+		adb.Spec.Details.Wallet = dbv1alpha1.WalletSpec{}
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(adb.Status.WalletExpiryTime).To(BeNil())
+	})
+
+	It("should set the AutonomousDatabase as the controller of the wallet Secret", func() {
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+
+		owner := metav1.GetControllerOf(secret)
+		Expect(owner).ToNot(BeNil())
+		Expect(owner.Kind).To(Equal("AutonomousDatabase"))
+		Expect(owner.Name).To(Equal("adb"))
+		Expect(*owner.BlockOwnerDeletion).To(BeTrue())
+	})
+
+	Context("the wallet is stored in another namespace", func() {
+		BeforeEach(func() {
+			adb.Spec.Details.Wallet.Namespace = common.String("app-namespace")
+		})
+
+		It("should not store the wallet in a namespace which is not allowed", func() {
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(ContainSubstring("cannot be stored in the namespace app-namespace")))
+			Expect(dbService.walletCalls).To(BeZero())
+			Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("WalletNamespaceNotAllowed")))
+
+			secret := &corev1.Secret{}
+			err := reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app-namespace", Name: "adb-wallet"}, secret)
+			Expect(apiErrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should annotate the wallet Secret with the owner in an allowed namespace", func() {
+			reconciler.WalletNamespaces = ParseWalletNamespaces("other-namespace, app-namespace")
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app-namespace", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Annotations).To(HaveKeyWithValue(dbv1alpha1.WalletOwnerAnnotation, "default/adb"))
+			Expect(metav1.GetControllerOf(secret)).To(BeNil())
+		})
+
+		It("should not overwrite a Secret which only has the app label of the resource", func() {
+			reconciler.WalletNamespaces = ParseWalletNamespaces("app-namespace")
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateAlways
+
+			forged := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "adb-wallet",
+					Namespace: "app-namespace",
+					Labels:    map[string]string{"app": "adb"},
+				},
+				Data: map[string][]byte{"token": []byte("do-not-overwrite")},
+			}
+			Expect(reconciler.KubeClient.Create(context.TODO(), forged)).To(Succeed())
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(BeZero())
+			Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("WalletNotOwned")))
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app-namespace", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Data).To(Equal(map[string][]byte{"token": []byte("do-not-overwrite")}))
+		})
+	})
+
+	It("should rewrite the hosts in the tnsnames.ora before storing the wallet", func() {
+		adb.Spec.Details.Wallet.HostRewrite = common.String("db.example.com")
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+		// The fake client doesn't convert the stringData to the data
+		Expect(secret.StringData["tnsnames.ora"]).To(ContainSubstring("(host=adb.db.example.com)"))
+		Expect(secret.StringData["tnsnames.ora"]).ToNot(ContainSubstring("us-phoenix-1"))
+	})
+
+	It("should rewrite the hosts in the existing wallet Secret", func() {
+		files, err := oci.UnzipWallet(readTestdata("wallet.zip"))
+		Expect(err).ToNot(HaveOccurred())
+		wallet := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb-wallet",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "adb"},
+			},
+			Data: files,
+		}
+		Expect(reconciler.KubeClient.Create(context.TODO(), wallet)).To(Succeed())
+
+		adb.Spec.Details.Wallet.HostRewrite = common.String("db.example.com")
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.walletCalls).To(BeZero())
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+		Expect(string(secret.Data["tnsnames.ora"])).To(ContainSubstring("(host=adb.db.example.com)"))
+	})
+
+	It("should annotate the wallet Secret with the checksum of the files", func() {
+		adb.Spec.Details.Wallet.Checksum = common.Bool(true)
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+
+		// The fake client doesn't convert the stringData to the data
+		data := map[string][]byte{}
+		for key, val := range secret.StringData {
+			data[key] = []byte(val)
+		}
+		Expect(secret.Annotations).To(HaveKeyWithValue(dbv1alpha1.WalletChecksumAnnotation, oci.WalletChecksum(data)))
+	})
+
+	It("should not create the Secret if the wallet is always invalid", func() {
+		corruptWallet := readTestdata("corrupt_wallet.zip")
+		dbService.walletZips = [][]byte{corruptWallet, corruptWallet, corruptWallet}
+
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).ToNot(Succeed())
+		Expect(dbService.walletCalls).To(Equal(walletDownloadAttempts))
+
+		secret := &corev1.Secret{}
+		err := reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)
+		Expect(apiErrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should never generate the wallet if manageWallet is false", func() {
+		adb.Spec.Details.ManageWallet = common.Bool(false)
+		adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateAlways
+		adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(false)
+		adb.Status.WalletMTLSConnectionRequired = common.Bool(true)
+
+		Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.walletCalls).To(BeZero())
+
+		secret := &corev1.Secret{}
+		err := reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)
+		Expect(apiErrors.IsNotFound(err)).To(BeTrue())
+
+		// The Ready condition doesn't wait for the wallet
+		setReadyCondition(adb, nil)
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionReady)).To(BeTrue())
+	})
+
+	Context("when the wallet is regenerated", func() {
+		BeforeEach(func() {
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+		})
+
+		It("should not download the wallet again if the Secret exists by default", func() {
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+		})
+
+		It("should download the wallet again if the Secret is removed with ifMissing", func() {
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateIfMissing
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(reconciler.KubeClient.Delete(context.TODO(), secret)).To(Succeed())
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(2))
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+		})
+
+		It("should replace the wallet in every reconcile with always", func() {
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateAlways
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(3))
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKey("tnsnames.ora"))
+			Expect(secret.Data).To(HaveKey("cwallet.sso"))
+		})
+
+		It("should update the checksum when the wallet is replaced, and remove it once it's disabled", func() {
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateAlways
+			adb.Spec.Details.Wallet.Checksum = common.Bool(true)
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Annotations).To(HaveKeyWithValue(dbv1alpha1.WalletChecksumAnnotation, oci.WalletChecksum(secret.Data)))
+
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateIfMissing
+			adb.Spec.Details.Wallet.Checksum = common.Bool(false)
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Annotations).ToNot(HaveKey(dbv1alpha1.WalletChecksumAnnotation))
+		})
+
+		It("should leave the wallet to the user with never", func() {
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateNever
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(reconciler.KubeClient.Delete(context.TODO(), secret)).To(Succeed())
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+
+			err := reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)
+			Expect(apiErrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("when isMtlsConnectionRequired changes", func() {
+		BeforeEach(func() {
+			adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(true)
+
+			Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+			Expect(adb.Status.WalletMTLSConnectionRequired).To(Equal(common.Bool(true)))
+		})
+
+		It("should not regenerate the wallet if the setting doesn't change", func() {
+			Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+		})
+
+		It("should regenerate the wallet once the database is AVAILABLE", func() {
+			adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(false)
+
+			// The update of the setting is in progress
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateUpdating
+			Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(1))
+			Expect(adb.Status.WalletMTLSConnectionRequired).To(Equal(common.Bool(true)))
+
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+			Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(2))
+			Expect(adb.Status.WalletMTLSConnectionRequired).To(Equal(common.Bool(false)))
+
+			// The fake client doesn't convert the stringData of the created Secret, so the data is only set by the update
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKey("tnsnames.ora"))
+			Expect(secret.Data).To(HaveKey("cwallet.sso"))
+		})
+
+		It("should clear the recorded setting once the wallet is not requested", func() {
+			adb.Spec.Details.Wallet = dbv1alpha1.WalletSpec{}
+
+			Expect(reconciler.validateWalletMTLS(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(adb.Status.WalletMTLSConnectionRequired).To(BeNil())
+		})
+	})
+
+	Context("when the wallet is rotated", func() {
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			adb.Spec.Action = dbv1alpha1.ADBActionRotateWallet
+			Expect(reconciler.KubeClient.Update(context.TODO(), adb)).To(Succeed())
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			recorder = record.NewFakeRecorder(10)
+			reconciler.Recorder = recorder
+		})
+
+		It("should store a new wallet once the rotation completes, and record the action", func() {
+			lookupKey := types.NamespacedName{Namespace: "default", Name: "adb-wallet"}
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, secret)).To(Succeed())
+			// The fake client doesn't convert the stringData to the data
+			oldCwallet := []byte(secret.StringData["cwallet.sso"])
+
+			By("Requesting the rotation")
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(Equal(1))
+			Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(And(ContainSubstring("Warning"), ContainSubstring("invalidated"))))
+
+			By("Waiting while the wallet is UPDATING")
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(Equal(1))
+			Expect(dbService.walletCalls).To(Equal(1))
+
+			By("Storing the new wallet once the wallet is ACTIVE")
+			dbService.walletState = database.AutonomousDatabaseWalletLifecycleStateActive
+			dbService.walletZips = [][]byte{newTestWallet("rotated-cwallet-sso")}
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(Equal(1))
+			Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletRotating)).To(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring("WalletRotated")))
+
+			Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, secret)).To(Succeed())
+			Expect(secret.Data["cwallet.sso"]).To(Equal([]byte("rotated-cwallet-sso")))
+			Expect(secret.Data["cwallet.sso"]).ToNot(Equal(oldCwallet))
+
+			updatedADB := &dbv1alpha1.AutonomousDatabase{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+			Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+			Expect(adb.PendingAction()).To(BeEmpty())
+		})
+
+		It("should not rotate the wallet again until the action is removed and set again", func() {
+			adb.Status.LastAction = dbv1alpha1.ADBActionRotateWallet
+			adb.Status.LastActionGeneration = adb.GetGeneration()
+
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(BeZero())
+
+			By("Removing the action from the spec")
+			adb.Spec.Action = ""
+			resetLastAction(adb)
+			Expect(adb.Status.LastAction).To(BeEmpty())
+
+			By("Setting the action again")
+			adb.Spec.Action = dbv1alpha1.ADBActionRotateWallet
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(Equal(1))
+		})
+
+		It("should not rotate the wallet until the database is AVAILABLE", func() {
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+			Expect(reconciler.validateWalletRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.rotateCalls).To(BeZero())
+		})
+	})
+
+	Context("when the wallet generation conflicts", func() {
+		conflict := fakeServiceError{statusCode: 409, code: "IncorrectState", message: "The database is not ready"}
+
+		It("should requeue the generation with a backoff instead of waiting in the reconcile", func() {
+			dbService.walletErrs = []error{conflict, conflict}
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(errWalletPending))
+			Expect(dbService.walletCalls).To(Equal(1))
+			Expect(walletRetryInterval(adb)).To(Equal(walletGenerationBackoff))
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(errWalletPending))
+			Expect(dbService.walletCalls).To(Equal(2))
+			Expect(walletRetryInterval(adb)).To(Equal(2 * walletGenerationBackoff))
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(3))
+			Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)).To(BeNil())
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "adb-wallet"}, secret)).To(Succeed())
+		})
+
+		It("should cap the backoff, or use the downloadInterval if set", func() {
+			adb.Status.WalletDownloadRetries = 10
+			Expect(walletRetryInterval(adb)).To(Equal(requeueResult.RequeueAfter))
+
+			adb.Spec.Details.Wallet.DownloadInterval = &metav1.Duration{Duration: time.Minute}
+			Expect(walletRetryInterval(adb)).To(Equal(time.Minute))
+		})
+
+		It("should set the WalletPending condition if the generation conflicts", func() {
+			dbService.walletErrs = []error{conflict}
+
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(errWalletPending))
+			Expect(dbService.walletCalls).To(Equal(1))
+			Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)).To(BeTrue())
+			Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+
+			// The condition is removed once the wallet is generated
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)).To(BeNil())
+		})
+
+		It("should set the WalletFailed condition once the downloadRetries are exhausted", func() {
+			adb.Spec.Details.Wallet.DownloadRetries = common.Int(1)
+			dbService.walletErrs = []error{conflict, conflict}
+
+			By("Retrying the generation in the next reconcile")
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(errWalletPending))
+			Expect(adb.Status.WalletDownloadRetries).To(Equal(1))
+
+			By("Giving up once the retries are exhausted")
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(MatchError(errWalletFailed))
+			Expect(dbService.walletCalls).To(Equal(2))
+			Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletPending)).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletFailed)).To(BeTrue())
+
+			By("Not retrying the generation until the spec changes")
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(2))
+
+			adb.SetGeneration(adb.GetGeneration() + 1)
+			Expect(reconciler.validateWallet(context.TODO(), reconciler.Log, adb)).To(Succeed())
+			Expect(dbService.walletCalls).To(Equal(3))
+			Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionWalletFailed)).To(BeNil())
+			Expect(adb.Status.WalletDownloadRetries).To(BeZero())
+		})
+
+		It("should not retry the other errors", func() {
+			dbService.walletErrs = []error{fakeServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound"}}
+
+			err := reconciler.validateWallet(context.TODO(), reconciler.Log, adb)
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, errWalletPending)).To(BeFalse())
+			Expect(dbService.walletCalls).To(Equal(1))
+		})
+	})
+
+	Context("when the Ready condition is set", func() {
+		conflict := fakeServiceError{statusCode: 409, code: "IncorrectState", message: "The database is not ready"}
+
+		BeforeEach(func() {
+			dbService.walletErrs = []error{conflict}
+		})
+
+		It("should wait for the wallet by default", func() {
+			walletErr := reconciler.validateWallet(context.TODO(), reconciler.Log, adb)
+			Expect(walletErr).To(MatchError(errWalletPending))
+
+			setReadyCondition(adb, walletErr)
+			cond := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionReady)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("WalletNotStored"))
+
+			// The wallet is generated in the next reconcile
+			walletErr = reconciler.validateWallet(context.TODO(), reconciler.Log, adb)
+			Expect(walletErr).ToNot(HaveOccurred())
+
+			setReadyCondition(adb, walletErr)
+			Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionReady)).To(BeTrue())
+		})
+
+		It("should not wait for the wallet if readyRequiresWallet is false", func() {
+			adb.Spec.Details.ReadyRequiresWallet = common.Bool(false)
+
+			walletErr := reconciler.validateWallet(context.TODO(), reconciler.Log, adb)
+			Expect(walletErr).To(MatchError(errWalletPending))
+
+			setReadyCondition(adb, walletErr)
+			Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionReady)).To(BeTrue())
+		})
+
+		It("should not be ready until the database is AVAILABLE", func() {
+			adb.Spec.Details.ReadyRequiresWallet = common.Bool(false)
+			adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+			setReadyCondition(adb, nil)
+			cond := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionReady)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("NotAvailable"))
+		})
+	})
+})
+
+var _ = Describe("AutonomousDatabase encryption key rotation", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				Action: dbv1alpha1.ADBActionRotateEncryptionKey,
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				EncryptionKey: dbv1alpha1.EncryptionKeyStatus{
+					KmsKeyOCID:        "ocid1.key.oc1..fake",
+					KmsKeyVersionOCID: "ocid1.keyversion.oc1..v1",
+					VaultOCID:         "ocid1.vault.oc1..fake",
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should rotate the key, and record the action once the database is AVAILABLE again", func() {
+		By("Requesting the rotation")
+		Expect(reconciler.validateEncryptionKeyRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.keyCalls).To(Equal(1))
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateUpdating))
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("EncryptionKeyRotating")))
+
+		By("Waiting while the database is UPDATING")
+		Expect(reconciler.validateEncryptionKeyRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.keyCalls).To(Equal(1))
+
+		By("Removing the action once the database is AVAILABLE with the new key version")
+		adb.UpdateStatusFromOCIADB(database.AutonomousDatabase{
+			LifecycleState:    database.AutonomousDatabaseLifecycleStateAvailable,
+			IsDedicated:       common.Bool(false),
+			ConnectionStrings: &database.AutonomousDatabaseConnectionStrings{},
+			KmsKeyId:          common.String("ocid1.key.oc1..fake"),
+			KmsKeyVersionId:   common.String("ocid1.keyversion.oc1..v2"),
+			VaultId:           common.String("ocid1.vault.oc1..fake"),
+			KeyHistoryEntry: []database.AutonomousDatabaseKeyHistoryEntry{
+				{Id: common.String("ocid1.key.oc1..fake"), TimeActivated: &common.SDKTime{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}},
+				{Id: common.String("ocid1.key.oc1..fake"), TimeActivated: &common.SDKTime{Time: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)}},
+			},
+		})
+		Expect(reconciler.validateEncryptionKeyRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.keyCalls).To(Equal(1))
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionEncryptionKeyRotating)).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("ocid1.keyversion.oc1..v2")))
+		Expect(adb.Status.EncryptionKey.KmsKeyVersionOCID).To(Equal("ocid1.keyversion.oc1..v2"))
+		Expect(adb.Status.EncryptionKey.LastRotationTime).To(Equal(dbv1alpha1.FormatSDKTime(&common.SDKTime{Time: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)})))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should ignore the action without a request if the database uses an Oracle-managed key", func() {
+		adb.Status.EncryptionKey = dbv1alpha1.EncryptionKeyStatus{KmsKeyOCID: "ORACLE_MANAGED_KEY"}
+
+		Expect(reconciler.validateEncryptionKeyRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.keyCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAction")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should not rotate the key until the database is AVAILABLE", func() {
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+		Expect(reconciler.validateEncryptionKeyRotation(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.keyCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase connection URLs refresh", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				Action: dbv1alpha1.ADBActionRefreshConnectionURLs,
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				DbWorkload:     database.AutonomousDatabaseDbWorkloadApex,
+				ConnectionURLs: dbv1alpha1.ConnectionURLsStatus{
+					ApexURL: "https://old.adb.oraclecloudapps.com/ords/apex",
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DbWorkload: database.AutonomousDatabaseDbWorkloadApex,
+				ConnectionUrls: &database.AutonomousDatabaseConnectionUrls{
+					SqlDevWebUrl: common.String("https://new.adb.oraclecloudapps.com/ords/sql-developer"),
+					ApexUrl:      common.String("https://new.adb.oraclecloudapps.com/ords/apex"),
+				},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should update the URLs in the status and record the action", func() {
+		Expect(reconciler.validateConnectionURLsRefresh(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.getADBCalls).To(Equal(1))
+		Expect(adb.Status.ConnectionURLs).To(Equal(dbv1alpha1.ConnectionURLsStatus{
+			SQLDevWebURL: "https://new.adb.oraclecloudapps.com/ords/sql-developer",
+			ApexURL:      "https://new.adb.oraclecloudapps.com/ords/apex",
+		}))
+		Expect(recorder.Events).To(Receive(ContainSubstring("ConnectionURLsRefreshed")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should ignore the action without a request if the database is not an APEX or AJD database", func() {
+		adb.Status.DbWorkload = database.AutonomousDatabaseDbWorkloadOltp
+
+		Expect(reconciler.validateConnectionURLsRefresh(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.getADBCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAction")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should not refresh the URLs until the database is AVAILABLE", func() {
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateUpdating
+
+		Expect(reconciler.validateConnectionURLsRefresh(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.getADBCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase shrink", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				Action: dbv1alpha1.ADBActionShrink,
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState:            database.AutonomousDatabaseLifecycleStateAvailable,
+				AllocatedStorageSizeInGBs: 2048,
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should shrink the storage, and record the reclaimed storage once the database is AVAILABLE again", func() {
+		By("Requesting the shrink")
+		Expect(reconciler.validateShrink(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.shrinkCalls).To(Equal(1))
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateUpdating))
+		Expect(adb.Status.Shrink.AllocatedStorageBeforeInGBs).To(Equal(2048))
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionShrinking)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("StorageShrinking")))
+
+		By("Waiting while the database is UPDATING")
+		Expect(reconciler.validateShrink(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.shrinkCalls).To(Equal(1))
+
+		By("Recording the reclaimed storage once the database is AVAILABLE with less storage")
+		adb.UpdateStatusFromOCIADB(database.AutonomousDatabase{
+			LifecycleState:            database.AutonomousDatabaseLifecycleStateAvailable,
+			IsDedicated:               common.Bool(false),
+			ConnectionStrings:         &database.AutonomousDatabaseConnectionStrings{},
+			AllocatedStorageSizeInTBs: common.Float64(1.5),
+		})
+		Expect(reconciler.validateShrink(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.shrinkCalls).To(Equal(1))
+		Expect(adb.Status.AllocatedStorageSizeInGBs).To(Equal(1536))
+		Expect(adb.Status.Shrink.ReclaimedStorageSizeInGBs).To(Equal(512))
+		Expect(adb.Status.Shrink.LastShrinkTime).ToNot(BeEmpty())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionShrinking)).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("512 GB")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should ignore the action without a request if the database is dedicated", func() {
+		adb.Status.IsDedicated = true
+
+		Expect(reconciler.validateShrink(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.shrinkCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAction")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should not shrink the storage until the database is AVAILABLE", func() {
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+		Expect(reconciler.validateShrink(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.shrinkCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase refreshable clone detach", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.ConfirmDetachAnnotation: "clonedb"},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				Action: dbv1alpha1.ADBActionDetachClone,
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState:     database.AutonomousDatabaseLifecycleStateAvailable,
+				DbName:             "clonedb",
+				IsRefreshableClone: true,
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should detach the clone, and record the action once the database is a standalone database", func() {
+		By("Requesting the detach")
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(Equal(1))
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateUpdating))
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionCloneDetaching)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("CloneDetaching")))
+
+		By("Waiting until OCI reports the database is no longer a refreshable clone")
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(Equal(1))
+
+		By("Removing the action once the database is a standalone database")
+		adb.UpdateStatusFromOCIADB(database.AutonomousDatabase{
+			LifecycleState:     database.AutonomousDatabaseLifecycleStateAvailable,
+			IsDedicated:        common.Bool(false),
+			ConnectionStrings:  &database.AutonomousDatabaseConnectionStrings{},
+			IsRefreshableClone: common.Bool(false),
+		})
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(Equal(1))
+		Expect(adb.Status.IsRefreshableClone).To(BeFalse())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionCloneDetaching)).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("CloneDetached")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should ignore the action without a request if the database is not a refreshable clone", func() {
+		adb.Status.IsRefreshableClone = false
+
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAction")))
+
+		updatedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), client.ObjectKeyFromObject(adb), updatedADB)).To(Succeed())
+		Expect(updatedADB.Spec.Action).ToNot(BeEmpty())
+		Expect(adb.PendingAction()).To(BeEmpty())
+	})
+
+	It("should ignore the action without a request if the detach is not confirmed", func() {
+		adb.SetAnnotations(map[string]string{dbv1alpha1.ConfirmDetachAnnotation: "anotherdb"})
+
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("InvalidAction")))
+	})
+
+	It("should not detach the clone until the database is AVAILABLE", func() {
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+		Expect(reconciler.validateCloneDetach(context.TODO(), reconciler.Log, adb)).To(Succeed())
+		Expect(dbService.detachCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase admin password rotation", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+		difADB     *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					AdminPassword: dbv1alpha1.PasswordSpec{
+						K8sSecret: dbv1alpha1.K8sSecretSpec{Name: common.String("new-admin-password")},
+					},
+				},
+				HealthCheck: dbv1alpha1.HealthCheckSpec{Enabled: common.Bool(true)},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+
+		difADB = &dbv1alpha1.AutonomousDatabase{}
+		difADB.Spec.Details.AdminPassword = adb.Spec.Details.AdminPassword
+
+		dbService = &fakeDatabaseService{}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	setConnected := func(status metav1.ConditionStatus) {
+		meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+			Type:   dbv1alpha1.ADBConditionConnected,
+			Status: status,
+			Reason: "Test",
+		})
+	}
+
+	It("should signal the rotation until the new password is confirmed by the health check", func() {
+		By("Sending the new password")
+		sent, err := reconciler.validateAdminPassword(context.TODO(), reconciler.Log, adb, difADB, adb.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.pwdCalls).To(Equal(1))
+		Expect(recorder.Events).To(Receive(ContainSubstring("AdminPasswordRotating")))
+
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Updating"))
+
+		By("Waiting while the database is UPDATING")
+		setConnected(metav1.ConditionUnknown)
+		reconciler.validateAdminPasswordRotation(adb)
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)).To(BeTrue())
+
+		By("Waiting while the database doesn't accept connections")
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		setConnected(metav1.ConditionFalse)
+		reconciler.validateAdminPasswordRotation(adb)
+		condition = meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("WaitingForConnectivity"))
+
+		By("Confirming the rotation once the database accepts connections")
+		setConnected(metav1.ConditionTrue)
+		reconciler.validateAdminPasswordRotation(adb)
+		condition = meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Confirmed"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("AdminPasswordRotated")))
+	})
+
+	It("should complete the rotation once the database is AVAILABLE if the health check is disabled", func() {
+		adb.Spec.HealthCheck = dbv1alpha1.HealthCheckSpec{}
+
+		_, err := reconciler.validateAdminPassword(context.TODO(), reconciler.Log, adb, difADB, adb.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+		reconciler.validateAdminPasswordRotation(adb)
+
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Updated"))
+	})
+
+	It("should not send the password until the database is AVAILABLE", func() {
+		ociADB := adb.DeepCopy()
+		ociADB.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+
+		sent, err := reconciler.validateAdminPassword(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.pwdCalls).To(BeZero())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionAdminPasswordRotating)).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase Database Management", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	// getDifADB returns the difference between the spec and the database in OCI
+	getDifADB := func(ociStatus database.AutonomousDatabaseDatabaseManagementStatusEnum) (*dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase) {
+		dbService.ociADB.DatabaseManagementStatus = ociStatus
+		ociADB := &dbv1alpha1.AutonomousDatabase{}
+		ociADB.UpdateFromOCIADB(dbService.newOCIADB("ocid1.autonomousdatabase.oc1..fake", database.AutonomousDatabaseLifecycleStateAvailable))
+
+		difADB := adb.DeepCopy()
+		_, err := difADB.RemoveUnchangedDetails(ociADB.Spec)
+		Expect(err).NotTo(HaveOccurred())
+		return difADB, ociADB
+	}
+
+	It("should enable Database Management, and report the status from OCI", func() {
+		adb.Spec.Details.IsDatabaseManagementEnabled = common.Bool(true)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseDatabaseManagementStatusNotEnabled)
+		sent, err := reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.dbmCalls).To(Equal(1))
+		Expect(adb.Status.DatabaseManagementStatus).To(Equal(database.AutonomousDatabaseDatabaseManagementStatusEnabling))
+
+		By("Sending no request while it's ENABLING")
+		difADB, ociADB = getDifADB(database.AutonomousDatabaseDatabaseManagementStatusEnabling)
+		Expect(difADB.Spec.Details.IsDatabaseManagementEnabled).To(BeNil())
+		Expect(ociADB.Status.DatabaseManagementStatus).To(Equal(database.AutonomousDatabaseDatabaseManagementStatusEnabling))
+		sent, err = reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.dbmCalls).To(Equal(1))
+	})
+
+	It("should disable Database Management", func() {
+		adb.Spec.Details.IsDatabaseManagementEnabled = common.Bool(false)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseDatabaseManagementStatusEnabled)
+		sent, err := reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.dbmCalls).To(Equal(1))
+		Expect(adb.Status.DatabaseManagementStatus).To(Equal(database.AutonomousDatabaseDatabaseManagementStatusDisabling))
+	})
+
+	It("should retry the transition which failed in OCI", func() {
+		adb.Spec.Details.IsDatabaseManagementEnabled = common.Bool(true)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseDatabaseManagementStatusFailedEnabling)
+		sent, err := reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.dbmCalls).To(Equal(1))
+	})
+
+	It("should defer the change until the previous transition completes", func() {
+		adb.Spec.Details.IsDatabaseManagementEnabled = common.Bool(true)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseDatabaseManagementStatusDisabling)
+		sent, err := reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		// The reconcile is requeued without a request, so that the spec is not recorded as applied
+		Expect(sent).To(BeTrue())
+		Expect(dbService.dbmCalls).To(BeZero())
+	})
+
+	It("should leave Database Management as is if the field is not set", func() {
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseDatabaseManagementStatusEnabled)
+		sent, err := reconciler.validateDatabaseManagement(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.dbmCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase Operations Insights", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		reconciler = newTestReconciler()
+		reconciler.dbService = dbService
+	})
+
+	getDifADB := func(ociStatus database.AutonomousDatabaseOperationsInsightsStatusEnum, state database.AutonomousDatabaseLifecycleStateEnum) (*dbv1alpha1.AutonomousDatabase, *dbv1alpha1.AutonomousDatabase) {
+		dbService.ociADB.OperationsInsightsStatus = ociStatus
+		ociADB := &dbv1alpha1.AutonomousDatabase{}
+		ociADB.UpdateFromOCIADB(dbService.newOCIADB("ocid1.autonomousdatabase.oc1..fake", state))
+
+		difADB := adb.DeepCopy()
+		_, err := difADB.RemoveUnchangedDetails(ociADB.Spec)
+		Expect(err).NotTo(HaveOccurred())
+		return difADB, ociADB
+	}
+
+	DescribeTable("should toggle Operations Insights",
+		func(enabled bool, ociStatus database.AutonomousDatabaseOperationsInsightsStatusEnum, expectedStatus database.AutonomousDatabaseOperationsInsightsStatusEnum) {
+			adb.Spec.Details.IsOperationsInsightsEnabled = common.Bool(enabled)
+
+			difADB, ociADB := getDifADB(ociStatus, database.AutonomousDatabaseLifecycleStateAvailable)
+			sent, err := reconciler.validateOperationsInsights(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(BeTrue())
+			Expect(dbService.opsiCalls).To(Equal(1))
+			Expect(adb.Status.OperationsInsightsStatus).To(Equal(expectedStatus))
+		},
+		Entry("enable", true, database.AutonomousDatabaseOperationsInsightsStatusNotEnabled, database.AutonomousDatabaseOperationsInsightsStatusEnabling),
+		Entry("disable", false, database.AutonomousDatabaseOperationsInsightsStatusEnabled, database.AutonomousDatabaseOperationsInsightsStatusDisabling),
+		Entry("retry the failed disabling", false, database.AutonomousDatabaseOperationsInsightsStatusFailedDisabling, database.AutonomousDatabaseOperationsInsightsStatusDisabling),
+	)
+
+	It("should not enable Operations Insights until the database is AVAILABLE", func() {
+		adb.Spec.Details.IsOperationsInsightsEnabled = common.Bool(true)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseOperationsInsightsStatusNotEnabled, database.AutonomousDatabaseLifecycleStateStopped)
+		sent, err := reconciler.validateOperationsInsights(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.opsiCalls).To(BeZero())
+	})
+
+	It("should send no request once the status matches the spec", func() {
+		adb.Spec.Details.IsOperationsInsightsEnabled = common.Bool(true)
+
+		difADB, ociADB := getDifADB(database.AutonomousDatabaseOperationsInsightsStatusEnabled, database.AutonomousDatabaseLifecycleStateAvailable)
+		Expect(ociADB.Status.OperationsInsightsStatus).To(Equal(database.AutonomousDatabaseOperationsInsightsStatusEnabled))
+		sent, err := reconciler.validateOperationsInsights(context.TODO(), reconciler.Log, adb, difADB, ociADB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.opsiCalls).To(BeZero())
+	})
+})
+
+var _ = Describe("AutonomousDatabase defined tags", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					DefinedTags: map[string]map[string]string{
+						"Operations": {"CostCenter": "42"},
+					},
+					IgnoredTagNamespaces: []string{"Defaults"},
+				},
+			},
+		}
+
+		// The Tag Defaults are applied by OCI, so they only exist in the OCI response
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DefinedTags: map[string]map[string]interface{}{
+					"Operations":  {"CostCenter": "42"},
+					"Oracle-Tags": {"CreatedBy": "ocid1.user.oc1..fake"},
+					"Defaults":    {"Environment": "dev"},
+				},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler = newTestReconciler(adb)
+		reconciler.dbService = dbService
+	})
+
+	It("should not strip the default tags which only exist in OCI", func() {
+		modifiedADB := adb.DeepCopy()
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+
+		Expect(modifiedADB.Status.DefinedTags).To(HaveKeyWithValue("Oracle-Tags", map[string]string{"CreatedBy": "ocid1.user.oc1..fake"}))
+	})
+
+	It("should keep the tags in the ignored namespaces when the defined tags change", func() {
+		adb.Spec.Details.DefinedTags["Operations"]["CostCenter"] = "43"
+
+		modifiedADB := adb.DeepCopy()
+		_, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(requeueResult))
+
+		Expect(dbService.generalFieldsDifADB).ToNot(BeNil())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.DefinedTags).To(Equal(map[string]map[string]string{
+			"Operations":  {"CostCenter": "43"},
+			"Oracle-Tags": {"CreatedBy": "ocid1.user.oc1..fake"},
+			"Defaults":    {"Environment": "dev"},
+		}))
+		// The spec is not modified
+		Expect(modifiedADB.Spec.Details.DefinedTags).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("AutonomousDatabase compartment adoption", func() {
+	It("should create a bound resource for each database in the compartment", func() {
+		const compartmentOCID = "ocid1.compartment.oc1..fake"
+
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.AdoptCompartmentAnnotation: ""},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				},
+				OCIConfig: dbv1alpha1.OCIConfigSpec{
+					ConfigMapName: common.String("oci-cred"),
+					SecretName:    common.String("oci-privatekey"),
+				},
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CompartmentId: common.String(compartmentOCID),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+			adbSummaries: []database.AutonomousDatabaseSummary{
+				{
+					// The database of the annotated resource
+					Id:             common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CompartmentId:  common.String(compartmentOCID),
+					DbName:         common.String("FAKEDB"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+				},
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..sales"),
+					CompartmentId:  common.String(compartmentOCID),
+					DbName:         common.String("SALESDB"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateStopped,
+				},
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..terminated"),
+					CompartmentId:  common.String(compartmentOCID),
+					DbName:         common.String("OLDDB"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateTerminated,
+				},
+				{
+					Id:             common.String("ocid1.autonomousdatabase.oc1..other"),
+					CompartmentId:  common.String("ocid1.compartment.oc1..other"),
+					DbName:         common.String("OTHERDB"),
+					LifecycleState: database.AutonomousDatabaseSummaryLifecycleStateAvailable,
+				},
+			},
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		adbList := &dbv1alpha1.AutonomousDatabaseList{}
+		Expect(reconciler.KubeClient.List(context.TODO(), adbList)).To(Succeed())
+		Expect(adbList.Items).To(HaveLen(2))
+
+		salesADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), types.NamespacedName{Name: "salesdb", Namespace: "default"}, salesADB)).To(Succeed())
+		Expect(salesADB.Spec.Details.AutonomousDatabaseOCID).To(Equal(common.String("ocid1.autonomousdatabase.oc1..sales")))
+		Expect(salesADB.Spec.OCIConfig).To(Equal(adb.Spec.OCIConfig))
+		Expect(salesADB.Spec.HardLink).To(Equal(common.Bool(false)))
+
+		// The annotation is one-shot
+		adoptingADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, adoptingADB)).To(Succeed())
+		Expect(adoptingADB.GetAnnotations()).ToNot(HaveKey(dbv1alpha1.AdoptCompartmentAnnotation))
+	})
+})
+
+var _ = Describe("AutonomousDatabase failed update", func() {
+	It("should retry only the fields which are not applied to the database", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					DisplayName:            common.String("new-name"),
+					CPUCoreCount:           common.Int(2),
+				},
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:          common.String("old-name"),
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		// The general fields are applied
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.generalFieldsDifADB).ToNot(BeNil())
+		Expect(dbService.generalFieldsDifADB.Spec.Details.DisplayName).To(Equal(common.String("new-name")))
+
+		// The scaling fails after the display name has changed in OCI
+		dbService.ociADB.DisplayName = common.String("new-name")
+		dbService.generalFieldsDifADB = nil
+		dbService.scaleErr = errors.New("fake scaling error")
+
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(failedUpdateRequeue))
+		Expect(dbService.scaleCalls).To(Equal(1))
+
+		failedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, failedADB)).To(Succeed())
+		Expect(failedADB.Status.LastSyncTime).To(BeNil())
+
+		// Only the scaling is retried
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbService.scaleCalls).To(Equal(2))
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+	})
+})
+
+var _ = Describe("AutonomousDatabase last error", func() {
+	It("should store the details of the OCI service error until the spec is synced", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(2),
+				},
+			},
+		}
+
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+			scaleErr:    fakeServiceError{statusCode: 400, code: "LimitExceeded", message: "fake limit message"},
+		}
+		reconciler := newTestReconciler(adb)
+		reconciler.newDBService = func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+			return dbService, nil
+		}
+
+		lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		failedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, failedADB)).To(Succeed())
+		Expect(failedADB.Status.LastErrorCode).To(Equal("LimitExceeded"))
+		Expect(failedADB.Status.LastError).To(Equal("fake limit message"))
+		Expect(failedADB.Status.LastRequestId).To(Equal("fake-request-id"))
+
+		// The scaling succeeds and the database converges with the spec
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		dbService.ociADB.CpuCoreCount = common.Int(2)
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		syncedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, syncedADB)).To(Succeed())
+		Expect(syncedADB.Status.LastSyncTime).ToNot(BeNil())
+		Expect(syncedADB.Status.LastErrorCode).To(BeEmpty())
+		Expect(syncedADB.Status.LastError).To(BeEmpty())
+		Expect(syncedADB.Status.LastRequestId).To(BeEmpty())
+	})
+
+	It("should only store the message of the other errors", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		setLastError(adb, fmt.Errorf("wrapped: %w", fakeServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound", message: "fake message"}))
+		Expect(adb.Status.LastErrorCode).To(Equal("NotAuthorizedOrNotFound"))
+		Expect(adb.Status.LastRequestId).To(Equal("fake-request-id"))
+
+		setLastError(adb, errors.New("fake error"))
+		Expect(adb.Status.LastErrorCode).To(BeEmpty())
+		Expect(adb.Status.LastError).To(Equal("fake error"))
+		Expect(adb.Status.LastRequestId).To(BeEmpty())
+	})
+})
+
+var _ = Describe("AutonomousDatabase drift report", func() {
+	It("should report the fields changed out of band, but not the fields changed in the spec", func() {
+		lastSucSpec := dbv1alpha1.AutonomousDatabaseSpec{
+			Details: dbv1alpha1.AutonomousDatabaseDetails{
+				AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				DisplayName:            common.String("adb"),
+				CPUCoreCount:           common.Int(1),
+				FreeformTags:           map[string]string{"env": "test"},
+			},
+		}
+		lastSucSpecBytes, err := json.Marshal(lastSucSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.LastSuccessfulSpec: string(lastSucSpecBytes)},
+			},
+			Spec: *lastSucSpec.DeepCopy(),
+		}
+		// The cpuCoreCount is changed in the spec
+		adb.Spec.Details.CPUCoreCount = common.Int(2)
+
+		// The displayName and the tags are changed out of band
+		dbService := &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName:          common.String("renamed"),
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+				FreeformTags:         map[string]string{"env": "prod"},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		recorder := record.NewFakeRecorder(10)
+		reconciler := newTestReconciler(adb)
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+
+		_, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+
+		var event string
+		Expect(recorder.Events).To(Receive(&event))
+		Expect(event).To(ContainSubstring("DriftDetected"))
+		Expect(event).To(ContainSubstring("spec.details.displayName"))
+		Expect(event).To(ContainSubstring("spec.details.freeformTags"))
+		Expect(event).ToNot(ContainSubstring("spec.details.cpuCoreCount"))
+	})
+})
+
+var _ = Describe("AutonomousDatabase ignored fields", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		lastSucSpec := dbv1alpha1.AutonomousDatabaseSpec{
+			Details: dbv1alpha1.AutonomousDatabaseDetails{
+				AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				CPUCoreCount:           common.Int(1),
+				DataStorageSizeInTBs:   common.Int(1),
+				FreeformTags:           map[string]string{"env": "test"},
+			},
+		}
+		lastSucSpecBytes, err := json.Marshal(lastSucSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "adb",
+				Namespace:   "default",
+				Annotations: map[string]string{dbv1alpha1.LastSuccessfulSpec: string(lastSucSpecBytes)},
+			},
+			Spec: *lastSucSpec.DeepCopy(),
+		}
+
+		// The tags and the CPU are changed out of band, e.g. by another controller
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(4),
+				DataStorageSizeInTBs: common.Int(1),
+				FreeformTags:         map[string]string{"env": "prod", "owner": "finops"},
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = newTestReconciler()
+		reconciler.Recorder = recorder
+		reconciler.dbService = dbService
+	})
+
+	It("should revert the fields which are not ignored", func() {
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("DriftDetected")))
+	})
+
+	It("should neither report nor revert the ignored freeformTags and cpuCoreCount", func() {
+		adb.Spec.Details.IgnoreFields = []string{"freeformTags", "cpuCoreCount"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeFalse())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+		Expect(dbService.scaleCalls).To(BeZero())
+		Expect(recorder.Events).ToNot(Receive())
+		// The spec is not changed
+		Expect(adb.Spec.Details.FreeformTags).To(Equal(map[string]string{"env": "test"}))
+		Expect(*adb.Spec.Details.CPUCoreCount).To(Equal(1))
+	})
+
+	It("should only report and revert the fields which are not ignored", func() {
+		adb.Spec.Details.IgnoreFields = []string{"freeformTags"}
+
+		sent, _, err := reconciler.updateADB(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(BeTrue())
+		Expect(dbService.generalFieldsDifADB).To(BeNil())
+		Expect(dbService.scaleCalls).To(Equal(1))
+
+		var event string
+		Expect(recorder.Events).To(Receive(&event))
+		Expect(event).To(ContainSubstring("spec.details.cpuCoreCount"))
+		Expect(event).ToNot(ContainSubstring("spec.details.freeformTags"))
+	})
+})
+
+// newSelfSignedCertificate returns a self-signed CA certificate for the host and its key
+func newSelfSignedCertificate(host string) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	return der, key
+}
+
+var _ = Describe("AutonomousDatabase connectivity check", func() {
+	const host = "adb.us-phoenix-1.oraclecloud.com"
+
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		adb        *dbv1alpha1.AutonomousDatabase
+		listener   net.Listener
+		listenerCA []byte
+	)
+
+	BeforeEach(func() {
+		der, key := newSelfSignedCertificate(host)
+		listenerCA = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+		var err error
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		// The next spec assigns a new listener, so the goroutine accepts on its own copy
+		l := listener
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				_ = conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}
+		}()
+
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					Wallet: dbv1alpha1.WalletSpec{
+						Name: common.String("adb-wallet"),
+					},
+				},
+				HealthCheck: dbv1alpha1.HealthCheckSpec{Enabled: common.Bool(true)},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+				AllConnectionStrings: []dbv1alpha1.ConnectionStringProfile{
+					{
+						ConnectionStrings: []dbv1alpha1.ConnectionStringSpec{
+							{TNSName: "mydb_high", ConnectionString: listener.Addr().String() + "/mydb_high.adb.oraclecloud.com"},
+						},
+					},
+				},
+			},
+		}
+
+		reconciler = newTestReconciler(adb)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	// storeWallet stores the wallet Secret whose tnsnames.ora points to the listener under the host reported by OCI
+	storeWallet := func(ewalletPem []byte) {
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		adb.Status.AllConnectionStrings[0].ConnectionStrings[0].ConnectionString = host + ":" + port + "/mydb_high.adb.oraclecloud.com"
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb-wallet",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "adb"},
+			},
+			Data: map[string][]byte{
+				"tnsnames.ora": []byte("mydb_high = (description=(address=(protocol=tcps)(port=" + port + ")(host=127.0.0.1))" +
+					"(connect_data=(service_name=mydb_high.adb.oraclecloud.com)))\n"),
+				"ewallet.pem": ewalletPem,
+			},
+		}
+		Expect(reconciler.KubeClient.Create(context.TODO(), secret)).To(Succeed())
+	}
+
+	It("should verify the listener by a TLS handshake with the wallet certificates", func() {
+		storeWallet(listenerCA)
+
+		reconciler.validateConnectivity(context.TODO(), reconciler.Log, adb)
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("verified by the wallet"))
+	})
+
+	It("should report the failure if the TLS handshake fails", func() {
+		otherCA, _ := newSelfSignedCertificate(host)
+		storeWallet(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherCA}))
+
+		reconciler.validateConnectivity(context.TODO(), reconciler.Log, adb)
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("HandshakeFailed"))
+	})
+
+	It("should only open a TCP connection if the wallet is not stored", func() {
+		reconciler.validateConnectivity(context.TODO(), reconciler.Log, adb)
+		condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).ToNot(ContainSubstring("verified by the wallet"))
+	})
+})
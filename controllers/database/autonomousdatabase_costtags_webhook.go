@@ -0,0 +1,193 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// AutonomousDatabaseCostTagsPath is the path where the cost-tracking tags policy is served
+const AutonomousDatabaseCostTagsPath = "/mutate-database-oracle-com-v1alpha1-autonomousdatabase-costtags"
+
+//+kubebuilder:webhook:verbs=create,path=/mutate-database-oracle-com-v1alpha1-autonomousdatabase-costtags,mutating=true,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=autonomousdatabases,versions=v1alpha1,name=mautonomousdatabasecosttags.kb.io,admissionReviewVersions={v1}
+
+// CostTagsPolicy is how the missing cost-tracking tags are handled
+type CostTagsPolicy string
+
+const (
+	// CostTagsPolicyReject denies the provisioning of a database without the required tags
+	CostTagsPolicyReject CostTagsPolicy = "reject"
+	// CostTagsPolicyInject adds the missing tags with their default values
+	CostTagsPolicyInject CostTagsPolicy = "inject"
+)
+
+// AutonomousDatabaseCostTagsPolicy requires the cost-tracking defined tags in a tag namespace on every database to be
+// provisioned. The databases which are bound to an existing OCID are not checked, since their tags are managed in OCI.
+type AutonomousDatabaseCostTagsPolicy struct {
+	Log logr.Logger
+
+	Policy CostTagsPolicy
+	// TagNamespace is the namespace of the defined tags
+	TagNamespace string
+	// RequiredTags maps the keys of the required tags to the values which are injected if the tags are missing
+	RequiredTags map[string]string
+
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &AutonomousDatabaseCostTagsPolicy{}
+
+// ParseCostTagsPolicy builds the policy from the manager flags. The required tags are a comma-separated list of
+// keys with the default values, e.g. "CostCenter=unassigned,Project=unassigned". The default values can be left out
+// with the reject policy. If no tag is required, the policy allows all the requests.
+func ParseCostTagsPolicy(logger logr.Logger, policy string, tagNamespace string, requiredTags string) (*AutonomousDatabaseCostTagsPolicy, error) {
+	p := &AutonomousDatabaseCostTagsPolicy{
+		Log:          logger,
+		Policy:       CostTagsPolicy(strings.ToLower(strings.TrimSpace(policy))),
+		TagNamespace: strings.TrimSpace(tagNamespace),
+		RequiredTags: make(map[string]string),
+	}
+
+	if strings.TrimSpace(requiredTags) == "" {
+		return p, nil
+	}
+
+	if p.Policy != CostTagsPolicyReject && p.Policy != CostTagsPolicyInject {
+		return nil, fmt.Errorf("unknown cost tags policy %q; the valid policies are %s and %s", policy, CostTagsPolicyInject, CostTagsPolicyReject)
+	}
+	if p.TagNamespace == "" {
+		return nil, fmt.Errorf("the tag namespace of the required cost tags is not set")
+	}
+
+	for _, tag := range strings.Split(requiredTags, ",") {
+		key, value := tag, ""
+		if i := strings.Index(tag, "="); i >= 0 {
+			key, value = tag[:i], tag[i+1:]
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		if key == "" {
+			return nil, fmt.Errorf("invalid required cost tag %q", tag)
+		}
+		if value == "" && p.Policy == CostTagsPolicyInject {
+			return nil, fmt.Errorf("the required cost tag %s has no default value to inject", key)
+		}
+		p.RequiredTags[key] = value
+	}
+
+	return p, nil
+}
+
+// InjectDecoder implements admission.DecoderInjector
+func (p *AutonomousDatabaseCostTagsPolicy) InjectDecoder(d *admission.Decoder) error {
+	p.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler
+func (p *AutonomousDatabaseCostTagsPolicy) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	adb := &dbv1alpha1.AutonomousDatabase{}
+	if err := p.decoder.Decode(req, adb); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Only the provision operation creates a new database
+	if len(p.RequiredTags) == 0 || adb.Spec.Details.AutonomousDatabaseOCID != nil {
+		return admission.Allowed("")
+	}
+
+	missing := p.missingTags(adb)
+	if len(missing) == 0 {
+		return admission.Allowed("")
+	}
+
+	if p.Policy == CostTagsPolicyReject {
+		return admission.Denied(fmt.Sprintf("the defined tags %s are required in spec.details.definedTags.%s",
+			strings.Join(missing, ", "), p.TagNamespace))
+	}
+
+	p.Log.Info("Inject the missing cost tags", "Namespace", adb.GetNamespace(), "Name", adb.GetName(), "tags", missing)
+
+	if adb.Spec.Details.DefinedTags == nil {
+		adb.Spec.Details.DefinedTags = make(map[string]map[string]string)
+	}
+	if adb.Spec.Details.DefinedTags[p.TagNamespace] == nil {
+		adb.Spec.Details.DefinedTags[p.TagNamespace] = make(map[string]string)
+	}
+	for _, key := range missing {
+		adb.Spec.Details.DefinedTags[p.TagNamespace][key] = p.RequiredTags[key]
+	}
+
+	marshaled, err := json.Marshal(adb)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// missingTags returns the sorted keys of the required tags which are missing or empty in the definedTags of the
+// database
+func (p *AutonomousDatabaseCostTagsPolicy) missingTags(adb *dbv1alpha1.AutonomousDatabase) []string {
+	tags := adb.Spec.Details.DefinedTags[p.TagNamespace]
+
+	var missing []string
+	for key := range p.RequiredTags {
+		if tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
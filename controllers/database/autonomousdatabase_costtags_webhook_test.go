@@ -0,0 +1,159 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("AutonomousDatabase cost tags policy", func() {
+	var (
+		decoder *admission.Decoder
+		adb     *dbv1alpha1.AutonomousDatabase
+	)
+
+	newRequest := func(adb *dbv1alpha1.AutonomousDatabase) admission.Request {
+		raw, err := json.Marshal(adb)
+		Expect(err).ToNot(HaveOccurred())
+
+		return admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+	}
+
+	newPolicy := func(policy string, requiredTags string) *AutonomousDatabaseCostTagsPolicy {
+		p, err := ParseCostTagsPolicy(logr.Discard(), policy, "Finance", requiredTags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.InjectDecoder(decoder)).To(Succeed())
+		return p
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+		var err error
+		decoder, err = admission.NewDecoder(scheme)
+		Expect(err).ToNot(HaveOccurred())
+
+		adb = &dbv1alpha1.AutonomousDatabase{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "database.oracle.com/v1alpha1",
+				Kind:       "AutonomousDatabase",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID: common.String("ocid1.compartment.oc1..fake"),
+					DisplayName:     common.String("adb"),
+					DefinedTags:     map[string]map[string]string{"Finance": {"CostCenter": "42"}},
+				},
+			},
+		}
+	})
+
+	It("should reject a database without the required tags", func() {
+		p := newPolicy("reject", "CostCenter,Project")
+
+		resp := p.Handle(context.TODO(), newRequest(adb))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(string(resp.Result.Reason)).To(Equal("the defined tags Project are required in spec.details.definedTags.Finance"))
+
+		adb.Spec.Details.DefinedTags["Finance"]["Project"] = "apollo"
+		resp = p.Handle(context.TODO(), newRequest(adb))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should inject the missing tags with the default values", func() {
+		p := newPolicy("inject", "CostCenter=unassigned,Project=unassigned")
+
+		resp := p.Handle(context.TODO(), newRequest(adb))
+		Expect(resp.Allowed).To(BeTrue())
+		// The CostCenter tag is kept
+		Expect(resp.Patches).To(HaveLen(1))
+		Expect(resp.Patches[0].Operation).To(Equal("add"))
+		Expect(resp.Patches[0].Path).To(Equal("/spec/details/definedTags/Finance/Project"))
+		Expect(resp.Patches[0].Value).To(Equal("unassigned"))
+	})
+
+	It("should not check the databases to be bound", func() {
+		p := newPolicy("reject", "Project")
+
+		adb.Spec.Details.AutonomousDatabaseOCID = common.String("ocid1.autonomousdatabase.oc1..existing")
+		resp := p.Handle(context.TODO(), newRequest(adb))
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should allow all the requests if no tag is required", func() {
+		p := newPolicy("reject", "")
+
+		adb.Spec.Details.DefinedTags = nil
+		resp := p.Handle(context.TODO(), newRequest(adb))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+
+	It("should reject an invalid policy", func() {
+		_, err := ParseCostTagsPolicy(logr.Discard(), "warn", "Finance", "Project")
+		Expect(err).To(MatchError(ContainSubstring(`unknown cost tags policy "warn"`)))
+
+		_, err = ParseCostTagsPolicy(logr.Discard(), "reject", "", "Project")
+		Expect(err).To(MatchError(ContainSubstring("tag namespace")))
+
+		_, err = ParseCostTagsPolicy(logr.Discard(), "inject", "Finance", "Project")
+		Expect(err).To(MatchError(ContainSubstring("has no default value")))
+	})
+})
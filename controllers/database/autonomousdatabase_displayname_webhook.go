@@ -0,0 +1,170 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+// AutonomousDatabaseDisplayNamePath is the path where the display name validator is served
+const AutonomousDatabaseDisplayNamePath = "/validate-database-oracle-com-v1alpha1-autonomousdatabase-displayname"
+
+//+kubebuilder:webhook:verbs=create,path=/validate-database-oracle-com-v1alpha1-autonomousdatabase-displayname,mutating=false,failurePolicy=ignore,sideEffects=None,groups=database.oracle.com,resources=autonomousdatabases,versions=v1alpha1,name=vautonomousdatabasedisplayname.kb.io,admissionReviewVersions={v1}
+
+// AutonomousDatabaseDisplayNameValidator checks that no database in the compartment has the display name of the
+// database to be provisioned. OCI allows duplicate display names, but the lookups by the display name assume that
+// it's unique. A duplicate is reported as a warning unless RejectDuplicate is set.
+type AutonomousDatabaseDisplayNameValidator struct {
+	KubeClient client.Client
+	Log        logr.Logger
+
+	// RejectDuplicate denies the request instead of returning a warning
+	RejectDuplicate bool
+
+	decoder *admission.Decoder
+	// newDBService builds the dbService from the OCI config of the resource. Only overridden in the tests.
+	newDBService func(adb *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error)
+}
+
+var _ admission.Handler = &AutonomousDatabaseDisplayNameValidator{}
+
+// InjectDecoder implements admission.DecoderInjector
+func (v *AutonomousDatabaseDisplayNameValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler
+func (v *AutonomousDatabaseDisplayNameValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	adb := &dbv1alpha1.AutonomousDatabase{}
+	if err := v.decoder.Decode(req, adb); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Only the provision operation creates a new database. With createIfMissing, a database with the display name
+	// is expected and is bound instead, and with generateNameSuffix, the display name is made unique.
+	if adb.Spec.Details.AutonomousDatabaseOCID != nil ||
+		(adb.Spec.Details.CreateIfMissing != nil && *adb.Spec.Details.CreateIfMissing) ||
+		(adb.Spec.Details.GenerateNameSuffix != nil && *adb.Spec.Details.GenerateNameSuffix) ||
+		adb.Spec.Details.CompartmentOCID == nil ||
+		adb.Spec.Details.DisplayName == nil {
+		return admission.Allowed("")
+	}
+
+	l := v.Log.WithName("validateDisplayName").WithValues("Namespace", adb.GetNamespace(), "Name", adb.GetName())
+
+	duplicates, err := v.listDuplicates(ctx, adb)
+	if err != nil {
+		// The check is best effort; the provision operation doesn't depend on it
+		l.Info("Cannot check the uniqueness of the display name", "error", err.Error())
+		return admission.Allowed("").WithWarnings(
+			fmt.Sprintf("cannot check the uniqueness of the display name %s: %s", *adb.Spec.Details.DisplayName, err.Error()))
+	}
+
+	if len(duplicates) == 0 {
+		return admission.Allowed("")
+	}
+
+	msg := fmt.Sprintf("the display name %s is already used by the Autonomous Database(s) %s in the compartment %s",
+		*adb.Spec.Details.DisplayName, strings.Join(duplicates, ", "), *adb.Spec.Details.CompartmentOCID)
+
+	if v.RejectDuplicate {
+		return admission.Denied(msg)
+	}
+	return admission.Allowed("").WithWarnings(msg)
+}
+
+// listDuplicates returns the OCIDs of the databases which are not terminated and have the same display name
+func (v *AutonomousDatabaseDisplayNameValidator) listDuplicates(ctx context.Context, adb *dbv1alpha1.AutonomousDatabase) ([]string, error) {
+	dbService, err := v.getDBService(adb)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := dbService.ListAutonomousDatabasesByDisplayName(ctx, *adb.Spec.Details.CompartmentOCID, *adb.Spec.Details.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []string
+	for _, summary := range resp.Items {
+		if summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminated ||
+			summary.LifecycleState == database.AutonomousDatabaseSummaryLifecycleStateTerminating {
+			continue
+		}
+		duplicates = append(duplicates, *summary.Id)
+	}
+
+	return duplicates, nil
+}
+
+func (v *AutonomousDatabaseDisplayNameValidator) getDBService(adb *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+	if v.newDBService != nil {
+		return v.newDBService(adb)
+	}
+
+	authData := oci.APIKeyAuth{
+		ConfigMapName: adb.Spec.OCIConfig.ConfigMapName,
+		SecretName:    adb.Spec.OCIConfig.SecretName,
+		Namespace:     adb.GetNamespace(),
+	}
+
+	provider, err := oci.GetOCIProvider(v.KubeClient, authData)
+	if err != nil {
+		return nil, err
+	}
+
+	return oci.NewDatabaseService(v.Log, v.KubeClient, provider, adb.Spec.OCIConfig.EndpointOverride)
+}
@@ -0,0 +1,108 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// The reasons of the OCIRequestFailed condition, which classify the error of the OCI request
+const (
+	ociErrorInvalidRequest = "InvalidRequest"
+	ociErrorNotAuthorized  = "NotAuthorized"
+	ociErrorNotFound       = "NotFound"
+	ociErrorRejected       = "RequestRejected"
+	ociErrorConflict       = "Conflict"
+	ociErrorThrottled      = "Throttled"
+	ociErrorServiceError   = "ServiceError"
+	// The error is not returned by OCI, e.g. the request times out or a Secret cannot be read
+	ociErrorRequestFailed = "RequestFailed"
+)
+
+// classifyOCIError returns whether the request is worth retrying soon, and the reason of the OCIRequestFailed
+// condition. A conflict, e.g. the database is in a transition, the throttling and the server errors are retriable.
+// The other client errors, e.g. an invalid request, a missing permission or a missing database, are not expected to
+// succeed until the spec or the OCI config is fixed. The errors which are not returned by OCI are retriable.
+func classifyOCIError(err error) (retriable bool, condition string) {
+	var serviceErr common.ServiceError
+	if !errors.As(err, &serviceErr) {
+		return true, ociErrorRequestFailed
+	}
+
+	switch code := serviceErr.GetHTTPStatusCode(); {
+	case code == http.StatusConflict:
+		return true, ociErrorConflict
+	case code == http.StatusTooManyRequests:
+		return true, ociErrorThrottled
+	case code >= http.StatusInternalServerError:
+		return true, ociErrorServiceError
+	case code == http.StatusBadRequest:
+		return false, ociErrorInvalidRequest
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return false, ociErrorNotAuthorized
+	case code == http.StatusNotFound:
+		return false, ociErrorNotFound
+	default:
+		return false, ociErrorRejected
+	}
+}
+
+// setOCIRequestFailed sets the OCIRequestFailed condition with the classification of the error, or removes the
+// condition if the err is nil
+func setOCIRequestFailed(adb *dbv1alpha1.AutonomousDatabase, err error) {
+	if err == nil {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionOCIRequestFailed)
+		return
+	}
+
+	_, reason := classifyOCIError(err)
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionOCIRequestFailed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+}
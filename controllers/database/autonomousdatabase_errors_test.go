@@ -0,0 +1,139 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+var _ = Describe("AutonomousDatabase OCI error classification", func() {
+	DescribeTable("should classify the OCI errors",
+		func(err error, retriable bool, reason string) {
+			gotRetriable, gotReason := classifyOCIError(err)
+			Expect(gotRetriable).To(Equal(retriable))
+			Expect(gotReason).To(Equal(reason))
+		},
+		Entry("400", fakeServiceError{statusCode: 400, code: "InvalidParameter"}, false, ociErrorInvalidRequest),
+		Entry("401", fakeServiceError{statusCode: 401, code: "NotAuthenticated"}, false, ociErrorNotAuthorized),
+		Entry("403", fakeServiceError{statusCode: 403, code: "NotAllowed"}, false, ociErrorNotAuthorized),
+		Entry("404", fakeServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound"}, false, ociErrorNotFound),
+		Entry("412", fakeServiceError{statusCode: 412, code: "NoEtagMatch"}, false, ociErrorRejected),
+		Entry("409", fakeServiceError{statusCode: 409, code: "IncorrectState"}, true, ociErrorConflict),
+		Entry("429", fakeServiceError{statusCode: 429, code: "TooManyRequests"}, true, ociErrorThrottled),
+		Entry("500", fakeServiceError{statusCode: 500, code: "InternalServerError"}, true, ociErrorServiceError),
+		Entry("503", fakeServiceError{statusCode: 503, code: "ServiceUnavailable"}, true, ociErrorServiceError),
+		Entry("a wrapped service error", fmt.Errorf("wrapped: %w", fakeServiceError{statusCode: 429}), true, ociErrorThrottled),
+		Entry("an error not returned by OCI", errors.New("fake error"), true, ociErrorRequestFailed),
+	)
+
+	It("should set the condition until the request succeeds", func() {
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		setLastError(adb, fakeServiceError{statusCode: 403, code: "NotAllowed", message: "fake message"})
+
+		cond := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionOCIRequestFailed)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(ociErrorNotAuthorized))
+
+		setLastError(adb, nil)
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionOCIRequestFailed)).To(BeNil())
+	})
+
+	DescribeTable("should requeue the failed update by the classification",
+		func(scaleErr error, requeueAfter time.Duration) {
+			scheme := runtime.NewScheme()
+			Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			adb := &dbv1alpha1.AutonomousDatabase{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "adb",
+					Namespace: "default",
+				},
+				Spec: dbv1alpha1.AutonomousDatabaseSpec{
+					Details: dbv1alpha1.AutonomousDatabaseDetails{
+						AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+						CPUCoreCount:           common.Int(2),
+					},
+				},
+			}
+
+			dbService := &fakeDatabaseService{
+				ociADB: database.AutonomousDatabase{
+					CpuCoreCount:         common.Int(1),
+					DataStorageSizeInTBs: common.Int(1),
+				},
+				getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+				scaleErr:    scaleErr,
+			}
+			reconciler := &AutonomousDatabaseReconciler{
+				KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(adb).Build(),
+				Log:        logr.Discard(),
+				Recorder:   record.NewFakeRecorder(10),
+				newDBService: func(logr.Logger, *dbv1alpha1.AutonomousDatabase) (oci.DatabaseService, error) {
+					return dbService, nil
+				},
+			}
+
+			lookupKey := types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+			result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(requeueAfter))
+		},
+		Entry("a throttled request", fakeServiceError{statusCode: 429, code: "TooManyRequests"}, failedUpdateRequeue),
+		Entry("a missing permission", fakeServiceError{statusCode: 403, code: "NotAllowed"}, fatalErrorRequeue),
+	)
+})
@@ -0,0 +1,131 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// The state label of the AutonomousDatabases which haven't been synced with OCI yet
+const adbUnknownState = "UNKNOWN"
+
+const adbMetricsListTimeout = 5 * time.Second
+
+// AutonomousDatabaseCollector counts the AutonomousDatabases by the lifecycleState, and exports the time left until
+// the stored wallets expire. The values are computed from the objects in the cluster, so no OCI request is sent
+// during a scrape.
+type AutonomousDatabaseCollector struct {
+	kubeClient       client.Client
+	logger           logr.Logger
+	countDesc        *prometheus.Desc
+	walletExpiryDesc *prometheus.Desc
+}
+
+// NewAutonomousDatabaseCollector returns a collector which reads the AutonomousDatabases using the kubeClient.
+// Pass the client from the manager so that the objects are read from the informer cache.
+func NewAutonomousDatabaseCollector(kubeClient client.Client, logger logr.Logger) *AutonomousDatabaseCollector {
+	return &AutonomousDatabaseCollector{
+		kubeClient: kubeClient,
+		logger:     logger.WithName("metrics"),
+		countDesc: prometheus.NewDesc(
+			"adb_count",
+			"Number of AutonomousDatabases by the lifecycleState",
+			[]string{"state"},
+			nil,
+		),
+		walletExpiryDesc: prometheus.NewDesc(
+			"adb_wallet_expiry_seconds",
+			"Seconds until the certificates in the stored wallet of the AutonomousDatabase expire; negative once expired",
+			[]string{"namespace", "name"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *AutonomousDatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.countDesc
+	ch <- c.walletExpiryDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *AutonomousDatabaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.TODO(), adbMetricsListTimeout)
+	defer cancel()
+
+	adbList := &dbv1alpha1.AutonomousDatabaseList{}
+	if err := c.kubeClient.List(ctx, adbList); err != nil {
+		c.logger.Error(err, "Fail to list AutonomousDatabases")
+		ch <- prometheus.NewInvalidMetric(c.countDesc, err)
+		ch <- prometheus.NewInvalidMetric(c.walletExpiryDesc, err)
+		return
+	}
+
+	// Export all the states so that the series don't disappear when the count drops to zero
+	counts := map[string]int{adbUnknownState: 0}
+	for _, state := range database.GetAutonomousDatabaseLifecycleStateEnumValues() {
+		counts[string(state)] = 0
+	}
+
+	for _, adb := range adbList.Items {
+		state := string(adb.Status.LifecycleState)
+		if state == "" {
+			state = adbUnknownState
+		}
+		counts[state]++
+
+		// The wallets which have no certificate, or are managed by the user, are not exported
+		if adb.Status.WalletExpiryTime != nil {
+			ch <- prometheus.MustNewConstMetric(c.walletExpiryDesc, prometheus.GaugeValue,
+				time.Until(adb.Status.WalletExpiryTime.Time).Seconds(), adb.GetNamespace(), adb.GetName())
+		}
+	}
+
+	for state, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.countDesc, prometheus.GaugeValue, float64(count), state)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+var _ = Describe("AutonomousDatabaseCollector", func() {
+	newADB := func(name string, state database.AutonomousDatabaseLifecycleStateEnum) client.Object {
+		return &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     dbv1alpha1.AutonomousDatabaseStatus{LifecycleState: state},
+		}
+	}
+
+	// gatherGauge returns the values of the gauge by the given label
+	gatherGauge := func(kubeClient client.Client, name string, labelName string) map[string]float64 {
+		registry := prometheus.NewPedanticRegistry()
+		Expect(registry.Register(NewAutonomousDatabaseCollector(kubeClient, logr.Discard()))).To(Succeed())
+
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		values := map[string]float64{}
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == labelName {
+						values[label.GetValue()] = metric.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return values
+	}
+
+	// gatherCounts returns the value of adb_count by the state label
+	gatherCounts := func(kubeClient client.Client) map[string]float64 {
+		return gatherGauge(kubeClient, "adb_count", "state")
+	}
+
+	It("should count the cached AutonomousDatabases by the lifecycleState", func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newADB("adb1", database.AutonomousDatabaseLifecycleStateAvailable),
+			newADB("adb2", database.AutonomousDatabaseLifecycleStateAvailable),
+			newADB("adb3", database.AutonomousDatabaseLifecycleStateStopped),
+			newADB("adb4", ""),
+		).Build()
+
+		counts := gatherCounts(kubeClient)
+		Expect(counts).To(HaveKeyWithValue("AVAILABLE", 2.0))
+		Expect(counts).To(HaveKeyWithValue("STOPPED", 1.0))
+		Expect(counts).To(HaveKeyWithValue("UNKNOWN", 1.0))
+		Expect(counts).To(HaveKeyWithValue("TERMINATED", 0.0))
+	})
+
+	It("should export the time left until the stored wallet expires", func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		data, err := oci.UnzipWallet(readTestdata("wallet.zip"))
+		Expect(err).ToNot(HaveOccurred())
+
+		withWallet := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "adb1", Namespace: "default"},
+		}
+		setWalletExpiry(logr.Discard(), withWallet, data)
+
+		kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			withWallet,
+			newADB("adb2", database.AutonomousDatabaseLifecycleStateAvailable),
+		).Build()
+
+		expiries := gatherGauge(kubeClient, "adb_wallet_expiry_seconds", "name")
+		Expect(expiries).To(HaveLen(1))
+		Expect(expiries["adb1"]).To(BeNumerically("~", time.Until(testWalletExpiry).Seconds(), 60))
+	})
+})
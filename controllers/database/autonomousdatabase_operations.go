@@ -0,0 +1,127 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// OCIOperation is a kind of the OCI requests which the AutonomousDatabase controller sends on a database
+type OCIOperation string
+
+const (
+	// OCIOperationCreate provisions a database
+	OCIOperationCreate OCIOperation = "create"
+	// OCIOperationGet reads a database from OCI
+	OCIOperationGet OCIOperation = "get"
+	// OCIOperationUpdate changes the attributes or the lifecycleState of a database, except terminating it
+	OCIOperationUpdate OCIOperation = "update"
+	// OCIOperationTerminate terminates a database
+	OCIOperationTerminate OCIOperation = "terminate"
+)
+
+var ociOperations = []OCIOperation{OCIOperationCreate, OCIOperationGet, OCIOperationUpdate, OCIOperationTerminate}
+
+// ParseOCIOperations parses a comma-separated list of the OCI operations, e.g. "create,update,get".
+// An empty list returns nil, which allows all the operations.
+func ParseOCIOperations(list string) (map[OCIOperation]bool, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	allowed := make(map[OCIOperation]bool)
+	for _, name := range strings.Split(list, ",") {
+		op := OCIOperation(strings.ToLower(strings.TrimSpace(name)))
+		if !isKnownOCIOperation(op) {
+			return nil, fmt.Errorf("unknown OCI operation %q; the valid operations are %s", name, strings.Join(ociOperationNames(), ", "))
+		}
+		allowed[op] = true
+	}
+	return allowed, nil
+}
+
+func isKnownOCIOperation(op OCIOperation) bool {
+	for _, known := range ociOperations {
+		if op == known {
+			return true
+		}
+	}
+	return false
+}
+
+func ociOperationNames() []string {
+	names := make([]string, len(ociOperations))
+	for i, op := range ociOperations {
+		names[i] = string(op)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isOperationAllowed returns true if the AllowedOperations is not set or contains the operation
+func (r *AutonomousDatabaseReconciler) isOperationAllowed(op OCIOperation) bool {
+	return r.AllowedOperations == nil || r.AllowedOperations[op]
+}
+
+// denyOperation sets the OperationDenied condition and emits a warning event instead of sending the request of a
+// disallowed operation. The status is updated since the reconcile exits without sending any request.
+func (r *AutonomousDatabaseReconciler) denyOperation(ctx context.Context, logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase, op OCIOperation) error {
+	msg := fmt.Sprintf("The %s operation is not in the operations allowed by --allowed-operations; no request is sent to OCI", op)
+	logger.Info(msg)
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "OperationDenied", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionOperationDenied,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             strings.ToUpper(string(op[:1])) + string(op[1:]) + "NotAllowed",
+		Message:            msg,
+	})
+	return r.updateStatus(ctx, adb)
+}
@@ -0,0 +1,203 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("ParseOCIOperations", func() {
+	It("should allow all the operations with an empty list", func() {
+		allowed, err := ParseOCIOperations("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeNil())
+	})
+
+	It("should parse the operations regardless of the spaces and the case", func() {
+		allowed, err := ParseOCIOperations("create, Update ,get")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(Equal(map[OCIOperation]bool{
+			OCIOperationCreate: true,
+			OCIOperationUpdate: true,
+			OCIOperationGet:    true,
+		}))
+	})
+
+	It("should reject an unknown operation", func() {
+		_, err := ParseOCIOperations("create,delete")
+		Expect(err).To(MatchError(ContainSubstring(`unknown OCI operation "delete"`)))
+	})
+})
+
+var _ = Describe("AutonomousDatabase allowed operations", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		recorder   *record.FakeRecorder
+		adb        *dbv1alpha1.AutonomousDatabase
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "adb",
+				Namespace:  "default",
+				Finalizers: []string{dbv1alpha1.ADBFinalizer},
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					AutonomousDatabaseOCID: common.String("ocid1.autonomousdatabase.oc1..fake"),
+					CPUCoreCount:           common.Int(1),
+				},
+			},
+			Status: dbv1alpha1.AutonomousDatabaseStatus{
+				LifecycleState: database.AutonomousDatabaseLifecycleStateAvailable,
+			},
+		}
+		lookupKey = types.NamespacedName{Name: adb.Name, Namespace: adb.Namespace}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				CpuCoreCount:         common.Int(1),
+				DataStorageSizeInTBs: common.Int(1),
+			},
+			getADBState: database.AutonomousDatabaseLifecycleStateAvailable,
+		}
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &AutonomousDatabaseReconciler{
+			KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(adb).Build(),
+			Log:        logr.Discard(),
+			Recorder:   recorder,
+			// Provision and bind, but never terminate
+			AllowedOperations: map[OCIOperation]bool{
+				OCIOperationCreate: true,
+				OCIOperationGet:    true,
+				OCIOperationUpdate: true,
+			},
+			dbService: dbService,
+		}
+	})
+
+	It("should only remove the finalizer on delete if terminate is not allowed", func() {
+		Expect(reconciler.KubeClient.Delete(context.TODO(), adb)).To(Succeed())
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, adb)).To(Succeed())
+
+		exitReconcile, result, err := reconciler.validateCleanup(context.TODO(), reconciler.Log, adb)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exitReconcile).To(BeTrue())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.deleteCalls).To(BeZero())
+		Expect(adb.GetFinalizers()).ToNot(ContainElement(dbv1alpha1.ADBFinalizer))
+		Expect(recorder.Events).To(Receive(ContainSubstring("OperationDenied")))
+	})
+
+	It("should not terminate the database by the lifecycleState if terminate is not allowed", func() {
+		adb.Spec.Details.LifecycleState = database.AutonomousDatabaseLifecycleStateTerminated
+
+		modifiedADB := adb.DeepCopy()
+		exit, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(dbService.deleteCalls).To(BeZero())
+
+		clusterADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, clusterADB)).To(Succeed())
+		condition := meta.FindStatusCondition(clusterADB.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal("TerminateNotAllowed"))
+	})
+
+	It("should not scale the database if update is not allowed, and clear the condition once it's allowed", func() {
+		delete(reconciler.AllowedOperations, OCIOperationUpdate)
+		adb.Spec.Details.CPUCoreCount = common.Int(2)
+
+		modifiedADB := adb.DeepCopy()
+		exit, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(dbService.scaleCalls).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)).To(BeTrue())
+
+		reconciler.AllowedOperations = nil
+		exit, _, err = reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeFalse())
+		Expect(dbService.scaleCalls).To(Equal(1))
+		Expect(meta.FindStatusCondition(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)).To(BeNil())
+	})
+
+	It("should not provision the database if create is not allowed", func() {
+		delete(reconciler.AllowedOperations, OCIOperationCreate)
+		adb.Spec.Details.AutonomousDatabaseOCID = nil
+
+		modifiedADB := adb.DeepCopy()
+		exit, result, err := reconciler.validateOperation(context.TODO(), reconciler.Log, modifiedADB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(result).To(Equal(emptyResult))
+		Expect(dbService.createdADB).To(BeNil())
+		Expect(meta.IsStatusConditionTrue(modifiedADB.Status.Conditions, dbv1alpha1.ADBConditionOperationDenied)).To(BeTrue())
+	})
+
+	It("should not get the database if get is not allowed", func() {
+		delete(reconciler.AllowedOperations, OCIOperationGet)
+
+		exit, _, err := reconciler.validateOperation(context.TODO(), reconciler.Log, adb.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exit).To(BeTrue())
+		Expect(dbService.getADBCalls).To(BeZero())
+	})
+})
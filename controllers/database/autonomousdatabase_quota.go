@@ -0,0 +1,92 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+// validateQuota returns an error if the compartment doesn't have the quota to provision the database, instead of
+// sending the request which OCI rejects. The QuotaExceeded condition is set with the exhausted limit, and the
+// provisioning is retried with the backoff of the failed reconciles until the quota is available. The check is only
+// done if QuotaPrecheck is enabled, since it requires the permission to read the resource availability.
+func (r *AutonomousDatabaseReconciler) validateQuota(
+	ctx context.Context,
+	logger logr.Logger,
+	adb *dbv1alpha1.AutonomousDatabase,
+	createADB *dbv1alpha1.AutonomousDatabase) error {
+
+	limitName, required := oci.GetADBLimit(createADB)
+	if !r.QuotaPrecheck || limitName == "" || createADB.Spec.Details.CompartmentOCID == nil {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionQuotaExceeded)
+		return nil
+	}
+
+	available, err := r.limService.GetDatabaseAvailability(ctx, *createADB.Spec.Details.CompartmentOCID, limitName)
+	if err != nil {
+		return err
+	}
+
+	if available >= required {
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ADBConditionQuotaExceeded)
+		return nil
+	}
+
+	msg := fmt.Sprintf("The compartment %s has %g of the limit %s available, but the database requires %g",
+		*createADB.Spec.Details.CompartmentOCID, available, limitName, required)
+	logger.WithName("validateQuota").Info(msg)
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "QuotaExceeded", msg)
+
+	meta.SetStatusCondition(&adb.Status.Conditions, metav1.Condition{
+		Type:               dbv1alpha1.ADBConditionQuotaExceeded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             "InsufficientQuota",
+		Message:            msg,
+	})
+	return fmt.Errorf("the compartment doesn't have the quota of the limit %s to provision the database", limitName)
+}
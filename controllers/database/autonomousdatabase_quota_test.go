@@ -0,0 +1,129 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+// fakeLimitsService returns the same availability for all the limits
+type fakeLimitsService struct {
+	oci.LimitsService
+
+	available float32
+	limitName string
+}
+
+func (s *fakeLimitsService) GetDatabaseAvailability(ctx context.Context, compartmentOCID string, limitName string) (float32, error) {
+	s.limitName = limitName
+	return s.available, nil
+}
+
+var _ = Describe("AutonomousDatabase quota pre-check", func() {
+	var (
+		reconciler *AutonomousDatabaseReconciler
+		dbService  *fakeDatabaseService
+		limService *fakeLimitsService
+		adb        *dbv1alpha1.AutonomousDatabase
+	)
+
+	BeforeEach(func() {
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "adb",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details: dbv1alpha1.AutonomousDatabaseDetails{
+					CompartmentOCID: common.String("ocid1.compartment.oc1..fake"),
+					CPUCoreCount:    common.Int(2),
+				},
+			},
+		}
+
+		dbService = &fakeDatabaseService{}
+		limService = &fakeLimitsService{}
+		reconciler = &AutonomousDatabaseReconciler{
+			Recorder:      record.NewFakeRecorder(10),
+			QuotaPrecheck: true,
+			dbService:     dbService,
+			limService:    limService,
+		}
+	})
+
+	It("should fail before the create request if the quota is exhausted", func() {
+		limService.available = 0
+
+		err := reconciler.createADB(context.TODO(), logr.Discard(), adb)
+		Expect(err).To(MatchError(ContainSubstring("doesn't have the quota of the limit atp-ocpu-count")))
+		Expect(dbService.createdADB).To(BeNil())
+
+		cond := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionQuotaExceeded)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Message).To(ContainSubstring("requires 2"))
+	})
+
+	It("should create the database once the quota is available", func() {
+		limService.available = 1
+		Expect(reconciler.createADB(context.TODO(), logr.Discard(), adb)).ToNot(Succeed())
+
+		limService.available = 2
+		Expect(reconciler.createADB(context.TODO(), logr.Discard(), adb)).To(Succeed())
+		Expect(dbService.createdADB).ToNot(BeNil())
+		Expect(meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionQuotaExceeded)).To(BeNil())
+	})
+
+	It("should not check the quota unless the pre-check is enabled", func() {
+		reconciler.QuotaPrecheck = false
+
+		Expect(reconciler.createADB(context.TODO(), logr.Discard(), adb)).To(Succeed())
+		Expect(limService.limitName).To(BeEmpty())
+		Expect(dbService.createdADB).ToNot(BeNil())
+	})
+})
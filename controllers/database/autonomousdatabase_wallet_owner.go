@@ -0,0 +1,111 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// ParseWalletNamespaces parses a comma-separated list of the namespaces where the wallet Secrets of the resources in
+// the other namespaces may be created. An empty list returns nil, which only allows the namespace of the resource.
+func ParseWalletNamespaces(list string) map[string]bool {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, namespace := range strings.Split(list, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			allowed[namespace] = true
+		}
+	}
+	return allowed
+}
+
+// validateWalletNamespace returns an error if the wallet Secret is in another namespace which is not in the
+// WalletNamespaces, so that the author of a resource cannot write the Secrets of the other tenants.
+func (r *AutonomousDatabaseReconciler) validateWalletNamespace(adb *dbv1alpha1.AutonomousDatabase, walletNamespace string) error {
+	if walletNamespace == adb.GetNamespace() || r.WalletNamespaces[walletNamespace] {
+		return nil
+	}
+
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletNamespaceNotAllowed",
+		fmt.Sprintf("The wallet cannot be stored in the namespace %s; the namespace has to be allowed by --adb-wallet-namespaces", walletNamespace))
+	return fmt.Errorf("the wallet cannot be stored in the namespace %s", walletNamespace)
+}
+
+// walletOwner returns the value of the WalletOwnerAnnotation of the resource
+func walletOwner(adb *dbv1alpha1.AutonomousDatabase) string {
+	return adb.GetNamespace() + "/" + adb.GetName()
+}
+
+// isWalletOwner returns true if the Secret is written by the resource. The Secrets in the namespace of the resource
+// are controlled by the resource, except the ones created by the earlier versions, which only have the app label. An
+// owner reference cannot point to another namespace, so the Secrets there are annotated with the owner instead.
+func isWalletOwner(adb *dbv1alpha1.AutonomousDatabase, secret *corev1.Secret) bool {
+	if ref := metav1.GetControllerOf(secret); ref != nil {
+		return ref.UID == adb.GetUID() && ref.Name == adb.GetName()
+	}
+
+	if secret.GetNamespace() != adb.GetNamespace() {
+		return secret.GetAnnotations()[dbv1alpha1.WalletOwnerAnnotation] == walletOwner(adb)
+	}
+
+	return secret.GetLabels()["app"] == adb.GetName()
+}
+
+// walletAnnotations returns the annotations of a new wallet Secret in the namespace
+func walletAnnotations(adb *dbv1alpha1.AutonomousDatabase, namespace string) map[string]string {
+	if namespace == adb.GetNamespace() {
+		return nil
+	}
+	return map[string]string{dbv1alpha1.WalletOwnerAnnotation: walletOwner(adb)}
+}
+
+// refuseWallet reports that the existing Secret belongs to something else, so it's left as is
+func (r *AutonomousDatabaseReconciler) refuseWallet(adb *dbv1alpha1.AutonomousDatabase, secret *corev1.Secret) {
+	r.Recorder.Event(adb, corev1.EventTypeWarning, "WalletNotOwned",
+		fmt.Sprintf("The Secret %s/%s exists but is not owned by the resource; it's left as is", secret.GetNamespace(), secret.GetName()))
+}
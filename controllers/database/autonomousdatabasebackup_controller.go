@@ -44,6 +44,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v64/database"
 	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +57,7 @@ import (
 
 	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	"github.com/oracle/oracle-database-operator/commons/adb_family"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
 	"github.com/oracle/oracle-database-operator/commons/oci"
 )
 
@@ -66,7 +68,13 @@ type AutonomousDatabaseBackupReconciler struct {
 	Scheme     *runtime.Scheme
 	Recorder   record.EventRecorder
 
+	// MaxConcurrentReconciles is the max number of the resources reconciled at the same time. Zero uses the
+	// DefaultADBBackupMaxConcurrentReconciles.
+	MaxConcurrentReconciles int
+
 	dbService oci.DatabaseService
+	// newDBService builds the dbService from the OCI config of the resource. Only overridden in the tests.
+	newDBService func(backup *dbv1alpha1.AutonomousDatabaseBackup) (oci.DatabaseService, error)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -74,11 +82,16 @@ func (r *AutonomousDatabaseBackupReconciler) SetupWithManager(mgr ctrl.Manager)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabaseBackup{}).
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 100}). // ReconcileHandler is never invoked concurrently with the same object.
+		WithOptions(r.controllerOptions()). // ReconcileHandler is never invoked concurrently with the same object.
 		Complete(r)
 }
 
-//+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabasebackups,verbs=get;list;watch;create;delete
+// controllerOptions returns the options of the controller
+func (r *AutonomousDatabaseBackupReconciler) controllerOptions() controller.Options {
+	return concurrencyOptions(r.MaxConcurrentReconciles, DefaultADBBackupMaxConcurrentReconciles)
+}
+
+//+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabasebackups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabasebackups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabases,verbs=get;list
 
@@ -96,6 +109,13 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 		return emptyResult, err
 	}
 
+	/******************************************************************
+	* Delete the long-term backup in OCI before the resource is deleted
+	******************************************************************/
+	if !backup.GetDeletionTimestamp().IsZero() {
+		return r.deleteBackup(ctx, logger, backup)
+	}
+
 	/******************************************************************
 	* Look up the owner AutonomousDatabase and set the ownerReference
 	* if the owner hasn't been set yet.
@@ -118,12 +138,12 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 	* Get status from OCI AutonomousDatabaseBackup
 	******************************************************************/
 	if backup.Spec.AutonomousDatabaseBackupOCID != nil {
-		backupResp, err := r.dbService.GetAutonomousDatabaseBackup(*backup.Spec.AutonomousDatabaseBackupOCID)
+		backupResp, err := r.dbService.GetAutonomousDatabaseBackup(ctx, *backup.Spec.AutonomousDatabaseBackupOCID)
 		if err != nil {
 			return r.manageError(backup, err)
 		}
 
-		adbResp, err := r.dbService.GetAutonomousDatabase(*backupResp.AutonomousDatabaseId)
+		adbResp, err := r.dbService.GetAutonomousDatabase(ctx, *backupResp.AutonomousDatabaseId)
 		if err != nil {
 			return r.manageError(backup, err)
 		}
@@ -148,13 +168,15 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 	if backup.Spec.AutonomousDatabaseBackupOCID == nil {
 		// Create a new backup
 		logger.Info("Sending CreateAutonomousDatabaseBackup request to OCI")
-		backupResp, err := r.dbService.CreateAutonomousDatabaseBackup(backup, adbOCID)
+		unlock := adbMutations.Lock(adbOCID)
+		backupResp, err := r.dbService.CreateAutonomousDatabaseBackup(ctx, backup, adbOCID)
+		unlock()
 		if err != nil {
 			return r.manageError(backup, err)
 		}
 
 		// After the creation, update the status first
-		adbResp, err := r.dbService.GetAutonomousDatabase(*backupResp.AutonomousDatabaseId)
+		adbResp, err := r.dbService.GetAutonomousDatabase(ctx, *backupResp.AutonomousDatabaseId)
 		if err != nil {
 			return r.manageError(backup, err)
 		}
@@ -164,9 +186,12 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 			return r.manageError(backup, err)
 		}
 
-		// Then update the OCID
+		// Then update the OCID. A long-term backup is deleted in OCI with the resource.
 		backup.Spec.AutonomousDatabaseBackupOCID = backupResp.Id
 		backup.UpdateStatusFromOCIBackup(backupResp.AutonomousDatabaseBackup, adbResp.AutonomousDatabase)
+		if backup.IsLongTermBackup() {
+			controllerutil.AddFinalizer(backup, dbv1alpha1.ADBBackupFinalizer)
+		}
 
 		if err := r.KubeClient.Update(context.TODO(), backup); err != nil {
 			// Do no requeue otherwise it will create multiple backups
@@ -192,11 +217,140 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 		return requeueResult, nil
 	}
 
+	// A bound long-term backup is deleted in OCI with the resource as well
+	if backup.IsLongTermBackup() && !controllerutil.ContainsFinalizer(backup, dbv1alpha1.ADBBackupFinalizer) {
+		if err := k8s.AddFinalizerAndPatch(r.KubeClient, backup, dbv1alpha1.ADBBackupFinalizer); err != nil {
+			return r.manageError(backup, err)
+		}
+	}
+
+	/******************************************************************
+	*	Copy the backup to another region once it's ACTIVE
+	******************************************************************/
+	if result, err := r.copyBackup(ctx, logger, backup); err != nil || result.Requeue {
+		return result, err
+	}
+
 	logger.Info("AutonomousDatabaseBackup reconciles successfully")
 
 	return emptyResult, nil
 }
 
+// deleteBackup deletes the long-term backup in OCI and then removes the finalizer, so that the resource is deleted.
+// The automatic backups never have the finalizer and are only removed from the cluster. The backup is kept in OCI if
+// the resource is deleted together with its AutonomousDatabase resource, since the long-term backups are meant to
+// outlive the database.
+func (r *AutonomousDatabaseBackupReconciler) deleteBackup(ctx context.Context, logger logr.Logger, backup *dbv1alpha1.AutonomousDatabaseBackup) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(backup, dbv1alpha1.ADBBackupFinalizer) {
+		return emptyResult, nil
+	}
+
+	l := logger.WithName("deleteBackup")
+
+	if backup.Spec.AutonomousDatabaseBackupOCID != nil && backup.IsLongTermBackup() &&
+		backup.Status.LifecycleState != database.AutonomousDatabaseBackupLifecycleStateDeleting &&
+		backup.Status.LifecycleState != database.AutonomousDatabaseBackupLifecycleStateDeleted {
+
+		ownerDeleted, err := r.isOwnerADBDeleted(backup)
+		if err != nil {
+			return r.manageError(backup, err)
+		}
+
+		if ownerDeleted {
+			l.Info("The AutonomousDatabase is deleted; keep the long-term backup in OCI")
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupKept",
+				"The AutonomousDatabase is deleted; the long-term backup "+*backup.Spec.AutonomousDatabaseBackupOCID+" is kept in OCI")
+		} else {
+			if err := r.setupOCIClients(backup); err != nil {
+				return r.manageError(backup, err)
+			}
+
+			l.Info("Sending DeleteAutonomousDatabaseBackup request to OCI")
+			if err := r.dbService.DeleteAutonomousDatabaseBackup(ctx, *backup.Spec.AutonomousDatabaseBackupOCID); err != nil {
+				if _, reason := classifyOCIError(err); reason != ociErrorNotFound {
+					return r.manageError(backup, err)
+				}
+				l.Info("The backup is not found in OCI")
+			}
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "BackupDeleted",
+				"The long-term backup "+*backup.Spec.AutonomousDatabaseBackupOCID+" is deleted in OCI")
+		}
+	}
+
+	if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, backup, dbv1alpha1.ADBBackupFinalizer); err != nil {
+		return r.manageError(backup, err)
+	}
+
+	return emptyResult, nil
+}
+
+// isOwnerADBDeleted returns true if the owner AutonomousDatabase resource of the backup is not found or is being
+// deleted. It returns false if the backup has no owner.
+func (r *AutonomousDatabaseBackupReconciler) isOwnerADBDeleted(backup *dbv1alpha1.AutonomousDatabaseBackup) (bool, error) {
+	for _, owner := range backup.GetOwnerReferences() {
+		if owner.Kind != "AutonomousDatabase" {
+			continue
+		}
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		if err := k8s.FetchResource(r.KubeClient, backup.GetNamespace(), owner.Name, adb); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return !adb.GetDeletionTimestamp().IsZero(), nil
+	}
+
+	return false, nil
+}
+
+// copyBackup sends the cross-region copy request if the backup is ACTIVE and the copy hasn't been created yet.
+// Otherwise, the status of the copy is updated from the destination region. The function requeues the request
+// while the copy is in an intermediate state.
+func (r *AutonomousDatabaseBackupReconciler) copyBackup(ctx context.Context, logger logr.Logger, backup *dbv1alpha1.AutonomousDatabaseBackup) (ctrl.Result, error) {
+	if backup.Spec.CrossRegionCopy.Region == nil ||
+		backup.Status.LifecycleState != database.AutonomousDatabaseBackupLifecycleStateActive {
+		return emptyResult, nil
+	}
+
+	l := logger.WithName("copyBackup")
+	region := *backup.Spec.CrossRegionCopy.Region
+
+	if backup.Status.CrossRegionCopy.AutonomousDatabaseBackupOCID == "" {
+		l.Info("Sending CopyAutonomousDatabaseBackup request to OCI", "region", region)
+
+		copyResp, err := r.dbService.CopyAutonomousDatabaseBackup(ctx, backup)
+		if err != nil {
+			return r.manageError(backup, err)
+		}
+
+		backup.UpdateCopyStatusFromOCIBackup(region, copyResp.AutonomousDatabaseBackup)
+	} else {
+		copyResp, err := r.dbService.GetAutonomousDatabaseBackupInRegion(ctx, backup.Status.CrossRegionCopy.AutonomousDatabaseBackupOCID, region)
+		if err != nil {
+			return r.manageError(backup, err)
+		}
+
+		backup.UpdateCopyStatusFromOCIBackup(region, copyResp.AutonomousDatabaseBackup)
+	}
+
+	if err := r.KubeClient.Status().Update(context.TODO(), backup); err != nil {
+		return r.manageError(backup, err)
+	}
+
+	if dbv1alpha1.IsBackupIntermediateState(backup.Status.CrossRegionCopy.LifecycleState) {
+		l.Info("The copy of the backup is " + string(backup.Status.CrossRegionCopy.LifecycleState) + "; reconcile queued")
+		return requeueResult, nil
+	}
+
+	if backup.Status.CrossRegionCopy.LifecycleState == database.AutonomousDatabaseBackupLifecycleStateFailed {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "CopyFailed", "The copy of the backup in "+region+" failed")
+	}
+
+	return emptyResult, nil
+}
+
 // setOwnerAutonomousDatabase sets the owner of the AutonomousDatabaseBackup if the AutonomousDatabase resource with the same database OCID is found
 func (r *AutonomousDatabaseBackupReconciler) setOwnerAutonomousDatabase(backup *dbv1alpha1.AutonomousDatabaseBackup, adb *dbv1alpha1.AutonomousDatabase) error {
 	logger := r.Log.WithName("set-owner-reference")
@@ -230,8 +384,8 @@ func (r *AutonomousDatabaseBackupReconciler) verifyTargetADB(backup *dbv1alpha1.
 	if backup.Spec.Target.OCIADB.OCID != nil {
 		return *backup.Spec.Target.OCIADB.OCID, nil
 	}
-	if ownerADB != nil && ownerADB.Spec.Details.AutonomousDatabaseOCID != nil {
-		return *ownerADB.Spec.Details.AutonomousDatabaseOCID, nil
+	if ownerADB != nil && ownerADB.GetAutonomousDatabaseOCID() != nil {
+		return *ownerADB.GetAutonomousDatabaseOCID(), nil
 	}
 
 	return "", errors.New("cannot get the OCID of the targetADB")
@@ -240,6 +394,11 @@ func (r *AutonomousDatabaseBackupReconciler) verifyTargetADB(backup *dbv1alpha1.
 func (r *AutonomousDatabaseBackupReconciler) setupOCIClients(backup *dbv1alpha1.AutonomousDatabaseBackup) error {
 	var err error
 
+	if r.newDBService != nil {
+		r.dbService, err = r.newDBService(backup)
+		return err
+	}
+
 	authData := oci.APIKeyAuth{
 		ConfigMapName: backup.Spec.OCIConfig.ConfigMapName,
 		SecretName:    backup.Spec.OCIConfig.SecretName,
@@ -251,7 +410,7 @@ func (r *AutonomousDatabaseBackupReconciler) setupOCIClients(backup *dbv1alpha1.
 		return err
 	}
 
-	r.dbService, err = oci.NewDatabaseService(r.Log, r.KubeClient, provider)
+	r.dbService, err = oci.NewDatabaseService(r.Log, r.KubeClient, provider, backup.Spec.OCIConfig.EndpointOverride)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,181 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+var _ = Describe("AutonomousDatabaseBackup deletion", func() {
+	const backupOCID = "ocid1.autonomousdatabasebackup.oc1..fake"
+
+	var (
+		reconciler *AutonomousDatabaseBackupReconciler
+		dbService  *fakeDatabaseService
+		backup     *dbv1alpha1.AutonomousDatabaseBackup
+		lookupKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		backup = &dbv1alpha1.AutonomousDatabaseBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup",
+				Namespace: "default",
+			},
+			Spec: dbv1alpha1.AutonomousDatabaseBackupSpec{
+				Target: dbv1alpha1.TargetSpec{
+					OCIADB: dbv1alpha1.OCIADBSpec{OCID: common.String("ocid1.autonomousdatabase.oc1..fake")},
+				},
+				AutonomousDatabaseBackupOCID: common.String(backupOCID),
+			},
+		}
+		lookupKey = types.NamespacedName{Name: backup.Name, Namespace: backup.Namespace}
+
+		dbService = &fakeDatabaseService{
+			ociADB: database.AutonomousDatabase{
+				DisplayName: common.String("adb"),
+				DbName:      common.String("adb"),
+			},
+			ociBackup: database.AutonomousDatabaseBackup{
+				AutonomousDatabaseId: common.String("ocid1.autonomousdatabase.oc1..fake"),
+				CompartmentId:        common.String("ocid1.compartment.oc1..fake"),
+				IsAutomatic:          common.Bool(false),
+				LifecycleState:       database.AutonomousDatabaseBackupLifecycleStateActive,
+			},
+		}
+	})
+
+	// newReconciler builds the reconciler with the given resources in the cluster
+	newReconciler := func(objs ...runtime.Object) {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		reconciler = &AutonomousDatabaseBackupReconciler{
+			KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+			Log:        logr.Discard(),
+			Scheme:     scheme,
+			Recorder:   record.NewFakeRecorder(10),
+			newDBService: func(*dbv1alpha1.AutonomousDatabaseBackup) (oci.DatabaseService, error) {
+				return dbService, nil
+			},
+		}
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	// deleteBackup deletes the resource and reconciles the deletion
+	deleteBackup := func() {
+		current := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, current)).To(Succeed())
+		Expect(reconciler.KubeClient.Delete(context.TODO(), current)).To(Succeed())
+		reconcile()
+	}
+
+	isBackupRemoved := func() bool {
+		err := reconciler.KubeClient.Get(context.TODO(), lookupKey, &dbv1alpha1.AutonomousDatabaseBackup{})
+		return apiErrors.IsNotFound(err)
+	}
+
+	It("should delete the long-term backup in OCI with the resource", func() {
+		dbService.ociBackup.Type = "LONGTERM"
+		newReconciler(backup)
+
+		reconcile()
+		current := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, current)).To(Succeed())
+		Expect(controllerutil.ContainsFinalizer(current, dbv1alpha1.ADBBackupFinalizer)).To(BeTrue())
+
+		deleteBackup()
+		Expect(dbService.deletedBackups).To(Equal([]string{backupOCID}))
+		Expect(isBackupRemoved()).To(BeTrue())
+	})
+
+	It("should only remove an automatic backup from the cluster", func() {
+		dbService.ociBackup.Type = database.AutonomousDatabaseBackupTypeIncremental
+		dbService.ociBackup.IsAutomatic = common.Bool(true)
+		newReconciler(backup)
+
+		reconcile()
+		current := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, current)).To(Succeed())
+		Expect(current.GetFinalizers()).To(BeEmpty())
+
+		Expect(reconciler.KubeClient.Delete(context.TODO(), current)).To(Succeed())
+		Expect(isBackupRemoved()).To(BeTrue())
+		Expect(dbService.deletedBackups).To(BeEmpty())
+	})
+
+	It("should keep the long-term backup in OCI if its AutonomousDatabase is deleted", func() {
+		backup.SetFinalizers([]string{dbv1alpha1.ADBBackupFinalizer})
+		backup.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "database.oracle.com/v1alpha1",
+			Kind:       "AutonomousDatabase",
+			Name:       "adb",
+			UID:        "adb-uid",
+		}})
+		backup.Status.Type = "LONGTERM"
+		backup.Status.LifecycleState = database.AutonomousDatabaseBackupLifecycleStateActive
+		newReconciler(backup)
+
+		deleteBackup()
+		Expect(dbService.deletedBackups).To(BeEmpty())
+		Expect(isBackupRemoved()).To(BeTrue())
+		Expect(reconciler.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("BackupKept")))
+	})
+})
@@ -50,6 +50,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -67,6 +68,10 @@ type AutonomousDatabaseRestoreReconciler struct {
 	Scheme     *runtime.Scheme
 	Recorder   record.EventRecorder
 
+	// MaxConcurrentReconciles is the max number of the resources reconciled at the same time. Zero uses the
+	// DefaultADBRestoreMaxConcurrentReconciles.
+	MaxConcurrentReconciles int
+
 	dbService   oci.DatabaseService
 	workService oci.WorkRequestService
 }
@@ -76,9 +81,15 @@ func (r *AutonomousDatabaseRestoreReconciler) SetupWithManager(mgr ctrl.Manager)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabaseRestore{}).
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(r.controllerOptions()).
 		Complete(r)
 }
 
+// controllerOptions returns the options of the controller
+func (r *AutonomousDatabaseRestoreReconciler) controllerOptions() controller.Options {
+	return concurrencyOptions(r.MaxConcurrentReconciles, DefaultADBRestoreMaxConcurrentReconciles)
+}
+
 //+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabaserestores,verbs=get;list;watch;create;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabaserestores/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabases,verbs=get;list
@@ -136,13 +147,15 @@ func (r *AutonomousDatabaseRestoreReconciler) Reconcile(ctx context.Context, req
 		}
 
 		logger.Info("Sending RestoreAutonomousDatabase request to OCI")
-		adbResp, err := r.dbService.RestoreAutonomousDatabase(adbOCID, *restoreTime)
+		unlock := adbMutations.Lock(adbOCID)
+		adbResp, err := r.dbService.RestoreAutonomousDatabase(ctx, adbOCID, *restoreTime)
+		unlock()
 		if err != nil {
 			return r.manageError(restore, err)
 		}
 
 		// Update the restore status
-		workResp, err := r.workService.Get(*adbResp.OpcWorkRequestId)
+		workResp, err := r.workService.Get(ctx, *adbResp.OpcWorkRequestId)
 		if err != nil {
 			return r.manageError(restore, err)
 		}
@@ -155,12 +168,12 @@ func (r *AutonomousDatabaseRestoreReconciler) Reconcile(ctx context.Context, req
 	} else {
 		// Update the status
 		logger.Info("Update the status of the restore session")
-		adbResp, err := r.dbService.GetAutonomousDatabase(adbOCID)
+		adbResp, err := r.dbService.GetAutonomousDatabase(ctx, adbOCID)
 		if err != nil {
 			return r.manageError(restore, err)
 		}
 
-		workResp, err := r.workService.Get(restore.Status.WorkRequestOCID)
+		workResp, err := r.workService.Get(ctx, restore.Status.WorkRequestOCID)
 		if err != nil {
 			return r.manageError(restore, err)
 		}
@@ -239,8 +252,8 @@ func (r *AutonomousDatabaseRestoreReconciler) verifyTargetADB(restore *dbv1alpha
 	if restore.Spec.Target.OCIADB.OCID != nil {
 		return *restore.Spec.Target.OCIADB.OCID, nil
 	}
-	if ownerADB != nil && ownerADB.Spec.Details.AutonomousDatabaseOCID != nil {
-		return *ownerADB.Spec.Details.AutonomousDatabaseOCID, nil
+	if ownerADB != nil && ownerADB.GetAutonomousDatabaseOCID() != nil {
+		return *ownerADB.GetAutonomousDatabaseOCID(), nil
 	}
 
 	return "", errors.New("cannot get the OCID of the targetADB")
@@ -260,7 +273,7 @@ func (r *AutonomousDatabaseRestoreReconciler) setupOCIClients(restore *dbv1alpha
 		return err
 	}
 
-	r.dbService, err = oci.NewDatabaseService(r.Log, r.KubeClient, provider)
+	r.dbService, err = oci.NewDatabaseService(r.Log, r.KubeClient, provider, restore.Spec.OCIConfig.EndpointOverride)
 	if err != nil {
 		return err
 	}
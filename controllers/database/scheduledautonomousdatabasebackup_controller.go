@@ -0,0 +1,272 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/cron"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
+)
+
+// scheduledBackupLabel is the label of the AutonomousDatabaseBackups created by a ScheduledAutonomousDatabaseBackup.
+// The value is the name of the ScheduledAutonomousDatabaseBackup.
+const scheduledBackupLabel = "database.oracle.com/scheduled-backup"
+
+// ScheduledAutonomousDatabaseBackupReconciler reconciles a ScheduledAutonomousDatabaseBackup object
+type ScheduledAutonomousDatabaseBackupReconciler struct {
+	KubeClient client.Client
+	Log        logr.Logger
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+
+	// MaxConcurrentReconciles is the max number of the resources reconciled at the same time. Zero uses the
+	// DefaultScheduledADBBackupMaxConcurrentReconciles.
+	MaxConcurrentReconciles int
+
+	// Only overridden in the tests
+	now func() time.Time
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScheduledAutonomousDatabaseBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dbv1alpha1.ScheduledAutonomousDatabaseBackup{}).
+		Owns(&dbv1alpha1.AutonomousDatabaseBackup{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(r.controllerOptions()).
+		Complete(r)
+}
+
+// controllerOptions returns the options of the controller
+func (r *ScheduledAutonomousDatabaseBackupReconciler) controllerOptions() controller.Options {
+	return concurrencyOptions(r.MaxConcurrentReconciles, DefaultScheduledADBBackupMaxConcurrentReconciles)
+}
+
+//+kubebuilder:rbac:groups=database.oracle.com,resources=scheduledautonomousdatabasebackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=database.oracle.com,resources=scheduledautonomousdatabasebackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=database.oracle.com,resources=autonomousdatabasebackups,verbs=get;list;watch;create;patch;delete
+
+func (r *ScheduledAutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("Namespace/Name", req.NamespacedName)
+
+	scheduled := &dbv1alpha1.ScheduledAutonomousDatabaseBackup{}
+	if err := r.KubeClient.Get(context.TODO(), req.NamespacedName, scheduled); err != nil {
+		// Ignore not-found errors, since they can't be fixed by an immediate requeue.
+		if apiErrors.IsNotFound(err) {
+			return emptyResult, nil
+		}
+		return emptyResult, err
+	}
+
+	schedule, err := cron.Parse(scheduled.Spec.Schedule)
+	if err != nil {
+		// The schedule won't be fixed by a requeue
+		r.Recorder.Event(scheduled, corev1.EventTypeWarning, "ReconcileFailed", err.Error())
+		logger.Error(err, "invalid schedule; stop reconcile")
+		return emptyResult, nil
+	}
+
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	now = now.UTC()
+
+	/******************************************************************
+	* Create the backup of the latest run that is due. The runs missed
+	* before it are skipped.
+	******************************************************************/
+	suspended := scheduled.Spec.Suspend != nil && *scheduled.Spec.Suspend
+
+	if !suspended {
+		if runTime := latestDueRun(schedule, scheduled, now); !runTime.IsZero() {
+			backup, err := r.createBackup(scheduled, runTime)
+			if err != nil {
+				return r.manageError(scheduled, err)
+			}
+			logger.Info("Created AutonomousDatabaseBackup " + backup.Name)
+
+			scheduled.Status.LastScheduleTime = &metav1.Time{Time: runTime}
+			scheduled.Status.LastBackupName = backup.Name
+		}
+	}
+
+	/******************************************************************
+	* Delete the oldest backups beyond the historyLimit
+	******************************************************************/
+	if err := r.pruneBackups(logger, scheduled); err != nil {
+		return r.manageError(scheduled, err)
+	}
+
+	/******************************************************************
+	* Update the status and requeue at the next run
+	******************************************************************/
+	var next time.Time
+	if !suspended {
+		next = schedule.Next(now)
+	}
+
+	if next.IsZero() {
+		scheduled.Status.NextScheduleTime = nil
+	} else {
+		scheduled.Status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+
+	if err := r.KubeClient.Status().Update(context.TODO(), scheduled); err != nil {
+		return r.manageError(scheduled, err)
+	}
+
+	if next.IsZero() {
+		logger.Info("No backup is scheduled")
+		return emptyResult, nil
+	}
+
+	logger.Info("Next backup is scheduled at " + next.Format(time.RFC3339))
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// latestDueRun returns the latest run between the last schedule time and now. The creation time of the resource is
+// used if there hasn't been any run yet. The zero time is returned if no run is due.
+func latestDueRun(schedule *cron.Schedule, scheduled *dbv1alpha1.ScheduledAutonomousDatabaseBackup, now time.Time) time.Time {
+	since := scheduled.GetCreationTimestamp().Time
+	if scheduled.Status.LastScheduleTime != nil {
+		since = scheduled.Status.LastScheduleTime.Time
+	}
+
+	var due time.Time
+	for t := schedule.Next(since.UTC()); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		due = t
+	}
+	return due
+}
+
+// createBackup creates the AutonomousDatabaseBackup of the given run. The name of the backup is derived from the run
+// time, so a backup that already exists is not created again.
+func (r *ScheduledAutonomousDatabaseBackupReconciler) createBackup(scheduled *dbv1alpha1.ScheduledAutonomousDatabaseBackup, runTime time.Time) (*dbv1alpha1.AutonomousDatabaseBackup, error) {
+	backup := &dbv1alpha1.AutonomousDatabaseBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: scheduled.GetNamespace(),
+			Name:      scheduled.GetName() + "-" + runTime.Format("200601021504"),
+			Labels: map[string]string{
+				scheduledBackupLabel: scheduled.GetName(),
+			},
+		},
+		Spec: dbv1alpha1.AutonomousDatabaseBackupSpec{
+			Target:                *scheduled.Spec.Target.DeepCopy(),
+			RetentionPeriodInDays: scheduled.Spec.RetentionPeriodInDays,
+			OCIConfig:             *scheduled.Spec.OCIConfig.DeepCopy(),
+		},
+	}
+
+	if err := ctrl.SetControllerReference(scheduled, backup, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	if err := r.KubeClient.Create(context.TODO(), backup); err != nil && !apiErrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	r.Recorder.Event(scheduled, corev1.EventTypeNormal, "BackupCreated", "Created AutonomousDatabaseBackup "+backup.Name)
+
+	return backup, nil
+}
+
+// pruneBackups deletes the oldest AutonomousDatabaseBackups of the ScheduledAutonomousDatabaseBackup until
+// the number of backups is within spec.historyLimit. The backups are kept in OCI until the end of their retention
+// period, so the finalizer which deletes a long-term backup in OCI is removed first.
+func (r *ScheduledAutonomousDatabaseBackupReconciler) pruneBackups(logger logr.Logger, scheduled *dbv1alpha1.ScheduledAutonomousDatabaseBackup) error {
+	limit := dbv1alpha1.DefaultBackupHistoryLimit
+	if scheduled.Spec.HistoryLimit != nil {
+		limit = *scheduled.Spec.HistoryLimit
+	}
+
+	backupList := &dbv1alpha1.AutonomousDatabaseBackupList{}
+	if err := r.KubeClient.List(context.TODO(), backupList,
+		client.InNamespace(scheduled.GetNamespace()),
+		client.MatchingLabels{scheduledBackupLabel: scheduled.GetName()}); err != nil {
+		return err
+	}
+
+	if len(backupList.Items) <= limit {
+		return nil
+	}
+
+	// The names end with the run time, so sorting by name sorts the backups from the oldest to the newest
+	backups := backupList.Items
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].GetName() < backups[j].GetName()
+	})
+
+	for i := 0; i < len(backups)-limit; i++ {
+		if controllerutil.ContainsFinalizer(&backups[i], dbv1alpha1.ADBBackupFinalizer) {
+			if err := k8s.RemoveFinalizerAndPatch(r.KubeClient, &backups[i], dbv1alpha1.ADBBackupFinalizer); err != nil {
+				return err
+			}
+		}
+		if err := r.KubeClient.Delete(context.TODO(), &backups[i]); err != nil && !apiErrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Deleted AutonomousDatabaseBackup " + backups[i].GetName())
+	}
+
+	return nil
+}
+
+func (r *ScheduledAutonomousDatabaseBackupReconciler) manageError(scheduled *dbv1alpha1.ScheduledAutonomousDatabaseBackup, issue error) (ctrl.Result, error) {
+	// Send event
+	r.Recorder.Event(scheduled, corev1.EventTypeWarning, "ReconcileFailed", issue.Error())
+
+	return emptyResult, issue
+}
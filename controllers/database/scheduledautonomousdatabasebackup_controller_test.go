@@ -0,0 +1,178 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("ScheduledAutonomousDatabaseBackup", func() {
+	var (
+		reconciler *ScheduledAutonomousDatabaseBackupReconciler
+		lookupKey  types.NamespacedName
+		clock      time.Time
+	)
+
+	created := time.Date(2022, 6, 15, 10, 5, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		scheduled := &dbv1alpha1.ScheduledAutonomousDatabaseBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "nightly",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Spec: dbv1alpha1.ScheduledAutonomousDatabaseBackupSpec{
+				Target: dbv1alpha1.TargetSpec{
+					K8sADB: dbv1alpha1.K8sADBSpec{Name: common.String("adb")},
+				},
+				Schedule:              "*/10 * * * *",
+				RetentionPeriodInDays: common.Int(90),
+				HistoryLimit:          common.Int(3),
+			},
+		}
+
+		clock = created
+		lookupKey = types.NamespacedName{Name: scheduled.Name, Namespace: scheduled.Namespace}
+		reconciler = &ScheduledAutonomousDatabaseBackupReconciler{
+			KubeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(scheduled).Build(),
+			Log:        logr.Discard(),
+			Scheme:     scheme,
+			Recorder:   record.NewFakeRecorder(10),
+			now:        func() time.Time { return clock },
+		}
+	})
+
+	// reconcileAt advances the clock to the given time and reconciles
+	reconcileAt := func(t time.Time) ctrl.Result {
+		clock = t
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: lookupKey})
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	backupNames := func() []string {
+		backupList := &dbv1alpha1.AutonomousDatabaseBackupList{}
+		Expect(reconciler.KubeClient.List(context.TODO(), backupList,
+			client.MatchingLabels{scheduledBackupLabel: lookupKey.Name})).To(Succeed())
+
+		var names []string
+		for _, backup := range backupList.Items {
+			names = append(names, backup.Name)
+		}
+		return names
+	}
+
+	It("should create the backups on schedule and prune the old ones", func() {
+		By("waiting for the first run")
+		result := reconcileAt(created.Add(time.Minute))
+		Expect(result.RequeueAfter).To(Equal(4 * time.Minute))
+		Expect(backupNames()).To(BeEmpty())
+
+		By("creating a backup at each run")
+		reconcileAt(time.Date(2022, 6, 15, 10, 10, 0, 0, time.UTC))
+		Expect(backupNames()).To(ConsistOf("nightly-202206151010"))
+
+		backup := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(),
+			types.NamespacedName{Name: "nightly-202206151010", Namespace: "default"}, backup)).To(Succeed())
+		Expect(backup.Spec.Target.K8sADB.Name).To(Equal(common.String("adb")))
+		Expect(backup.Spec.RetentionPeriodInDays).To(Equal(common.Int(90)))
+		Expect(backup.OwnerReferences).To(HaveLen(1))
+		Expect(backup.OwnerReferences[0].Kind).To(Equal("ScheduledAutonomousDatabaseBackup"))
+		Expect(*backup.OwnerReferences[0].Controller).To(BeTrue())
+
+		reconcileAt(time.Date(2022, 6, 15, 10, 20, 30, 0, time.UTC))
+		result = reconcileAt(time.Date(2022, 6, 15, 10, 30, 0, 0, time.UTC))
+		Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+		Expect(backupNames()).To(ConsistOf("nightly-202206151010", "nightly-202206151020", "nightly-202206151030"))
+
+		By("pruning the oldest backup beyond the historyLimit")
+		// The finalizer of the long-term backup is removed, so that the backup is kept in OCI
+		Expect(reconciler.KubeClient.Get(context.TODO(),
+			types.NamespacedName{Name: "nightly-202206151010", Namespace: "default"}, backup)).To(Succeed())
+		controllerutil.AddFinalizer(backup, dbv1alpha1.ADBBackupFinalizer)
+		Expect(reconciler.KubeClient.Update(context.TODO(), backup)).To(Succeed())
+		reconcileAt(time.Date(2022, 6, 15, 10, 40, 0, 0, time.UTC))
+		Expect(backupNames()).To(ConsistOf("nightly-202206151020", "nightly-202206151030", "nightly-202206151040"))
+
+		By("creating only the latest of the missed runs")
+		reconcileAt(time.Date(2022, 6, 15, 11, 15, 0, 0, time.UTC))
+		Expect(backupNames()).To(ConsistOf("nightly-202206151030", "nightly-202206151040", "nightly-202206151110"))
+
+		scheduled := &dbv1alpha1.ScheduledAutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, scheduled)).To(Succeed())
+		Expect(scheduled.Status.LastScheduleTime.Time).To(BeTemporally("==", time.Date(2022, 6, 15, 11, 10, 0, 0, time.UTC)))
+		Expect(scheduled.Status.NextScheduleTime.Time).To(BeTemporally("==", time.Date(2022, 6, 15, 11, 20, 0, 0, time.UTC)))
+		Expect(scheduled.Status.LastBackupName).To(Equal("nightly-202206151110"))
+	})
+
+	It("should not create backups while suspended", func() {
+		scheduled := &dbv1alpha1.ScheduledAutonomousDatabaseBackup{}
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, scheduled)).To(Succeed())
+		scheduled.Spec.Suspend = common.Bool(true)
+		Expect(reconciler.KubeClient.Update(context.TODO(), scheduled)).To(Succeed())
+
+		result := reconcileAt(time.Date(2022, 6, 15, 10, 30, 0, 0, time.UTC))
+		Expect(result).To(Equal(emptyResult))
+		Expect(backupNames()).To(BeEmpty())
+
+		Expect(reconciler.KubeClient.Get(context.TODO(), lookupKey, scheduled)).To(Succeed())
+		Expect(scheduled.Status.NextScheduleTime).To(BeNil())
+	})
+})
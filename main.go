@@ -53,8 +53,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
 	databasecontroller "github.com/oracle/oracle-database-operator/controllers/database"
 	// +kubebuilder:scaffold:imports
 )
@@ -74,12 +76,87 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var adbWaitForTermination bool
+	var adbTerminationMaxWait time.Duration
+	var adbProvisionMaxWait time.Duration
+	var adbResyncPeriod time.Duration
+	var adbResyncJitter float64
+	var adbRejectDuplicateDisplayName bool
+	var adbWarnDWWithoutAutoScaling bool
+	var allowedOperations string
+	var shutdownGracePeriod time.Duration
+	var adbQuotaPrecheck bool
+	var adbCostTagsPolicy string
+	var adbCostTagsNamespace string
+	var adbRequiredCostTags string
+	var adbMaxConcurrentReconciles int
+	var adbBackupMaxConcurrentReconciles int
+	var adbRestoreMaxConcurrentReconciles int
+	var adbScheduledBackupMaxConcurrentReconciles int
+	var acdMaxConcurrentReconciles int
+	var passwordVolumeRoot string
+	var adbWalletNamespaces string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&adbWaitForTermination, "adb-wait-for-termination", true,
+		"Wait until the Autonomous Database is TERMINATED in OCI before removing the finalizer of the resource.")
+	flag.DurationVar(&adbTerminationMaxWait, "adb-termination-max-wait", time.Hour,
+		"The max time to wait for the Autonomous Database to be TERMINATED. "+
+			"The finalizer is removed with a warning event after that. Set to 0 to wait without a limit.")
+	flag.DurationVar(&adbProvisionMaxWait, "adb-provision-max-wait", 15*time.Minute,
+		"The max time for an Autonomous Database to be PROVISIONING. "+
+			"The Stalled condition is set and the reconcile is requeued less often after that. Set to 0 to wait without a limit.")
+	flag.DurationVar(&adbResyncPeriod, "adb-resync-period", 10*time.Minute,
+		"The max time between two syncs of an Autonomous Database with OCI if its spec doesn't change. "+
+			"Set to 0 to sync in every reconcile.")
+	flag.Float64Var(&adbResyncJitter, "adb-resync-jitter", 0.1,
+		"The max fraction of the resync period randomly added to each resync of an Autonomous Database, "+
+			"so that the resources don't sync with OCI at the same time. Set to 0 to disable the jitter.")
+	flag.BoolVar(&adbRejectDuplicateDisplayName, "adb-reject-duplicate-display-name", false,
+		"Reject the provisioning of an Autonomous Database whose display name is already used in the compartment. "+
+			"A warning is returned by default.")
+	flag.BoolVar(&adbWarnDWWithoutAutoScaling, "adb-warn-dw-without-autoscaling", true,
+		"Return a warning when an Autonomous Database with the DW workload is provisioned without auto scaling.")
+	flag.StringVar(&allowedOperations, "allowed-operations", "",
+		"The comma-separated OCI operations which the AutonomousDatabase controller may send, out of create, get, update and terminate, "+
+			"e.g. create,get,update to never terminate a database. All the operations are allowed by default.")
+	flag.BoolVar(&adbQuotaPrecheck, "adb-quota-precheck", false,
+		"Check the available quota in the compartment with the OCI Limits service before an Autonomous Database is provisioned, "+
+			"and fail with the QuotaExceeded condition if it's exhausted. Requires the permission to inspect the resource availability.")
+	flag.StringVar(&adbCostTagsPolicy, "adb-cost-tags-policy", string(databasecontroller.CostTagsPolicyReject),
+		"How an Autonomous Database to be provisioned without the required cost tags is handled: "+
+			"reject denies the request, and inject adds the missing tags with their default values.")
+	flag.StringVar(&adbCostTagsNamespace, "adb-cost-tags-namespace", "",
+		"The namespace of the defined tags which are required by --adb-required-cost-tags.")
+	flag.StringVar(&adbRequiredCostTags, "adb-required-cost-tags", "",
+		"The comma-separated keys of the defined tags which are required on every Autonomous Database to be provisioned, "+
+			"with the default values to inject, e.g. CostCenter=unassigned,Project=unassigned. No tag is required by default.")
+	flag.IntVar(&adbMaxConcurrentReconciles, "adb-max-concurrent-reconciles", databasecontroller.DefaultADBMaxConcurrentReconciles,
+		"The max number of the AutonomousDatabase resources reconciled at the same time. "+
+			"Lower it if the OCI requests are throttled.")
+	flag.IntVar(&adbBackupMaxConcurrentReconciles, "adb-backup-max-concurrent-reconciles", databasecontroller.DefaultADBBackupMaxConcurrentReconciles,
+		"The max number of the AutonomousDatabaseBackup resources reconciled at the same time.")
+	flag.IntVar(&adbRestoreMaxConcurrentReconciles, "adb-restore-max-concurrent-reconciles", databasecontroller.DefaultADBRestoreMaxConcurrentReconciles,
+		"The max number of the AutonomousDatabaseRestore resources reconciled at the same time.")
+	flag.IntVar(&adbScheduledBackupMaxConcurrentReconciles, "adb-scheduled-backup-max-concurrent-reconciles", databasecontroller.DefaultScheduledADBBackupMaxConcurrentReconciles,
+		"The max number of the ScheduledAutonomousDatabaseBackup resources reconciled at the same time.")
+	flag.IntVar(&acdMaxConcurrentReconciles, "acd-max-concurrent-reconciles", databasecontroller.DefaultACDMaxConcurrentReconciles,
+		"The max number of the AutonomousContainerDatabase resources reconciled at the same time.")
+	flag.StringVar(&adbWalletNamespaces, "adb-wallet-namespaces", "",
+		"A comma-separated list of the namespaces where the wallet Secrets of the AutonomousDatabase resources in the other namespaces "+
+			"may be stored with spec.details.wallet.namespace. Empty only allows the namespace of the resource.")
+	flag.StringVar(&passwordVolumeRoot, "password-volume-root", oci.DefaultPasswordVolumeRoot,
+		"The directory where the volumes of the password files are mounted. The volumePath of a password has to be under the directory. "+
+			"Set to an empty string to disallow the password files.")
+	flag.DurationVar(&shutdownGracePeriod, "shutdown-grace-period", 30*time.Second,
+		"The max time to wait for the in-flight reconciles to finish when the manager is stopped, "+
+			"so that the status of the resources is persisted. Set to 0 to stop immediately, or to a negative value to wait without a limit.")
 	flag.Parse()
 
+	oci.PasswordVolumeRoot = passwordVolumeRoot
+
 	// Initialize new logger Opts
 	options := &zap.Options{
 		Development: true,
@@ -88,12 +165,28 @@ func main() {
 
 	ctrl.SetLogger(zap.New(func(o *zap.Options) { *o = *options }))
 
+	adbAllowedOperations, err := databasecontroller.ParseOCIOperations(allowedOperations)
+	if err != nil {
+		setupLog.Error(err, "invalid --allowed-operations")
+		os.Exit(1)
+	}
+
+	adbCostTags, err := databasecontroller.ParseCostTagsPolicy(
+		ctrl.Log.WithName("webhooks").WithName("AutonomousDatabaseCostTags"),
+		adbCostTagsPolicy, adbCostTagsNamespace, adbRequiredCostTags)
+	if err != nil {
+		setupLog.Error(err, "invalid cost tags policy")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "a9d608ea.oracle.com",
+
+		GracefulShutdownTimeout: &shutdownGracePeriod,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -109,6 +202,18 @@ func main() {
 		Log:        ctrl.Log.WithName("controllers").WithName("database").WithName("AutonomousDatabase"),
 		Scheme:     mgr.GetScheme(),
 		Recorder:   mgr.GetEventRecorderFor("AutonomousDatabase"),
+
+		SkipTerminationWait: !adbWaitForTermination,
+		TerminationMaxWait:  adbTerminationMaxWait,
+		ProvisionMaxWait:    adbProvisionMaxWait,
+		ResyncPeriod:        adbResyncPeriod,
+		ResyncJitter:        adbResyncJitter,
+		AllowedOperations:   adbAllowedOperations,
+		QuotaPrecheck:       adbQuotaPrecheck,
+		WalletNamespaces:    databasecontroller.ParseWalletNamespaces(adbWalletNamespaces),
+		ShutdownGracePeriod: shutdownGracePeriod,
+
+		MaxConcurrentReconciles: adbMaxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutonomousDatabase")
 		os.Exit(1)
@@ -118,15 +223,30 @@ func main() {
 		Log:        ctrl.Log.WithName("controllers").WithName("AutonomousDatabaseBackup"),
 		Scheme:     mgr.GetScheme(),
 		Recorder:   mgr.GetEventRecorderFor("AutonomousDatabaseBackup"),
+
+		MaxConcurrentReconciles: adbBackupMaxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutonomousDatabaseBackup")
 		os.Exit(1)
 	}
+	if err = (&databasecontroller.ScheduledAutonomousDatabaseBackupReconciler{
+		KubeClient: mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("ScheduledAutonomousDatabaseBackup"),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("ScheduledAutonomousDatabaseBackup"),
+
+		MaxConcurrentReconciles: adbScheduledBackupMaxConcurrentReconciles,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ScheduledAutonomousDatabaseBackup")
+		os.Exit(1)
+	}
 	if err = (&databasecontroller.AutonomousDatabaseRestoreReconciler{
 		KubeClient: mgr.GetClient(),
 		Log:        ctrl.Log.WithName("controllers").WithName("AutonomousDatabaseRestore"),
 		Scheme:     mgr.GetScheme(),
 		Recorder:   mgr.GetEventRecorderFor("AutonomousDatabaseRestore"),
+
+		MaxConcurrentReconciles: adbRestoreMaxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutonomousDatabaseRestore")
 		os.Exit(1)
@@ -136,6 +256,8 @@ func main() {
 		Log:        ctrl.Log.WithName("controllers").WithName("AutonomousContainerDatabase"),
 		Scheme:     mgr.GetScheme(),
 		Recorder:   mgr.GetEventRecorderFor("AutonomousContainerDatabase"),
+
+		MaxConcurrentReconciles: acdMaxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutonomousContainerDatabase")
 		os.Exit(1)
@@ -210,10 +332,30 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AutonomousDatabase")
 			os.Exit(1)
 		}
+		mgr.GetWebhookServer().Register(databasecontroller.AutonomousDatabaseDisplayNamePath, &webhook.Admission{
+			Handler: &databasecontroller.AutonomousDatabaseDisplayNameValidator{
+				KubeClient:      mgr.GetClient(),
+				Log:             ctrl.Log.WithName("webhooks").WithName("AutonomousDatabaseDisplayName"),
+				RejectDuplicate: adbRejectDuplicateDisplayName,
+			},
+		})
+		mgr.GetWebhookServer().Register(databasecontroller.AutonomousDatabaseAutoScalingPath, &webhook.Admission{
+			Handler: &databasecontroller.AutonomousDatabaseAutoScalingAdvisor{
+				Log:      ctrl.Log.WithName("webhooks").WithName("AutonomousDatabaseAutoScaling"),
+				Disabled: !adbWarnDWWithoutAutoScaling,
+			},
+		})
+		mgr.GetWebhookServer().Register(databasecontroller.AutonomousDatabaseCostTagsPath, &webhook.Admission{
+			Handler: adbCostTags,
+		})
 		if err = (&databasev1alpha1.AutonomousDatabaseBackup{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AutonomousDatabaseBackup")
 			os.Exit(1)
 		}
+		if err = (&databasev1alpha1.ScheduledAutonomousDatabaseBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ScheduledAutonomousDatabaseBackup")
+			os.Exit(1)
+		}
 		if err = (&databasev1alpha1.AutonomousDatabaseRestore{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AutonomousDatabaseRestore")
 			os.Exit(1)
@@ -139,4 +139,44 @@ var _ = Describe("test ACD binding", func() {
 
 		It("Should terminate the ACD", e2ebehavior.AssertACDTerminate(&k8sClient, &dbClient, &acdLookupKey))
 	})
+
+	Describe("ACD binding to a container database with Autonomous Data Guard", func() {
+		BeforeEach(func() {
+			if SharedDataGuardACDOCID == "" {
+				Skip("dataGuardACDOCID is not set in the test configuration")
+			}
+		})
+
+		It("Should create an AutonomousContainerDatabase resource", func() {
+			acd := &dbv1alpha1.AutonomousContainerDatabase{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "database.oracle.com/v1alpha1",
+					Kind:       "AutonomousContainerDatabase",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bindacd-dataguard",
+					Namespace: ADBNamespace,
+				},
+				Spec: dbv1alpha1.AutonomousContainerDatabaseSpec{
+					AutonomousContainerDatabaseOCID: common.String(SharedDataGuardACDOCID),
+					OCIConfig: dbv1alpha1.OCIConfigSpec{
+						ConfigMapName: common.String(SharedOCIConfigMapName),
+						SecretName:    common.String(SharedOCISecretName),
+					},
+				},
+			}
+
+			acdLookupKey = types.NamespacedName{Name: acd.Name, Namespace: acd.Namespace}
+
+			Expect(k8sClient.Create(context.TODO(), acd)).Should(Succeed())
+		})
+
+		It("Should bind to an ACD", e2ebehavior.AssertACDBind(&k8sClient, &dbClient, &acdLookupKey, database.AutonomousContainerDatabaseLifecycleStateAvailable))
+
+		It("Should enable the automatic failover", e2ebehavior.AssertACDAutomaticFailover(&k8sClient, &dbClient, &acdLookupKey, true))
+
+		It("Should disable the automatic failover", e2ebehavior.AssertACDAutomaticFailover(&k8sClient, &dbClient, &acdLookupKey, false))
+
+		It("Should delete ACD local resource", e2ebehavior.AssertACDLocalDelete(&k8sClient, &dbClient, &acdLookupKey))
+	})
 })
@@ -74,7 +74,7 @@ var _ = Describe("test ADB binding with hardLink=true", func() {
 	It("should init the test", func() {
 		By("creating a temp ADB in OCI for binding test")
 		dbName := e2eutil.GenerateDBName()
-		createResp, err := e2eutil.CreateAutonomousDatabase(dbClient, &SharedCompartmentOCID, &dbName, &SharedPlainTextAdminPassword)
+		createResp, err := e2eutil.CreateAutonomousDatabase(context.TODO(), dbClient, &SharedCompartmentOCID, &dbName, &SharedPlainTextAdminPassword)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(createResp.AutonomousDatabase.Id).ShouldNot(BeNil())
 
@@ -86,7 +86,7 @@ var _ = Describe("test ADB binding with hardLink=true", func() {
 		workClient, err := workrequests.NewWorkRequestClientWithConfigurationProvider(configProvider)
 		Expect(err).ShouldNot(HaveOccurred())
 
-		err = e2eutil.WaitUntilWorkCompleted(workClient, createResp.OpcWorkRequestId)
+		err = e2eutil.WaitUntilWorkCompleted(context.TODO(), workClient, createResp.OpcWorkRequestId)
 		Expect(err).ShouldNot(HaveOccurred())
 	})
 
@@ -128,16 +128,62 @@ var _ = Describe("test ADB binding with hardLink=true", func() {
 
 		It("should bind to an ADB", e2ebehavior.AssertBind(&k8sClient, &adbLookupKey))
 
-		It("Should download an instance wallet using the password from K8s Secret "+SharedWalletPassSecretName, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey))
+		It("Should download an instance wallet using the password from K8s Secret "+SharedWalletPassSecretName, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateIfMissing))
+
+		It("Should rotate the wallet", e2ebehavior.AssertWalletRotation(&k8sClient, &adbLookupKey))
+
+		It("Should rotate the encryption key", e2ebehavior.AssertEncryptionKeyRotation(&k8sClient, &adbLookupKey))
+
+		It("Should refresh the connection URLs", e2ebehavior.AssertConnectionURLsRefresh(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("Should shrink the storage", e2ebehavior.AssertShrink(&k8sClient, &adbLookupKey))
+
+		It("Should detach the refreshable clone", e2ebehavior.AssertCloneDetach(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("Should regenerate the wallet in every reconcile", e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateAlways))
+
+		It("Should leave the wallet to the user", e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateNever))
+
+		It("Should connect to the database", e2ebehavior.AssertConnectable(&k8sClient, &adbLookupKey))
 
 		It("should update ADB", e2ebehavior.UpdateAndAssertDetails(&k8sClient, &dbClient, &adbLookupKey, SharedNewAdminPassSecretName, &SharedPlainTextNewAdminPassword, &SharedPlainTextWalletPassword))
 
+		It("should not send any request when the same spec is applied again", e2ebehavior.AssertIdempotentApply(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("should retry the update which fails partially", e2ebehavior.AssertUpdateRollback(&k8sClient, &dbClient, &adbLookupKey, SharedRollbackAdminPassSecretName, &SharedPlainTextRollbackAdminPassword))
+
+		It("should signal the admin password rotation until the connectivity is confirmed", e2ebehavior.AssertAdminPasswordRotation(&k8sClient, &dbClient, &adbLookupKey, SharedAdminPassSecretName, &SharedPlainTextAdminPassword, &SharedPlainTextWalletPassword))
+
+		It("should converge after an update during a transition", e2ebehavior.AssertConflictRetry(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("should toggle the auto scaling without changing the CPU", e2ebehavior.UpdateAndAssertAutoScaling(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("should change the dbWorkload from OLTP to DW", e2ebehavior.UpdateAndAssertDbWorkload(&k8sClient, &dbClient, &adbLookupKey, database.AutonomousDatabaseDbWorkloadDw))
+
+		It("should change the dbWorkload from DW back to OLTP", e2ebehavior.UpdateAndAssertDbWorkload(&k8sClient, &dbClient, &adbLookupKey, database.AutonomousDatabaseDbWorkloadOltp))
+
+		It("should enable Database Management", e2ebehavior.AssertDatabaseManagement(&k8sClient, &dbClient, &adbLookupKey, true))
+
+		It("should disable Database Management", e2ebehavior.AssertDatabaseManagement(&k8sClient, &dbClient, &adbLookupKey, false))
+
+		It("should enable Operations Insights", e2ebehavior.AssertOperationsInsights(&k8sClient, &dbClient, &adbLookupKey, true))
+
+		It("should disable Operations Insights", e2ebehavior.AssertOperationsInsights(&k8sClient, &dbClient, &adbLookupKey, false))
+
+		It("should stop the ADB before scaling it and start it again", e2ebehavior.UpdateAndAssertStopBeforeScaling(&k8sClient, &dbClient, &adbLookupKey))
+
+		It("should change the disaster recovery type to BACKUP_BASED", e2ebehavior.UpdateAndAssertDisasterRecoveryType(&k8sClient, &adbLookupKey, dbv1alpha1.DisasterRecoveryTypeBackupBased))
+
+		It("should create the cross-region disaster recovery peer", e2ebehavior.UpdateAndAssertDisasterRecoveryPeer(&k8sClient, &dbClient, &adbLookupKey, &SharedDisasterRecoveryPeerRegion))
+
 		It("Should stop ADB", e2ebehavior.UpdateAndAssertADBState(&k8sClient, &dbClient, &adbLookupKey, database.AutonomousDatabaseLifecycleStateStopped))
 
 		It("Should restart ADB", e2ebehavior.UpdateAndAssertADBState(&k8sClient, &dbClient, &adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable))
 
 		It("Should change to RESTRICTED network access", e2ebehavior.TestNetworkAccessRestricted(&k8sClient, &dbClient, &adbLookupKey, false))
 
+		It("Should regenerate the wallet after isMTLSConnectionRequired changes", e2ebehavior.AssertWalletMTLSToggle(&k8sClient, &dbClient, &adbLookupKey))
+
 		It("Should change isMTLSConnectionRequired to false", e2ebehavior.TestNetworkAccessRestricted(&k8sClient, &dbClient, &adbLookupKey, false))
 
 		It("Should should change to PRIVATE network access", e2ebehavior.TestNetworkAccessPrivate(&k8sClient, &dbClient, &adbLookupKey, false, &SharedSubnetOCID, &SharedNsgOCID))
@@ -187,7 +233,7 @@ var _ = Describe("test ADB binding with hardLink=true", func() {
 
 		It("should bind to an ADB", e2ebehavior.AssertBind(&k8sClient, &adbLookupKey))
 
-		It("Should download an instance wallet using the password from OCI Secret OCID "+SharedInstanceWalletPasswordOCID, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey))
+		It("Should download an instance wallet using the password from OCI Secret OCID "+SharedInstanceWalletPasswordOCID, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateIfMissing))
 
 		It("Should delete the resource in cluster and terminate the database in OCI", e2ebehavior.AssertHardLinkDelete(&k8sClient, &dbClient, &adbLookupKey))
 	})
@@ -227,6 +273,8 @@ var _ = Describe("test ADB binding with hardLink=true", func() {
 
 		It("Should check for TERMINATED state in local resource", e2ebehavior.AssertADBLocalState(&k8sClient, &adbLookupKey, database.AutonomousDatabaseLifecycleStateTerminated))
 
+		It("Should report Terminated and stop polling the database", e2ebehavior.AssertADBTerminated(&k8sClient, &adbLookupKey))
+
 		It("Should delete local resource", e2ebehavior.AssertSoftLinkDelete(&k8sClient, &adbLookupKey))
 	})
 })
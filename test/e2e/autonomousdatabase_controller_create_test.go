@@ -72,9 +72,11 @@ var _ = Describe("test ADB provisioning", func() {
 		const backupName = "adb-backup"
 		const restoreName = "adb-restore"
 		duplicateAdbResourceName := "duplicateadb"
+		createIfMissingResourceName := "createifmissingadb"
 
 		var adbLookupKey = types.NamespacedName{Name: resourceName, Namespace: ADBNamespace}
 		var dupAdbLookupKey = types.NamespacedName{Name: duplicateAdbResourceName, Namespace: ADBNamespace}
+		var createIfMissingLookupKey = types.NamespacedName{Name: createIfMissingResourceName, Namespace: ADBNamespace}
 
 		It("Should create a AutonomousDatabase resource", func() {
 			dbName = e2eutil.GenerateDBName()
@@ -173,13 +175,48 @@ var _ = Describe("test ADB provisioning", func() {
 			Expect(k8sClient.Delete(context.TODO(), duplicateAdb)).To(Succeed())
 		})
 
+		It("Should bind a resource with createIfMissing to the database with the same display name", func() {
+			boundAdb := &dbv1alpha1.AutonomousDatabase{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "database.oracle.com/v1alpha1",
+					Kind:       "AutonomousDatabase",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      createIfMissingResourceName,
+					Namespace: ADBNamespace,
+				},
+				Spec: dbv1alpha1.AutonomousDatabaseSpec{
+					Details: dbv1alpha1.AutonomousDatabaseDetails{
+						CompartmentOCID: common.String(SharedCompartmentOCID),
+						DbName:          common.String(dbName),
+						DisplayName:     common.String(dbName),
+						CreateIfMissing: common.Bool(true),
+					},
+					OCIConfig: dbv1alpha1.OCIConfigSpec{
+						ConfigMapName: common.String(SharedOCIConfigMapName),
+						SecretName:    common.String(SharedOCISecretName),
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(context.TODO(), boundAdb)).To(Succeed())
+		})
+
+		It("Should bind to the existing database", e2ebehavior.AssertCreateIfMissing(&k8sClient, &createIfMissingLookupKey, &adbLookupKey))
+
+		It("Should delete the resource with createIfMissing without terminating the database", func() {
+			boundAdb := &dbv1alpha1.AutonomousDatabase{}
+			Expect(k8sClient.Get(context.TODO(), createIfMissingLookupKey, boundAdb)).To(Succeed())
+			Expect(k8sClient.Delete(context.TODO(), boundAdb)).To(Succeed())
+		})
+
 		It("Should create an Autonomous Database Backup", func() {
 			e2ebehavior.AssertADBState(&k8sClient, &dbClient, &adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
 
 			// Get adb ocid
 			adb := &dbv1alpha1.AutonomousDatabase{}
 			Expect(k8sClient.Get(context.TODO(), adbLookupKey, adb)).To(Succeed())
-			databaseOCID := adb.Spec.Details.AutonomousDatabaseOCID
+			databaseOCID := adb.GetAutonomousDatabaseOCID()
 			tnsEntry := dbName + "_high"
 			err := e2ebehavior.ConfigureADBBackup(&dbClient, databaseOCID, &tnsEntry, &SharedPlainTextAdminPassword, &SharedPlainTextWalletPassword, &SharedBucketUrl, &SharedAuthToken, &SharedOciUser)
 			Expect(err).ShouldNot(HaveOccurred())
@@ -248,7 +285,7 @@ var _ = Describe("test ADB provisioning", func() {
 			e2ebehavior.AssertBackupRestore(&k8sClient, &dbClient, &restoreLookupKey, &adbLookupKey, database.AutonomousDatabaseLifecycleStateRestoreInProgress)()
 		})
 
-		It("Should download an instance wallet using the password from K8s Secret "+SharedWalletPassSecretName, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey))
+		It("Should download an instance wallet using the password from K8s Secret "+SharedWalletPassSecretName, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateIfMissing))
 
 		It("Should delete the resource in cluster and terminate the database in OCI", e2ebehavior.AssertHardLinkDelete(&k8sClient, &dbClient, &adbLookupKey))
 	})
@@ -284,6 +321,8 @@ var _ = Describe("test ADB provisioning", func() {
 						},
 						DataStorageSizeInTBs: common.Int(1),
 						IsAutoScalingEnabled: common.Bool(true),
+						// No database has the generated display name, so a new one is provisioned
+						CreateIfMissing: common.Bool(true),
 
 						Wallet: dbv1alpha1.WalletSpec{
 							Name: common.String(downloadedWallet),
@@ -307,7 +346,13 @@ var _ = Describe("test ADB provisioning", func() {
 
 		It("Should provision ADB using the password from OCI Secret OCID "+SharedAdminPasswordOCID, e2ebehavior.AssertProvision(&k8sClient, &adbLookupKey))
 
-		It("Should download an instance wallet using the password from OCI Secret OCID "+SharedInstanceWalletPasswordOCID, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey))
+		It("Should provision a new database with createIfMissing", e2ebehavior.AssertCreateIfMissing(&k8sClient, &adbLookupKey, nil))
+
+		It("Should download an instance wallet using the password from OCI Secret OCID "+SharedInstanceWalletPasswordOCID, e2ebehavior.AssertWallet(&k8sClient, &adbLookupKey, dbv1alpha1.WalletRegenerateIfMissing))
+
+		It("Should add a cross-region standby peer", e2ebehavior.AssertStandbyPeerAdded(&k8sClient, &dbClient, &adbLookupKey, &SharedDisasterRecoveryPeerRegion))
+
+		It("Should remove the cross-region standby peer", e2ebehavior.AssertStandbyPeerRemoved(&k8sClient, &dbClient, &adbLookupKey, &SharedDisasterRecoveryPeerRegion))
 
 		It("Should delete the resource in cluster and terminate the database in OCI", e2ebehavior.AssertHardLinkDelete(&k8sClient, &dbClient, &adbLookupKey))
 	})
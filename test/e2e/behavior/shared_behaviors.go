@@ -42,7 +42,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"time"
 
 	"github.com/onsi/ginkgo/v2"
@@ -50,12 +49,16 @@ import (
 	"github.com/oracle/oci-go-sdk/v64/common"
 	"github.com/oracle/oci-go-sdk/v64/database"
 	"github.com/oracle/oci-go-sdk/v64/workrequests"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
 	"github.com/oracle/oracle-database-operator/test/e2e/util"
 	"os"
 	"os/exec"
@@ -76,11 +79,17 @@ var (
 	Expect                  = gomega.Expect
 	BeNil                   = gomega.BeNil
 	Eventually              = gomega.Eventually
+	Consistently            = gomega.Consistently
 	Equal                   = gomega.Equal
 	Succeed                 = gomega.Succeed
 	HaveOccurred            = gomega.HaveOccurred
 	BeNumerically           = gomega.BeNumerically
 	BeTrue                  = gomega.BeTrue
+	BeFalse                 = gomega.BeFalse
+	HaveKey                 = gomega.HaveKey
+	BeEmpty                 = gomega.BeEmpty
+	ContainElement          = gomega.ContainElement
+	Skip                    = ginkgo.Skip
 	changeTimeout           = time.Second * 300
 	provisionTimeout        = time.Second * 15
 	bindTimeout             = time.Second * 30
@@ -89,6 +98,8 @@ var (
 	updateADBTimeout        = time.Minute * 7
 	changeLocalStateTimeout = time.Second * 600
 	updateACDTimeout        = time.Minute * 3
+	terminateTimeout        = time.Minute * 20
+	drPeerTimeout           = time.Minute * 30
 )
 
 func AssertProvision(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
@@ -110,11 +121,11 @@ func AssertProvision(k8sClient *client.Client, adbLookupKey *types.NamespacedNam
 				return nil, err
 			}
 
-			return createdADB.Spec.Details.AutonomousDatabaseOCID, nil
+			return createdADB.GetAutonomousDatabaseOCID(), nil
 		}, provisionTimeout, intervalTime).ShouldNot(BeNil())
 
 		fmt.Fprintf(GinkgoWriter, "AutonomousDatabase DbName = %s, and AutonomousDatabaseOCID = %s\n",
-			*createdADB.Spec.Details.DbName, *createdADB.Spec.Details.AutonomousDatabaseOCID)
+			createdADB.Status.DbName, *createdADB.GetAutonomousDatabaseOCID())
 	}
 }
 
@@ -138,17 +149,67 @@ func AssertBind(k8sClient *client.Client, adbLookupKey *types.NamespacedName) fu
 			if err != nil {
 				return false
 			}
-			return (boundADB.Spec.Details.CompartmentOCID != nil &&
-				boundADB.Spec.Details.DbWorkload != "" &&
-				boundADB.Spec.Details.DbName != nil)
-		}, bindTimeout).Should(Equal(true), "Attributes in the resource should not be empty")
+			return (boundADB.Status.CompartmentOCID != "" &&
+				boundADB.Status.DbWorkload != "" &&
+				boundADB.Status.DbName != "")
+		}, bindTimeout).Should(Equal(true), "Attributes in the status should not be empty")
 
 		fmt.Fprintf(GinkgoWriter, "AutonomousDatabase DbName = %s, and AutonomousDatabaseOCID = %s\n",
-			*boundADB.Spec.Details.DbName, *boundADB.Spec.Details.AutonomousDatabaseOCID)
+			boundADB.Status.DbName, *boundADB.GetAutonomousDatabaseOCID())
 	}
 }
 
-func AssertWallet(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+// AssertCreateIfMissing asserts that a resource with createIfMissing is bound to the database of the resource with
+// existingADBLookupKey if it's not nil, which has the same displayName. Otherwise a new database has to be provisioned.
+func AssertCreateIfMissing(k8sClient *client.Client, adbLookupKey *types.NamespacedName, existingADBLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		var existingOCID string
+		if existingADBLookupKey != nil {
+			existingADB := &dbv1alpha1.AutonomousDatabase{}
+			Expect(derefK8sClient.Get(context.TODO(), *existingADBLookupKey, existingADB)).To(Succeed())
+			Expect(existingADB.GetAutonomousDatabaseOCID()).NotTo(BeNil())
+			existingOCID = *existingADB.GetAutonomousDatabaseOCID()
+		}
+
+		By("Checking the AutonomousDatabaseOCID populates in the AutonomousDatabase resource")
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Eventually(func() (*string, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return nil, err
+			}
+			return adb.GetAutonomousDatabaseOCID(), nil
+		}, provisionTimeout, intervalTime).ShouldNot(BeNil())
+
+		if existingADBLookupKey != nil {
+			By("Checking the resource is bound to the existing database " + existingOCID)
+			Expect(*adb.GetAutonomousDatabaseOCID()).To(Equal(existingOCID))
+		} else {
+			By("Checking a new database is provisioned with the displayName")
+			Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
+				err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+				return adb.Status.LifecycleState, err
+			}, provisionTimeout, intervalTime).Should(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		}
+
+		Eventually(func() (string, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.DisplayName, err
+		}, bindTimeout, intervalTime).Should(Equal(*adb.Spec.Details.DisplayName))
+
+		fmt.Fprintf(GinkgoWriter, "AutonomousDatabase DisplayName = %s, and AutonomousDatabaseOCID = %s\n",
+			adb.Status.DisplayName, *adb.GetAutonomousDatabaseOCID())
+	}
+}
+
+// AssertWallet sets the wallet.regenerate to the mode, and asserts that the wallet Secret is managed accordingly.
+// The Secret is removed to check that the operator doesn't create it again if the mode is never, and the Secret
+// has to be replaced after the mode is changed if the mode is always.
+func AssertWallet(k8sClient *client.Client, adbLookupKey *types.NamespacedName, mode dbv1alpha1.WalletRegenerateEnum) func() {
 	return func() {
 		walletTimeout := time.Second * 120
 
@@ -162,6 +223,11 @@ func AssertWallet(k8sClient *client.Client, adbLookupKey *types.NamespacedName)
 		adb := &dbv1alpha1.AutonomousDatabase{}
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
 
+		// The operator never generates the wallet of a database it only observes
+		if adb.Spec.Details.ManageWallet != nil && !*adb.Spec.Details.ManageWallet {
+			Skip("The wallet is not managed by the operator")
+		}
+
 		// The default name is xxx-instance-wallet
 		if adb.Spec.Details.Wallet.Name == nil {
 			walletName = adb.Name + "-instance-wallet"
@@ -169,8 +235,41 @@ func AssertWallet(k8sClient *client.Client, adbLookupKey *types.NamespacedName)
 			walletName = *adb.Spec.Details.Wallet.Name
 		}
 
-		By("Checking the wallet secret " + walletName + " is created and is not empty")
-		walletLookupKey := types.NamespacedName{Name: walletName, Namespace: adbLookupKey.Namespace}
+		// The wallet is created in the namespace of the resource unless the wallet.namespace is specified
+		walletNamespace := adbLookupKey.Namespace
+		if adb.Spec.Details.Wallet.Namespace != nil && *adb.Spec.Details.Wallet.Namespace != "" {
+			walletNamespace = *adb.Spec.Details.Wallet.Namespace
+		}
+
+		walletLookupKey := types.NamespacedName{Name: walletName, Namespace: walletNamespace}
+
+		// The resourceVersion of the Secret before the mode is changed
+		var lastResourceVersion string
+		if err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet); err == nil {
+			lastResourceVersion = instanceWallet.ResourceVersion
+		}
+
+		if adb.Spec.Details.Wallet.Regenerate != mode || adb.Spec.Details.Wallet.Checksum == nil {
+			By("Setting the wallet.regenerate to " + string(mode) + " and enabling the checksum")
+			adb.Spec.Details.Wallet.Regenerate = mode
+			adb.Spec.Details.Wallet.Checksum = common.Bool(true)
+			Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+		}
+
+		if mode == dbv1alpha1.WalletRegenerateNever {
+			By("Checking the wallet secret " + walletNamespace + "/" + walletName + " is not created again once removed")
+			if lastResourceVersion != "" {
+				Expect(client.IgnoreNotFound(derefK8sClient.Delete(context.TODO(), instanceWallet))).To(Succeed())
+			}
+
+			Consistently(func() bool {
+				err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)
+				return k8sErrors.IsNotFound(err)
+			}, time.Second*30).Should(Equal(true))
+			return
+		}
+
+		By("Checking the wallet secret " + walletNamespace + "/" + walletName + " is created and is not empty")
 
 		// We'll need to retry until wallet is downloaded
 		Eventually(func() bool {
@@ -178,7 +277,391 @@ func AssertWallet(k8sClient *client.Client, adbLookupKey *types.NamespacedName)
 			return err == nil
 		}, walletTimeout).Should(Equal(true))
 
+		if mode == dbv1alpha1.WalletRegenerateAlways && lastResourceVersion != "" {
+			By("Checking the wallet secret is regenerated")
+			Eventually(func() (string, error) {
+				err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)
+				return instanceWallet.ResourceVersion, err
+			}, walletTimeout).ShouldNot(Equal(lastResourceVersion))
+		}
+
 		Expect(len(instanceWallet.Data)).To(BeNumerically(">", 0))
+		Expect(instanceWallet.Data).To(HaveKey("tnsnames.ora"))
+		Expect(instanceWallet.Data).To(HaveKey("cwallet.sso"))
+
+		if *adb.Spec.Details.Wallet.Checksum {
+			By("Checking the checksum annotation matches the files in the wallet secret")
+			// The wallet might be regenerated in between, so the Secret is read again until they match
+			Eventually(func() (bool, error) {
+				if err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet); err != nil {
+					return false, err
+				}
+				checksum := instanceWallet.Annotations[dbv1alpha1.WalletChecksumAnnotation]
+				return checksum == oci.WalletChecksum(instanceWallet.Data), nil
+			}, walletTimeout, intervalTime).Should(BeTrue())
+		}
+
+		// The Ready condition waits for the wallet unless the readyRequiresWallet is false, and the wallet is stored by now
+		By("Checking the Ready condition is true")
+		Eventually(func() (metav1.ConditionStatus, error) {
+			readyADB := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, readyADB); err != nil {
+				return "", err
+			}
+
+			condition := meta.FindStatusCondition(readyADB.Status.Conditions, dbv1alpha1.ADBConditionReady)
+			if condition == nil {
+				return "", nil
+			}
+			return condition.Status, nil
+		}, walletTimeout, intervalTime).Should(Equal(metav1.ConditionTrue))
+
+		// The wallet is garbage-collected with the resource if they are in the same namespace
+		if walletNamespace == adbLookupKey.Namespace {
+			owner := metav1.GetControllerOf(instanceWallet)
+			Expect(owner).NotTo(BeNil())
+			Expect(owner.UID).To(Equal(adb.UID))
+		}
+
+		if adb.Spec.Details.Wallet.SplitProfiles == nil || !*adb.Spec.Details.Wallet.SplitProfiles {
+			return
+		}
+
+		By("Checking the Secrets of the connection profiles are created in " + walletNamespace)
+		profileSecrets := &corev1.SecretList{}
+		Eventually(func() (int, error) {
+			err := derefK8sClient.List(context.TODO(), profileSecrets,
+				client.InNamespace(walletNamespace),
+				client.MatchingLabels{"app": adb.Name},
+				client.HasLabels{dbv1alpha1.WalletProfileLabel})
+			return len(profileSecrets.Items), err
+		}, walletTimeout).Should(BeNumerically(">", 0))
+
+		for _, secret := range profileSecrets.Items {
+			profile := secret.Labels[dbv1alpha1.WalletProfileLabel]
+			Expect(secret.Name).To(Equal(walletName + "-" + profile))
+			Expect(secret.Data).To(HaveKey("tns_alias"))
+		}
+	}
+}
+
+// AssertWalletRotation requests the rotateWallet action, and asserts that the action is removed from the spec once
+// the rotation completes, and the content of the wallet Secret changes
+func AssertWalletRotation(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		rotateTimeout := time.Minute * 10
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		walletName := adb.Name + "-instance-wallet"
+		if adb.Spec.Details.Wallet.Name != nil {
+			walletName = *adb.Spec.Details.Wallet.Name
+		}
+		walletNamespace := adbLookupKey.Namespace
+		if adb.Spec.Details.Wallet.Namespace != nil && *adb.Spec.Details.Wallet.Namespace != "" {
+			walletNamespace = *adb.Spec.Details.Wallet.Namespace
+		}
+		walletLookupKey := types.NamespacedName{Name: walletName, Namespace: walletNamespace}
+
+		instanceWallet := &corev1.Secret{}
+		Expect(derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)).To(Succeed())
+		Expect(instanceWallet.Data).To(HaveKey("cwallet.sso"))
+		oldCwallet := instanceWallet.Data["cwallet.sso"]
+
+		By("Requesting the rotateWallet action")
+		adb.Spec.Action = dbv1alpha1.ADBActionRotateWallet
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the action is removed from the spec once the rotation completes")
+		Eventually(func() (dbv1alpha1.ADBActionEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Spec.Action, err
+		}, rotateTimeout, intervalTime).Should(BeEmpty())
+
+		By("Checking the content of the wallet Secret changes")
+		Eventually(func() ([]byte, error) {
+			err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)
+			return instanceWallet.Data["cwallet.sso"], err
+		}, time.Second*60, intervalTime).ShouldNot(Equal(oldCwallet))
+	}
+}
+
+// AssertWalletMTLSToggle flips the isMtlsConnectionRequired of the database, and asserts that the wallet Secret is
+// replaced once the setting is applied, and that the setting is recorded in the status
+func AssertWalletMTLSToggle(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		AssertADBState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		Expect(adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired).ToNot(BeNil())
+
+		walletName := adb.Name + "-instance-wallet"
+		if adb.Spec.Details.Wallet.Name != nil {
+			walletName = *adb.Spec.Details.Wallet.Name
+		}
+		walletNamespace := adbLookupKey.Namespace
+		if adb.Spec.Details.Wallet.Namespace != nil && *adb.Spec.Details.Wallet.Namespace != "" {
+			walletNamespace = *adb.Spec.Details.Wallet.Namespace
+		}
+		walletLookupKey := types.NamespacedName{Name: walletName, Namespace: walletNamespace}
+
+		// The wallet which is left to the user is not regenerated
+		if adb.Spec.Details.Wallet.Regenerate == dbv1alpha1.WalletRegenerateNever {
+			By("Changing the wallet regenerate mode to ifMissing")
+			adb.Spec.Details.Wallet.Regenerate = dbv1alpha1.WalletRegenerateIfMissing
+			Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+		}
+
+		instanceWallet := &corev1.Secret{}
+		Eventually(func() ([]byte, error) {
+			err := derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)
+			return instanceWallet.Data["cwallet.sso"], err
+		}, time.Second*60, intervalTime).ShouldNot(BeEmpty())
+		oldCwallet := instanceWallet.Data["cwallet.sso"]
+
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		isMTLSRequired := !*adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired
+
+		By(fmt.Sprintf("Changing isMTLSConnectionRequired to %t", isMTLSRequired))
+		adb.Spec.Details.NetworkAccess.IsMTLSConnectionRequired = common.Bool(isMTLSRequired)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the setting is applied to the database")
+		Eventually(func() (*bool, error) {
+			resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), *dbClient, adb.GetAutonomousDatabaseOCID(), nil)
+			return resp.IsMtlsConnectionRequired, err
+		}, time.Minute*10, intervalTime).Should(Equal(common.Bool(isMTLSRequired)))
+
+		By("Checking the setting of the stored wallet is recorded in the status")
+		Eventually(func() (*bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.WalletMTLSConnectionRequired, err
+		}, time.Minute*5, intervalTime).Should(Equal(common.Bool(isMTLSRequired)))
+
+		By("Checking the content of the wallet Secret changes")
+		Expect(derefK8sClient.Get(context.TODO(), walletLookupKey, instanceWallet)).To(Succeed())
+		Expect(instanceWallet.Data["cwallet.sso"]).ToNot(Equal(oldCwallet))
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+	}
+}
+
+// AssertEncryptionKeyRotation requests the rotateEncryptionKey action, and asserts that the action is removed from the
+// spec once the rotation completes and that the key version in the status changes. It's skipped if the database
+// doesn't use a customer-managed key.
+func AssertEncryptionKeyRotation(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		rotateTimeout := time.Minute * 20
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		if !adb.Status.EncryptionKey.IsCustomerManaged() {
+			Skip("The database doesn't use a customer-managed key")
+		}
+		oldKeyVersion := adb.Status.EncryptionKey.KmsKeyVersionOCID
+
+		By("Requesting the rotateEncryptionKey action")
+		adb.Spec.Action = dbv1alpha1.ADBActionRotateEncryptionKey
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the action is removed from the spec once the rotation completes")
+		Eventually(func() (dbv1alpha1.ADBActionEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Spec.Action, err
+		}, rotateTimeout, intervalTime).Should(BeEmpty())
+
+		By("Checking the key version and the rotation time in the status")
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		Expect(adb.Status.EncryptionKey.KmsKeyVersionOCID).ToNot(Equal(oldKeyVersion))
+		Expect(adb.Status.EncryptionKey.LastRotationTime).ToNot(BeEmpty())
+	}
+}
+
+// AssertConnectionURLsRefresh requests the refreshConnectionURLs action, and asserts that the action is removed from
+// the spec and that the URLs in the status match the database in OCI. It's skipped if the database is not an APEX or
+// AJD database.
+func AssertConnectionURLsRefresh(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		refreshTimeout := time.Minute * 3
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		if !dbv1alpha1.IsConnectionURLsRefreshAllowed(adb.Status.DbWorkload) {
+			Skip("The database is not an APEX or AJD database")
+		}
+
+		By("Requesting the refreshConnectionURLs action")
+		adb.Spec.Action = dbv1alpha1.ADBActionRefreshConnectionURLs
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the action is removed from the spec once the URLs are refreshed")
+		Eventually(func() (dbv1alpha1.ADBActionEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Spec.Action, err
+		}, refreshTimeout, intervalTime).Should(BeEmpty())
+
+		By("Checking the URLs in the status match the database in OCI")
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, adb.GetAutonomousDatabaseOCID(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ConnectionUrls).ToNot(BeNil())
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		expectedADB.UpdateStatusFromOCIADB(resp.AutonomousDatabase)
+		Eventually(func() (dbv1alpha1.ConnectionURLsStatus, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.ConnectionURLs, err
+		}, refreshTimeout, intervalTime).Should(Equal(expectedADB.Status.ConnectionURLs))
+	}
+}
+
+// AssertShrink requests the shrink action, and asserts that the action is removed from the spec once the shrink
+// completes and that the allocated storage doesn't grow. The allocated storage only decreases if the database has
+// unused storage to reclaim. It's skipped if the database doesn't support the shrink.
+func AssertShrink(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		shrinkTimeout := time.Minute * 30
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		if !adb.IsShrinkAllowed() {
+			Skip("The database doesn't support the shrink")
+		}
+		allocatedBefore := adb.Status.AllocatedStorageSizeInGBs
+
+		By("Requesting the shrink action")
+		adb.Spec.Action = dbv1alpha1.ADBActionShrink
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the action is removed from the spec once the shrink completes")
+		Eventually(func() (dbv1alpha1.ADBActionEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Spec.Action, err
+		}, shrinkTimeout, intervalTime).Should(BeEmpty())
+
+		By("Checking the reclaimed storage in the status")
+		Eventually(func() (string, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.Shrink.LastShrinkTime, err
+		}, time.Minute*3, intervalTime).ShouldNot(BeEmpty())
+
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		Expect(adb.Status.AllocatedStorageSizeInGBs).To(BeNumerically("<=", allocatedBefore))
+		Expect(adb.Status.Shrink.ReclaimedStorageSizeInGBs).To(Equal(allocatedBefore - adb.Status.AllocatedStorageSizeInGBs))
+	}
+}
+
+// AssertCloneDetach confirms and requests the detachClone action, and asserts that the database becomes a standalone
+// database and the action is removed from the spec. It's skipped if the database is not a refreshable clone.
+func AssertCloneDetach(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		detachTimeout := time.Minute * 20
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		if !adb.Status.IsRefreshableClone {
+			Skip("The database is not a refreshable clone")
+		}
+
+		By("Requesting the detachClone action with the confirmation")
+		anns := adb.GetAnnotations()
+		if anns == nil {
+			anns = map[string]string{}
+		}
+		anns[dbv1alpha1.ConfirmDetachAnnotation] = adb.Status.DbName
+		adb.SetAnnotations(anns)
+		adb.Spec.Action = dbv1alpha1.ADBActionDetachClone
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the action is removed from the spec once the clone is detached")
+		Eventually(func() (dbv1alpha1.ADBActionEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Spec.Action, err
+		}, detachTimeout, intervalTime).Should(BeEmpty())
+
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+		Expect(adb.Status.IsRefreshableClone).To(BeFalse())
+
+		By("Checking the database in OCI is a standalone database")
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, adb.GetAutonomousDatabaseOCID(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.IsRefreshableClone).ToNot(BeNil())
+		Expect(*resp.IsRefreshableClone).To(BeFalse())
+	}
+}
+
+// AssertConnectable asserts that the wallet is downloaded, and then enables the health check and waits until
+// the Connected condition becomes true
+func AssertConnectable(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		connectTimeout := time.Second * 180
+
+		AssertWallet(k8sClient, adbLookupKey, dbv1alpha1.WalletRegenerateIfMissing)()
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		By("Enabling the health check of the AutonomousDatabase")
+		adb.Spec.HealthCheck.Enabled = common.Bool(true)
+		adb.Spec.HealthCheck.IntervalSeconds = common.Int(10)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the Connected condition is true")
+		Eventually(func() (metav1.ConditionStatus, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+
+			condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)
+			if condition == nil {
+				return "", nil
+			}
+			return condition.Status, nil
+		}, connectTimeout, intervalTime).Should(Equal(metav1.ConditionTrue))
+
+		// The connectivity check should never change the lifecycleState
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
 	}
 }
 
@@ -227,6 +710,26 @@ func compareStringMap(obj1 map[string]string, obj2 map[string]string) bool {
 	return true
 }
 
+// compareStringSet returns true if the slices have the same elements regardless of the order
+func compareStringSet(obj1 []string, obj2 []string) bool {
+	if len(obj1) != len(obj2) {
+		return false
+	}
+
+	counts := map[string]int{}
+	for _, v := range obj1 {
+		counts[v]++
+	}
+	for _, v := range obj2 {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UpdateDetails updates spec.details from local resource and OCI
 func UpdateDetails(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, newSecretName string, newAdminPassword *string) func() *dbv1alpha1.AutonomousDatabase {
 	return func() *dbv1alpha1.AutonomousDatabase {
@@ -249,7 +752,7 @@ func UpdateDetails(k8sClient *client.Client, dbClient *database.DatabaseClient,
 		// , the List request returns PROVISIONING state. In this case the update request will fail with
 		// conflict state error.
 		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
-			listResp, err := e2eutil.ListAutonomousDatabases(derefDBClient, expectedADB.Spec.Details.CompartmentOCID, expectedADB.Spec.Details.DisplayName)
+			listResp, err := e2eutil.ListAutonomousDatabases(context.TODO(), derefDBClient, common.String(expectedADB.Status.CompartmentOCID), common.String(expectedADB.Status.DisplayName))
 			if err != nil {
 				return "", err
 			}
@@ -261,25 +764,34 @@ func UpdateDetails(k8sClient *client.Client, dbClient *database.DatabaseClient,
 			return database.AutonomousDatabaseLifecycleStateEnum(listResp.Items[0].LifecycleState), nil
 		}, updateADBTimeout, intervalTime).Should(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
 
-		// Update
-		var newDisplayName = *expectedADB.Spec.Details.DisplayName + "_new"
+		// Update. The current values are read from the status, since they might not be set in the spec.
+		var newDisplayName = expectedADB.Status.DisplayName + "_new"
 
 		var newCPUCoreCount int
-		if *expectedADB.Spec.Details.CPUCoreCount == 1 {
+		if expectedADB.Status.CPUCoreCount == 1 {
 			newCPUCoreCount = 2
 		} else {
 			newCPUCoreCount = 1
 		}
 
+		var newBackupRetentionPeriodInDays int
+		if expectedADB.Status.BackupRetentionPeriodInDays == 30 {
+			newBackupRetentionPeriodInDays = 7
+		} else {
+			newBackupRetentionPeriodInDays = 30
+		}
+
 		var newKey = "testKey"
 		var newVal = "testVal"
 
-		By(fmt.Sprintf("Updating the ADB with newDisplayName = %s, newCPUCoreCount = %d and newFreeformTag = %s:%s\n",
-			newDisplayName, newCPUCoreCount, newKey, newVal))
+		By(fmt.Sprintf("Updating the ADB with newDisplayName = %s, newCPUCoreCount = %d, newBackupRetentionPeriodInDays = %d and newFreeformTag = %s:%s\n",
+			newDisplayName, newCPUCoreCount, newBackupRetentionPeriodInDays, newKey, newVal))
 
 		expectedADB.Spec.Details.DisplayName = common.String(newDisplayName)
 		expectedADB.Spec.Details.CPUCoreCount = common.Int(newCPUCoreCount)
+		expectedADB.Spec.Details.BackupRetentionPeriodInDays = common.Int(newBackupRetentionPeriodInDays)
 		expectedADB.Spec.Details.FreeformTags = map[string]string{newKey: newVal}
+		expectedADB.Spec.Details.CustomerContacts = []string{"dba@example.com", "ops@example.com"}
 		expectedADB.Spec.Details.AdminPassword.K8sSecret.Name = common.String(newSecretName)
 
 		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
@@ -301,183 +813,758 @@ func AssertADBDetails(k8sClient *client.Client,
 		Expect(dbClient).NotTo(BeNil())
 		Expect(adbLookupKey).NotTo(BeNil())
 
+		derefK8sClient := *k8sClient
 		derefDBClient := *dbClient
 
-		expectedADBDetails := expectedADB.Spec.Details
 		Eventually(func() (bool, error) {
+			// The backupRetentionPeriodInDays is missing from the OCI object, and the cpuCoreCount can be raised by
+			// the auto scaling, so the values tracked by the controller are read from the status of the local resource
+			currentADB := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, currentADB); err != nil {
+				return false, err
+			}
+
 			// Fetch the ADB from OCI when it's in AVAILABLE state, and retry if its attributes doesn't match the new ADB's attributes
 			retryPolicy := e2eutil.NewLifecycleStateRetryPolicyADB(database.AutonomousDatabaseLifecycleStateAvailable)
-			resp, err := e2eutil.GetAutonomousDatabase(derefDBClient, expectedADB.Spec.Details.AutonomousDatabaseOCID, &retryPolicy)
+			resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, expectedADB.GetAutonomousDatabaseOCID(), &retryPolicy)
 			if err != nil {
 				return false, err
 			}
 
-			debug := false
-			if debug {
-				if !compareString(expectedADBDetails.AutonomousDatabaseOCID, resp.AutonomousDatabase.Id) {
-					fmt.Fprintf(GinkgoWriter, "Expected OCID: %v\nGot: %v\n", expectedADBDetails.AutonomousDatabaseOCID, resp.AutonomousDatabase.Id)
-				}
-				if !compareString(expectedADBDetails.CompartmentOCID, resp.AutonomousDatabase.CompartmentId) {
-					fmt.Fprintf(GinkgoWriter, "Expected CompartmentOCID: %v\nGot: %v\n", expectedADBDetails.CompartmentOCID, resp.CompartmentId)
-				}
-				if !compareString(expectedADBDetails.DisplayName, resp.AutonomousDatabase.DisplayName) {
-					fmt.Fprintf(GinkgoWriter, "Expected DisplayName: %v\nGot: %v\n", expectedADBDetails.DisplayName, resp.AutonomousDatabase.DisplayName)
-				}
-				if !compareString(expectedADBDetails.DbName, resp.AutonomousDatabase.DbName) {
-					fmt.Fprintf(GinkgoWriter, "Expected DbName: %v\nGot:%v\n", expectedADBDetails.DbName, resp.AutonomousDatabase.DbName)
-				}
-				if expectedADBDetails.DbWorkload != resp.AutonomousDatabase.DbWorkload {
-					fmt.Fprintf(GinkgoWriter, "Expected DbWorkload: %v\nGot: %v\n", expectedADBDetails.DbWorkload, resp.AutonomousDatabase.DbWorkload)
-				}
-				if !compareBool(expectedADBDetails.IsDedicated, resp.AutonomousDatabase.IsDedicated) {
-					fmt.Fprintf(GinkgoWriter, "Expected IsDedicated: %v\nGot: %v\n", expectedADBDetails.IsDedicated, resp.AutonomousDatabase.IsDedicated)
-				}
-				if !compareString(expectedADBDetails.DbVersion, resp.AutonomousDatabase.DbVersion) {
-					fmt.Fprintf(GinkgoWriter, "Expected DbVersion: %v\nGot: %v\n", expectedADBDetails.DbVersion, resp.AutonomousDatabase.DbVersion)
-				}
-				if !compareInt(expectedADBDetails.DataStorageSizeInTBs, resp.AutonomousDatabase.DataStorageSizeInTBs) {
-					fmt.Fprintf(GinkgoWriter, "Expected DataStorageSize: %v\nGot: %v\n", expectedADBDetails.DataStorageSizeInTBs, resp.AutonomousDatabase.DataStorageSizeInTBs)
-				}
-				if !compareInt(expectedADBDetails.CPUCoreCount, resp.AutonomousDatabase.CpuCoreCount) {
-					fmt.Fprintf(GinkgoWriter, "Expected CPUCoreCount: %v\nGot: %v\n", expectedADBDetails.CPUCoreCount, resp.AutonomousDatabase.CpuCoreCount)
-				}
-				if !compareBool(expectedADBDetails.IsAutoScalingEnabled, resp.AutonomousDatabase.IsAutoScalingEnabled) {
-					fmt.Fprintf(GinkgoWriter, "Expected IsAutoScalingEnabled: %v\nGot: %v\n", expectedADBDetails.IsAutoScalingEnabled, resp.AutonomousDatabase.IsAutoScalingEnabled)
-				}
-				if !compareStringMap(expectedADBDetails.FreeformTags, resp.AutonomousDatabase.FreeformTags) {
-					fmt.Fprintf(GinkgoWriter, "Expected FreeformTags: %v\nGot: %v\n", expectedADBDetails.FreeformTags, resp.AutonomousDatabase.FreeformTags)
-				}
-				if !compareBool(expectedADBDetails.NetworkAccess.IsAccessControlEnabled, resp.AutonomousDatabase.IsAccessControlEnabled) {
-					fmt.Fprintf(GinkgoWriter, "Expected IsAccessControlEnabled: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.IsAccessControlEnabled, resp.AutonomousDatabase.IsAccessControlEnabled)
-				}
-				if !reflect.DeepEqual(expectedADBDetails.NetworkAccess.AccessControlList, resp.AutonomousDatabase.WhitelistedIps) {
-					fmt.Fprintf(GinkgoWriter, "Expected AccessControlList: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.AccessControlList, resp.AutonomousDatabase.WhitelistedIps)
-				}
-				if !compareBool(expectedADBDetails.NetworkAccess.IsMTLSConnectionRequired, resp.AutonomousDatabase.IsMtlsConnectionRequired) {
-					fmt.Fprintf(GinkgoWriter, "Expected IsMTLSConnectionRequired: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.IsMTLSConnectionRequired, resp.AutonomousDatabase.IsMtlsConnectionRequired)
-				}
-				if !compareString(expectedADBDetails.NetworkAccess.PrivateEndpoint.SubnetOCID, resp.AutonomousDatabase.SubnetId) {
-					fmt.Fprintf(GinkgoWriter, "Expected SubnetOCID: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.PrivateEndpoint.SubnetOCID, resp.AutonomousDatabase.SubnetId)
+			// Compare with the attributes from OCI in the same way as the controller does. The attributes which are
+			// not set in the spec are not managed by the operator, so they are skipped. The adminPassword and the wallet
+			// are skipped as well, since they are missing from e2eutil.GetAutonomousDatabase(). The adminUsername is
+			// missing too, and UpdateFromOCIADB() leaves it as is.
+			// We don't compare LifecycleState in this case. We only make sure that the ADB is in AVAIABLE state before
+			// proceeding to the next test.
+			ociADB := expectedADB.DeepCopy()
+			ociADB.Status = *currentADB.Status.DeepCopy()
+			ociADB.UpdateFromOCIADB(resp.AutonomousDatabase)
+
+			difADB := expectedADB.DeepCopy()
+			difADB.Spec.Details.AdminPassword = dbv1alpha1.PasswordSpec{}
+			difADB.Spec.Details.Wallet = dbv1alpha1.WalletSpec{}
+			difADB.Spec.Details.LifecycleState = ""
+
+			// OCI doesn't keep the order of the customer contacts
+			if difADB.Spec.Details.CustomerContacts != nil {
+				if !compareStringSet(difADB.Spec.Details.CustomerContacts, ociADB.Spec.Details.CustomerContacts) {
+					fmt.Fprintf(GinkgoWriter, "Expected customerContacts: %v\nGot: %v\n",
+						difADB.Spec.Details.CustomerContacts, ociADB.Spec.Details.CustomerContacts)
+					return false, nil
 				}
-				if !reflect.DeepEqual(expectedADBDetails.NetworkAccess.PrivateEndpoint.NsgOCIDs, resp.AutonomousDatabase.NsgIds) {
-					fmt.Fprintf(GinkgoWriter, "Expected NsgOCIDs: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.PrivateEndpoint.NsgOCIDs, resp.AutonomousDatabase.NsgIds)
+				difADB.Spec.Details.CustomerContacts = nil
+			}
+
+			// OCI assigns the private endpoint IP if it's not specified, so it's only compared if it's set
+			expectedIP := difADB.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP
+			if expectedIP != nil {
+				ociIP := ociADB.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP
+				if !compareString(expectedIP, ociIP) {
+					var gotIP = "<nil>"
+					if ociIP != nil {
+						gotIP = *ociIP
+					}
+					fmt.Fprintf(GinkgoWriter, "Expected privateEndpointIP: %s\nGot: %s\n", *expectedIP, gotIP)
+					return false, nil
 				}
-				if !compareString(expectedADBDetails.NetworkAccess.PrivateEndpoint.HostnamePrefix, resp.AutonomousDatabase.PrivateEndpointLabel) {
-					fmt.Fprintf(GinkgoWriter, "Expected HostnamePrefix: %v\nGot: %v\n", expectedADBDetails.NetworkAccess.PrivateEndpoint.HostnamePrefix, resp.AutonomousDatabase.PrivateEndpointLabel)
+				difADB.Spec.Details.NetworkAccess.PrivateEndpoint.PrivateEndpointIP = nil
+			}
+
+			// The access control is compared on its own, since OCI keeps the inactive whitelist once it's disabled
+			expectedAccessControl := difADB.Spec.Details.NetworkAccess.IsAccessControlEnabled
+			if expectedAccessControl != nil {
+				ociAccessControl := ociADB.Spec.Details.NetworkAccess.IsAccessControlEnabled
+				if !compareBool(expectedAccessControl, ociAccessControl) {
+					var gotAccessControl = "<nil>"
+					if ociAccessControl != nil {
+						gotAccessControl = fmt.Sprintf("%t", *ociAccessControl)
+					}
+					fmt.Fprintf(GinkgoWriter, "Expected isAccessControlEnabled: %t\nGot: %s\n", *expectedAccessControl, gotAccessControl)
+					return false, nil
 				}
+				difADB.Spec.Details.NetworkAccess.IsAccessControlEnabled = nil
 			}
 
-			// Compare the elements one by one rather than doing reflect.DeelEqual(adb1, adb2), since some parameters
-			// (e.g. adminPassword, wallet) are missing from e2eutil.GetAutonomousDatabase().
-			// We don't compare LifecycleState in this case. We only make sure that the ADB is in AVAIABLE state before
-			// proceeding to the next test.
-			same := compareString(expectedADBDetails.AutonomousDatabaseOCID, resp.AutonomousDatabase.Id) &&
-				compareString(expectedADBDetails.CompartmentOCID, resp.AutonomousDatabase.CompartmentId) &&
-				compareString(expectedADBDetails.DisplayName, resp.AutonomousDatabase.DisplayName) &&
-				compareString(expectedADBDetails.DbName, resp.AutonomousDatabase.DbName) &&
-				expectedADBDetails.DbWorkload == resp.AutonomousDatabase.DbWorkload &&
-				compareBool(expectedADBDetails.IsDedicated, resp.AutonomousDatabase.IsDedicated) &&
-				compareString(expectedADBDetails.DbVersion, resp.AutonomousDatabase.DbVersion) &&
-				compareInt(expectedADBDetails.DataStorageSizeInTBs, resp.AutonomousDatabase.DataStorageSizeInTBs) &&
-				compareInt(expectedADBDetails.CPUCoreCount, resp.AutonomousDatabase.CpuCoreCount) &&
-				compareBool(expectedADBDetails.IsAutoScalingEnabled, resp.AutonomousDatabase.IsAutoScalingEnabled) &&
-				compareStringMap(expectedADBDetails.FreeformTags, resp.AutonomousDatabase.FreeformTags) &&
-				compareBool(expectedADBDetails.NetworkAccess.IsAccessControlEnabled, resp.AutonomousDatabase.IsAccessControlEnabled) &&
-				reflect.DeepEqual(expectedADBDetails.NetworkAccess.AccessControlList, resp.AutonomousDatabase.WhitelistedIps) &&
-				compareBool(expectedADBDetails.NetworkAccess.IsMTLSConnectionRequired, resp.AutonomousDatabase.IsMtlsConnectionRequired) &&
-				compareString(expectedADBDetails.NetworkAccess.PrivateEndpoint.SubnetOCID, resp.AutonomousDatabase.SubnetId) &&
-				reflect.DeepEqual(expectedADBDetails.NetworkAccess.PrivateEndpoint.NsgOCIDs, resp.AutonomousDatabase.NsgIds) &&
-				compareString(expectedADBDetails.NetworkAccess.PrivateEndpoint.HostnamePrefix, resp.AutonomousDatabase.PrivateEndpointLabel)
+			// The nsgOCIDs are compared regardless of the order, since OCI doesn't keep it
+			changed, err := difADB.RemoveUnchangedDetails(ociADB.Spec)
+			if err != nil {
+				return false, err
+			}
+
+			if changed {
+				fmt.Fprintf(GinkgoWriter, "The attributes don't match yet: %+v\n", difADB.Spec.Details)
+			}
+
+			return !changed, nil
+		}, updateADBTimeout, intervalTime).Should(BeTrue())
+
+		// IMPORTANT: make sure the local resource has finished reconciling, otherwise the changes will
+		// be conflicted with the next test and cause unknow result.
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+	}
+}
+
+func TestNetworkAccessRestricted(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, isMTLSConnectionRequired bool) func() {
+	return func() {
+		networkRestrictedSpec := dbv1alpha1.NetworkAccessSpec{
+			AccessType:               dbv1alpha1.NetworkAccessTypeRestricted,
+			IsMTLSConnectionRequired: common.Bool(isMTLSConnectionRequired),
+			AccessControlList:        []string{"192.168.0.1"},
+		}
+
+		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkRestrictedSpec)()
+	}
+}
+
+/* Runs a script that connects to an ADB */
+func AssertAdminPassword(dbClient *database.DatabaseClient, databaseOCID *string, tnsEntry *string, adminPassword *string, walletPassword *string) error {
+	By("Downloading wallet zip")
+	walletZip, err := e2eutil.DownloadWalletZip(context.TODO(), *dbClient, databaseOCID, walletPassword)
+	if err != nil {
+		fmt.Fprint(GinkgoWriter, err)
+		panic(err)
+	}
+	fmt.Fprint(GinkgoWriter, walletZip+" successfully downloaded.\n")
+
+	By("Installing SQLcl")
+	if _, err := os.Stat("sqlcl-latest.zip"); errors.Is(err, os.ErrNotExist) {
+		cmd := exec.Command("wget", "https://download.oracle.com/otn_software/java/sqldeveloper/sqlcl-latest.zip")
+		_, err = cmd.Output()
+		Expect(err).To(BeNil())
+		cmd = exec.Command("unzip", "sqlcl-latest.zip")
+		_, err = cmd.Output()
+		Expect(err).To(BeNil())
+	}
+
+	proxy := os.Getenv("HTTP_PROXY")
+
+	By("Verify the adb connection")
+	cmd := exec.Command("./sqlcl/bin/sql", "/nolog", "@verify_connection.sql", proxy, walletZip, *adminPassword, strings.ToLower(*tnsEntry))
+	stdout, err := cmd.Output()
+
+	fmt.Fprint(GinkgoWriter, string(stdout))
+
+	return err
+}
+
+func TestNetworkAccessPrivate(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, isMTLSConnectionRequired bool, subnetOCID *string, nsgOCIDs *string) func() {
+	return func() {
+		Expect(*subnetOCID).ToNot(Equal(""))
+		Expect(*nsgOCIDs).ToNot(Equal(""))
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		derefK8sClient := *k8sClient
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).Should(Succeed())
+
+		networkPrivateSpec := dbv1alpha1.NetworkAccessSpec{
+			AccessType:               dbv1alpha1.NetworkAccessTypePrivate,
+			AccessControlList:        []string{},
+			IsMTLSConnectionRequired: common.Bool(isMTLSConnectionRequired),
+			PrivateEndpoint: dbv1alpha1.PrivateEndpointSpec{
+				HostnamePrefix: adb.Spec.Details.DbName,
+				NsgOCIDs:       []string{*nsgOCIDs},
+				SubnetOCID:     common.String(*subnetOCID),
+			},
+		}
+
+		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkPrivateSpec)()
+	}
+}
+
+func TestNetworkAccessPublic(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		networkPublicSpec := dbv1alpha1.NetworkAccessSpec{
+			AccessType:               dbv1alpha1.NetworkAccessTypePublic,
+			IsMTLSConnectionRequired: common.Bool(true),
+		}
+
+		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkPublicSpec)()
+	}
+}
+
+func TestNetworkAccess(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, networkSpec dbv1alpha1.NetworkAccessSpec) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		AssertADBState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		adb.Spec.Details.NetworkAccess = networkSpec
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, adb)()
+	}
+}
+
+// UpdateAndAssertDetails changes the below fields:
+// displayName: "bar" -> "bar_new"
+// adminPassword: "foo" -> "foo_new",
+// cpuCoreCount: from 1 to 2, or from 2 to 1
+// backupRetentionPeriodInDays: from 30 to 7, or to 30 otherwise
+func UpdateAndAssertDetails(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, newSecretName string, newAdminPassword *string, walletPassword *string) func() {
+	return func() {
+		expectedADB := UpdateDetails(k8sClient, dbClient, adbLookupKey, newSecretName, newAdminPassword)()
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+
+		ocid := expectedADB.GetAutonomousDatabaseOCID()
+		tnsEntry := expectedADB.Status.DbName + "_high"
+		err := AssertAdminPassword(dbClient, ocid, &tnsEntry, newAdminPassword, walletPassword)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+}
+
+// AssertUpdateRollback changes the displayName along with the admin password in a K8s Secret which doesn't exist yet,
+// so that the update fails after the displayName is applied. It asserts that the spec is not recorded as applied
+// until the Secret is created, and that the remaining change is retried until the database converges with the spec.
+func AssertUpdateRollback(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, newSecretName string, newAdminPassword *string) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+		Expect(newAdminPassword).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		var newDisplayName = expectedADB.Status.DisplayName + "_rb"
+
+		By(fmt.Sprintf("Updating the ADB with newDisplayName = %s and the missing K8s Secret %s\n", newDisplayName, newSecretName))
+		expectedADB.Spec.Details.DisplayName = common.String(newDisplayName)
+		expectedADB.Spec.Details.AdminPassword.K8sSecret.Name = common.String(newSecretName)
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
+
+		By("Checking the displayName is applied before the update fails")
+		Eventually(func() (string, error) {
+			resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, expectedADB.GetAutonomousDatabaseOCID(), nil)
+			if err != nil {
+				return "", err
+			}
+			return *resp.DisplayName, nil
+		}, updateADBTimeout, intervalTime).Should(Equal(newDisplayName))
+
+		currentADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, currentADB)).To(Succeed())
+		Expect(currentADB.Status.ObservedGeneration).To(BeNumerically("<", currentADB.GetGeneration()))
+
+		By("Creating the K8s Secret " + newSecretName)
+		data := map[string]string{
+			newSecretName: *newAdminPassword,
+		}
+		adminSecret, err := e2eutil.CreateKubeSecret(adbLookupKey.Namespace, newSecretName, data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(derefK8sClient.Create(context.TODO(), adminSecret)).To(Succeed())
+
+		By("Checking the failed update is retried until the spec is applied")
+		Eventually(func() (bool, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return false, err
+			}
+			return adb.Status.ObservedGeneration == adb.GetGeneration(), nil
+		}, updateADBTimeout, intervalTime).Should(BeTrue())
+
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+	}
+}
+
+// AssertAdminPasswordRotation changes the admin password to the one in the K8s Secret newSecretName while the health
+// check is enabled. It asserts that the AdminPasswordRotating condition is true until the new password is confirmed by
+// the connectivity check, and that the database accepts the new password once the Connected condition is true.
+func AssertAdminPasswordRotation(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, newSecretName string, newAdminPassword *string, walletPassword *string) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+		Expect(newAdminPassword).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		Expect(adb.Spec.HealthCheck.Enabled).To(Equal(common.Bool(true)))
+
+		By("Updating the ADB with the admin password in the K8s Secret " + newSecretName)
+		adb.Spec.Details.AdminPassword.K8sSecret.Name = common.String(newSecretName)
+		adb.Spec.Details.AdminPassword.OCISecret.OCID = nil
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		getCondition := func(conditionType string) (*metav1.Condition, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return nil, err
+			}
+			return meta.FindStatusCondition(adb.Status.Conditions, conditionType), nil
+		}
+
+		By("Checking the AdminPasswordRotating condition is true while the password is being changed")
+		Eventually(func() (metav1.ConditionStatus, error) {
+			condition, err := getCondition(dbv1alpha1.ADBConditionAdminPasswordRotating)
+			if condition == nil {
+				return "", err
+			}
+			// The rotation might be confirmed already if the update completes between two polls
+			if condition.Status == metav1.ConditionFalse && condition.ObservedGeneration == adb.GetGeneration() {
+				return metav1.ConditionTrue, nil
+			}
+			return condition.Status, err
+		}, changeTimeout, intervalTime).Should(Equal(metav1.ConditionTrue))
+
+		By("Checking the rotation is confirmed by the connectivity check")
+		Eventually(func() (string, error) {
+			condition, err := getCondition(dbv1alpha1.ADBConditionAdminPasswordRotating)
+			if condition == nil || condition.Status != metav1.ConditionFalse {
+				return "", err
+			}
+			return condition.Reason, err
+		}, updateADBTimeout, intervalTime).Should(Equal("Confirmed"))
+
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionConnected)).To(BeTrue())
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+
+		tnsEntry := adb.Status.DbName + "_high"
+		err := AssertAdminPassword(dbClient, adb.GetAutonomousDatabaseOCID(), &tnsEntry, newAdminPassword, walletPassword)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+}
+
+// AssertConflictRetry scales the database directly in OCI, and then changes the displayName and the cpuCoreCount of
+// the resource while the database is transitioning. The update of the resource is retried if it conflicts with the
+// status written by the controller, or if it's rejected because the controller has observed the transition. It
+// asserts that the controller defers the change until the database is AVAILABLE, and then converges without an error.
+func AssertConflictRetry(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefDBClient := *dbClient
+		countingClient := e2eutil.NewCountingClient(*k8sClient)
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(countingClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		newCPUCoreCount := 2
+		if expectedADB.Status.CPUCoreCount == 2 {
+			newCPUCoreCount = 1
+		}
+		newDisplayName := expectedADB.Status.DisplayName + "_cr"
+
+		By(fmt.Sprintf("Scaling the ADB in OCI to cpuCoreCount = %d\n", newCPUCoreCount))
+		_, err := e2eutil.ScaleAutonomousDatabase(context.TODO(), derefDBClient, expectedADB.GetAutonomousDatabaseOCID(), newCPUCoreCount)
+		Expect(err).NotTo(HaveOccurred())
+
+		By(fmt.Sprintf("Updating the ADB with newDisplayName = %s while the database is transitioning\n", newDisplayName))
+		Eventually(func() error {
+			if err := countingClient.Get(context.TODO(), *adbLookupKey, expectedADB); err != nil {
+				return err
+			}
+			expectedADB.Spec.Details.DisplayName = common.String(newDisplayName)
+			expectedADB.Spec.Details.CPUCoreCount = common.Int(newCPUCoreCount)
+			return countingClient.Update(context.TODO(), expectedADB)
+		}, updateADBTimeout, intervalTime).Should(Succeed())
+
+		fmt.Fprintf(GinkgoWriter, "The update was retried after %d conflicts and %d rejections\n",
+			countingClient.Failures(metav1.StatusReasonConflict), countingClient.Failures(metav1.StatusReasonForbidden))
+
+		By("Checking the controller converges without an error")
+		Eventually(func() (bool, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := countingClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return false, err
+			}
+			return adb.Status.ObservedGeneration == adb.GetGeneration() && adb.Status.LastError == "", nil
+		}, updateADBTimeout, intervalTime).Should(BeTrue())
+
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+	}
+}
+
+// UpdateAndAssertAutoScaling flips isAutoScalingEnabled and isAutoScalingForStorageEnabled,
+// and asserts that cpuCoreCount and dataStorageSizeInTBs remain the same, and the maxStorageSizeInTBs follows the
+// storage auto scaling
+func UpdateAndAssertAutoScaling(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		isAutoScalingEnabled := !expectedADB.Status.IsAutoScalingEnabled
+		isAutoScalingForStorageEnabled := !expectedADB.Status.IsAutoScalingForStorageEnabled
+
+		By(fmt.Sprintf("Updating the ADB with isAutoScalingEnabled = %t and isAutoScalingForStorageEnabled = %t\n",
+			isAutoScalingEnabled, isAutoScalingForStorageEnabled))
+
+		expectedADB.Spec.Details.IsAutoScalingEnabled = common.Bool(isAutoScalingEnabled)
+		expectedADB.Spec.Details.IsAutoScalingForStorageEnabled = common.Bool(isAutoScalingForStorageEnabled)
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
+
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+
+		By("Checking the max storage follows the storage auto scaling")
+		expectedMaxStorage := expectedADB.Status.DataStorageSizeInTBs
+		if isAutoScalingForStorageEnabled {
+			expectedMaxStorage *= 3
+		}
+		Eventually(func() (int, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.MaxStorageSizeInTBs, err
+		}, changeTimeout, intervalTime).Should(Equal(expectedMaxStorage))
+	}
+}
+
+// UpdateAndAssertDbWorkload changes the dbWorkload to a type that OCI allows, and asserts that the
+// DbWorkloadUpdating condition is set to false once the transition completes
+func UpdateAndAssertDbWorkload(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, dbWorkload database.AutonomousDatabaseDbWorkloadEnum) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		By(fmt.Sprintf("Updating the ADB with dbWorkload = %s\n", dbWorkload))
+		expectedADB.Spec.Details.DbWorkload = dbWorkload
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
+
+		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+
+		By("Checking the DbWorkloadUpdating condition is false")
+		Eventually(func() (metav1.ConditionStatus, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+
+			condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionDbWorkloadUpdating)
+			if condition == nil {
+				return "", nil
+			}
+			return condition.Status, nil
+		}, changeTimeout, intervalTime).Should(Equal(metav1.ConditionFalse))
+	}
+}
+
+// AssertDatabaseManagement sets isDatabaseManagementEnabled, and asserts that the status of Database Management in
+// the resource and in OCI becomes ENABLED or NOT_ENABLED accordingly
+func AssertDatabaseManagement(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, enabled bool) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		expectedStatus := database.AutonomousDatabaseDatabaseManagementStatusNotEnabled
+		if enabled {
+			expectedStatus = database.AutonomousDatabaseDatabaseManagementStatusEnabled
+		}
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		By(fmt.Sprintf("Updating the ADB with isDatabaseManagementEnabled = %t\n", enabled))
+		adb.Spec.Details.IsDatabaseManagementEnabled = common.Bool(enabled)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the status of Database Management in the resource is " + string(expectedStatus))
+		Eventually(func() (database.AutonomousDatabaseDatabaseManagementStatusEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.DatabaseManagementStatus, err
+		}, updateADBTimeout, intervalTime).Should(Equal(expectedStatus))
+
+		By("Checking the status of Database Management in OCI is " + string(expectedStatus))
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, adb.GetAutonomousDatabaseOCID(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.DatabaseManagementStatus).To(Equal(expectedStatus))
+		Expect(adb.Status.ObservedGeneration).To(Equal(adb.GetGeneration()))
+	}
+}
+
+// AssertOperationsInsights sets isOperationsInsightsEnabled, and asserts that the status of Operations Insights in
+// the resource and in OCI becomes ENABLED or NOT_ENABLED accordingly
+func AssertOperationsInsights(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, enabled bool) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		expectedStatus := database.AutonomousDatabaseOperationsInsightsStatusNotEnabled
+		if enabled {
+			expectedStatus = database.AutonomousDatabaseOperationsInsightsStatusEnabled
+		}
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		By(fmt.Sprintf("Updating the ADB with isOperationsInsightsEnabled = %t\n", enabled))
+		adb.Spec.Details.IsOperationsInsightsEnabled = common.Bool(enabled)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the status of Operations Insights in the resource is " + string(expectedStatus))
+		Eventually(func() (database.AutonomousDatabaseOperationsInsightsStatusEnum, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.OperationsInsightsStatus, err
+		}, updateADBTimeout, intervalTime).Should(Equal(expectedStatus))
+
+		By("Checking the status of Operations Insights in OCI is " + string(expectedStatus))
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), derefDBClient, adb.GetAutonomousDatabaseOCID(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.OperationsInsightsStatus).To(Equal(expectedStatus))
+		Expect(adb.Status.ObservedGeneration).To(Equal(adb.GetGeneration()))
+	}
+}
+
+// UpdateAndAssertStopBeforeScaling enables stopBeforeScaling and changes the cpuCoreCount, and then asserts the
+// database is stopped, scaled and started again
+func UpdateAndAssertStopBeforeScaling(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		var newCPUCoreCount int
+		if expectedADB.Status.CPUCoreCount == 1 {
+			newCPUCoreCount = 2
+		} else {
+			newCPUCoreCount = 1
+		}
+
+		By(fmt.Sprintf("Updating the ADB with stopBeforeScaling = true and cpuCoreCount = %d\n", newCPUCoreCount))
+		expectedADB.Spec.Details.StopBeforeScaling = common.Bool(true)
+		expectedADB.Spec.Details.CPUCoreCount = common.Int(newCPUCoreCount)
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
+
+		stoppedForScaling := func() (metav1.ConditionStatus, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+
+			condition := meta.FindStatusCondition(adb.Status.Conditions, dbv1alpha1.ADBConditionStoppedForScaling)
+			if condition == nil {
+				return "", nil
+			}
+			return condition.Status, nil
+		}
 
-			return same, nil
-		}, updateADBTimeout, intervalTime).Should(BeTrue())
+		By("Checking the database is stopped for scaling")
+		Eventually(stoppedForScaling, changeTimeout, intervalTime).Should(Equal(metav1.ConditionTrue))
+		AssertADBRemoteState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateStopped)()
 
-		// IMPORTANT: make sure the local resource has finished reconciling, otherwise the changes will
-		// be conflicted with the next test and cause unknow result.
-		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+		By("Checking the database is scaled and started again")
+		Eventually(stoppedForScaling, updateADBTimeout, intervalTime).Should(Equal(metav1.ConditionFalse))
+		AssertADBState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		scaledADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, scaledADB)).To(Succeed())
+		Expect(scaledADB.Status.CPUCoreCount).To(Equal(newCPUCoreCount))
 	}
 }
 
-func TestNetworkAccessRestricted(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, isMTLSConnectionRequired bool) func() {
+// UpdateAndAssertDisasterRecoveryType changes the disasterRecoveryType, and asserts the type is recorded in the
+// status once the database is AVAILABLE again
+func UpdateAndAssertDisasterRecoveryType(k8sClient *client.Client, adbLookupKey *types.NamespacedName, drType dbv1alpha1.DisasterRecoveryTypeEnum) func() {
 	return func() {
-		networkRestrictedSpec := dbv1alpha1.NetworkAccessSpec{
-			AccessType:               dbv1alpha1.NetworkAccessTypeRestricted,
-			IsMTLSConnectionRequired: common.Bool(isMTLSConnectionRequired),
-			AccessControlList:        []string{"192.168.0.1"},
-		}
-
-		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkRestrictedSpec)()
-	}
-}
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
 
-/* Runs a script that connects to an ADB */
-func AssertAdminPassword(dbClient *database.DatabaseClient, databaseOCID *string, tnsEntry *string, adminPassword *string, walletPassword *string) error {
-	By("Downloading wallet zip")
-	walletZip, err := e2eutil.DownloadWalletZip(*dbClient, databaseOCID, walletPassword)
-	if err != nil {
-		fmt.Fprint(GinkgoWriter, err)
-		panic(err)
-	}
-	fmt.Fprint(GinkgoWriter, walletZip+" successfully downloaded.\n")
+		derefK8sClient := *k8sClient
 
-	By("Installing SQLcl")
-	if _, err := os.Stat("sqlcl-latest.zip"); errors.Is(err, os.ErrNotExist) {
-		cmd := exec.Command("wget", "https://download.oracle.com/otn_software/java/sqldeveloper/sqlcl-latest.zip")
-		_, err = cmd.Output()
-		Expect(err).To(BeNil())
-		cmd = exec.Command("unzip", "sqlcl-latest.zip")
-		_, err = cmd.Output()
-		Expect(err).To(BeNil())
-	}
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
 
-	proxy := os.Getenv("HTTP_PROXY")
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
 
-	By("Verify the adb connection")
-	cmd := exec.Command("./sqlcl/bin/sql", "/nolog", "@verify_connection.sql", proxy, walletZip, *adminPassword, strings.ToLower(*tnsEntry))
-	stdout, err := cmd.Output()
+		By(fmt.Sprintf("Updating the ADB with disasterRecoveryType = %s\n", drType))
+		expectedADB.Spec.Details.DisasterRecoveryType = drType
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
 
-	fmt.Fprint(GinkgoWriter, string(stdout))
+		By("Checking the disaster recovery type in the status")
+		Eventually(func() (dbv1alpha1.DisasterRecoveryTypeEnum, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+			return adb.Status.DisasterRecovery.Type, nil
+		}, changeTimeout, intervalTime).Should(Equal(drType))
 
-	return err
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+	}
 }
 
-func TestNetworkAccessPrivate(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, isMTLSConnectionRequired bool, subnetOCID *string, nsgOCIDs *string) func() {
+// UpdateAndAssertDisasterRecoveryPeer creates the disaster recovery peer in the peerRegion, and asserts the peer
+// becomes an AVAILABLE standby in that region. The test is skipped if the peerRegion is empty.
+func UpdateAndAssertDisasterRecoveryPeer(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, peerRegion *string) func() {
 	return func() {
-		Expect(*subnetOCID).ToNot(Equal(""))
-		Expect(*nsgOCIDs).ToNot(Equal(""))
+		if peerRegion == nil || *peerRegion == "" {
+			Skip("disasterRecoveryPeerRegion is not set in the test configuration")
+		}
+
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
 
-		adb := &dbv1alpha1.AutonomousDatabase{}
 		derefK8sClient := *k8sClient
-		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).Should(Succeed())
 
-		networkPrivateSpec := dbv1alpha1.NetworkAccessSpec{
-			AccessType:               dbv1alpha1.NetworkAccessTypePrivate,
-			AccessControlList:        []string{},
-			IsMTLSConnectionRequired: common.Bool(isMTLSConnectionRequired),
-			PrivateEndpoint: dbv1alpha1.PrivateEndpointSpec{
-				HostnamePrefix: adb.Spec.Details.DbName,
-				NsgOCIDs:       []string{*nsgOCIDs},
-				SubnetOCID:     common.String(*subnetOCID),
-			},
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		expectedADB := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, expectedADB)).To(Succeed())
+
+		By("Updating the ADB with a disaster recovery peer in " + *peerRegion)
+		expectedADB.Spec.Details.DisasterRecoveryPeer = dbv1alpha1.DisasterRecoveryPeerSpec{
+			Region:               common.String(*peerRegion),
+			DisasterRecoveryType: dbv1alpha1.DisasterRecoveryTypeBackupBased,
 		}
+		Expect(derefK8sClient.Update(context.TODO(), expectedADB)).To(Succeed())
 
-		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkPrivateSpec)()
+		By("Checking the disaster recovery peer is recorded in the status")
+		var peerStatus dbv1alpha1.DisasterRecoveryPeerStatus
+		Eventually(func() (string, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+			for _, peer := range adb.Status.DisasterRecovery.Peers {
+				if strings.EqualFold(peer.Region, *peerRegion) {
+					peerStatus = peer
+				}
+			}
+			return peerStatus.AutonomousDatabaseOCID, nil
+		}, changeTimeout, intervalTime).ShouldNot(BeEmpty())
+
+		By("Checking the disaster recovery peer is an AVAILABLE standby in " + *peerRegion)
+		// The client is a copy, so the region of the dbClient is not changed
+		regionalClient := *dbClient
+		regionalClient.SetRegion(*peerRegion)
+
+		peerOCID := common.String(peerStatus.AutonomousDatabaseOCID)
+		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
+			resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), regionalClient, peerOCID, nil)
+			if err != nil {
+				return "", err
+			}
+			return resp.LifecycleState, nil
+		}, drPeerTimeout, time.Second*20).Should(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), regionalClient, peerOCID, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Role).To(Equal(database.AutonomousDatabaseRoleStandby))
+
+		By("Checking the peer is listed in the status of the primary")
+		Eventually(func() ([]string, error) {
+			adb := &dbv1alpha1.AutonomousDatabase{}
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return nil, err
+			}
+			return adb.Status.DisasterRecovery.PeerAutonomousDatabaseOCIDs, nil
+		}, changeTimeout, intervalTime).Should(ContainElement(*peerOCID))
 	}
 }
 
-func TestNetworkAccessPublic(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+// AssertStandbyPeerAdded adds the region to spec.details.disasterRecoveryPeers, and asserts that an AVAILABLE standby
+// peer is created in the region and reported in the status with its role
+func AssertStandbyPeerAdded(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, peerRegion *string) func() {
 	return func() {
-		networkPublicSpec := dbv1alpha1.NetworkAccessSpec{
-			AccessType:               dbv1alpha1.NetworkAccessTypePublic,
-			IsMTLSConnectionRequired: common.Bool(true),
+		if peerRegion == nil || *peerRegion == "" {
+			Skip("disasterRecoveryPeerRegion is not set in the test configuration")
 		}
 
-		TestNetworkAccess(k8sClient, dbClient, adbLookupKey, networkPublicSpec)()
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		AssertADBLocalState(k8sClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		By("Adding a standby peer in " + *peerRegion)
+		adb.Spec.Details.DisasterRecoveryPeers = append(adb.Spec.Details.DisasterRecoveryPeers, dbv1alpha1.DisasterRecoveryPeerSpec{
+			Region: common.String(*peerRegion),
+		})
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking the standby peer is AVAILABLE in the status")
+		var peer dbv1alpha1.DisasterRecoveryPeerStatus
+		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return "", err
+			}
+			for _, p := range adb.Status.DisasterRecovery.Peers {
+				if p.Region == *peerRegion {
+					peer = p
+					return p.LifecycleState, nil
+				}
+			}
+			return "", nil
+		}, drPeerTimeout, time.Second*20).Should(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+
+		Expect(peer.DisasterRecoveryType).To(Equal(dbv1alpha1.DisasterRecoveryTypeADG))
+		Expect(peer.Role).To(Equal(database.AutonomousDatabaseRoleStandby))
+
+		By("Checking the standby peer in " + *peerRegion)
+		// The client is a copy, so the region of the dbClient is not changed
+		regionalClient := *dbClient
+		regionalClient.SetRegion(*peerRegion)
+
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), regionalClient, common.String(peer.AutonomousDatabaseOCID), nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.Role).To(Equal(database.AutonomousDatabaseRoleStandby))
 	}
 }
 
-func TestNetworkAccess(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, networkSpec dbv1alpha1.NetworkAccessSpec) func() {
+// AssertStandbyPeerRemoved removes the region from spec.details.disasterRecoveryPeers, and asserts that the standby
+// peer in the region is terminated and removed from the status
+func AssertStandbyPeerRemoved(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, peerRegion *string) func() {
 	return func() {
+		if peerRegion == nil || *peerRegion == "" {
+			Skip("disasterRecoveryPeerRegion is not set in the test configuration")
+		}
+
 		Expect(k8sClient).NotTo(BeNil())
 		Expect(dbClient).NotTo(BeNil())
 		Expect(adbLookupKey).NotTo(BeNil())
@@ -485,28 +1572,41 @@ func TestNetworkAccess(k8sClient *client.Client, dbClient *database.DatabaseClie
 		derefK8sClient := *k8sClient
 
 		adb := &dbv1alpha1.AutonomousDatabase{}
-		AssertADBState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		Expect(adb.HasDisasterRecoveryPeerIn(*peerRegion)).To(BeTrue())
 
-		adb.Spec.Details.NetworkAccess = networkSpec
+		var peerOCID string
+		for _, p := range adb.Status.DisasterRecovery.Peers {
+			if p.Region == *peerRegion {
+				peerOCID = p.AutonomousDatabaseOCID
+			}
+		}
+
+		By("Removing the standby peer in " + *peerRegion)
+		var peers []dbv1alpha1.DisasterRecoveryPeerSpec
+		for _, p := range adb.Spec.Details.DisasterRecoveryPeers {
+			if p.Region == nil || *p.Region != *peerRegion {
+				peers = append(peers, p)
+			}
+		}
+		adb.Spec.Details.DisasterRecoveryPeers = peers
 		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
-		AssertADBDetails(k8sClient, dbClient, adbLookupKey, adb)()
-	}
-}
 
-// UpdateAndAssertDetails changes the below fields:
-// displayName: "bar" -> "bar_new"
-// adminPassword: "foo" -> "foo_new",
-// cpuCoreCount: from 1 to 2, or from 2 to 1
-func UpdateAndAssertDetails(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, newSecretName string, newAdminPassword *string, walletPassword *string) func() {
-	return func() {
-		expectedADB := UpdateDetails(k8sClient, dbClient, adbLookupKey, newSecretName, newAdminPassword)()
-		AssertADBDetails(k8sClient, dbClient, adbLookupKey, expectedADB)()
+		By("Checking the standby peer is removed from the status")
+		Eventually(func() (bool, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return false, err
+			}
+			return adb.HasDisasterRecoveryPeerIn(*peerRegion), nil
+		}, drPeerTimeout, time.Second*20).Should(BeFalse())
 
-		ocid := expectedADB.Spec.Details.AutonomousDatabaseOCID
-		tnsEntry := *expectedADB.Spec.Details.DbName + "_high"
-		err := AssertAdminPassword(dbClient, ocid, &tnsEntry, newAdminPassword, walletPassword)
+		By("Checking the standby peer is TERMINATED in " + *peerRegion)
+		regionalClient := *dbClient
+		regionalClient.SetRegion(*peerRegion)
+
+		resp, err := e2eutil.GetAutonomousDatabase(context.TODO(), regionalClient, common.String(peerOCID), nil)
 		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateTerminated))
 	}
 }
 
@@ -529,6 +1629,109 @@ func AssertADBState(k8sClient *client.Client, dbClient *database.DatabaseClient,
 	}
 }
 
+// AssertIdempotentApply applies the spec of the AVAILABLE database again, and asserts that no request is sent to OCI,
+// i.e. the spec has no spurious difference from the database. The sync is only recorded in the status if no request
+// is sent in the reconcile, and any update request changes the state of the database in OCI.
+func AssertIdempotentApply(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		AssertADBState(k8sClient, dbClient, adbLookupKey, database.AutonomousDatabaseLifecycleStateAvailable)()
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+
+		By("Waiting until the spec is synced")
+		Eventually(func() (bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.LastSyncTime != nil && adb.Status.ObservedGeneration == adb.GetGeneration(), err
+		}, changeLocalStateTimeout, intervalTime).Should(BeTrue())
+
+		generation := adb.GetGeneration()
+		lastSyncTime := *adb.Status.LastSyncTime
+
+		By("Applying the same spec again with a forced refresh")
+		// The sync time is recorded in seconds
+		time.Sleep(time.Second)
+		annotations := adb.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[dbv1alpha1.ForceRefreshAnnotation] = ""
+		adb.SetAnnotations(annotations)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+		Expect(adb.GetGeneration()).To(Equal(generation))
+
+		By("Checking if the sync is recorded without any request sent")
+		Eventually(func() (bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return !adb.IsForceRefreshRequested() && adb.Status.LastSyncTime.After(lastSyncTime.Time), err
+		}, changeLocalStateTimeout, intervalTime).Should(BeTrue())
+		Expect(adb.Status.ObservedGeneration).To(Equal(generation))
+		Expect(adb.Status.LifecycleState).To(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+
+		By("Checking if the database in OCI stays AVAILABLE")
+		Consistently(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
+			return returnADBRemoteState(context.TODO(), derefK8sClient, derefDBClient, adb.GetAutonomousDatabaseOCID(), nil)
+		}, time.Minute, intervalTime).Should(Equal(database.AutonomousDatabaseLifecycleStateAvailable))
+	}
+}
+
+// AssertBootstrap asserts the bootstrap SQL in spec.bootstrap.configMapName has run once, and is not run again
+// after a refresh
+func AssertBootstrap(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		Expect(adb.Spec.Bootstrap.ConfigMapName).NotTo(BeNil())
+
+		By("Waiting until the bootstrap SQL is completed")
+		Eventually(func() (bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.Status.Bootstrap.CompletionTime != nil, err
+		}, changeLocalStateTimeout, intervalTime).Should(BeTrue())
+		Expect(adb.Status.Bootstrap.ConfigMapName).To(Equal(*adb.Spec.Bootstrap.ConfigMapName))
+		Expect(meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionBootstrapFailed)).To(BeFalse())
+
+		completionTime := *adb.Status.Bootstrap.CompletionTime
+
+		job := &batchv1.Job{}
+		jobLookupKey := types.NamespacedName{Name: adb.GetName() + "-bootstrap", Namespace: adb.GetNamespace()}
+		Expect(derefK8sClient.Get(context.TODO(), jobLookupKey, job)).To(Succeed())
+		jobUID := job.GetUID()
+
+		By("Refreshing the resource")
+		annotations := adb.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[dbv1alpha1.ForceRefreshAnnotation] = ""
+		adb.SetAnnotations(annotations)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+		By("Checking if the bootstrap SQL is not run again")
+		Consistently(func() (bool, error) {
+			if err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb); err != nil {
+				return false, err
+			}
+			if err := derefK8sClient.Get(context.TODO(), jobLookupKey, job); err != nil {
+				return false, err
+			}
+			return job.GetUID() == jobUID && job.Status.Succeeded == 1 &&
+				adb.Status.Bootstrap.CompletionTime != nil && adb.Status.Bootstrap.CompletionTime.Equal(&completionTime), nil
+		}, time.Minute, intervalTime).Should(BeTrue())
+	}
+}
+
 // AssertHardLinkDelete asserts the database is terminated in OCI when hardLink is set to true
 func AssertHardLinkDelete(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName) func() {
 	return func() {
@@ -541,16 +1744,46 @@ func AssertHardLinkDelete(k8sClient *client.Client, dbClient *database.DatabaseC
 
 		adb := &dbv1alpha1.AutonomousDatabase{}
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+
+		By("Checking if the deletion is rejected without the confirmation")
+		Expect(derefK8sClient.Delete(context.TODO(), adb)).NotTo(Succeed())
+
+		By("Confirming the termination with the dbName")
+		anns := adb.GetAnnotations()
+		if anns == nil {
+			anns = map[string]string{}
+		}
+		anns[dbv1alpha1.ConfirmTerminateAnnotation] = adb.Status.DbName
+		adb.SetAnnotations(anns)
+		Expect(derefK8sClient.Update(context.TODO(), adb)).To(Succeed())
 		Expect(derefK8sClient.Delete(context.TODO(), adb)).To(Succeed())
 
 		By("Checking if the ADB in OCI is in TERMINATING state")
-		// Check every 10 secs for total 60 secs
+		// Check every 10 secs for total 60 secs. The poll in flight is canceled when Eventually gives up.
+		terminatingCtx, cancelTerminating := context.WithTimeout(context.Background(), changeTimeout)
+		defer cancelTerminating()
 		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
 			retryPolicy := e2eutil.NewLifecycleStateRetryPolicyADB(database.AutonomousDatabaseLifecycleStateTerminating)
-			return returnADBRemoteState(derefK8sClient, derefDBClient, adb.Spec.Details.AutonomousDatabaseOCID, &retryPolicy)
+			return returnADBRemoteState(terminatingCtx, derefK8sClient, derefDBClient, adb.GetAutonomousDatabaseOCID(), &retryPolicy)
 		}, changeTimeout).Should(Equal(database.AutonomousDatabaseLifecycleStateTerminating))
 
-		AssertSoftLinkDelete(k8sClient, adbLookupKey)()
+		By("Checking if the AutonomousDatabase resource remains until the ADB in OCI is TERMINATED")
+		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
+		Expect(adb.GetDeletionTimestamp()).NotTo(BeNil())
+
+		By("Checking if the ADB in OCI is in TERMINATED state")
+		terminatedCtx, cancelTerminated := context.WithTimeout(context.Background(), terminateTimeout)
+		defer cancelTerminated()
+		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
+			retryPolicy := e2eutil.NewLifecycleStateRetryPolicyADB(database.AutonomousDatabaseLifecycleStateTerminated)
+			return returnADBRemoteState(terminatedCtx, derefK8sClient, derefDBClient, adb.GetAutonomousDatabaseOCID(), &retryPolicy)
+		}, terminateTimeout, intervalTime).Should(Equal(database.AutonomousDatabaseLifecycleStateTerminated))
+
+		By("Checking if the AutonomousDatabase resource is deleted")
+		Eventually(func() (isDeleted bool) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, &dbv1alpha1.AutonomousDatabase{})
+			return err != nil && k8sErrors.IsNotFound(err)
+		}, changeTimeout, intervalTime).Should(Equal(true))
 	}
 }
 
@@ -564,6 +1797,17 @@ func AssertSoftLinkDelete(k8sClient *client.Client, adbLookupKey *types.Namespac
 
 		existingAdb := &dbv1alpha1.AutonomousDatabase{}
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, existingAdb)).To(Succeed())
+
+		// The wallet in the namespace of the resource is owned by the resource. A wallet in another namespace
+		// cannot have the owner reference, so it's kept.
+		walletLookupKey := types.NamespacedName{Name: existingAdb.Name + "-instance-wallet", Namespace: adbLookupKey.Namespace}
+		if existingAdb.Spec.Details.Wallet.Name != nil {
+			walletLookupKey.Name = *existingAdb.Spec.Details.Wallet.Name
+		}
+		walletNamespace := existingAdb.Spec.Details.Wallet.Namespace
+		walletOwned := (walletNamespace == nil || *walletNamespace == "" || *walletNamespace == adbLookupKey.Namespace) &&
+			derefK8sClient.Get(context.TODO(), walletLookupKey, &corev1.Secret{}) == nil
+
 		Expect(derefK8sClient.Delete(context.TODO(), existingAdb)).To(Succeed())
 
 		By("Checking if the AutonomousDatabase resource is deleted")
@@ -577,6 +1821,14 @@ func AssertSoftLinkDelete(k8sClient *client.Client, adbLookupKey *types.Namespac
 			}
 			return
 		}, changeTimeout, intervalTime).Should(Equal(true))
+
+		if walletOwned {
+			By("Checking if the wallet Secret " + walletLookupKey.Name + " is garbage-collected")
+			Eventually(func() bool {
+				err := derefK8sClient.Get(context.TODO(), walletLookupKey, &corev1.Secret{})
+				return k8sErrors.IsNotFound(err)
+			}, changeTimeout, intervalTime).Should(BeTrue())
+		}
 	}
 }
 
@@ -595,6 +1847,32 @@ func AssertADBLocalState(k8sClient *client.Client, adbLookupKey *types.Namespace
 	}
 }
 
+// AssertADBTerminated asserts that the resource bound to a TERMINATED database reports the Terminated condition, and
+// the reconcile quiesces, i.e. the status is no longer updated
+func AssertADBTerminated(k8sClient *client.Client, adbLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(adbLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		adb := &dbv1alpha1.AutonomousDatabase{}
+
+		By("Checking if the Terminated condition is true")
+		Eventually(func() (bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return meta.IsStatusConditionTrue(adb.Status.Conditions, dbv1alpha1.ADBConditionTerminated), err
+		}, changeLocalStateTimeout, intervalTime).Should(BeTrue())
+
+		By("Checking if the reconcile quiesces")
+		resourceVersion := adb.GetResourceVersion()
+		Consistently(func() (string, error) {
+			err := derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)
+			return adb.GetResourceVersion(), err
+		}, time.Minute, intervalTime).Should(Equal(resourceVersion))
+	}
+}
+
 func AssertADBRemoteState(k8sClient *client.Client, dbClient *database.DatabaseClient, adbLookupKey *types.NamespacedName, state database.AutonomousDatabaseLifecycleStateEnum) func() {
 	return func() {
 		Expect(k8sClient).NotTo(BeNil())
@@ -606,7 +1884,7 @@ func AssertADBRemoteState(k8sClient *client.Client, dbClient *database.DatabaseC
 		adb := &dbv1alpha1.AutonomousDatabase{}
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
 		By("Checking if the lifecycleState of remote resource is " + string(state))
-		AssertADBRemoteStateOCID(k8sClient, dbClient, adb.Spec.Details.AutonomousDatabaseOCID, state, changeTimeout)()
+		AssertADBRemoteStateOCID(k8sClient, dbClient, adb.GetAutonomousDatabaseOCID(), state, changeTimeout)()
 	}
 }
 
@@ -622,7 +1900,7 @@ func AssertADBRemoteStateForBackupRestore(k8sClient *client.Client, dbClient *da
 		adb := &dbv1alpha1.AutonomousDatabase{}
 		Expect(derefK8sClient.Get(context.TODO(), *adbLookupKey, adb)).To(Succeed())
 		By("Checking if the lifecycleState of remote resource is " + string(state))
-		AssertADBRemoteStateOCID(k8sClient, dbClient, adb.Spec.Details.AutonomousDatabaseOCID, state, backupTimeout)()
+		AssertADBRemoteStateOCID(k8sClient, dbClient, adb.GetAutonomousDatabaseOCID(), state, backupTimeout)()
 	}
 }
 
@@ -637,9 +1915,12 @@ func AssertADBRemoteStateOCID(k8sClient *client.Client, dbClient *database.Datab
 		derefK8sClient := *k8sClient
 		derefDBClient := *dbClient
 
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
 		By("Checking if the lifecycleState of the ADB in OCI is " + string(state))
 		Eventually(func() (database.AutonomousDatabaseLifecycleStateEnum, error) {
-			return returnADBRemoteState(derefK8sClient, derefDBClient, adbID, nil)
+			return returnADBRemoteState(ctx, derefK8sClient, derefDBClient, adbID, nil)
 		}, timeout, intervalTime).Should(Equal(state))
 	}
 }
@@ -670,8 +1951,8 @@ func returnADBLocalState(k8sClient client.Client, adbLookupKey types.NamespacedN
 	return adb.Status.LifecycleState, nil
 }
 
-func returnADBRemoteState(k8sClient client.Client, dbClient database.DatabaseClient, adbID *string, retryPolicy *common.RetryPolicy) (database.AutonomousDatabaseLifecycleStateEnum, error) {
-	resp, err := e2eutil.GetAutonomousDatabase(dbClient, adbID, retryPolicy)
+func returnADBRemoteState(ctx context.Context, k8sClient client.Client, dbClient database.DatabaseClient, adbID *string, retryPolicy *common.RetryPolicy) (database.AutonomousDatabaseLifecycleStateEnum, error) {
+	resp, err := e2eutil.GetAutonomousDatabase(ctx, dbClient, adbID, retryPolicy)
 	if err != nil {
 		return "", err
 	}
@@ -687,8 +1968,8 @@ func returnACDLocalState(k8sClient client.Client, acdLookupKey types.NamespacedN
 	return acd.Status.LifecycleState, nil
 }
 
-func returnACDRemoteState(k8sClient client.Client, dbClient database.DatabaseClient, acdID *string, retryPolicy *common.RetryPolicy) (database.AutonomousContainerDatabaseLifecycleStateEnum, error) {
-	resp, err := e2eutil.GetAutonomousContainerDatabase(dbClient, acdID, retryPolicy)
+func returnACDRemoteState(ctx context.Context, k8sClient client.Client, dbClient database.DatabaseClient, acdID *string, retryPolicy *common.RetryPolicy) (database.AutonomousContainerDatabaseLifecycleStateEnum, error) {
+	resp, err := e2eutil.GetAutonomousContainerDatabase(ctx, dbClient, acdID, retryPolicy)
 	if err != nil {
 		return "", err
 	}
@@ -699,7 +1980,7 @@ func returnACDRemoteState(k8sClient client.Client, dbClient database.DatabaseCli
 func ConfigureADBBackup(dbClient *database.DatabaseClient, databaseOCID *string, tnsEntry *string, adminPassword *string, walletPassword *string, bucket *string, authToken *string, ociUser *string) error {
 
 	By("Downloading wallet zip")
-	walletZip, err := e2eutil.DownloadWalletZip(*dbClient, databaseOCID, walletPassword)
+	walletZip, err := e2eutil.DownloadWalletZip(context.TODO(), *dbClient, databaseOCID, walletPassword)
 	if err != nil {
 		fmt.Fprint(GinkgoWriter, err)
 		panic(err)
@@ -756,6 +2037,39 @@ func AssertBackupRestore(k8sClient *client.Client, dbClient *database.DatabaseCl
 	}
 }
 
+// AssertBackupCopied asserts that the backup is copied to the region of spec.crossRegionCopy.region,
+// and the copy is ACTIVE in that region
+func AssertBackupCopied(k8sClient *client.Client, dbClient *database.DatabaseClient, backupLookupKey *types.NamespacedName) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(backupLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+
+		By("Checking the copy of the backup is ACTIVE")
+		backup := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Eventually(func() (database.AutonomousDatabaseBackupLifecycleStateEnum, error) {
+			if err := derefK8sClient.Get(context.TODO(), *backupLookupKey, backup); err != nil {
+				return "", err
+			}
+			return backup.Status.CrossRegionCopy.LifecycleState, nil
+		}, backupTimeout, time.Second*20).Should(Equal(database.AutonomousDatabaseBackupLifecycleStateActive))
+
+		Expect(backup.Spec.CrossRegionCopy.Region).NotTo(BeNil())
+		Expect(backup.Status.CrossRegionCopy.Region).To(Equal(*backup.Spec.CrossRegionCopy.Region))
+
+		By("Checking the copy of the backup in " + backup.Status.CrossRegionCopy.Region)
+		// The client is a copy, so the region of the dbClient is not changed
+		regionalClient := *dbClient
+		regionalClient.SetRegion(backup.Status.CrossRegionCopy.Region)
+
+		resp, err := e2eutil.GetAutonomousDatabaseBackup(context.TODO(), regionalClient, common.String(backup.Status.CrossRegionCopy.AutonomousDatabaseBackupOCID))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.LifecycleState).To(Equal(database.AutonomousDatabaseBackupLifecycleStateActive))
+	}
+}
+
 func AssertACDState(k8sClient *client.Client, dbClient *database.DatabaseClient, acdLookupKey *types.NamespacedName, state database.AutonomousContainerDatabaseLifecycleStateEnum, timeout time.Duration) func() {
 	return func() {
 		AssertACDLocalState(k8sClient, acdLookupKey, state, timeout)()
@@ -799,9 +2113,12 @@ func AssertACDRemoteStateOCID(k8sClient *client.Client, dbClient *database.Datab
 		derefK8sClient := *k8sClient
 		derefDBClient := *dbClient
 
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
 		By("Checking if the lifecycleState of the ACD in OCI is " + string(state))
 		Eventually(func() (database.AutonomousContainerDatabaseLifecycleStateEnum, error) {
-			return returnACDRemoteState(derefK8sClient, derefDBClient, acdID, nil)
+			return returnACDRemoteState(ctx, derefK8sClient, derefDBClient, acdID, nil)
 		}, timeout, intervalTime).Should(Equal(state))
 	}
 }
@@ -852,7 +2169,7 @@ func AssertACDSpec(k8sClient *client.Client, dbClient *database.DatabaseClient,
 		Eventually(func() (bool, error) {
 			// Fetch the ACD from OCI when it's in AVAILABLE state, and retry if its attributes doesn't match the new ACD's attributes
 			retryPolicy := e2eutil.NewLifecycleStateRetryPolicyACD(database.AutonomousContainerDatabaseLifecycleStateAvailable)
-			resp, err := e2eutil.GetAutonomousContainerDatabase(derefDBClient, expectedACD.Spec.AutonomousContainerDatabaseOCID, &retryPolicy)
+			resp, err := e2eutil.GetAutonomousContainerDatabase(context.TODO(), derefDBClient, expectedACD.Spec.AutonomousContainerDatabaseOCID, &retryPolicy)
 			if err != nil {
 				return false, err
 			}
@@ -940,6 +2257,40 @@ func AssertACDTerminate(k8sClient *client.Client, dbClient *database.DatabaseCli
 	}
 }
 
+// AssertACDAutomaticFailover sets isAutomaticFailoverEnabled, and asserts that the automatic failover of the Data
+// Guard association is armed or disarmed in the resource and in OCI accordingly
+func AssertACDAutomaticFailover(k8sClient *client.Client, dbClient *database.DatabaseClient, acdLookupKey *types.NamespacedName, enabled bool) func() {
+	return func() {
+		Expect(k8sClient).NotTo(BeNil())
+		Expect(dbClient).NotTo(BeNil())
+		Expect(acdLookupKey).NotTo(BeNil())
+
+		derefK8sClient := *k8sClient
+		derefDBClient := *dbClient
+
+		AssertACDLocalState(k8sClient, acdLookupKey, database.AutonomousContainerDatabaseLifecycleStateAvailable, changeTimeout)()
+
+		acd := &dbv1alpha1.AutonomousContainerDatabase{}
+		Expect(derefK8sClient.Get(context.TODO(), *acdLookupKey, acd)).To(Succeed())
+
+		By(fmt.Sprintf("Updating the ACD with isAutomaticFailoverEnabled = %t\n", enabled))
+		acd.Spec.IsAutomaticFailoverEnabled = common.Bool(enabled)
+		Expect(derefK8sClient.Update(context.TODO(), acd)).To(Succeed())
+
+		By(fmt.Sprintf("Checking the automatic failover in the resource is %t", enabled))
+		Eventually(func() (*bool, error) {
+			err := derefK8sClient.Get(context.TODO(), *acdLookupKey, acd)
+			return acd.Status.IsAutomaticFailoverEnabled, err
+		}, changeTimeout, intervalTime).Should(Equal(common.Bool(enabled)))
+
+		By(fmt.Sprintf("Checking the automatic failover in OCI is %t", enabled))
+		resp, err := e2eutil.ListAutonomousContainerDatabaseDataguardAssociations(context.TODO(), derefDBClient, acd.Spec.AutonomousContainerDatabaseOCID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).NotTo(BeEmpty())
+		Expect(resp.Items[0].IsAutomaticFailoverEnabled).To(Equal(common.Bool(enabled)))
+	}
+}
+
 func AssertACDLocalDelete(k8sClient *client.Client, dbClient *database.DatabaseClient, acdLookupKey *types.NamespacedName) func() {
 	return func() {
 		Expect(k8sClient).NotTo(BeNil())
@@ -84,7 +84,9 @@ var (
 	Describe     = ginkgo.Describe
 	PDescribe    = ginkgo.PDescribe
 	FDescribe    = ginkgo.FDescribe
+	BeforeEach   = ginkgo.BeforeEach
 	AfterEach    = ginkgo.AfterEach
+	Skip         = ginkgo.Skip
 	By           = ginkgo.By
 	It           = ginkgo.It
 	FIt          = ginkgo.FIt
@@ -109,6 +111,7 @@ var SharedOCIConfigMapName = "oci-cred"
 var SharedOCISecretName = "oci-privatekey"
 var SharedPlainTextAdminPassword = "Welcome_1234"
 var SharedPlainTextNewAdminPassword = "Welcome_1234_new"
+var SharedPlainTextRollbackAdminPassword = "Welcome_1234_rb"
 var SharedPlainTextWalletPassword = "Welcome_1234"
 var SharedCompartmentOCID string
 
@@ -122,9 +125,12 @@ var SharedBucketUrl string
 var SharedAuthToken string
 var SharedOciUser string
 var SharedExadataVMClusterOCID string
+var SharedDisasterRecoveryPeerRegion string
+var SharedDataGuardACDOCID string
 
 const SharedAdminPassSecretName string = "adb-admin-password"
 const SharedNewAdminPassSecretName string = "new-adb-admin-password"
+const SharedRollbackAdminPassSecretName string = "rollback-adb-admin-password"
 const SharedWalletPassSecretName string = "adb-wallet-password"
 
 func TestAPIs(t *testing.T) {
@@ -224,6 +230,8 @@ var _ = BeforeSuite(func() {
 	SharedAuthToken = testConfig.AuthToken
 	SharedOciUser = testConfig.OciUser
 	SharedExadataVMClusterOCID = testConfig.ExadataVMClusterOCID
+	SharedDisasterRecoveryPeerRegion = testConfig.DisasterRecoveryPeerRegion
+	SharedDataGuardACDOCID = testConfig.DataGuardACDOCID
 
 	By("checking if the required parameters exist")
 	Expect(testConfig.OCIConfigFile).ToNot(Equal(""))
@@ -246,6 +254,9 @@ var _ = BeforeSuite(func() {
 	By("creating a OCI DB client")
 	dbClient, err = database.NewDatabaseClientWithConfigurationProvider(configProvider)
 	Expect(err).ToNot(HaveOccurred())
+	if testConfig.DatabaseEndpoint != "" {
+		dbClient.Host = testConfig.DatabaseEndpoint
+	}
 
 	By("creating a configMap for calling OCI")
 	ociConfigMap, err := ociConfigUtil.CreateOCIConfigMap(ADBNamespace, SharedOCIConfigMapName)
@@ -309,9 +320,9 @@ var _ = AfterSuite(func() {
 	By(fmt.Sprintf("Found %d AutonomousDatabase(s)", len(adbList.Items)))
 
 	for _, adb := range adbList.Items {
-		if adb.Spec.Details.AutonomousDatabaseOCID != nil {
-			By("Terminating database " + *adb.Spec.Details.DbName)
-			Expect(e2eutil.DeleteAutonomousDatabase(dbClient, adb.Spec.Details.AutonomousDatabaseOCID)).Should(Succeed())
+		if adb.GetAutonomousDatabaseOCID() != nil {
+			By("Terminating database " + adb.Status.DbName)
+			Expect(e2eutil.DeleteAutonomousDatabase(context.TODO(), dbClient, adb.GetAutonomousDatabaseOCID())).Should(Succeed())
 		}
 	}
 
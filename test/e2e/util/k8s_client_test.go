@@ -0,0 +1,77 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package e2eutil
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CountingClient", func() {
+	It("should count the conflicts of the Update requests", func() {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "default"},
+		}
+		kubeClient := NewCountingClient(fake.NewClientBuilder().WithObjects(configMap).Build())
+		key := types.NamespacedName{Name: "cm", Namespace: "default"}
+
+		stale := &corev1.ConfigMap{}
+		Expect(kubeClient.Get(context.TODO(), key, stale)).To(Succeed())
+
+		current := stale.DeepCopy()
+		current.Data = map[string]string{"owner": "controller"}
+		Expect(kubeClient.Update(context.TODO(), current)).To(Succeed())
+
+		stale.Data = map[string]string{"owner": "test"}
+		Expect(kubeClient.Update(context.TODO(), stale)).NotTo(Succeed())
+		Expect(kubeClient.Failures(metav1.StatusReasonConflict)).To(Equal(1))
+
+		By("Retrying with the latest resource")
+		Expect(kubeClient.Get(context.TODO(), key, stale)).To(Succeed())
+		stale.Data = map[string]string{"owner": "test"}
+		Expect(kubeClient.Update(context.TODO(), stale)).To(Succeed())
+		Expect(kubeClient.Failures(metav1.StatusReasonConflict)).To(Equal(1))
+	})
+})
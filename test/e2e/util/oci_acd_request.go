@@ -48,7 +48,7 @@ import (
 	// "time"
 )
 
-func CreateAutonomousContainerDatabase(dbClient database.DatabaseClient, compartmentId *string, acdName *string, exadataVmClusterID *string) (response database.CreateAutonomousContainerDatabaseResponse, err error) {
+func CreateAutonomousContainerDatabase(ctx context.Context, dbClient database.DatabaseClient, compartmentId *string, acdName *string, exadataVmClusterID *string) (response database.CreateAutonomousContainerDatabaseResponse, err error) {
 	acdDetails := database.CreateAutonomousContainerDatabaseDetails{
 		DisplayName:                acdName,
 		CloudAutonomousVmClusterId: exadataVmClusterID,
@@ -60,10 +60,10 @@ func CreateAutonomousContainerDatabase(dbClient database.DatabaseClient, compart
 		CreateAutonomousContainerDatabaseDetails: acdDetails,
 	}
 
-	return dbClient.CreateAutonomousContainerDatabase(context.Background(), createACDRequest)
+	return dbClient.CreateAutonomousContainerDatabase(ctx, createACDRequest)
 }
 
-func GetAutonomousContainerDatabase(dbClient database.DatabaseClient, acdOCID *string, retryPolicy *common.RetryPolicy) (database.GetAutonomousContainerDatabaseResponse, error) {
+func GetAutonomousContainerDatabase(ctx context.Context, dbClient database.DatabaseClient, acdOCID *string, retryPolicy *common.RetryPolicy) (database.GetAutonomousContainerDatabaseResponse, error) {
 	getRequest := database.GetAutonomousContainerDatabaseRequest{
 		AutonomousContainerDatabaseId: acdOCID,
 	}
@@ -74,5 +74,5 @@ func GetAutonomousContainerDatabase(dbClient database.DatabaseClient, acdOCID *s
 		}
 	}
 
-	return dbClient.GetAutonomousContainerDatabase(context.TODO(), getRequest)
+	return dbClient.GetAutonomousContainerDatabase(ctx, getRequest)
 }
@@ -48,7 +48,7 @@ import (
 	"time"
 )
 
-func CreateAutonomousDatabase(dbClient database.DatabaseClient, compartmentID *string, dbName *string, adminPassword *string) (response database.CreateAutonomousDatabaseResponse, err error) {
+func CreateAutonomousDatabase(ctx context.Context, dbClient database.DatabaseClient, compartmentID *string, dbName *string, adminPassword *string) (response database.CreateAutonomousDatabaseResponse, err error) {
 	createAutonomousDatabaseDetails := database.CreateAutonomousDatabaseDetails{
 		CompartmentId:        compartmentID,
 		DbName:               dbName,
@@ -64,10 +64,12 @@ func CreateAutonomousDatabase(dbClient database.DatabaseClient, compartmentID *s
 		CreateAutonomousDatabaseDetails: createAutonomousDatabaseDetails,
 	}
 
-	return dbClient.CreateAutonomousDatabase(context.TODO(), createAutonomousDatabaseRequest)
+	return dbClient.CreateAutonomousDatabase(ctx, createAutonomousDatabaseRequest)
 }
 
-func GetAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *string, retryPolicy *common.RetryPolicy) (database.GetAutonomousDatabaseResponse, error) {
+// GetAutonomousDatabase gets the database with the dbClient, whose Host can be set to a custom endpoint. The retries of
+// the retryPolicy stop when ctx is canceled.
+func GetAutonomousDatabase(ctx context.Context, dbClient database.DatabaseClient, databaseOCID *string, retryPolicy *common.RetryPolicy) (database.GetAutonomousDatabaseResponse, error) {
 	getRequest := database.GetAutonomousDatabaseRequest{
 		AutonomousDatabaseId: databaseOCID,
 	}
@@ -78,18 +80,74 @@ func GetAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *strin
 		}
 	}
 
-	return dbClient.GetAutonomousDatabase(context.TODO(), getRequest)
+	return dbClient.GetAutonomousDatabase(ctx, getRequest)
 }
 
-func ListAutonomousDatabases(dbClient database.DatabaseClient, compartmentOCID *string, displayName *string) (database.ListAutonomousDatabasesResponse, error) {
+// ScaleAutonomousDatabase changes the cpuCoreCount of the database directly in OCI, e.g. to start a transition which
+// the operator doesn't know yet
+func ScaleAutonomousDatabase(ctx context.Context, dbClient database.DatabaseClient, databaseOCID *string, cpuCoreCount int) (database.UpdateAutonomousDatabaseResponse, error) {
+	updateRequest := database.UpdateAutonomousDatabaseRequest{
+		AutonomousDatabaseId: databaseOCID,
+		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
+			CpuCoreCount: common.Int(cpuCoreCount),
+		},
+	}
+
+	return dbClient.UpdateAutonomousDatabase(ctx, updateRequest)
+}
+
+func GetAutonomousDatabaseBackup(ctx context.Context, dbClient database.DatabaseClient, backupOCID *string) (database.GetAutonomousDatabaseBackupResponse, error) {
+	getRequest := database.GetAutonomousDatabaseBackupRequest{
+		AutonomousDatabaseBackupId: backupOCID,
+	}
+
+	return dbClient.GetAutonomousDatabaseBackup(ctx, getRequest)
+}
+
+// autonomousDatabaseLister is the part of the DatabaseClient which lists the databases
+type autonomousDatabaseLister interface {
+	ListAutonomousDatabases(ctx context.Context, request database.ListAutonomousDatabasesRequest) (database.ListAutonomousDatabasesResponse, error)
+}
+
+// ListAutonomousDatabases lists the databases with the display name in the compartment. The items of all the pages
+// are returned in a single response.
+func ListAutonomousDatabases(ctx context.Context, dbClient database.DatabaseClient, compartmentOCID *string, displayName *string) (database.ListAutonomousDatabasesResponse, error) {
+	return listAutonomousDatabases(ctx, dbClient, compartmentOCID, displayName)
+}
+
+func listAutonomousDatabases(ctx context.Context, lister autonomousDatabaseLister, compartmentOCID *string, displayName *string) (database.ListAutonomousDatabasesResponse, error) {
 	listRequest := database.ListAutonomousDatabasesRequest{
 		CompartmentId: compartmentOCID,
 		DisplayName:   displayName,
 	}
-	return dbClient.ListAutonomousDatabases(context.TODO(), listRequest)
+
+	var items []database.AutonomousDatabaseSummary
+	for {
+		resp, err := lister.ListAutonomousDatabases(ctx, listRequest)
+		if err != nil {
+			return resp, err
+		}
+		items = append(items, resp.Items...)
+
+		if resp.OpcNextPage == nil {
+			resp.Items = items
+			return resp, nil
+		}
+		listRequest.Page = resp.OpcNextPage
+	}
+}
+
+// ListAutonomousContainerDatabaseDataguardAssociations lists the Data Guard associations of the Autonomous Container
+// Database
+func ListAutonomousContainerDatabaseDataguardAssociations(ctx context.Context, dbClient database.DatabaseClient, acdOCID *string) (database.ListAutonomousContainerDatabaseDataguardAssociationsResponse, error) {
+	listRequest := database.ListAutonomousContainerDatabaseDataguardAssociationsRequest{
+		AutonomousContainerDatabaseId: acdOCID,
+	}
+
+	return dbClient.ListAutonomousContainerDatabaseDataguardAssociations(ctx, listRequest)
 }
 
-func deleteAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *string) error {
+func deleteAutonomousDatabase(ctx context.Context, dbClient database.DatabaseClient, databaseOCID *string) error {
 	if databaseOCID == nil {
 		return nil
 	}
@@ -98,7 +156,7 @@ func deleteAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *st
 		AutonomousDatabaseId: databaseOCID,
 	}
 
-	if _, err := dbClient.DeleteAutonomousDatabase(context.TODO(), req); err != nil {
+	if _, err := dbClient.DeleteAutonomousDatabase(ctx, req); err != nil {
 		return err
 	}
 
@@ -106,17 +164,17 @@ func deleteAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *st
 }
 
 // DeleteAutonomousDatabase terminates the database if it exists and is not in TERMINATED state
-func DeleteAutonomousDatabase(dbClient database.DatabaseClient, databaseOCID *string) error {
+func DeleteAutonomousDatabase(ctx context.Context, dbClient database.DatabaseClient, databaseOCID *string) error {
 	if databaseOCID == nil {
 		return nil
 	}
 
-	resp, err := GetAutonomousDatabase(dbClient, databaseOCID, nil)
+	resp, err := GetAutonomousDatabase(ctx, dbClient, databaseOCID, nil)
 	if err != nil {
 		return nil
 	}
 	if resp.AutonomousDatabase.LifecycleState != database.AutonomousDatabaseLifecycleStateTerminated {
-		deleteAutonomousDatabase(dbClient, databaseOCID)
+		deleteAutonomousDatabase(ctx, dbClient, databaseOCID)
 	}
 
 	return nil
@@ -153,7 +211,7 @@ func NewLifecycleStateRetryPolicyACD(lifecycleState database.AutonomousContainer
 	return generateRetryPolicy(shouldRetry)
 }
 
-func DownloadWalletZip(dbClient database.DatabaseClient, databaseOCID *string, walletPassword *string) (string, error) {
+func DownloadWalletZip(ctx context.Context, dbClient database.DatabaseClient, databaseOCID *string, walletPassword *string) (string, error) {
 
 	req := database.GenerateAutonomousDatabaseWalletRequest{
 		AutonomousDatabaseId: common.String(*databaseOCID),
@@ -162,7 +220,7 @@ func DownloadWalletZip(dbClient database.DatabaseClient, databaseOCID *string, w
 		},
 	}
 
-	resp, err := dbClient.GenerateAutonomousDatabaseWallet(context.TODO(), req)
+	resp, err := dbClient.GenerateAutonomousDatabaseWallet(ctx, req)
 	if err != nil {
 		return "", err
 	}
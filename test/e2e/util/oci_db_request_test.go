@@ -0,0 +1,156 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package e2eutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v64/common"
+	"github.com/oracle/oci-go-sdk/v64/database"
+)
+
+// fakeLister returns the pages keyed by the page token. The first page has an empty token.
+type fakeLister struct {
+	pages    map[string]database.ListAutonomousDatabasesResponse
+	requests []database.ListAutonomousDatabasesRequest
+}
+
+func (l *fakeLister) ListAutonomousDatabases(ctx context.Context, request database.ListAutonomousDatabasesRequest) (database.ListAutonomousDatabasesResponse, error) {
+	l.requests = append(l.requests, request)
+
+	page := ""
+	if request.Page != nil {
+		page = *request.Page
+	}
+	resp, ok := l.pages[page]
+	if !ok {
+		return database.ListAutonomousDatabasesResponse{}, errors.New("page not found: " + page)
+	}
+	return resp, nil
+}
+
+var _ = Describe("ListAutonomousDatabases", func() {
+	It("should return the databases of all the pages", func() {
+		lister := &fakeLister{
+			pages: map[string]database.ListAutonomousDatabasesResponse{
+				"": {
+					Items:       []database.AutonomousDatabaseSummary{{Id: common.String("adb1")}},
+					OpcNextPage: common.String("page2"),
+				},
+				"page2": {
+					Items: []database.AutonomousDatabaseSummary{{Id: common.String("adb2")}, {Id: common.String("adb3")}},
+				},
+			},
+		}
+
+		resp, err := listAutonomousDatabases(context.Background(), lister, common.String("ocid1.compartment.oc1..fake"), common.String("mydb"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(3))
+		Expect(*resp.Items[2].Id).To(Equal("adb3"))
+
+		Expect(lister.requests).To(HaveLen(2))
+		for _, request := range lister.requests {
+			Expect(request.CompartmentId).To(Equal(common.String("ocid1.compartment.oc1..fake")))
+			Expect(request.DisplayName).To(Equal(common.String("mydb")))
+		}
+	})
+
+	It("should return the error of a page", func() {
+		lister := &fakeLister{
+			pages: map[string]database.ListAutonomousDatabasesResponse{
+				"": {OpcNextPage: common.String("missing")},
+			},
+		}
+
+		_, err := listAutonomousDatabases(context.Background(), lister, common.String("ocid1.compartment.oc1..fake"), nil)
+		Expect(err).To(MatchError("page not found: missing"))
+	})
+})
+
+// newFakeDatabaseClient returns a DatabaseClient which sends the requests to the server
+func newFakeDatabaseClient(server *httptest.Server) database.DatabaseClient {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	provider := common.NewRawConfigurationProvider("ocid1.tenancy.oc1..fake", "ocid1.user.oc1..fake", "us-phoenix-1",
+		"fingerprint", string(privateKey), nil)
+	dbClient, err := database.NewDatabaseClientWithConfigurationProvider(provider)
+	Expect(err).ToNot(HaveOccurred())
+	dbClient.Host = server.URL
+
+	return dbClient
+}
+
+var _ = Describe("GetAutonomousDatabase", func() {
+	It("should stop polling when the context is canceled", func() {
+		requests := make(chan struct{}, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests <- struct{}{}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "ocid1.autonomousdatabase.oc1..fake", "lifecycleState": "PROVISIONING"}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			// Cancel while the poll waits for the next attempt
+			<-requests
+			cancel()
+		}()
+
+		retryPolicy := NewLifecycleStateRetryPolicyADB(database.AutonomousDatabaseLifecycleStateAvailable)
+		start := time.Now()
+		_, err := GetAutonomousDatabase(ctx, newFakeDatabaseClient(server), common.String("ocid1.autonomousdatabase.oc1..fake"), &retryPolicy)
+
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Consistently(requests, time.Second).ShouldNot(Receive())
+	})
+})
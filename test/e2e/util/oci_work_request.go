@@ -47,7 +47,7 @@ import (
 	"time"
 )
 
-func WaitUntilWorkCompleted(workClient workrequests.WorkRequestClient, opcWorkRequestID *string) error {
+func WaitUntilWorkCompleted(ctx context.Context, workClient workrequests.WorkRequestClient, opcWorkRequestID *string) error {
 	retryPolicy := getCompleteWorkRetryPolicy()
 
 	// Apply wait until work complete retryPolicy
@@ -59,7 +59,7 @@ func WaitUntilWorkCompleted(workClient workrequests.WorkRequestClient, opcWorkRe
 	}
 
 	// GetWorkRequest retries until the work status is SUCCEEDED
-	if _, err := workClient.GetWorkRequest(context.TODO(), workRequest); err != nil {
+	if _, err := workClient.GetWorkRequest(ctx, workRequest); err != nil {
 		return err
 	}
 
@@ -133,6 +133,9 @@ type testConfiguration struct {
 	AuthToken                  string `yaml:"authToken"`
 	OciUser                    string `yaml:"ociUser"`
 	ExadataVMClusterOCID       string `yaml:"exadataVMClusterOCID"`
+	DisasterRecoveryPeerRegion string `yaml:"disasterRecoveryPeerRegion"`
+	DataGuardACDOCID           string `yaml:"dataGuardACDOCID"`
+	DatabaseEndpoint           string `yaml:"databaseEndpoint"`
 }
 
 func GetTestConfig(filename string) (*testConfiguration, error) {